@@ -213,7 +213,10 @@ var (
 	OpsGTE                      = pdm("Ops.gte", "Greater than or equal to (short name)")
 	OpsIn                       = pdm("Ops.in", "In")
 	OpsNIn                      = pdm("Ops.nin", "Not in")
+	OpsBetween                  = pdm("Ops.between", "Between two values (inclusive), supplied as the lower and upper bound")
 	OpsNull                     = pdm("Ops.null", "Null")
+	OpsIsNull                   = pdm("Ops.isNull", "Null (alternative name)")
+	OpsIsNotNull                = pdm("Ops.isNotNull", "Not null")
 )
 
 // pldclient/states.go
@@ -224,6 +227,7 @@ var (
 	StateSchema                  = pdm("State.schema", "The ID of the schema for this state, which defines what fields it has and which are indexed for query")
 	StateContractAddress         = pdm("State.contractAddress", "The address of the contract that manages this state within the domain")
 	StateData                    = pdm("State.data", "The JSON formatted data for this state")
+	StateExternalID              = pdm("State.externalId", "True if the ID was supplied by the domain, and does not match the hash Paladin would have calculated from the data. Only set for custom hash domains configured with flagExternalStateIDs")
 	StateConfirmed               = pdm("State.confirmed", "The confirmation record, if this an on-chain confirmation has been indexed from the base ledger for this state")
 	StateSpent                   = pdm("State.spent", "The spend record, if this an on-chain spend has been indexed from the base ledger for this state")
 	StateRead                    = pdm("State.read", "Read record, only returned when querying within an in-memory domain context to represent read-lock on a state from a transaction in that domain context")
@@ -287,6 +291,13 @@ var (
 	PeerStatsReliableHighestSent = pdm("PeerStats.reliableHighestSent", "Outbound reliable messages are assigned a sequence. This is the highest sequence sent to the peer since activation")
 	PeerStatsReliableAckBase     = pdm("PeerStats.reliableAckBase", "Outbound reliable messages are assigned a sequence. This is the lowest sequence that has not received an acknowledgement from the peer")
 
+	TransportStatsName          = pdm("TransportStats.name", "The name of the transport")
+	TransportStatsSentMsgs      = pdm("TransportStats.sentMsgs", "Count of messages sent over this transport since it was registered")
+	TransportStatsReceivedMsgs  = pdm("TransportStats.receivedMsgs", "Count of messages received over this transport since it was registered")
+	TransportStatsSentBytes     = pdm("TransportStats.sentBytes", "Count of payload bytes sent over this transport since it was registered (does not include header data)")
+	TransportStatsReceivedBytes = pdm("TransportStats.receivedBytes", "Count of payload bytes received over this transport since it was registered (does not include header data)")
+	TransportStatsSendErrors    = pdm("TransportStats.sendErrors", "Count of messages the transport failed to accept for sending since it was registered")
+
 	ReliableMessageSequence    = pdm("ReliableMessage.sequence", "Sequence number for the position of this message in the local database")
 	ReliableMessageID          = pdm("ReliableMessage.id", "UUID for this message. A separate message, with a separate ID, is allocated for each participant that will receive the message")
 	ReliableMessageCreated     = pdm("ReliableMessage.created", "The time this message was created")
@@ -116,6 +116,11 @@ var (
 	MsgJSONRPCInvalidParam        = pde("PD020704", "method %s parameter %d invalid: %s")
 	MsgJSONRPCResultSerialization = pde("PD020705", "method %s result serialization failed: %s")
 	MsgJSONRPCAysncNonWSConn      = pde("PD020706", "method %s only available on WebSocket connections")
+	MsgJSONRPCRateLimitExceeded   = pde("PD020707", "Request rate limit exceeded")
+	MsgJSONRPCSubscribeNoTopic    = pde("PD020708", "pld_subscribe requires a single topic parameter")
+	MsgJSONRPCUnsubscribeNoID     = pde("PD020709", "pld_unsubscribe requires a single subscription ID parameter")
+	MsgJSONRPCBatchRequestTimeout = pde("PD020710", "method %s did not complete within the batch request timeout of %s")
+	MsgJSONRPCRequestTooLarge     = pde("PD020711", "Request exceeds the maximum size of %d bytes", 413)
 
 	// Signing module PD0208XX
 	MsgSigningModuleBadPathError                = pde("PD020800", "Path '%s' does not exist, or it is not a directory")
@@ -161,4 +166,5 @@ var (
 	MsgWSClientClosing             = pde("PD021102", "Websocket closing")
 	MsgWSClientConnectFailed       = pde("PD021103", "Websocket connect failed")
 	MsgWSClientHeartbeatTimeout    = pde("PD021104", "Websocket heartbeat timed out after %.2fms", 500)
+	MsgWSClientNoAddressesResolved = pde("PD021105", "No addresses resolved for host '%s'")
 )
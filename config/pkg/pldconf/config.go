@@ -35,4 +35,7 @@ type PaladinConfig struct {
 	PublicTxManager        PublicTxManagerConfig  `json:"publicTxManager"`
 	IdentityResolver       IdentityResolverConfig `json:"identityResolver"`
 	GroupManager           GroupManagerConfig     `json:"groupManager"`
+	// Embedded runs without the transport, registry and plugin managers - so no domains,
+	// and no private transactions or privacy group distribution
+	Embedded *bool `json:"embedded"`
 }
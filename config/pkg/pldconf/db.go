@@ -45,4 +45,5 @@ type SQLDBConfig struct {
 	MigrationsDir   string                      `json:"migrationsDir"`
 	DebugQueries    bool                        `json:"debugQueries"`
 	StatementCache  *bool                       `json:"statementCache"`
+	QueryTimeout    *string                     `json:"queryTimeout"` // applied to queries made via persistence.QueryContext, 0 for no timeout
 }
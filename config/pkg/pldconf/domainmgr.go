@@ -27,6 +27,10 @@ type DomainManagerConfig struct {
 
 type DomainManagerManagerConfig struct {
 	ContractCache CacheConfig `json:"contractCache"`
+	PingTimeout   *string     `json:"pingTimeout"` // how long to wait for a domain plugin to answer a health check ping
+	// how long to wait for requests in-flight to a domain plugin to complete, when a new connection
+	// from the plugin replaces the existing one (hot-reload)
+	ReloadDrainTimeout *string `json:"reloadDrainTimeout"`
 }
 
 type DomainConfig struct {
@@ -36,12 +40,23 @@ type DomainConfig struct {
 	RegistryAddress string           `json:"registryAddress"`
 	AllowSigning    bool             `json:"allowSigning"`
 	DefaultGasLimit *uint64          `json:"defaultGasLimit"`
+	// The number of blocks that must be mined on top of the block containing a base ledger
+	// transaction submitted for this domain, before it is considered final
+	RequiredConfirmationDepth *uint `json:"requiredConfirmationDepth"`
+	// For domains with a custom hash function, also calculate the default Paladin hash of each state,
+	// and flag states with a different ID as externalId. Off by default, as it adds a hash to every state write.
+	FlagExternalStateIDs bool `json:"flagExternalStateIDs"`
 }
 
 var ContractCacheDefaults = &CacheConfig{
 	Capacity: confutil.P(1000),
 }
 
+var DomainManagerDefaults = &DomainManagerManagerConfig{
+	PingTimeout:        confutil.P("5s"),
+	ReloadDrainTimeout: confutil.P("30s"),
+}
+
 type DomainInitConfig struct {
 	Retry RetryConfig `json:"retry"`
 }
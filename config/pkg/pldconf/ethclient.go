@@ -20,11 +20,15 @@ import (
 )
 
 type EthClientConfig struct {
-	WS                WSClientConfig   `json:"ws"`
-	HTTP              HTTPClientConfig `json:"http"`
-	EstimateGasFactor *float64         `json:"gasEstimateFactor"`
+	WS                 WSClientConfig   `json:"ws"`
+	HTTP               HTTPClientConfig `json:"http"`
+	EstimateGasFactor  *float64         `json:"gasEstimateFactor"`
+	DNSRefreshInterval *string          `json:"dnsRefreshInterval"` // if set, the WS hostname is re-resolved on reconnect and at this interval
+	// how often each connection in a WebSocket pool is checked, with unhealthy connections skipped until they pass a check
+	WSPoolHealthCheckInterval *string `json:"wsPoolHealthCheckInterval"`
 }
 
 var EthClientDefaults = &EthClientConfig{
-	EstimateGasFactor: confutil.P(2.0),
+	EstimateGasFactor:         confutil.P(2.0),
+	WSPoolHealthCheckInterval: confutil.P("30s"),
 }
@@ -31,7 +31,8 @@ var DefaultGRPCConfig = &GRPCConfig{
 }
 
 type PluginConfig struct {
-	Type    string  `json:"type"`
-	Library string  `json:"library"`
-	Class   *string `json:"class,omitempty"`
+	Type     string  `json:"type"`
+	Library  string  `json:"library"`
+	Class    *string `json:"class,omitempty"`
+	Replicas *int    `json:"replicas,omitempty"` // domain plugins only - number of instances to load and balance requests across
 }
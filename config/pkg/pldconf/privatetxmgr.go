@@ -45,6 +45,7 @@ var PrivateTxManagerDefaults = &PrivateTxManagerConfig{
 		MaxPendingEvents:                    confutil.P(500),
 		RoundRobinCoordinatorBlockRangeSize: confutil.P(100),
 		AssembleRequestTimeout:              confutil.P("1s"),
+		TransactionLifecycleTimeout:         confutil.P("30m"),
 	},
 	RequestTimeout: confutil.P("1s"),
 }
@@ -58,4 +59,5 @@ type PrivateTxManagerSequencerConfig struct {
 	StaleTimeout                        *string `json:"staleTimeout,omitempty"`
 	RoundRobinCoordinatorBlockRangeSize *int    `json:"roundRobinCoordinatorBlockRangeSize,omitempty"`
 	AssembleRequestTimeout              *string `json:"assembleRequestTimeout,omitempty"`
+	TransactionLifecycleTimeout         *string `json:"transactionLifecycleTimeout,omitempty"`
 }
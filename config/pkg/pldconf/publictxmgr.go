@@ -35,6 +35,7 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 		OrchestratorStaleTimeout: confutil.P("5m"),
 		OrchestratorSwapTimeout:  confutil.P("10m"),
 		NonceCacheTimeout:        confutil.P("1h"),
+		MaxSubmitBatchSize:       confutil.P(50),
 		Retry: RetryConfig{
 			InitialDelay: confutil.P("250ms"),
 			MaxDelay:     confutil.P("30s"),
@@ -55,12 +56,14 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 		},
 	},
 	Orchestrator: PublicTxManagerOrchestratorConfig{
-		MaxInFlight:          confutil.P(500),
-		Interval:             confutil.P("5s"),
-		ResubmitInterval:     confutil.P("5m"),
-		StaleTimeout:         confutil.P("5m"),
-		StageRetryTime:       confutil.P("10s"),
-		PersistenceRetryTime: confutil.P("5s"),
+		MaxInFlight:            confutil.P(500),
+		MaxParallelSubmissions: confutil.P(0),
+		Interval:               confutil.P("5s"),
+		ResubmitInterval:       confutil.P("5m"),
+		StaleTimeout:           confutil.P("5m"),
+		StageRetryTime:         confutil.P("10s"),
+		PersistenceRetryTime:   confutil.P("5s"),
+		NonceGapCheckInterval:  confutil.P("1m"),
 		SubmissionRetry: RetryConfigWithMax{
 			RetryConfig: RetryConfig{
 				InitialDelay: confutil.P("250ms"),
@@ -73,7 +76,14 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 	GasPrice: GasPriceConfig{
 		IncreaseMax:        nil,
 		IncreasePercentage: confutil.P(0),
-		FixedGasPrice:      nil,
+		ReplacementUnderpricedErrors: []string{
+			`(?i)replacement transaction underpriced`, // geth + besu
+			`(?i)replacement_underpriced`,             // besu
+			`(?i)could not replace existing tx`,       // erigon
+			`(?i)replacementnotallowed`,               // nethermind
+		},
+		ReplacementGasBumpPercent: confutil.P(10), // the minimum geth accepts for a replacement
+		FixedGasPrice:             nil,
 		Cache: CacheConfig{
 			Capacity: confutil.P(100),
 			// TODO: Enable a KB based cache with TTL in Paladin
@@ -94,6 +104,12 @@ var PublicTxManagerDefaults = &PublicTxManagerConfig{
 	},
 	GasLimit: GasLimitConfig{
 		GasEstimateFactor: confutil.P(1.5),
+		EstimateCache: GasEstimateCacheConfig{
+			CacheConfig: CacheConfig{
+				Capacity: confutil.P(1000),
+			},
+			TTL: confutil.P("30s"),
+		},
 	},
 }
 
@@ -104,6 +120,7 @@ type PublicTxManagerManagerConfig struct {
 	OrchestratorStaleTimeout *string                              `json:"orchestratorStaleTimeout"` // stale orchestrators exit after this time - TODO: Define stale
 	OrchestratorSwapTimeout  *string                              `json:"orchestratorSwapTimeout"`  // orchestrators are cycled out after this time, when all slots are full
 	NonceCacheTimeout        *string                              `json:"nonceCacheTimeout"`
+	MaxSubmitBatchSize       *int                                 `json:"maxSubmitBatchSize"` // max transactions accepted in a single publictx_submitBatch call
 	ActivityRecords          PublicTxManagerActivityRecordsConfig `json:"activityRecords"`
 	SubmissionWriter         FlushWriterConfig                    `json:"submissionWriter"`
 	Retry                    RetryConfig                          `json:"retry"`
@@ -119,15 +136,28 @@ type BalanceManagerConfig struct {
 }
 
 type GasPriceConfig struct {
-	IncreaseMax        *string            `json:"increaseMax"`
-	IncreasePercentage *int               `json:"increasePercentage"`
-	FixedGasPrice      any                `json:"fixedGasPrice"` // number or object
-	GasOracleAPI       GasOracleAPIConfig `json:"gasOracleAPI"`
-	Cache              CacheConfig        `json:"cache"`
+	IncreaseMax        *string `json:"increaseMax"`
+	IncreasePercentage *int    `json:"increasePercentage"`
+	// Regular expressions matching the errors a node returns when a transaction replacing one in its pool
+	// does not pay enough more than the original. These are retried with the price increased by
+	// ReplacementGasBumpPercent, rather than the IncreasePercentage.
+	ReplacementUnderpricedErrors []string           `json:"replacementUnderpricedErrors"`
+	ReplacementGasBumpPercent    *int               `json:"replacementGasBumpPercent"`
+	FixedGasPrice                any                `json:"fixedGasPrice"` // number or object
+	GasOracleAPI                 GasOracleAPIConfig `json:"gasOracleAPI"`
+	Cache                        CacheConfig        `json:"cache"`
 }
 
 type GasLimitConfig struct {
-	GasEstimateFactor *float64 `json:"gasEstimateFactor"`
+	GasEstimateFactor *float64               `json:"gasEstimateFactor"`
+	EstimateCache     GasEstimateCacheConfig `json:"estimateCache"`
+}
+
+// Gas estimates are cached for transactions with the same from, to, value and data, for up to the TTL.
+// A TTL of zero disables the cache.
+type GasEstimateCacheConfig struct {
+	CacheConfig
+	TTL *string `json:"ttl"`
 }
 
 type GasOracleAPIConfig struct {
@@ -137,12 +167,14 @@ type GasOracleAPIConfig struct {
 
 type PublicTxManagerOrchestratorConfig struct {
 	MaxInFlight               *int               `json:"maxInFlight"`
+	MaxParallelSubmissions    *int               `json:"maxParallelSubmissions"` // 0 means no limit
 	Interval                  *string            `json:"interval"`
 	ResubmitInterval          *string            `json:"resubmitInterval"`
 	StaleTimeout              *string            `json:"staleTimeout"`
 	StageRetryTime            *string            `json:"stageRetryTime"`
 	PersistenceRetryTime      *string            `json:"persistenceRetryTime"`
 	UnavailableBalanceHandler *string            `json:"unavailableBalanceHandler"`
+	NonceGapCheckInterval     *string            `json:"nonceGapCheckInterval"` // how often to check the chain for nonces missing below the in-flight transactions, 0 disables
 	SubmissionRetry           RetryConfigWithMax `json:"submissionRetry"`
 	TimeLineLoggingMaxEntries int                `json:"timelineMaxEntries"`
 }
@@ -80,9 +80,16 @@ type RegistryTransportsConfig struct {
 	// for different private node networks that all use the same logical
 	// transport name.
 	TransportMap map[string]string
+
+	// The name of the property on the node entry that contains the Ethereum address
+	// of the node's signing key. When message signing is enabled in the transport
+	// manager, this address is used to verify the author signature on messages
+	// received from the node.
+	SigningAddressProperty string `json:"signingAddressProperty"`
 }
 
 var RegistryTransportsDefaults = &RegistryTransportsConfig{
-	Enabled:        confutil.P(true),
-	PropertyRegexp: "^transport.(.*)$",
+	Enabled:                confutil.P(true),
+	PropertyRegexp:         "^transport.(.*)$",
+	SigningAddressProperty: "signingAddress",
 }
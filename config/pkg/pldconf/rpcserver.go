@@ -28,6 +28,11 @@ var WSDefaults = RPCServerConfigWS{
 	WriteBufferSize: confutil.P("64KB"),
 }
 
+var RPCServerDefaults = RPCServerConfig{
+	BatchRequestTimeout: confutil.P("2m"),
+	MaxRequestBodyBytes: confutil.P("10MB"),
+}
+
 type RPCServerConfigHTTP struct {
 	Disabled         bool                 `json:"disabled,omitempty"`
 	StaticServers    []StaticServerConfig `json:"staticServers,omitempty"` // Configurations for static file servers handled by the HTTP server (e.g., for serving a UI hosted on the same server as the RPC server)
@@ -41,7 +46,20 @@ type RPCServerConfigWS struct {
 	WriteBufferSize  *string `json:"writeBufferSize"`
 }
 
+// Requests are limited per remote IP address, across HTTP and WebSocket connections, using a token bucket.
+// Rate limiting is disabled unless RequestsPerSecond is set.
+type RPCServerRateLimitConfig struct {
+	RequestsPerSecond *float64 `json:"requestsPerSecond"`
+	Burst             *int     `json:"burst"` // defaults to the requests per second
+}
+
 type RPCServerConfig struct {
-	HTTP RPCServerConfigHTTP `json:"http,omitempty"`
-	WS   RPCServerConfigWS   `json:"ws,omitempty"`
+	HTTP      RPCServerConfigHTTP      `json:"http,omitempty"`
+	WS        RPCServerConfigWS        `json:"ws,omitempty"`
+	RateLimit RPCServerRateLimitConfig `json:"rateLimit,omitempty"`
+	// Requests in a batch that have not completed within this time are returned with an error, so that
+	// the rest of the batch can be returned. Set to 0 to disable.
+	BatchRequestTimeout *string `json:"batchRequestTimeout"`
+	// The maximum size of an HTTP request body, or WebSocket message, containing a JSON/RPC request or batch
+	MaxRequestBodyBytes *string `json:"maxRequestBodyBytes"`
 }
@@ -21,7 +21,26 @@ import (
 )
 
 type StateStoreConfig struct {
-	SchemaCache CacheConfig `json:"schemaCache"`
+	SchemaCache              CacheConfig `json:"schemaCache"`
+	DomainContextGCInterval  *string     `json:"domainContextGCInterval"`
+	DomainContextIdleTimeout *string     `json:"domainContextIdleTimeout"`
+	PostCommitWorkers        *int        `json:"postCommitWorkers"`
+	SnapshotTTL              *string     `json:"snapshotTTL"`
+	MaxBatchStates           *int        `json:"maxBatchStates"`
+	WarmSchemaCache          *bool       `json:"warmSchemaCache"`
+	DebugSQL                 *bool       `json:"debugSQL"`
+	SchemaBackfillRetry      RetryConfig `json:"schemaBackfillRetry"`
+}
+
+var StateStoreConfigDefaults = &StateStoreConfig{
+	DomainContextGCInterval:  confutil.P("1h"),
+	DomainContextIdleTimeout: confutil.P("24h"),
+	PostCommitWorkers:        confutil.P(4),
+	SnapshotTTL:              confutil.P("5m"),
+	MaxBatchStates:           confutil.P(1000),
+	WarmSchemaCache:          confutil.P(true),
+	DebugSQL:                 confutil.P(false),
+	SchemaBackfillRetry:      GenericRetryDefaults.RetryConfig,
 }
 
 var StateWriterConfigDefaults = FlushWriterConfig{
@@ -17,15 +17,37 @@ package pldconf
 import "github.com/kaleido-io/paladin/config/pkg/confutil"
 
 type TransportManagerConfig struct {
-	NodeName              string                      `json:"nodeName"`
-	SendQueueLen          *int                        `json:"sendQueueLen"`
-	PeerInactivityTimeout *string                     `json:"peerInactivityTimeout"`
-	PeerReaperInterval    *string                     `json:"peerReaperInterval"`
-	SendRetry             RetryConfigWithMax          `json:"sendRetry"`
-	ReliableScanRetry     RetryConfig                 `json:"reliableScanRetry"`
-	ReliableMessageResend *string                     `json:"reliableMessageResend"`
-	ReliableMessageWriter FlushWriterConfig           `json:"reliableMessageWriter"`
-	Transports            map[string]*TransportConfig `json:"transports"`
+	NodeName              string                          `json:"nodeName"`
+	SendQueueLen          *int                            `json:"sendQueueLen"`
+	PeerInactivityTimeout *string                         `json:"peerInactivityTimeout"`
+	PeerReaperInterval    *string                         `json:"peerReaperInterval"`
+	SendRetry             RetryConfigWithMax              `json:"sendRetry"`
+	ReliableScanRetry     RetryConfig                     `json:"reliableScanRetry"`
+	ReliableMessageResend *string                         `json:"reliableMessageResend"`
+	ReliableMessageWriter FlushWriterConfig               `json:"reliableMessageWriter"`
+	Transports            map[string]*TransportConfig     `json:"transports"`
+	MessageSigning        TransportMessageSigningConfig   `json:"messageSigning"`
+	ReplayProtection      TransportReplayProtectionConfig `json:"replayProtection"`
+	StateEncoding         *string                         `json:"stateEncoding"`         // json or cbor encoding of state data sent to other nodes
+	StateCompressionLevel *int                            `json:"stateCompressionLevel"` // 0 disables compression, 1-9 applies gzip at that level
+	MulticastConcurrency  *int                            `json:"multicastConcurrency"`  // max nodes sent to in parallel for a multicast
+	MaxMessageBytes       *string                         `json:"maxMessageBytes"`       // messages received from a transport larger than this are dropped
+}
+
+// When enabled, every message sent is signed by this node, and every message received
+// must carry a valid signature from the signing address registered for the sending node.
+type TransportMessageSigningConfig struct {
+	Enabled       *bool  `json:"enabled"`
+	KeyIdentifier string `json:"keyIdentifier"` // resolved through the key manager to the signing key of this node
+}
+
+// When enabled, every message received must carry a sequence greater than any seen before from
+// the sending node, other than within a window that allows for messages arriving out of order.
+// Should only be enabled when all nodes in the network stamp messages with a sequence.
+// Requires message signing, so that the sequence of each message is authenticated.
+type TransportReplayProtectionConfig struct {
+	Enabled *bool `json:"enabled"`
+	Window  *int  `json:"window"` // how far below the highest sequence seen a message can arrive, if its sequence has not been seen
 }
 
 type TransportInitConfig struct {
@@ -52,6 +74,17 @@ var TransportManagerDefaults = &TransportManagerConfig{
 		BatchTimeout: confutil.P("250ms"),
 		BatchMaxSize: confutil.P(50),
 	},
+	MessageSigning: TransportMessageSigningConfig{
+		Enabled: confutil.P(false),
+	},
+	ReplayProtection: TransportReplayProtectionConfig{
+		Enabled: confutil.P(false),
+		Window:  confutil.P(64),
+	},
+	StateEncoding:         confutil.P("json"),
+	StateCompressionLevel: confutil.P(0),
+	MulticastConcurrency:  confutil.P(10),
+	MaxMessageBytes:       confutil.P("4MB"),
 }
 
 type TransportConfig struct {
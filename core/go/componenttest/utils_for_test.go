@@ -305,7 +305,7 @@ func newInstanceForComponentTesting(t *testing.T, domainRegistryAddress *pldtype
 		"grpc":                grpc.NewPlugin(i.ctx),
 		"registry1":           static.NewPlugin(i.ctx),
 	}
-	pc := i.cm.PluginManager()
+	pc, _ := i.cm.PluginManager()
 	pl, err = plugins.NewUnitTestPluginLoader(pc.GRPCTargetURL(), pc.LoaderID().String(), loaderMap)
 	require.NoError(t, err)
 	go pl.Run()
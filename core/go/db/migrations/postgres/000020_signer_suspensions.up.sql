@@ -0,0 +1,9 @@
+BEGIN;
+
+CREATE TABLE signer_suspensions (
+  "signer"                    TEXT            NOT NULL,
+  "created"                   BIGINT          NOT NULL,
+  PRIMARY KEY("signer")
+);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "public_txns" DROP COLUMN "required_confirmation_depth";
+
+COMMIT;
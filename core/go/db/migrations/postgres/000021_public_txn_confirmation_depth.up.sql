@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "public_txns" ADD "required_confirmation_depth" BIGINT NOT NULL DEFAULT 0;
+
+COMMIT;
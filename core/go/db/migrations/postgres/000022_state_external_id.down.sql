@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "states" DROP COLUMN "external_id";
+
+COMMIT;
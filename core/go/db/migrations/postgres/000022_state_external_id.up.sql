@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "states" ADD "external_id" BOOLEAN NOT NULL DEFAULT false;
+
+COMMIT;
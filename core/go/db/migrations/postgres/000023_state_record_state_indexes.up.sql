@@ -0,0 +1,9 @@
+BEGIN;
+
+-- The state queries join the records on the state ID alone, which the primary keys
+-- (leading with the domain name) cannot be used for
+CREATE INDEX state_confirm_records_state ON state_confirm_records("state");
+CREATE INDEX state_spend_records_state ON state_spend_records("state");
+CREATE INDEX state_nullifiers_state_id ON state_nullifiers("state");
+
+COMMIT;
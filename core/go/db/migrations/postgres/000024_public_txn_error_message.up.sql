@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "public_txns" ADD "error_message" TEXT;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE state_transfers;
+
+COMMIT;
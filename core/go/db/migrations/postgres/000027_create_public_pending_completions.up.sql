@@ -0,0 +1,14 @@
+BEGIN;
+
+-- Confirmations of transactions with a required confirmation depth, which are held here
+-- until the chain is deep enough on top of them to write the completion
+CREATE TABLE public_pending_completions (
+    "pub_txn_id"   BIGINT  NOT NULL,
+    "created"      BIGINT  NOT NULL,
+    "tx_hash"      TEXT    NOT NULL,
+    "revert_data"  TEXT,
+    PRIMARY KEY ("pub_txn_id"),
+    FOREIGN KEY ("pub_txn_id") REFERENCES public_txns ("pub_txn_id") ON DELETE CASCADE
+);
+
+COMMIT;
@@ -0,0 +1 @@
+DROP TABLE signer_suspensions;
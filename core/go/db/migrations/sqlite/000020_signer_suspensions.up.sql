@@ -0,0 +1,5 @@
+CREATE TABLE signer_suspensions (
+  "signer"                    VARCHAR         NOT NULL,
+  "created"                   BIGINT          NOT NULL,
+  PRIMARY KEY("signer")
+);
@@ -0,0 +1 @@
+ALTER TABLE "public_txns" DROP COLUMN "required_confirmation_depth";
@@ -0,0 +1 @@
+ALTER TABLE "public_txns" ADD "required_confirmation_depth" BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE "states" DROP COLUMN "external_id";
@@ -0,0 +1 @@
+ALTER TABLE "states" ADD "external_id" BOOLEAN NOT NULL DEFAULT false;
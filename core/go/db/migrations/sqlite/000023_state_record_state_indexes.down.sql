@@ -0,0 +1,3 @@
+DROP INDEX state_confirm_records_state;
+DROP INDEX state_spend_records_state;
+DROP INDEX state_nullifiers_state_id;
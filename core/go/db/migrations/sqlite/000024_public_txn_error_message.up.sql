@@ -0,0 +1 @@
+ALTER TABLE "public_txns" ADD "error_message" TEXT;
@@ -0,0 +1,10 @@
+CREATE TABLE schema_aliases (
+    "domain_name"    VARCHAR NOT NULL,
+    "id"             VARCHAR NOT NULL,
+    "target"         VARCHAR NOT NULL,
+    "created"        BIGINT  NOT NULL,
+    "backfilled"     BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY ("domain_name", "id"),
+    FOREIGN KEY ("domain_name", "id") REFERENCES schemas ("domain_name", "id") ON DELETE CASCADE,
+    FOREIGN KEY ("domain_name", "target") REFERENCES schemas ("domain_name", "id") ON DELETE CASCADE
+);
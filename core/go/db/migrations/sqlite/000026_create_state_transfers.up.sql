@@ -0,0 +1,13 @@
+CREATE TABLE state_transfers (
+    "transaction"    UUID    NOT NULL,
+    "source_domain"  VARCHAR NOT NULL,
+    "source_state"   VARCHAR NOT NULL,
+    "target_domain"  VARCHAR NOT NULL,
+    "target_state"   VARCHAR NOT NULL,
+    "created"        BIGINT  NOT NULL,
+    PRIMARY KEY ("source_domain", "source_state"),
+    FOREIGN KEY ("source_domain", "source_state") REFERENCES states ("domain_name", "id") ON DELETE CASCADE,
+    FOREIGN KEY ("target_domain", "target_state") REFERENCES states ("domain_name", "id") ON DELETE CASCADE
+);
+CREATE INDEX state_transfers_transaction ON state_transfers("transaction");
+CREATE INDEX state_transfers_target ON state_transfers("target_domain", "target_state");
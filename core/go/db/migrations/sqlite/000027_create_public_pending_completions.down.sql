@@ -0,0 +1 @@
+DROP TABLE public_pending_completions;
@@ -0,0 +1,8 @@
+CREATE TABLE public_pending_completions (
+    "pub_txn_id"   INTEGER NOT NULL,
+    "created"      BIGINT  NOT NULL,
+    "tx_hash"      TEXT    NOT NULL,
+    "revert_data"  TEXT,
+    PRIMARY KEY ("pub_txn_id"),
+    FOREIGN KEY ("pub_txn_id") REFERENCES public_txns ("pub_txn_id") ON DELETE CASCADE
+);
@@ -0,0 +1,303 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigValidationError is a problem with the configuration that means Paladin cannot start
+type ConfigValidationError struct {
+	Path    string // JSON path of the invalid field
+	Message string
+}
+
+func (e ConfigValidationError) String() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ConfigValidationWarning is a problem with the configuration that does not prevent startup,
+// such as a key we do not recognize (which might be valid for a newer version of Paladin)
+type ConfigValidationWarning struct {
+	Path    string // JSON path of the field
+	Message string
+}
+
+func (w ConfigValidationWarning) String() string {
+	return fmt.Sprintf("%s: %s", w.Path, w.Message)
+}
+
+type configValidator struct {
+	ctx    context.Context
+	errors []ConfigValidationError
+}
+
+// ValidateConfig checks the parsed configuration for missing required fields, and values that
+// would otherwise be silently replaced by defaults when each component reads its config.
+func ValidateConfig(conf *pldconf.PaladinConfig) []ConfigValidationError {
+	v := &configValidator{ctx: context.Background()}
+
+	v.required("blockchain.http.url", conf.Blockchain.HTTP.URL)
+	v.duration("blockchain.dnsRefreshInterval", conf.Blockchain.DNSRefreshInterval)
+	v.duration("blockchain.wsPoolHealthCheckInterval", conf.Blockchain.WSPoolHealthCheckInterval)
+
+	v.allowed("db.type", conf.DB.Type, "", persistence.TypeSQLite, persistence.TypePostgres)
+	switch conf.DB.Type {
+	case persistence.TypePostgres:
+		v.sqlDB("db.postgres", &conf.DB.Postgres.SQLDBConfig)
+	default:
+		v.sqlDB("db.sqlite", &conf.DB.SQLite.SQLDBConfig)
+	}
+
+	if conf.Log.Level != nil {
+		v.allowed("log.level", strings.ToLower(*conf.Log.Level), "error", "warn", "warning", "info", "debug", "trace")
+	}
+	if conf.Log.Format != nil {
+		v.allowed("log.format", *conf.Log.Format, "simple", "json")
+	}
+	if conf.Log.Output != nil {
+		v.allowed("log.output", *conf.Log.Output, "stdout", "stderr", "file")
+	}
+
+	v.intMin("blockIndexer.commitBatchSize", conf.BlockIndexer.CommitBatchSize, 1)
+	v.duration("blockIndexer.commitBatchTimeout", conf.BlockIndexer.CommitBatchTimeout)
+	v.intMin("blockIndexer.requiredConfirmations", conf.BlockIndexer.RequiredConfirmations, 0)
+	v.intMin("blockIndexer.chainHeadCacheLen", conf.BlockIndexer.ChainHeadCacheLen, 1)
+	v.duration("blockIndexer.blockPollingInterval", conf.BlockIndexer.BlockPollingInterval)
+
+	v.duration("rpcServer.batchRequestTimeout", conf.RPCServer.BatchRequestTimeout)
+	v.duration("grpc.shutdownTimeout", conf.GRPC.ShutdownTimeout)
+	v.intMin("sendQueueLen", conf.SendQueueLen, 0)
+	v.duration("peerInactivityTimeout", conf.PeerInactivityTimeout)
+	v.duration("peerReaperInterval", conf.PeerReaperInterval)
+
+	ptm := &conf.PublicTxManager.Manager
+	v.intMin("publicTxManager.manager.maxInFlightOrchestrators", ptm.MaxInFlightOrchestrators, 1)
+	v.duration("publicTxManager.manager.interval", ptm.Interval)
+	v.duration("publicTxManager.manager.orchestratorIdleTimeout", ptm.OrchestratorIdleTimeout)
+	v.duration("publicTxManager.manager.orchestratorStaleTimeout", ptm.OrchestratorStaleTimeout)
+	v.duration("publicTxManager.manager.orchestratorSwapTimeout", ptm.OrchestratorSwapTimeout)
+	v.duration("publicTxManager.manager.nonceCacheTimeout", ptm.NonceCacheTimeout)
+	v.intMin("publicTxManager.manager.maxSubmitBatchSize", ptm.MaxSubmitBatchSize, 1)
+	v.duration("publicTxManager.orchestrator.nonceGapCheckInterval", conf.PublicTxManager.Orchestrator.NonceGapCheckInterval)
+	v.intMin("publicTxManager.gasLimit.estimateCache.capacity", conf.PublicTxManager.GasLimit.EstimateCache.Capacity, 1)
+	v.duration("publicTxManager.gasLimit.estimateCache.ttl", conf.PublicTxManager.GasLimit.EstimateCache.TTL)
+	for i, re := range conf.PublicTxManager.GasPrice.ReplacementUnderpricedErrors {
+		v.regexp(fmt.Sprintf("publicTxManager.gasPrice.replacementUnderpricedErrors[%d]", i), re)
+	}
+	v.intMin("publicTxManager.gasPrice.replacementGasBumpPercent", conf.PublicTxManager.GasPrice.ReplacementGasBumpPercent, 0)
+
+	for _, name := range sortedKeys(conf.Domains) {
+		if d := conf.Domains[name]; d != nil {
+			v.plugin(fmt.Sprintf("domains.%s.plugin", name), &d.Plugin)
+			v.required(fmt.Sprintf("domains.%s.registryAddress", name), d.RegistryAddress)
+		}
+	}
+	for _, name := range sortedKeys(conf.Transports) {
+		if t := conf.Transports[name]; t != nil {
+			v.plugin(fmt.Sprintf("transports.%s.plugin", name), &t.Plugin)
+		}
+	}
+	for _, name := range sortedKeys(conf.Registries) {
+		if r := conf.Registries[name]; r != nil {
+			v.plugin(fmt.Sprintf("registries.%s.plugin", name), &r.Plugin)
+		}
+	}
+
+	return v.errors
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *configValidator) fail(path string, msg i18n.ErrorMessageKey, inserts ...any) {
+	v.errors = append(v.errors, ConfigValidationError{
+		Path:    path,
+		Message: i18n.NewError(v.ctx, msg, inserts...).Error(),
+	})
+}
+
+func (v *configValidator) required(path, value string) {
+	if value == "" {
+		v.fail(path, msgs.MsgConfigValueRequired)
+	}
+}
+
+func (v *configValidator) allowed(path, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.fail(path, msgs.MsgConfigValueNotAllowed, value, allowed)
+}
+
+func (v *configValidator) intMin(path string, value *int, min int) {
+	if value != nil && *value < min {
+		v.fail(path, msgs.MsgConfigValueBelowMinimum, *value, min)
+	}
+}
+
+func (v *configValidator) duration(path string, value *string) {
+	if value != nil {
+		if _, err := time.ParseDuration(*value); err != nil {
+			v.fail(path, msgs.MsgConfigValueInvalidDuration, *value)
+		}
+	}
+}
+
+func (v *configValidator) regexp(path, value string) {
+	if _, err := regexp.Compile(value); err != nil {
+		v.fail(path, msgs.MsgConfigValueInvalidRegexp, value, err)
+	}
+}
+
+func (v *configValidator) sqlDB(path string, conf *pldconf.SQLDBConfig) {
+	v.required(path+".dsn", conf.DSN)
+	v.intMin(path+".maxOpenConns", conf.MaxOpenConns, 1)
+	v.intMin(path+".maxIdleConns", conf.MaxIdleConns, 0)
+	v.duration(path+".connMaxIdleTime", conf.ConnMaxIdleTime)
+	v.duration(path+".connMaxLifetime", conf.ConnMaxLifetime)
+	v.duration(path+".queryTimeout", conf.QueryTimeout)
+}
+
+func (v *configValidator) plugin(path string, conf *pldconf.PluginConfig) {
+	v.required(path+".type", conf.Type)
+	v.required(path+".library", conf.Library)
+}
+
+// CheckUnknownConfigKeys compares the raw YAML/JSON configuration against the fields
+// of the PaladinConfig structure, returning a warning for each key that will be ignored.
+func CheckUnknownConfigKeys(ctx context.Context, data []byte) ([]ConfigValidationWarning, error) {
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	var warnings []ConfigValidationWarning
+	for _, path := range unknownKeys("", reflect.TypeOf(pldconf.PaladinConfig{}), raw) {
+		warnings = append(warnings, ConfigValidationWarning{
+			Path:    path,
+			Message: i18n.NewError(ctx, msgs.MsgConfigUnknownKey).Error(),
+		})
+	}
+	return warnings, nil
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+func unknownKeys(path string, t reflect.Type, raw any) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		// Types with custom parsing (such as json.RawMessage) have their own rules
+		return nil
+	}
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]any)
+		if !ok {
+			return nil
+		}
+		fields := map[string]reflect.Type{}
+		jsonFields(t, fields)
+		for _, key := range sortedKeys(obj) {
+			ft, ok := fields[key]
+			if !ok {
+				// Go JSON parsing falls back to case-insensitive matching of field names
+				for name, candidate := range fields {
+					if strings.EqualFold(name, key) {
+						ft, ok = candidate, true
+						break
+					}
+				}
+			}
+			if !ok {
+				unknown = append(unknown, joinConfigPath(path, key))
+				continue
+			}
+			unknown = append(unknown, unknownKeys(joinConfigPath(path, key), ft, obj[key])...)
+		}
+	case reflect.Map:
+		obj, ok := raw.(map[string]any)
+		if !ok || t.Elem().Kind() == reflect.Interface {
+			return nil
+		}
+		for _, key := range sortedKeys(obj) {
+			unknown = append(unknown, unknownKeys(joinConfigPath(path, key), t.Elem(), obj[key])...)
+		}
+	case reflect.Slice, reflect.Array:
+		list, ok := raw.([]any)
+		if !ok {
+			return nil
+		}
+		for i, entry := range list {
+			unknown = append(unknown, unknownKeys(fmt.Sprintf("%s[%d]", path, i), t.Elem(), entry)...)
+		}
+	}
+	return unknown
+}
+
+// jsonFields collects the JSON names of the fields of a struct, including those promoted from embedded structs
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			jsonFields(ft, fields)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+}
+
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package componentmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validTestConfig() *pldconf.PaladinConfig {
+	conf := &pldconf.PaladinConfig{}
+	conf.Blockchain.HTTP.URL = "http://localhost:8545"
+	conf.DB.SQLite.DSN = ":memory:"
+	return conf
+}
+
+func errorPaths(errors []ConfigValidationError) []string {
+	paths := make([]string, len(errors))
+	for i, e := range errors {
+		paths[i] = e.Path
+	}
+	return paths
+}
+
+func TestValidateConfigOK(t *testing.T) {
+	conf := validTestConfig()
+	conf.Log.Level = confutil.P("DEBUG")
+	conf.BlockIndexer.CommitBatchTimeout = confutil.P("250ms")
+	conf.Domains = map[string]*pldconf.DomainConfig{
+		"domain1": {
+			Plugin:          pldconf.PluginConfig{Type: "c-shared", Library: "domain1.so"},
+			RegistryAddress: "0x2b4c3e1a8e1a1bba6b0b0ef38a6e8a1f3d3c6d8a",
+		},
+	}
+	assert.Empty(t, ValidateConfig(conf))
+}
+
+func TestValidateConfigErrors(t *testing.T) {
+	conf := &pldconf.PaladinConfig{}
+	conf.DB.Type = "postgres"
+	conf.Log.Format = confutil.P("xml")
+	conf.BlockIndexer.CommitBatchSize = confutil.P(0)
+	conf.BlockIndexer.RequiredConfirmations = confutil.P(-1)
+	conf.BlockIndexer.BlockPollingInterval = confutil.P("often")
+	conf.Domains = map[string]*pldconf.DomainConfig{
+		"domain1": {},
+	}
+	conf.Transports = map[string]*pldconf.TransportConfig{
+		"grpc": {Plugin: pldconf.PluginConfig{Type: "c-shared"}},
+	}
+
+	errors := ValidateConfig(conf)
+	assert.Equal(t, []string{
+		"blockchain.http.url",
+		"db.postgres.dsn",
+		"log.format",
+		"blockIndexer.commitBatchSize",
+		"blockIndexer.requiredConfirmations",
+		"blockIndexer.blockPollingInterval",
+		"domains.domain1.plugin.type",
+		"domains.domain1.plugin.library",
+		"domains.domain1.registryAddress",
+		"transports.grpc.plugin.library",
+	}, errorPaths(errors))
+	assert.Regexp(t, "PD010037", errors[0].Message)
+	assert.Regexp(t, "PD010040.*xml", errors[2].Message)
+	assert.Regexp(t, "PD010038.*-1", errors[4].Message)
+	assert.Regexp(t, "PD010039.*often", errors[5].Message)
+	assert.Equal(t, "blockchain.http.url: "+errors[0].Message, errors[0].String())
+
+	conf = validTestConfig()
+	conf.DB.Type = "mysql"
+	assert.Equal(t, []string{"db.type"}, errorPaths(ValidateConfig(conf)))
+
+	conf = validTestConfig()
+	conf.PublicTxManager.GasPrice.ReplacementUnderpricedErrors = []string{"underpriced", "under(priced"}
+	errors = ValidateConfig(conf)
+	assert.Equal(t, []string{"publicTxManager.gasPrice.replacementUnderpricedErrors[1]"}, errorPaths(errors))
+	assert.Regexp(t, "PD010043.*under\\(priced", errors[0].Message)
+}
+
+func TestCheckUnknownConfigKeys(t *testing.T) {
+	warnings, err := CheckUnknownConfigKeys(context.Background(), []byte(`
+nodeName: node1
+futureFeature: true
+db:
+  type: sqlite
+  sqlite:
+    dsn: ":memory:"
+    dsnn: typo
+blockIndexer:
+  fromBlock: latest
+  RequiredConfirmations: 5
+domains:
+  domain1:
+    plugin:
+      type: c-shared
+      libary: typo
+    config:
+      anything: goes
+wallets:
+- name: wallet1
+  unknown: 1
+keyManager:
+  identifierCache:
+    capacity: 10
+`))
+	require.NoError(t, err)
+	paths := make([]string, len(warnings))
+	for i, w := range warnings {
+		paths[i] = w.Path
+		assert.Regexp(t, "PD010041", w.Message)
+	}
+	assert.Equal(t, []string{
+		"db.sqlite.dsnn",
+		"domains.domain1.plugin.libary",
+		"futureFeature",
+		"wallets[0].unknown",
+	}, paths)
+	assert.Equal(t, "db.sqlite.dsnn: "+warnings[0].Message, warnings[0].String())
+
+	_, err = CheckUnknownConfigKeys(context.Background(), []byte(`{!!!`))
+	assert.Error(t, err)
+}
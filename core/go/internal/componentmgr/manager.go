@@ -60,7 +60,8 @@ type componentManager struct {
 	instanceUUID uuid.UUID
 	bgCtx        context.Context
 	// config
-	conf *pldconf.PaladinConfig
+	conf     *pldconf.PaladinConfig
+	embedded bool
 	// debug server
 	debugServer httpserver.Server
 	// pre-init
@@ -114,6 +115,7 @@ func NewComponentManager(bgCtx context.Context, grpcTarget string, instanceUUID
 		instanceUUID:          instanceUUID,
 		bgCtx:                 bgCtx,
 		conf:                  conf,
+		embedded:              confutil.Bool(conf.Embedded, false),
 		additionalManagers:    additionalManagers,
 		initResults:           make(map[string]*components.ManagerInitResult),
 		started:               make(map[string]stoppable),
@@ -131,7 +133,9 @@ func (cm *componentManager) startDebugServer() (httpserver.Server, error) {
 	cm.conf.DebugServer.Port = confutil.P(confutil.Int(cm.conf.DebugServer.Port, 0)) // if enabled with no port, we allocate one
 	server, err := httpserver.NewDebugServer(cm.bgCtx, &cm.conf.DebugServer.HTTPServerConfig)
 	if err == nil {
-		server.Router().PathPrefix("/debug/javadump").HandlerFunc(http.HandlerFunc(cm.javaDump))
+		if !cm.embedded {
+			server.Router().PathPrefix("/debug/javadump").HandlerFunc(http.HandlerFunc(cm.javaDump))
+		}
 		err = server.Start()
 	}
 	return server, err
@@ -179,19 +183,20 @@ func (cm *componentManager) Init() (err error) {
 		err = cm.wrapIfErr(err, msgs.MsgComponentDomainInitError)
 	}
 
-	if err == nil {
+	// the transport, registry and plugin managers are omitted in embedded mode
+	if err == nil && !cm.embedded {
 		cm.transportManager = transportmgr.NewTransportManager(cm.bgCtx, &cm.conf.TransportManagerConfig)
 		cm.initResults["transports_manager"], err = cm.transportManager.PreInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentTransportInitError)
 	}
 
-	if err == nil {
+	if err == nil && !cm.embedded {
 		cm.registryManager = registrymgr.NewRegistryManager(cm.bgCtx, &cm.conf.RegistryManagerConfig)
 		cm.initResults["registry_manager"], err = cm.registryManager.PreInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentRegistryInitError)
 	}
 
-	if err == nil {
+	if err == nil && !cm.embedded {
 		cm.pluginManager = plugins.NewPluginManager(cm.bgCtx, cm.grpcTarget, cm.instanceUUID, &cm.conf.PluginManagerConfig)
 		cm.initResults["plugin_manager"], err = cm.pluginManager.PreInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentPluginInitError)
@@ -251,17 +256,17 @@ func (cm *componentManager) Init() (err error) {
 		err = cm.wrapIfErr(err, msgs.MsgComponentDomainInitError)
 	}
 
-	if err == nil {
+	if err == nil && !cm.embedded {
 		err = cm.transportManager.PostInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentTransportInitError)
 	}
 
-	if err == nil {
+	if err == nil && !cm.embedded {
 		err = cm.registryManager.PostInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentRegistryInitError)
 	}
 
-	if err == nil {
+	if err == nil && !cm.embedded {
 		err = cm.pluginManager.PostInit(cm)
 		err = cm.wrapIfErr(err, msgs.MsgComponentPluginInitError)
 	}
@@ -350,17 +355,17 @@ func (cm *componentManager) StartManagers() (err error) {
 		err = cm.addIfStarted("domain_manager", cm.domainManager, err, msgs.MsgComponentDomainStartError)
 	}
 
-	if err == nil {
+	if err == nil && !cm.embedded {
 		err = cm.transportManager.Start()
 		err = cm.addIfStarted("transport_manager", cm.transportManager, err, msgs.MsgComponentTransportStartError)
 	}
 
-	if err == nil {
+	if err == nil && !cm.embedded {
 		err = cm.registryManager.Start()
 		err = cm.addIfStarted("registry_manager", cm.registryManager, err, msgs.MsgComponentRegistryStartError)
 	}
 
-	if err == nil {
+	if err == nil && !cm.embedded {
 		err = cm.pluginManager.Start()
 		err = cm.addIfStarted("plugin_manager", cm.pluginManager, err, msgs.MsgComponentPluginStartError)
 	}
@@ -397,8 +402,11 @@ func (cm *componentManager) StartManagers() (err error) {
 
 func (cm *componentManager) CompleteStart() error {
 	// Wait for the plugins to all start
-	err := cm.pluginManager.WaitForInit(cm.bgCtx)
-	err = cm.wrapIfErr(err, msgs.MsgComponentWaitPluginStartError)
+	var err error
+	if !cm.embedded {
+		err = cm.pluginManager.WaitForInit(cm.bgCtx)
+		err = cm.wrapIfErr(err, msgs.MsgComponentWaitPluginStartError)
+	}
 
 	// then start the block indexer
 	if err == nil {
@@ -460,6 +468,12 @@ func (cm *componentManager) buildInternalEventStreams() ([]*blockindexer.Interna
 				PreCommitHandler: initResult.PreCommitHandler,
 			})
 		}
+		if initResult.ReorgHandler != nil {
+			streams = append(streams, &blockindexer.InternalEventStream{
+				Type:         blockindexer.IESTypeReorgHandler,
+				ReorgHandler: initResult.ReorgHandler,
+			})
+		}
 	}
 	return streams, nil
 }
@@ -474,6 +488,8 @@ func (cm *componentManager) registerRPCModules() {
 	// We handle block indexer separately (doesn't fit the internal ManagerLifecycle model
 	// as it's currently a standalone re-usable component)
 	cm.rpcServer.Register(cm.BlockIndexer().RPCModule())
+	// Likewise for the connection diagnostics of the eth client
+	cm.rpcServer.Register(cm.ethClientFactory.RPCModule())
 }
 
 func (cm *componentManager) Stop() {
@@ -521,16 +537,16 @@ func (cm *componentManager) DomainManager() components.DomainManager {
 	return cm.domainManager
 }
 
-func (cm *componentManager) TransportManager() components.TransportManager {
-	return cm.transportManager
+func (cm *componentManager) TransportManager() (components.TransportManager, bool) {
+	return cm.transportManager, cm.transportManager != nil
 }
 
-func (cm *componentManager) RegistryManager() components.RegistryManager {
-	return cm.registryManager
+func (cm *componentManager) RegistryManager() (components.RegistryManager, bool) {
+	return cm.registryManager, cm.registryManager != nil
 }
 
-func (cm *componentManager) PluginManager() components.PluginManager {
-	return cm.pluginManager
+func (cm *componentManager) PluginManager() (components.PluginManager, bool) {
+	return cm.pluginManager, cm.pluginManager != nil
 }
 
 func (cm *componentManager) PublicTxManager() components.PublicTxManager {
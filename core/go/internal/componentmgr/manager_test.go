@@ -123,9 +123,15 @@ func TestInitOK(t *testing.T) {
 	assert.NotNil(t, cm.RPCServer())
 	assert.NotNil(t, cm.BlockIndexer())
 	assert.NotNil(t, cm.DomainManager())
-	assert.NotNil(t, cm.TransportManager())
-	assert.NotNil(t, cm.RegistryManager())
-	assert.NotNil(t, cm.PluginManager())
+	transportMgr, ok := cm.TransportManager()
+	assert.True(t, ok)
+	assert.NotNil(t, transportMgr)
+	registryMgr, ok := cm.RegistryManager()
+	assert.True(t, ok)
+	assert.NotNil(t, registryMgr)
+	pluginMgr, ok := cm.PluginManager()
+	assert.True(t, ok)
+	assert.NotNil(t, pluginMgr)
 	assert.NotNil(t, cm.PrivateTxManager())
 	assert.NotNil(t, cm.PublicTxManager())
 	assert.NotNil(t, cm.TxManager())
@@ -164,6 +170,7 @@ func TestStartOK(t *testing.T) {
 	mockEthClientFactory := ethclientmocks.NewEthClientFactory(t)
 	mockEthClientFactory.On("Start").Return(nil)
 	mockEthClientFactory.On("Stop").Return()
+	mockEthClientFactory.On("RPCModule").Return(nil)
 
 	mockBlockIndexer := blockindexermocks.NewBlockIndexer(t)
 	mockBlockIndexer.On("Start").Return(nil)
@@ -257,6 +264,151 @@ func TestStartOK(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestInitEmbeddedOK(t *testing.T) {
+
+	l, err := net.Listen("tcp4", ":0")
+	require.NoError(t, err)
+	debugPort := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	// Embedded mode omits the transport, registry and plugin managers, and everything
+	// that depends on them must still initialize
+	testConfig := &pldconf.PaladinConfig{
+		Embedded: confutil.P(true),
+		DB: pldconf.DBConfig{
+			Type: "sqlite",
+			SQLite: pldconf.SQLiteConfig{
+				SQLDBConfig: pldconf.SQLDBConfig{
+					DSN:           ":memory:",
+					AutoMigrate:   confutil.P(true),
+					MigrationsDir: "../../db/migrations/sqlite",
+				},
+			},
+		},
+		Blockchain: pldconf.EthClientConfig{
+			HTTP: pldconf.HTTPClientConfig{
+				URL: "http://localhost:8545", // we won't actually connect this test, just check the config
+			},
+		},
+		RPCServer: pldconf.RPCServerConfig{
+			HTTP: pldconf.RPCServerConfigHTTP{Disabled: true},
+			WS:   pldconf.RPCServerConfigWS{Disabled: true},
+		},
+		DebugServer: pldconf.DebugServerConfig{
+			Enabled: confutil.P(true),
+			HTTPServerConfig: pldconf.HTTPServerConfig{
+				Port: confutil.P(debugPort),
+			},
+		},
+	}
+
+	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), testConfig).(*componentManager)
+	err = cm.Init()
+	require.NoError(t, err)
+	defer cm.Stop()
+
+	assert.NotNil(t, cm.StateManager())
+	assert.NotNil(t, cm.BlockIndexer())
+	assert.NotNil(t, cm.DomainManager())
+	assert.NotNil(t, cm.PrivateTxManager())
+	assert.NotNil(t, cm.GroupManager())
+	assert.NotNil(t, cm.IdentityResolver())
+	_, ok := cm.TransportManager()
+	assert.False(t, ok)
+	_, ok = cm.RegistryManager()
+	assert.False(t, ok)
+	_, ok = cm.PluginManager()
+	assert.False(t, ok)
+
+	// Features that need the missing managers are rejected, rather than failing startup
+	ctx := context.Background()
+	err = cm.PrivateTxManager().HandleNewTx(ctx, cm.Persistence().NOTX(), &components.ValidatedTransaction{})
+	assert.Regexp(t, "PD010036.*transport", err)
+	_, err = cm.GroupManager().CreateGroup(ctx, cm.Persistence().NOTX(), &pldapi.PrivacyGroupInput{
+		Domain:  "domain1",
+		Members: []string{"me@node1"},
+	})
+	assert.Regexp(t, "PD010036.*transport", err)
+
+	// No plugin loader to send a javadump to
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%d/debug/javadump", debugPort))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+}
+
+func TestStartEmbeddedOK(t *testing.T) {
+
+	mockEthClientFactory := ethclientmocks.NewEthClientFactory(t)
+	mockEthClientFactory.On("Start").Return(nil)
+	mockEthClientFactory.On("Stop").Return()
+	mockEthClientFactory.On("RPCModule").Return(nil)
+
+	mockBlockIndexer := blockindexermocks.NewBlockIndexer(t)
+	mockBlockIndexer.On("Start").Return(nil)
+	mockBlockIndexer.On("GetBlockListenerHeight", mock.Anything).Return(uint64(12345), nil)
+	mockBlockIndexer.On("RPCModule").Return(nil)
+	mockBlockIndexer.On("Stop").Return()
+
+	mockKeyManager := componentsmocks.NewKeyManager(t)
+	mockKeyManager.On("Start").Return(nil)
+	mockKeyManager.On("Stop").Return()
+
+	mockDomainManager := componentsmocks.NewDomainManager(t)
+	mockDomainManager.On("Start").Return(nil)
+	mockDomainManager.On("Stop").Return()
+
+	mockPublicTxManager := componentsmocks.NewPublicTxManager(t)
+	mockPublicTxManager.On("Start").Return(nil)
+	mockPublicTxManager.On("Stop").Return()
+
+	mockPrivateTxManager := componentsmocks.NewPrivateTxManager(t)
+	mockPrivateTxManager.On("Start").Return(nil)
+	mockPrivateTxManager.On("Stop").Return()
+
+	mockTxManager := componentsmocks.NewTXManager(t)
+	mockTxManager.On("Start").Return(nil)
+	mockTxManager.On("Stop").Return()
+	mockTxManager.On("LoadBlockchainEventListeners").Return(nil)
+
+	mockGroupManager := componentsmocks.NewGroupManager(t)
+	mockGroupManager.On("Start").Return(nil)
+	mockGroupManager.On("Stop").Return()
+
+	mockStateManager := componentsmocks.NewStateManager(t)
+	mockStateManager.On("Start").Return(nil)
+	mockStateManager.On("Stop").Return()
+
+	mockRPCServer := rpcservermocks.NewRPCServer(t)
+	mockRPCServer.On("Start").Return(nil)
+	mockRPCServer.On("Register", mock.AnythingOfType("*rpcserver.RPCModule")).Return()
+	mockRPCServer.On("Stop").Return()
+	mockRPCServer.On("HTTPAddr").Return(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8545})
+	mockRPCServer.On("WSAddr").Return(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8546})
+
+	// No transport, registry or plugin managers are set, so any call to them would panic
+	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{
+		Embedded: confutil.P(true),
+	}).(*componentManager)
+	cm.ethClientFactory = mockEthClientFactory
+	cm.blockIndexer = mockBlockIndexer
+	cm.keyManager = mockKeyManager
+	cm.domainManager = mockDomainManager
+	cm.stateManager = mockStateManager
+	cm.rpcServer = mockRPCServer
+	cm.publicTxManager = mockPublicTxManager
+	cm.privateTxManager = mockPrivateTxManager
+	cm.txManager = mockTxManager
+	cm.groupManager = mockGroupManager
+
+	err := cm.StartManagers()
+	require.NoError(t, err)
+	err = cm.CompleteStart()
+	require.NoError(t, err)
+
+	cm.Stop()
+}
+
 func TestBuildInternalEventStreamsPreCommitPostCommit(t *testing.T) {
 	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{}, nil).(*componentManager)
 	handler := func(ctx context.Context, dbTX persistence.DBTX, blocks []*pldapi.IndexedBlock, transactions []*blockindexer.IndexedTransactionNotify) error {
@@ -276,6 +428,24 @@ func TestBuildInternalEventStreamsPreCommitPostCommit(t *testing.T) {
 
 }
 
+func TestBuildInternalEventStreamsReorgHandler(t *testing.T) {
+	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{}, nil).(*componentManager)
+	cm.initResults = map[string]*components.ManagerInitResult{
+		"utengine": {
+			ReorgHandler: func(ctx context.Context, dbTX persistence.DBTX, reorg *blockindexer.ReorgEvent) error {
+				return nil
+			},
+		},
+	}
+
+	streams, err := cm.buildInternalEventStreams()
+	assert.NoError(t, err)
+	assert.Len(t, streams, 1)
+	assert.Equal(t, blockindexer.IESTypeReorgHandler, streams[0].Type)
+	assert.NotNil(t, streams[0].ReorgHandler)
+
+}
+
 func TestErrorWrapping(t *testing.T) {
 	cm := NewComponentManager(context.Background(), tempSocketFile(t), uuid.New(), &pldconf.PaladinConfig{}, nil).(*componentManager)
 
@@ -41,11 +41,16 @@ type PreInitComponents interface {
 //
 // So that they can call each other, their external mockable interfaces provided
 // to the are all defined in this package.
+//
+// Some managers are optional, such as those providing the plugin and networking stack
+// that are not required in an embedded deployment. These return a boolean that is false
+// if the manager is not available, and the caller must disable any dependent features
+// (or fail PostInit with a clear error if it cannot function without it).
 type Managers interface {
 	DomainManager() DomainManager
-	TransportManager() TransportManager
-	RegistryManager() RegistryManager
-	PluginManager() PluginManager
+	TransportManager() (TransportManager, bool)
+	RegistryManager() (RegistryManager, bool)
+	PluginManager() (PluginManager, bool)
 	PrivateTxManager() PrivateTxManager
 	PublicTxManager() PublicTxManager
 	TxManager() TXManager
@@ -72,6 +77,7 @@ type AdditionalManager interface {
 // Managers can instruct the init of some of the PostInitComponents in a generic way
 type ManagerInitResult struct {
 	PreCommitHandler blockindexer.PreCommitHandler
+	ReorgHandler     blockindexer.ReorgHandler
 	RPCModules       []*rpcserver.RPCModule
 }
 
@@ -33,6 +33,7 @@ import (
 type DomainManagerToDomain interface {
 	plugintk.DomainAPI
 	Initialized()
+	Ping(ctx context.Context, req *prototk.PingRequest) (*prototk.PingResponse, error)
 }
 
 // Domain manager is the boundary between the paladin core / testbed and the domains
@@ -41,10 +42,13 @@ type DomainManager interface {
 	ConfiguredDomains() map[string]*pldconf.PluginConfig
 	DomainRegistered(name string, toDomain DomainManagerToDomain) (fromDomain plugintk.DomainCallbacks, err error)
 	GetDomainByName(ctx context.Context, name string) (Domain, error)
+	ReconfigureDomain(ctx context.Context, name string, config map[string]any) error
 	GetSmartContractByAddress(ctx context.Context, dbTX persistence.DBTX, addr pldtypes.EthAddress) (DomainSmartContract, error)
 	ExecDeployAndWait(ctx context.Context, txID uuid.UUID, call func() error) (dc DomainSmartContract, err error)
 	ExecAndWaitTransaction(ctx context.Context, txID uuid.UUID, call func() error) error
 	GetSigner() signerapi.InMemorySigner
+	// PingDomain confirms the domain plugin is responding on its stream, within the configured timeout
+	PingDomain(ctx context.Context, name string) error
 }
 
 // External interface for other components (engine, testbed) to call against a domain
@@ -54,6 +58,8 @@ type Domain interface {
 	RegistryAddress() *pldtypes.EthAddress
 	Configuration() *prototk.DomainConfig
 	CustomHashFunction() bool
+	FlagExternalStateIDs() bool
+	RequiredConfirmationDepth() uint
 
 	// Specific to domains that support privacy groups (domain should return error if it does not).
 	// Validates the input properties, and turns it into the full genesis configuration for a group
@@ -74,6 +74,11 @@ type StateDistribution struct {
 type StateDistributionWithData struct {
 	StateDistribution
 	StateData pldtypes.RawJSON `json:"stateData"`
+	// When the sending node is configured to encode/compress state data for transport, the
+	// data is carried in StateDataEncoded and StateData is empty
+	StateDataEncoding    string            `json:"stateDataEncoding,omitempty"`
+	StateDataCompression string            `json:"stateDataCompression,omitempty"`
+	StateDataEncoded     pldtypes.HexBytes `json:"stateDataEncoded,omitempty"`
 }
 
 type PrivateTxManager interface {
@@ -19,6 +19,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -39,8 +40,9 @@ var PublicTxFilterFields filters.FieldSet = filters.FieldMap{
 }
 
 type PublicTxSubmission struct {
-	Bindings             []*PaladinTXReference
-	pldapi.PublicTxInput // the request to create the transaction
+	Bindings                  []*PaladinTXReference
+	RequiredConfirmationDepth uint // blocks that must be mined on top of the confirming block before the transaction is final
+	pldapi.PublicTxInput           // the request to create the transaction
 }
 
 type PaladinTXReference struct {
@@ -60,6 +62,9 @@ type PublicTxManager interface {
 	QueryPublicTxForTransactions(ctx context.Context, dbTX persistence.DBTX, boundToTxns []uuid.UUID, jq *query.QueryJSON) (map[uuid.UUID][]*pldapi.PublicTx, error)
 	QueryPublicTxWithBindings(ctx context.Context, dbTX persistence.DBTX, jq *query.QueryJSON) ([]*pldapi.PublicTxWithBinding, error)
 	GetPublicTransactionForHash(ctx context.Context, dbTX persistence.DBTX, hash pldtypes.Bytes32) (*pldapi.PublicTxWithBinding, error)
+	ListPendingTransactions(ctx context.Context, address pldtypes.EthAddress, limit, offset int) ([]*pldapi.PublicTx, error)
+	// Administrative override of the gas limit of a pending transaction, which is resubmitted with the new limit
+	OverrideGasLimit(ctx context.Context, from pldtypes.EthAddress, nonce uint64, gas uint64) error
 
 	// Perform (potentially expensive) transaction level validation, such as gas estimation. Call before starting a DB transaction
 	ValidateTransaction(ctx context.Context, dbTX persistence.DBTX, transaction *PublicTxSubmission) error
@@ -67,9 +72,26 @@ type PublicTxManager interface {
 	WriteNewTransactions(ctx context.Context, dbTX persistence.DBTX, transactions []*PublicTxSubmission) ([]*pldapi.PublicTx, error)
 	// Convenience function that does ValidateTransaction+WriteNewTransactions for a single Tx
 	SingleTransactionSubmit(ctx context.Context, transaction *PublicTxSubmission) (*pldapi.PublicTx, error)
+	// Validates a batch of transactions, then writes them in a single DB transaction. Nonces are assigned in the order supplied
+	SubmitBatch(ctx context.Context, transactions []*pldapi.PublicTxInput) ([]*pldapi.PublicTx, error)
 
 	MatchUpdateConfirmedTransactions(ctx context.Context, dbTX persistence.DBTX, itxs []*blockindexer.IndexedTransactionNotify) ([]*PublicTxMatch, error)
 	NotifyConfirmPersisted(ctx context.Context, confirms []*PublicTxMatch)
 
+	// Completes transactions with a required confirmation depth, once the chain is deep enough on top of the block they were confirmed in
+	MatchFinalizedTransactions(ctx context.Context, dbTX persistence.DBTX, headBlock int64) ([]*PublicTxMatch, error)
+
+	// Returns transactions confirmed in blocks orphaned by a reorg to pending, keeping their nonces
+	MatchRevertReorgedTransactions(ctx context.Context, dbTX persistence.DBTX, fromBlock int64) ([]*PublicTxMatch, error)
+	NotifyReorgPersisted(ctx context.Context, reorged []*PublicTxMatch)
+
 	UpdateTransaction(ctx context.Context, id uuid.UUID, pubTXID uint64, from *pldtypes.EthAddress, tx *pldapi.TransactionInput, publicTxData []byte, txmgrDBUpdate func(dbTX persistence.DBTX) error) error
+
+	// Stop picking up new transactions for a signing address (persisted across restarts), while still tracking in-flight transactions to completion
+	SuspendSigner(ctx context.Context, address pldtypes.EthAddress) error
+	ResumeSigner(ctx context.Context, address pldtypes.EthAddress) error
+	GetSignerStatus(ctx context.Context, address pldtypes.EthAddress) (pldapi.SignerStatus, error)
+
+	// Replace the gas price configuration without restarting orchestrators, re-pricing submitted transactions on their next poll
+	ReloadGasConfig(ctx context.Context, newConf *pldconf.GasPriceConfig) error
 }
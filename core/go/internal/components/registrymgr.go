@@ -48,6 +48,7 @@ type RegistryManager interface {
 	ConfiguredRegistries() map[string]*pldconf.PluginConfig
 	RegistryRegistered(name string, id uuid.UUID, toRegistry RegistryManagerToRegistry) (fromRegistry plugintk.RegistryCallbacks, err error)
 	GetNodeTransports(ctx context.Context, node string) ([]*RegistryNodeTransportEntry, error)
+	GetNodeSigningAddress(ctx context.Context, node string) (*pldtypes.EthAddress, error)
 	GetRegistry(ctx context.Context, name string) (Registry, error)
 }
 
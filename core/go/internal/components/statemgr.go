@@ -37,18 +37,35 @@ type StateManager interface {
 	// Create a new domain context - caller is responsible for closing it
 	NewDomainContext(ctx context.Context, domain Domain, contractAddress pldtypes.EthAddress) DomainContext
 
+	// Create a new domain context that is held for the lifetime of a long-lived component, so is
+	// never garbage collected when idle - caller is responsible for closing it
+	NewLongLivedDomainContext(ctx context.Context, domain Domain, contractAddress pldtypes.EthAddress) DomainContext
+
 	// Get a previously created domain context
 	GetDomainContext(ctx context.Context, id uuid.UUID) DomainContext
 
 	// Ensure ABI schemas upserts all the specified schemas, using the given DB transaction
 	EnsureABISchemas(ctx context.Context, dbTX persistence.DBTX, domainName string, defs []*abi.Parameter) ([]Schema, error)
 
+	// MigrateABISchema adds indexed labels to an existing ABI schema, by creating a new schema with the
+	// same type and an alias from the old schema to the new one. Queries using either schema ID then
+	// use the new schema, and return states of both. The new labels are backfilled for the existing
+	// states in the background, so queries on the new labels only include all existing states once
+	// that is complete.
+	MigrateABISchema(ctx context.Context, domainName string, oldSchemaID pldtypes.Bytes32, newABI *abi.Parameter) (Schema, error)
+
 	// Get an individual schema by ID
 	GetSchemaByID(ctx context.Context, dbTX persistence.DBTX, domainName string, schemaID pldtypes.Bytes32, failNotFound bool) (*pldapi.Schema, error)
 
+	// Load all the schemas for a domain into the schema cache with a single query
+	WarmSchemaCache(ctx context.Context, domainName string) error
+
 	// State finalizations are written on the DB context of the block indexer, by the domain manager.
 	WriteStateFinalizations(ctx context.Context, dbTX persistence.DBTX, spends []*pldapi.StateSpendRecord, reads []*pldapi.StateReadRecord, confirms []*pldapi.StateConfirmRecord, infoRecords []*pldapi.StateInfoRecord) (err error)
 
+	// Removes the state finalizations written for transactions whose confirmation was orphaned by a block reorg
+	RevertStateFinalizations(ctx context.Context, dbTX persistence.DBTX, transactionIDs []uuid.UUID) error
+
 	// MUST NOT be called for states received over a network from another node.
 	// Writes a batch of states that have been pre-verified BY THIS NODE so can bypass domain hash verification.
 	WritePreVerifiedStates(ctx context.Context, dbTX persistence.DBTX, domainName string, states []*StateUpsertOutsideContext) ([]*pldapi.State, error)
@@ -62,17 +79,47 @@ type StateManager interface {
 	// Find states from outside of a domain context (noting you can reference a domain context by ID)
 	FindStates(ctx context.Context, dbTX persistence.DBTX, domainName string, schemaID pldtypes.Bytes32, query *query.QueryJSON, extQueryOptions *StateQueryOptions) (s []*pldapi.State, err error)
 
+	// Find states belonging to any of a set of contracts in a single query, such as to aggregate balances across
+	// multiple deployed instances of a domain. Domain context status qualifiers are not supported, as a domain
+	// context is scoped to a single contract.
+	FindStatesAcrossContracts(ctx context.Context, dbTX persistence.DBTX, domainName string, schemaID pldtypes.Bytes32, contractAddresses []pldtypes.EthAddress, query *query.QueryJSON, status pldapi.StateStatusQualifier) (s []*pldapi.State, err error)
+
+	// Create a token that can be passed in StateQueryOptions to page through the results of FindStates
+	// against a stable view of the states in the domain, excluding any created after the token.
+	// Tokens expire if unused for the configured snapshot TTL.
+	NewQueryToken(ctx context.Context, dbTX persistence.DBTX, domainName string) (uuid.UUID, error)
+
 	// GetState returns state by ID, with optional labels
 	GetStatesByID(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress *pldtypes.EthAddress, stateIDs []pldtypes.HexBytes, failNotFound, withLabels bool) ([]*pldapi.State, error)
 
 	// Get all states created, read or spent by a confirmed transaction
 	GetTransactionStates(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID) (*pldapi.TransactionStates, error)
+
+	// Like GetTransactionStates, but calls the supplied function for each state as it is read from the DB
+	// rather than building the full set in memory - for transactions that have a large number of states.
+	// Only states that are available in the DB are returned (not those where we only have the ID).
+	// Returning an error from the function stops the iteration, and is returned.
+	StreamTransactionStates(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID, fn func(*pldapi.StateBase) error) error
+
+	// Delete a state created in error, along with its labels. The state must be neither confirmed nor spent (directly,
+	// or via its nullifier), and must not be locked by any transaction in an active domain context.
+	// The nullifier record is retained.
+	DeleteState(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress pldtypes.EthAddress, stateID pldtypes.HexBytes) error
+
+	// Administrative version of DeleteState that bypasses the status check, also deleting any confirm/spend/read/info
+	// records and the nullifier for the state. Requires allowForce to be set. Locked states still cannot be deleted.
+	AdminDeleteState(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress pldtypes.EthAddress, stateID pldtypes.HexBytes, allowForce bool) error
 }
 
 type StateQueryOptions struct {
 	StatusQualifier pldapi.StateStatusQualifier
 	ExcludedIDs     []pldtypes.HexBytes
 	QueryModifier   func(db persistence.DBTX, query *gorm.DB) *gorm.DB
+	QueryToken      *uuid.UUID // from NewQueryToken - cannot be combined with a domain context status qualifier
+	// ExcludeData skips loading the state data from the DB, so states are returned with nil Data.
+	// For callers that only need the IDs or label values. The returned states must not be passed
+	// to a domain for processing. Does not apply to domain context status qualifiers.
+	ExcludeData bool
 }
 
 type DomainContextInfo struct {
@@ -81,6 +128,9 @@ type DomainContextInfo struct {
 	ContractAddress pldtypes.EthAddress `json:"contractAddress"`
 }
 
+// A function that is run against a domain context, such as the dry-run copy passed by DryRun
+type DomainContextFunction func(dc DomainContext) error
+
 // The DSI is the state interface that is exposed outside of the statestore package, for the
 // transaction engine to use to safely query and update the state in the context of a particular
 // domain.
@@ -109,6 +159,20 @@ type DomainContext interface {
 	// The dbTX is passed in to allow re-use of a connection during read operations.
 	FindAvailableStates(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON) (Schema, []*pldapi.State, error)
 
+	// FindAvailableStatesPage is FindAvailableStates with cursor based pagination, for enumerating a large
+	// number of states in chunks. Pass an empty cursor for the first page, then the returned nextCursor
+	// with the same query to continue from the last state returned. The query must have a limit, and
+	// results are sorted by the query's sort instructions followed by ".id" so that the order is stable.
+	// States inserted concurrently are returned in later pages only if they sort after the cursor.
+	// An empty nextCursor is returned when there are no more results.
+	FindAvailableStatesPage(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON, cursor string) (_ Schema, _ []*pldapi.State, nextCursor string, err error)
+
+	// FindAvailableStatesByIDs returns those of the specified states that are available, with the same
+	// rules as FindAvailableStates (including un-flushed states and spending locks in this domain context).
+	// States that are not found, or are not available, are omitted from the results.
+	// This is useful to check the states chosen as inputs to a transaction have not been spent.
+	FindAvailableStatesByIDs(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, ids []string) (Schema, []*pldapi.State, error)
+
 	// GetStatesByID retrieves a set of states by ID - regardless of whether they are:
 	// - Written to the DB or not (or just pending in the domain context)
 	// - Confirmed or not
@@ -118,9 +182,37 @@ type DomainContext interface {
 	// Return a snapshot of all currently known state locks
 	ExportSnapshot() ([]byte, error)
 
+	// MarkStatesUnspent makes states that were spent by a transaction available again, for example
+	// because the transaction was reverted on-chain. The spend records for the transaction are removed
+	// from the DB using the supplied transaction, and any in-memory spending locks it holds are released.
+	MarkStatesUnspent(dbTX persistence.DBTX, transactionID uuid.UUID, stateIDs []string) error
+
 	// ImportSnapshot is used to restore the state of the domain context, by adding a set of locks
 	ImportSnapshot([]byte) error
 
+	// DryRun calls the supplied function with a copy of this domain context, holding the same
+	// unflushed states and locks. Any states or locks added to the copy are discarded when the
+	// function returns (whether it succeeds or fails) and the copy cannot be flushed.
+	// This allows assembly logic to be validated without locking states in this context.
+	DryRun(fn DomainContextFunction) error
+
+	// SimulateAssemble runs the supplied function against a dry-run copy of this domain context, in the same
+	// way as DryRun, and returns the states that would have been created - those upserted with a create lock
+	// by the function, that this context was not already creating. All other mutations are discarded.
+	SimulateAssemble(fn DomainContextFunction) ([]*StateWithLabels, error)
+
+	// CrossDomainStateTransfer spends states of the contract of this context, and creates the correlated
+	// states in the target domain and contract, with a state_transfers record linking each pair. The spend
+	// and confirm records are written atomically - if the dbTX is not a full transaction, a new one is used.
+	// The target states are validated by the target domain in the same way as received states.
+	// Each source state must be confirmed, unspent (directly or via its nullifier), and not locked for spending
+	// by any domain context.
+	CrossDomainStateTransfer(dbTX persistence.DBTX, targetDomain string, targetContract pldtypes.EthAddress, transfers []*StateTransferRequest) error
+
+	// CountAvailableStates returns the number of states FindAvailableStates would return for the query
+	// (ignoring any limit), without loading the states from the DB.
+	CountAvailableStates(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON) (uint64, error)
+
 	// FindAvailableNullifiers is similar to FindAvailableStates, but for domains that leverage
 	// nullifiers to record spending.
 	//
@@ -168,6 +260,10 @@ type DomainContext interface {
 	// safely under the mutex of the domain context.
 	StateLocksByTransaction() map[uuid.UUID][]pldapi.StateLock
 
+	// GetLockStatus returns a copy of the locks held on a single state by transactions in this context,
+	// or nil if the state is not locked. Locks are only held in memory, so no DB query is performed.
+	GetLockStatus(stateID string) ([]pldapi.StateLock, error)
+
 	// Reset restores the world to the current state of the database, clearing any errors
 	// from failed flush, all un-flushed writes, and all in-memory state locks.
 	// It does not wait for an in-progress flush to complete
@@ -185,6 +281,12 @@ type DomainContext interface {
 	// If an error is returned by this function, then the postDBTx callback will be nil
 	Flush(dbTX persistence.DBTX) error
 
+	// FlushAsync performs the same synchronous flush as Flush, and then once the DB transaction
+	// commits queues the postCommit callback to a pool of workers in the state manager.
+	// This means post-commit work (such as notifying endorsers) does not block the caller,
+	// or the next flush. Errors from the callback are logged, and not returned to the caller.
+	FlushAsync(dbTX persistence.DBTX, postCommit func(ctx context.Context) error) error
+
 	// Removes the domain context from the state manager, and prevents any further use
 	Close()
 }
@@ -203,6 +305,16 @@ type StateUpsertOutsideContext struct {
 	Data            pldtypes.RawJSON
 }
 
+// A state to transfer out of the contract of a domain context, into a contract in another domain.
+// The source state is spent, and the target state created, by the transaction.
+type StateTransferRequest struct {
+	Transaction  uuid.UUID
+	SourceState  pldtypes.HexBytes
+	TargetID     pldtypes.HexBytes // optional, as for StateUpsertOutsideContext
+	TargetSchema pldtypes.Bytes32
+	TargetData   pldtypes.RawJSON
+}
+
 // StateWithLabels is a newly prepared state that has not yet been persisted
 type StateWithLabels struct {
 	*pldapi.State
@@ -223,6 +335,6 @@ type Schema interface {
 	ID() pldtypes.Bytes32
 	Signature() string
 	Persisted() *pldapi.Schema
-	ProcessState(ctx context.Context, contractAddress *pldtypes.EthAddress, data pldtypes.RawJSON, id pldtypes.HexBytes, customHash bool) (*StateWithLabels, error)
+	ProcessState(ctx context.Context, contractAddress *pldtypes.EthAddress, data pldtypes.RawJSON, id pldtypes.HexBytes, customHash bool, flagExternalID bool) (*StateWithLabels, error)
 	RecoverLabels(ctx context.Context, s *pldapi.State) (*StateWithLabels, error)
 }
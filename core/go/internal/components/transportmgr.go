@@ -37,6 +37,22 @@ type FireAndForgetMessageSend struct {
 	Payload       []byte
 }
 
+// The same fire-and-forget message, sent to each of a set of nodes
+type FireAndForgetMulticast struct {
+	Nodes               []string
+	Component           prototk.PaladinMsg_Component
+	MessageID           *uuid.UUID // the same ID is used for the message sent to every node
+	CorrelationID       *uuid.UUID
+	MessageType         string
+	Payload             []byte
+	RequireAllDelivered bool // fail the whole call if the message cannot be sent to any one of the nodes
+}
+
+type MulticastResult struct {
+	Node  string
+	Error error
+}
+
 type ReceivedMessage struct {
 	FromNode      string
 	MessageID     uuid.UUID
@@ -88,6 +104,9 @@ type TransportManager interface {
 	TransportRegistered(name string, id uuid.UUID, toTransport TransportManagerToTransport) (fromTransport plugintk.TransportCallbacks, err error)
 	LocalNodeName() string
 
+	// The largest message, once serialized, that is accepted from a transport plugin
+	MaxMessageBytes() int64
+
 	// Send a message - performs a cache-optimized registry lookup of the transport to use for the node,
 	// then synchronously calls the transport to *accept* the message for sending.
 	// The caller should assume this could involve I/O and hence might block the calling routine.
@@ -100,6 +119,12 @@ type TransportManager interface {
 	// at-most-once delivery semantics
 	Send(ctx context.Context, send *FireAndForgetMessageSend) error
 
+	// Send the same message to multiple nodes, in parallel - with the same at-most-once semantics as Send.
+	// There is one result per node (in the order supplied) containing the individual error, if any.
+	// An error is only returned for the call as a whole if RequireAllDelivered is set, and at least one
+	// of the sends failed.
+	Multicast(ctx context.Context, send *FireAndForgetMulticast) ([]*MulticastResult, error)
+
 	// Sends a message with at-least-once delivery semantics
 	//
 	// Each reliable message type has special building code in the transport manager, which assembles the full
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -47,20 +48,31 @@ import (
 	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
 )
 
+// The range of domain protocol versions this node can work with. Version 1 is the protocol that
+// pre-dates version negotiation, so it is assumed for plugins that do not return a negotiated version.
+const (
+	minDomainProtocolVersion uint32 = 1
+	maxDomainProtocolVersion uint32 = 1
+)
+
 type domain struct {
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 
-	conf            *pldconf.DomainConfig
-	defaultGasLimit pldtypes.HexUint64
-	dm              *domainManager
-	name            string
-	api             components.DomainManagerToDomain
-	registryAddress *pldtypes.EthAddress
+	conf                      *pldconf.DomainConfig
+	defaultGasLimit           pldtypes.HexUint64
+	requiredConfirmationDepth uint
+	flagExternalStateIDs      bool
+	dm                        *domainManager
+	name                      string
+	toDomain                  atomic.Pointer[components.DomainManagerToDomain] // replaced on hot-reload
+	registryAddress           *pldtypes.EthAddress
 
 	stateLock          sync.Mutex
 	initialized        atomic.Bool
 	initRetry          *retry.Retry
+	configureReq       *prototk.ConfigureDomainRequest
+	initReq            *prototk.InitDomainRequest
 	config             *prototk.DomainConfig
 	schemasBySignature map[string]components.Schema
 	schemasByID        map[string]components.Schema
@@ -69,8 +81,11 @@ type domain struct {
 	initError atomic.Pointer[error]
 	initDone  chan struct{}
 
-	inFlight     map[string]*inFlightDomainRequest
-	inFlightLock sync.Mutex
+	inFlight        map[string]*inFlightDomainRequest
+	inFlightLock    sync.Mutex
+	inFlightDrained chan struct{} // only set while waiting for in-flight requests to complete on hot-reload
+
+	reloadLock sync.Mutex
 }
 
 type inFlightDomainRequest struct {
@@ -90,7 +105,6 @@ func (dm *domainManager) newDomain(name string, conf *pldconf.DomainConfig, toDo
 		defaultGasLimit: DefaultDefaultGasLimit,                     // can be set by config below
 		initRetry:       retry.NewRetryIndefinite(&conf.Init.Retry), // indefinite retry
 		name:            name,
-		api:             toDomain,
 		initDone:        make(chan struct{}),
 		registryAddress: pldtypes.MustEthAddress(conf.RegistryAddress), // check earlier in startup
 
@@ -102,11 +116,21 @@ func (dm *domainManager) newDomain(name string, conf *pldconf.DomainConfig, toDo
 	if conf.DefaultGasLimit != nil {
 		d.defaultGasLimit = pldtypes.HexUint64(*conf.DefaultGasLimit)
 	}
+	if conf.RequiredConfirmationDepth != nil {
+		d.requiredConfirmationDepth = *conf.RequiredConfirmationDepth
+	}
+	d.flagExternalStateIDs = conf.FlagExternalStateIDs
+	d.toDomain.Store(&toDomain)
 	log.L(dm.bgCtx).Debugf("Domain %s configured. Config: %s", name, pldtypes.JSONString(conf.Config))
 	d.ctx, d.cancelCtx = context.WithCancel(log.WithLogField(dm.bgCtx, "domain", d.name))
 	return d
 }
 
+// The connection to the domain plugin, which is replaced when the plugin is hot-reloaded
+func (d *domain) api() components.DomainManagerToDomain {
+	return *d.toDomain.Load()
+}
+
 func (d *domain) processDomainConfig(dbTX persistence.DBTX, confRes *prototk.ConfigureDomainResponse) (*prototk.InitDomainRequest, error) {
 	d.stateLock.Lock()
 	defer d.stateLock.Unlock()
@@ -193,15 +217,14 @@ func (d *domain) init() {
 	err := d.initRetry.Do(d.ctx, func(attempt int) (bool, error) {
 
 		// Send the configuration to the domain for processing
-		confRes, err := d.api.ConfigureDomain(d.ctx, &prototk.ConfigureDomainRequest{
-			Name:                    d.name,
-			RegistryContractAddress: d.RegistryAddress().String(),
-			ChainId:                 d.dm.ethClientFactory.ChainID(),
-			ConfigJson:              pldtypes.JSONString(d.conf.Config).String(),
-		})
+		configureReq := d.newConfigureDomainRequest(d.conf.Config)
+		confRes, err := d.api().ConfigureDomain(d.ctx, configureReq)
 		if err != nil {
 			return true, err
 		}
+		if err := d.checkProtocolVersion(d.ctx, confRes); err != nil {
+			return true, err
+		}
 
 		// Process the configuration, so we can move onto init
 		var initReq *prototk.InitDomainRequest
@@ -214,7 +237,13 @@ func (d *domain) init() {
 		}
 
 		// Complete the initialization
-		_, err = d.api.InitDomain(d.ctx, initReq)
+		_, err = d.api().InitDomain(d.ctx, initReq)
+		if err == nil {
+			// Retained in case we need to replay them to a new connection from the plugin
+			d.stateLock.Lock()
+			d.configureReq, d.initReq = configureReq, initReq
+			d.stateLock.Unlock()
+		}
 
 		return true, err
 	})
@@ -226,8 +255,113 @@ func (d *domain) init() {
 		d.dm.setDomainAddress(d)
 		d.initialized.Store(true)
 		// Inform the plugin manager callback
-		d.api.Initialized()
+		d.api().Initialized()
+	}
+}
+
+func (d *domain) checkProtocolVersion(ctx context.Context, confRes *prototk.ConfigureDomainResponse) error {
+	version := confRes.NegotiatedVersion
+	if version == 0 {
+		version = 1
+	}
+	if version < minDomainProtocolVersion || version > maxDomainProtocolVersion {
+		err := i18n.NewError(ctx, msgs.MsgDomainProtocolVersionUnsupported, d.name, version, minDomainProtocolVersion, maxDomainProtocolVersion)
+		log.L(ctx).Error(err.Error())
+		return err
+	}
+	log.L(ctx).Debugf("Domain %s negotiated protocol version %d", d.name, version)
+	return nil
+}
+
+// Sends updated configuration to an initialized domain, which must accept any unchanged fields.
+// The schemas and events of the domain cannot change, as they are bound into the DB and event stream
+// during init - so if the domain returns different ones the previous configuration is restored.
+func (d *domain) reconfigure(ctx context.Context, newConfig map[string]any) error {
+	if err := d.checkInit(ctx); err != nil {
+		return err
+	}
+
+	// Cannot run in parallel with a hot-reload of the plugin, as that replays the current configuration,
+	// or with another reconfigure, as we compare against the current configuration
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
+	changed := changedConfigFields(d.conf.Config, newConfig)
+	if len(changed) == 0 {
+		log.L(ctx).Debugf("Domain %s reconfigure requested with no changes", d.name)
+		return nil
+	}
+
+	configureReq := d.newConfigureDomainRequest(newConfig)
+	confRes, err := d.api().ConfigureDomain(ctx, configureReq)
+	if err == nil {
+		err = d.checkProtocolVersion(ctx, confRes)
+	}
+	if err != nil {
+		return err
+	}
+
+	d.stateLock.Lock()
+	defer d.stateLock.Unlock()
+
+	newDomainConfig := confRes.DomainConfig
+	if fixedField := changedFixedConfigField(d.config, newDomainConfig); fixedField != "" {
+		if _, rollbackErr := d.api().ConfigureDomain(ctx, d.configureReq); rollbackErr != nil {
+			log.L(ctx).Errorf("Failed to restore previous configuration of domain %s: %s", d.name, rollbackErr)
+		}
+		return i18n.NewError(ctx, msgs.MsgDomainReconfigureNotAllowed, d.name, fixedField)
+	}
+
+	conf := *d.conf
+	conf.Config = newConfig
+	d.conf = &conf
+	d.config = newDomainConfig
+	d.configureReq = configureReq
+	d.dm.setDomainConfig(d.name, &conf)
+	log.L(ctx).Infof("Domain %s reconfigured. Changed fields: %v", d.name, changed)
+	return nil
+}
+
+func (d *domain) newConfigureDomainRequest(config map[string]any) *prototk.ConfigureDomainRequest {
+	return &prototk.ConfigureDomainRequest{
+		Name:                    d.name,
+		RegistryContractAddress: d.RegistryAddress().String(),
+		ChainId:                 d.dm.ethClientFactory.ChainID(),
+		MinProtocolVersion:      minDomainProtocolVersion,
+		MaxProtocolVersion:      maxDomainProtocolVersion,
+		ConfigJson:              pldtypes.JSONString(config).String(),
+	}
+}
+
+// The schemas and events of the domain are bound into the DB and event stream during init,
+// so returns the name of the first of those fields that differs (or "" if none do)
+func changedFixedConfigField(oldConfig, newConfig *prototk.DomainConfig) string {
+	switch {
+	case newConfig.CustomHashFunction != oldConfig.CustomHashFunction:
+		return "customHashFunction"
+	case !slices.Equal(newConfig.AbiStateSchemasJson, oldConfig.AbiStateSchemasJson):
+		return "abiStateSchemasJson"
+	case newConfig.AbiEventsJson != oldConfig.AbiEventsJson:
+		return "abiEventsJson"
+	}
+	return ""
+}
+
+// Returns the sorted names of the top-level fields that were added, removed or changed
+func changedConfigFields(oldConfig, newConfig map[string]any) []string {
+	var changed []string
+	for k, v := range newConfig {
+		if oldV, ok := oldConfig[k]; !ok || pldtypes.JSONString(oldV).String() != pldtypes.JSONString(v).String() {
+			changed = append(changed, k)
+		}
 	}
+	for k := range oldConfig {
+		if _, ok := newConfig[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	slices.Sort(changed)
+	return changed
 }
 
 func (d *domain) newInFlightDomainRequest(dbTX persistence.DBTX, dc components.DomainContext, readOnly bool) *inFlightDomainRequest {
@@ -248,6 +382,10 @@ func (i *inFlightDomainRequest) close() {
 	i.d.inFlightLock.Lock()
 	defer i.d.inFlightLock.Unlock()
 	delete(i.d.inFlight, i.id)
+	if len(i.d.inFlight) == 0 && i.d.inFlightDrained != nil {
+		close(i.d.inFlightDrained)
+		i.d.inFlightDrained = nil
+	}
 }
 
 func (d *domain) checkInFlight(ctx context.Context, stateQueryContext string, needWrite bool) (*inFlightDomainRequest, error) {
@@ -273,6 +411,15 @@ func (d *domain) checkInit(ctx context.Context) error {
 	return nil
 }
 
+func (d *domain) ping(ctx context.Context) error {
+	pingCtx, cancelCtx := context.WithTimeout(ctx, d.dm.pingTimeout)
+	defer cancelCtx()
+	if _, err := d.api().Ping(pingCtx, &prototk.PingRequest{}); err != nil {
+		return i18n.WrapError(ctx, err, msgs.MsgDomainPingFailed, d.name, d.dm.pingTimeout)
+	}
+	return nil
+}
+
 func (d *domain) Initialized() bool {
 	return d.initialized.Load()
 }
@@ -286,6 +433,9 @@ func (d *domain) RegistryAddress() *pldtypes.EthAddress {
 }
 
 func (d *domain) Configuration() *prototk.DomainConfig {
+	// can be swapped by a runtime reconfigure, or a hot-reload of the plugin
+	d.stateLock.Lock()
+	defer d.stateLock.Unlock()
 	return d.config
 }
 
@@ -439,7 +589,7 @@ func (d *domain) inlineEthSign(ctx context.Context, payload []byte, keyIdentifie
 		localKeyIdentifier, nodeName, err = pldtypes.PrivateIdentityLocator(keyIdentifier).Validate(ctx, "", true)
 	}
 
-	if err == nil && nodeName != "" && nodeName != d.dm.transportMgr.LocalNodeName() {
+	if err == nil && nodeName != "" && nodeName != d.dm.localNodeName {
 		return nil, i18n.NewError(ctx, msgs.MsgDomainSingingKeyMustBeLocalEthSign)
 	}
 
@@ -583,7 +733,7 @@ func (d *domain) InitDeploy(ctx context.Context, tx *components.PrivateContractD
 	txSpec.ConstructorParamsJson = tx.Inputs.String()
 
 	// Do the request with the domain
-	res, err := d.api.InitDeploy(ctx, &prototk.InitDeployRequest{
+	res, err := d.api().InitDeploy(ctx, &prototk.InitDeployRequest{
 		Transaction: txSpec,
 	})
 	if err != nil {
@@ -602,7 +752,7 @@ func (d *domain) PrepareDeploy(ctx context.Context, tx *components.PrivateContra
 
 	// All the work is done for us by the engine in resolving the verifiers
 	// after InitDeploy, so we just pass it along
-	res, err := d.api.PrepareDeploy(ctx, &prototk.PrepareDeployRequest{
+	res, err := d.api().PrepareDeploy(ctx, &prototk.PrepareDeployRequest{
 		Transaction:       tx.TransactionSpecification,
 		ResolvedVerifiers: tx.Verifiers,
 	})
@@ -669,7 +819,7 @@ func (d *domain) close() {
 }
 
 func (d *domain) getVerifier(ctx context.Context, algorithm string, verifierType string, privateKey []byte) (verifier string, err error) {
-	res, err := d.api.GetVerifier(ctx, &prototk.GetVerifierRequest{
+	res, err := d.api().GetVerifier(ctx, &prototk.GetVerifierRequest{
 		Algorithm:    algorithm,
 		VerifierType: verifierType,
 		PrivateKey:   privateKey,
@@ -681,7 +831,7 @@ func (d *domain) getVerifier(ctx context.Context, algorithm string, verifierType
 }
 
 func (d *domain) sign(ctx context.Context, algorithm string, payloadType string, privateKey []byte, payload []byte) (signature []byte, err error) {
-	res, err := d.api.Sign(ctx, &prototk.SignRequest{
+	res, err := d.api().Sign(ctx, &prototk.SignRequest{
 		Algorithm:   algorithm,
 		PayloadType: payloadType,
 		PrivateKey:  privateKey,
@@ -719,14 +869,22 @@ func (d *domain) toEndorsableListBase(states []*pldapi.StateBase) []*prototk.End
 
 func (d *domain) CustomHashFunction() bool {
 	// note config assured to be non-nil by GetDomainByName() not returning a domain until init complete
-	return d.config.CustomHashFunction
+	return d.Configuration().CustomHashFunction
+}
+
+func (d *domain) FlagExternalStateIDs() bool {
+	return d.flagExternalStateIDs
+}
+
+func (d *domain) RequiredConfirmationDepth() uint {
+	return d.requiredConfirmationDepth
 }
 
 func (d *domain) ValidateStateHashes(ctx context.Context, states []*components.FullState) ([]pldtypes.HexBytes, error) {
 	if len(states) == 0 {
 		return []pldtypes.HexBytes{}, nil
 	}
-	validateRes, err := d.api.ValidateStateHashes(d.ctx, &prototk.ValidateStateHashesRequest{
+	validateRes, err := d.api().ValidateStateHashes(d.ctx, &prototk.ValidateStateHashesRequest{
 		States: d.toEndorsableList(states),
 	})
 	if err != nil {
@@ -772,7 +930,7 @@ func (d *domain) BuildDomainReceipt(ctx context.Context, dbTX persistence.DBTX,
 	}
 
 	// As long as we have some knowledge, we call to the domain and see what it builds with what we have available
-	res, err := d.api.BuildReceipt(ctx, &prototk.BuildReceiptRequest{
+	res, err := d.api().BuildReceipt(ctx, &prototk.BuildReceiptRequest{
 		TransactionId: pldtypes.Bytes32UUIDFirst16(txID).String(),
 		Complete:      txStates.Unavailable == nil, // important for the domain to know if we have everything (it may fail with partial knowledge)
 		InputStates:   d.toEndorsableListBase(txStates.Spent),
@@ -822,7 +980,7 @@ func (d *domain) SendTransaction(ctx context.Context, req *prototk.SendTransacti
 
 func (d *domain) LocalNodeName(ctx context.Context, req *prototk.LocalNodeNameRequest) (*prototk.LocalNodeNameResponse, error) {
 	return &prototk.LocalNodeNameResponse{
-		Name: d.dm.transportMgr.LocalNodeName(),
+		Name: d.dm.localNodeName,
 	}, nil
 }
 
@@ -843,8 +1001,65 @@ func (d *domain) GetStatesByID(ctx context.Context, req *prototk.GetStatesByIDRe
 	}, err
 }
 
+// Unlike the other callbacks, this is not made in the context of a request from Paladin to the domain.
+// The states are written in their own DB transaction, and are trusted as they come from the domain
+// on our local node (no need to go back round ValidateStateHashes for custom hash functions).
+func (d *domain) PushStateUpdate(ctx context.Context, req *prototk.PushStateUpdateRequest) (*prototk.PushStateUpdateResponse, error) {
+	if err := d.checkInit(ctx); err != nil {
+		return nil, err
+	}
+
+	contractAddress, err := pldtypes.ParseEthAddress(req.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	psc, err := d.dm.GetSmartContractByAddress(ctx, d.dm.persistence.NOTX(), *contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if psc.Domain().Name() != d.name {
+		return nil, i18n.NewError(ctx, msgs.MsgDomainContractNotInDomain, contractAddress, d.name)
+	}
+
+	newStates := make([]*components.StateUpsertOutsideContext, len(req.States))
+	for i, state := range req.States {
+		var id pldtypes.HexBytes
+		if state.Id != nil {
+			id, err = pldtypes.ParseHexBytes(ctx, *state.Id)
+			if err != nil {
+				return nil, i18n.NewError(ctx, msgs.MsgDomainInvalidStateID, *state.Id)
+			}
+		}
+		schemaID, err := pldtypes.ParseBytes32(state.SchemaId)
+		if err != nil {
+			return nil, i18n.NewError(ctx, msgs.MsgDomainInvalidSchemaID, state.SchemaId)
+		}
+		newStates[i] = &components.StateUpsertOutsideContext{
+			ID:              id,
+			SchemaID:        schemaID,
+			ContractAddress: contractAddress,
+			Data:            pldtypes.RawJSON(state.StateDataJson),
+		}
+	}
+
+	var states []*pldapi.State
+	err = d.dm.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		states, err = d.dm.stateStore.WritePreVerifiedStates(ctx, dbTX, d.name, newStates)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stateIDs := make([]string, len(states))
+	for i, s := range states {
+		stateIDs[i] = s.ID.String()
+	}
+	return &prototk.PushStateUpdateResponse{StateIds: stateIDs}, nil
+}
+
 func (d *domain) ConfigurePrivacyGroup(ctx context.Context, inputConfiguration map[string]string) (configuration map[string]string, err error) {
-	res, err := d.api.ConfigurePrivacyGroup(ctx, &prototk.ConfigurePrivacyGroupRequest{
+	res, err := d.api().ConfigurePrivacyGroup(ctx, &prototk.ConfigurePrivacyGroupRequest{
 		InputConfiguration: inputConfiguration,
 	})
 	if err != nil {
@@ -857,7 +1072,7 @@ func (d *domain) InitPrivacyGroup(ctx context.Context, id pldtypes.HexBytes, gen
 
 	// This one is a straight forward pass-through to the domain - the Privacy Group manager does the
 	// hard work in validating the data returned against the genesis ABI spec returned.
-	res, err := d.api.InitPrivacyGroup(ctx, &prototk.InitPrivacyGroupRequest{
+	res, err := d.api().InitPrivacyGroup(ctx, &prototk.InitPrivacyGroupRequest{
 		PrivacyGroup: mapPrivacyGroupToProto(id, genesis),
 	})
 	if err != nil {
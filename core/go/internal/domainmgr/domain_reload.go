@@ -0,0 +1,127 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package domainmgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// Everything a new connection from the domain plugin needs to be brought up to the same point
+// as the one it replaces. The rest of the in-memory state for the domain (schemas, event stream,
+// in-flight requests) stays on the domain, which is retained across the reload.
+type domainReloadSnapshot struct {
+	configureReq *prototk.ConfigureDomainRequest
+	initReq      *prototk.InitDomainRequest
+	config       *prototk.DomainConfig
+}
+
+func (d *domain) reloadSnapshot() *domainReloadSnapshot {
+	d.stateLock.Lock()
+	defer d.stateLock.Unlock()
+	return &domainReloadSnapshot{
+		configureReq: d.configureReq,
+		initReq:      d.initReq,
+		config:       d.config,
+	}
+}
+
+// Hot-reload switches an initialized domain over to a new connection from the plugin (such as when the
+// plugin binary is updated) without losing the requests that are in-flight. Requests continue to be sent
+// over the old connection until the new one has been sent the same configure and init requests, and only
+// then is the new connection notified it is initialized.
+func (d *domain) reload(newAPI components.DomainManagerToDomain) {
+	d.reloadLock.Lock()
+	defer d.reloadLock.Unlock()
+
+	snapshot := d.reloadSnapshot()
+	log.L(d.ctx).Infof("Domain %s plugin reconnected. Hot-reloading", d.name)
+
+	// Give the requests in-flight to the old connection the chance to complete
+	d.waitInFlightDrained(d.ctx, d.dm.reloadDrainTimeout)
+
+	// We block retrying until we succeed, or are cancelled, in the same way as init
+	err := d.initRetry.Do(d.ctx, func(attempt int) (bool, error) {
+		return true, d.replayReloadSnapshot(d.ctx, newAPI, snapshot)
+	})
+	if err != nil {
+		log.L(d.ctx).Errorf("Domain %s hot-reload cancelled before completion: %s", d.name, err)
+		return
+	}
+
+	d.toDomain.Store(&newAPI)
+	log.L(d.ctx).Infof("Domain %s hot-reload complete", d.name)
+	newAPI.Initialized()
+}
+
+func (d *domain) replayReloadSnapshot(ctx context.Context, newAPI components.DomainManagerToDomain, snapshot *domainReloadSnapshot) error {
+	confRes, err := newAPI.ConfigureDomain(ctx, snapshot.configureReq)
+	if err != nil {
+		return err
+	}
+	if err := d.checkProtocolVersion(ctx, confRes); err != nil {
+		return err
+	}
+	// The new plugin must be compatible with what is bound into the DB and event stream for the domain
+	if fixedField := changedFixedConfigField(snapshot.config, confRes.DomainConfig); fixedField != "" {
+		return i18n.NewError(ctx, msgs.MsgDomainReconfigureNotAllowed, d.name, fixedField)
+	}
+	if _, err := newAPI.InitDomain(ctx, snapshot.initReq); err != nil {
+		return err
+	}
+
+	d.stateLock.Lock()
+	d.config = confRes.DomainConfig
+	d.stateLock.Unlock()
+	return nil
+}
+
+// Waits until there are no requests in-flight, or the timeout is reached. New requests
+// can still start while we wait, so we do not fail if the timeout is reached.
+func (d *domain) waitInFlightDrained(ctx context.Context, timeout time.Duration) {
+	d.inFlightLock.Lock()
+	remaining := len(d.inFlight)
+	if remaining == 0 {
+		d.inFlightLock.Unlock()
+		return
+	}
+	drained := make(chan struct{})
+	d.inFlightDrained = drained
+	d.inFlightLock.Unlock()
+
+	log.L(ctx).Infof("Waiting up to %s for %d requests in-flight to domain %s to complete", timeout, remaining, d.name)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+		return
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	d.inFlightLock.Lock()
+	defer d.inFlightLock.Unlock()
+	if d.inFlightDrained == drained {
+		d.inFlightDrained = nil
+	}
+	log.L(ctx).Warnf("Continuing hot-reload of domain %s with %d requests in-flight", d.name, len(d.inFlight))
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package domainmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReloadTestPlugin(t *testing.T, td *testDomainContext, domainConfig *prototk.DomainConfig) *testPlugin {
+	return newTestPlugin(&plugintk.DomainAPIFunctions{
+		ConfigureDomain: func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+			assert.Equal(t, "test1", cdr.Name)
+			assert.JSONEq(t, `{"some":"conf"}`, cdr.ConfigJson)
+			return &prototk.ConfigureDomainResponse{
+				DomainConfig: domainConfig,
+			}, nil
+		},
+		InitDomain: func(ctx context.Context, idr *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error) {
+			assert.Equal(t, td.tp.stateSchemas, idr.AbiStateSchemas)
+			return &prototk.InitDomainResponse{}, nil
+		},
+	})
+}
+
+func TestDomainHotReload(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+
+	newDomainConf := goodDomainConf()
+	newDomainConf.SigningAlgorithms = map[string]int32{"ecdsa:secp256k1": 32}
+	tp2 := newReloadTestPlugin(t, td, newDomainConf)
+
+	// The existing domain is kept, rather than being replaced
+	fromDomain, err := td.dm.DomainRegistered("test1", tp2)
+	require.NoError(t, err)
+	assert.Same(t, td.d, fromDomain)
+
+	// The switch-over waits for the request in-flight to complete
+	time.Sleep(10 * time.Millisecond)
+	assert.False(t, tp2.initialized.Load())
+	assert.Same(t, td.tp, td.d.api())
+	td.c.close()
+
+	require.Eventually(t, tp2.initialized.Load, 5*time.Second, 1*time.Millisecond)
+	assert.Same(t, tp2, td.d.api())
+	assert.Same(t, newDomainConf, td.d.Configuration())
+	assert.True(t, td.d.Initialized())
+}
+
+func TestDomainHotReloadDrainTimeout(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+
+	td.dm.reloadDrainTimeout = 1 * time.Millisecond
+	tp2 := newReloadTestPlugin(t, td, goodDomainConf())
+
+	// The request in-flight does not complete, but we continue
+	td.d.reload(tp2)
+	assert.True(t, tp2.initialized.Load())
+	assert.Same(t, tp2, td.d.api())
+	assert.Nil(t, td.d.inFlightDrained)
+}
+
+func TestDomainHotReloadSchemaChangeRejected(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+	td.c.close()
+
+	tp2 := newReloadTestPlugin(t, td, &prototk.DomainConfig{
+		AbiStateSchemasJson: []string{`{}`},
+	})
+
+	td.d.reload(tp2)
+	assert.False(t, tp2.initialized.Load())
+	assert.Same(t, td.tp, td.d.api())
+}
+
+func TestDomainHotReloadFail(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+	td.c.close()
+
+	tp2 := newReloadTestPlugin(t, td, goodDomainConf())
+	tp2.Functions.InitDomain = func(ctx context.Context, idr *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	td.d.reload(tp2)
+	assert.False(t, tp2.initialized.Load())
+
+	tp2.Functions.ConfigureDomain = func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	td.d.reload(tp2)
+	assert.False(t, tp2.initialized.Load())
+
+	tp2.Functions.ConfigureDomain = func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+		return &prototk.ConfigureDomainResponse{DomainConfig: goodDomainConf(), NegotiatedVersion: maxDomainProtocolVersion + 1}, nil
+	}
+	td.d.reload(tp2)
+	assert.False(t, tp2.initialized.Load())
+	assert.Same(t, td.tp, td.d.api())
+}
@@ -19,8 +19,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
@@ -161,6 +163,7 @@ type testPlugin struct {
 	initialized  atomic.Bool
 	d            *domain
 	stateSchemas []*prototk.StateSchema
+	ping         func(ctx context.Context) error
 }
 
 type testDomainContext struct {
@@ -177,6 +180,15 @@ func (tp *testPlugin) Initialized() {
 	tp.initialized.Store(true)
 }
 
+func (tp *testPlugin) Ping(ctx context.Context, req *prototk.PingRequest) (*prototk.PingResponse, error) {
+	if tp.ping != nil {
+		if err := tp.ping(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &prototk.PingResponse{}, nil
+}
+
 func newTestPlugin(domainFuncs *plugintk.DomainAPIFunctions) *testPlugin {
 	return &testPlugin{
 		DomainAPIBase: plugintk.DomainAPIBase{
@@ -190,10 +202,12 @@ func newTestDomain(t *testing.T, realDB bool, domainConfig *prototk.DomainConfig
 	ctx, dm, mc, dmDone := newTestDomainManager(t, realDB, &pldconf.DomainManagerConfig{
 		Domains: map[string]*pldconf.DomainConfig{
 			"test1": {
-				Config:          map[string]any{"some": "conf"},
-				RegistryAddress: pldtypes.RandHex(20),
-				DefaultGasLimit: confutil.P(uint64(100000)),
-				Init:            pldconf.DomainInitConfig{},
+				Config:                    map[string]any{"some": "conf"},
+				RegistryAddress:           pldtypes.RandHex(20),
+				DefaultGasLimit:           confutil.P(uint64(100000)),
+				RequiredConfirmationDepth: confutil.P(uint(3)),
+				FlagExternalStateIDs:      true,
+				Init:                      pldconf.DomainInitConfig{},
 			},
 		},
 	}, extraSetup...)
@@ -233,21 +247,21 @@ func newTestDomain(t *testing.T, realDB bool, domainConfig *prototk.DomainConfig
 	}
 
 	return &testDomainContext{
-			ctx:             ctx,
-			dm:              dm,
-			d:               tp.d,
-			tp:              tp,
-			c:               c,
-			mdc:             mdc,
-			contractAddress: addr,
-		}, func() {
-			c.close()
-			c.dCtx.Close()
-			if mdc != nil {
-				mdc.Close()
-			}
-			dmDone()
+		ctx:             ctx,
+		dm:              dm,
+		d:               tp.d,
+		tp:              tp,
+		c:               c,
+		mdc:             mdc,
+		contractAddress: addr,
+	}, func() {
+		c.close()
+		c.dCtx.Close()
+		if mdc != nil {
+			mdc.Close()
 		}
+		dmDone()
+	}
 }
 
 func registerTestDomain(t *testing.T, dm *domainManager, tp *testPlugin) {
@@ -294,6 +308,8 @@ func TestDomainInitStates(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, td.d, byAddr)
 	assert.True(t, td.d.Initialized())
+	assert.Equal(t, uint(3), td.d.RequiredConfirmationDepth())
+	assert.True(t, td.d.FlagExternalStateIDs())
 
 }
 
@@ -457,6 +473,40 @@ func TestDomainConfigureFail(t *testing.T) {
 	assert.Regexp(t, "pop", *d.initError.Load())
 }
 
+func TestDomainConfigureProtocolVersionUnsupported(t *testing.T) {
+
+	ctx, dm, _, done := newTestDomainManager(t, false, &pldconf.DomainManagerConfig{
+		Domains: map[string]*pldconf.DomainConfig{
+			"test1": {
+				Config:          map[string]any{"some": "config"},
+				RegistryAddress: pldtypes.RandHex(20),
+			},
+		},
+	})
+	defer done()
+
+	tp := newTestPlugin(&plugintk.DomainAPIFunctions{
+		ConfigureDomain: func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+			assert.Equal(t, minDomainProtocolVersion, cdr.MinProtocolVersion)
+			assert.Equal(t, maxDomainProtocolVersion, cdr.MaxProtocolVersion)
+			return &prototk.ConfigureDomainResponse{
+				DomainConfig:      &prototk.DomainConfig{},
+				NegotiatedVersion: maxDomainProtocolVersion + 1,
+			}, nil
+		},
+	})
+
+	_, err := dm.DomainRegistered("test1", tp)
+	require.NoError(t, err)
+
+	d, err := dm.getDomainByName(ctx, "test1")
+	require.NoError(t, err)
+
+	d.initRetry.UTSetMaxAttempts(1)
+	<-d.initDone
+	assert.Regexp(t, "PD011669", *d.initError.Load())
+}
+
 func TestDomainFindAvailableStatesNotInit(t *testing.T) {
 	td, done := newTestDomain(t, false, &prototk.DomainConfig{
 		AbiStateSchemasJson: []string{`{!!! invalid`},
@@ -1094,6 +1144,87 @@ func TestGetStatesFailCases(t *testing.T) {
 	require.EqualError(t, err, "pop")
 }
 
+func TestPushStateUpdate(t *testing.T) {
+	var mc *mockComponents
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(_mc *mockComponents) {
+		mc = _mc
+	})
+	defer done()
+
+	psc := goodPSC(t, td)
+	td.dm.contractCache.Set(psc.info.Address, psc)
+
+	schemaID := pldtypes.RandBytes32()
+	stateID := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	mc.db.ExpectBegin()
+	mc.stateStore.On("WritePreVerifiedStates", mock.Anything, mock.Anything, "test1", []*components.StateUpsertOutsideContext{
+		{ID: stateID, SchemaID: schemaID, ContractAddress: &psc.info.Address, Data: pldtypes.RawJSON(`{"some":"data"}`)},
+		{SchemaID: schemaID, ContractAddress: &psc.info.Address, Data: pldtypes.RawJSON(`{"other":"data"}`)},
+	}).Return([]*pldapi.State{
+		{StateBase: pldapi.StateBase{ID: stateID}},
+		{StateBase: pldapi.StateBase{ID: pldtypes.HexBytes{0x12, 0x34}}},
+	}, nil)
+	mc.db.ExpectCommit()
+
+	res, err := td.d.PushStateUpdate(td.ctx, &prototk.PushStateUpdateRequest{
+		ContractAddress: psc.info.Address.String(),
+		States: []*prototk.StateUpsert{
+			{Id: confutil.P(stateID.String()), SchemaId: schemaID.String(), StateDataJson: `{"some":"data"}`},
+			{SchemaId: schemaID.String(), StateDataJson: `{"other":"data"}`},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{stateID.String(), "0x1234"}, res.StateIds)
+}
+
+func TestPushStateUpdateFailCases(t *testing.T) {
+	var mc *mockComponents
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas(), func(_mc *mockComponents) {
+		mc = _mc
+	})
+	defer done()
+
+	psc := goodPSC(t, td)
+	td.dm.contractCache.Set(psc.info.Address, psc)
+
+	_, err := td.d.PushStateUpdate(td.ctx, &prototk.PushStateUpdateRequest{
+		ContractAddress: "bad",
+	})
+	require.ErrorContains(t, err, "bad address")
+
+	otherAddr := pldtypes.RandAddress()
+	td.dm.contractCache.Set(*otherAddr, &domainContract{d: &domain{name: "test2"}})
+	_, err = td.d.PushStateUpdate(td.ctx, &prototk.PushStateUpdateRequest{
+		ContractAddress: otherAddr.String(),
+	})
+	require.ErrorContains(t, err, "PD011670")
+
+	_, err = td.d.PushStateUpdate(td.ctx, &prototk.PushStateUpdateRequest{
+		ContractAddress: psc.info.Address.String(),
+		States:          []*prototk.StateUpsert{{Id: confutil.P("bad")}},
+	})
+	require.ErrorContains(t, err, "PD011650")
+
+	_, err = td.d.PushStateUpdate(td.ctx, &prototk.PushStateUpdateRequest{
+		ContractAddress: psc.info.Address.String(),
+		States:          []*prototk.StateUpsert{{SchemaId: "bad"}},
+	})
+	require.ErrorContains(t, err, "PD011641")
+
+	mc.db.ExpectBegin()
+	mc.stateStore.On("WritePreVerifiedStates", mock.Anything, mock.Anything, "test1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	mc.db.ExpectRollback()
+	_, err = td.d.PushStateUpdate(td.ctx, &prototk.PushStateUpdateRequest{
+		ContractAddress: psc.info.Address.String(),
+		States:          []*prototk.StateUpsert{{SchemaId: pldtypes.RandBytes32().String()}},
+	})
+	require.EqualError(t, err, "pop")
+
+	td.d.initialized.Store(false)
+	_, err = td.d.PushStateUpdate(td.ctx, &prototk.PushStateUpdateRequest{})
+	require.ErrorContains(t, err, "PD011601")
+}
+
 func TestMapStateLockType(t *testing.T) {
 	for _, pldType := range pldapi.StateLockType("").Options() {
 		assert.NotNil(t, mapStateLockType(pldapi.StateLockType(pldType)))
@@ -1484,3 +1615,141 @@ func TestDomainInitPrivacyGroupBadResFromAddr(t *testing.T) {
 	assert.Regexp(t, "bad address", err)
 
 }
+
+func TestDomainReconfigure(t *testing.T) {
+	td, done := newTestDomain(t, true, goodDomainConf())
+	defer done()
+
+	// No changes is a no-op that does not call the domain
+	err := td.dm.ReconfigureDomain(td.ctx, "test1", map[string]any{"some": "conf"})
+	require.NoError(t, err)
+
+	newDomainConf := goodDomainConf()
+	newDomainConf.SigningAlgorithms = map[string]int32{"ecdsa:secp256k1": 32}
+	configureCalls := 0
+	td.tp.Functions.ConfigureDomain = func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+		configureCalls++
+		assert.JSONEq(t, `{"some":"newconf","other":true}`, cdr.ConfigJson)
+		return &prototk.ConfigureDomainResponse{DomainConfig: newDomainConf}, nil
+	}
+
+	err = td.dm.ReconfigureDomain(td.ctx, "test1", map[string]any{"some": "newconf", "other": true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, configureCalls)
+	assert.Same(t, newDomainConf, td.d.Configuration())
+	assert.Equal(t, map[string]any{"some": "newconf", "other": true}, td.dm.conf.Domains["test1"].Config)
+
+	// Reconfiguring again with the same config is idempotent
+	err = td.dm.ReconfigureDomain(td.ctx, "test1", map[string]any{"some": "newconf", "other": true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, configureCalls)
+}
+
+func TestDomainReconfigureConcurrent(t *testing.T) {
+	td, done := newTestDomain(t, true, goodDomainConf())
+	defer done()
+
+	td.tp.Functions.ConfigureDomain = func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+		return &prototk.ConfigureDomainResponse{DomainConfig: goodDomainConf()}, nil
+	}
+
+	// Reconfigure in parallel with readers of the domain and manager configuration (run with -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			err := td.dm.ReconfigureDomain(td.ctx, "test1", map[string]any{"some": fmt.Sprintf("conf%d", i)})
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NotNil(t, td.d.Configuration())
+			assert.False(t, td.d.CustomHashFunction())
+			assert.Contains(t, td.dm.ConfiguredDomains(), "test1")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDomainReconfigureSchemaChangeRejected(t *testing.T) {
+	td, done := newTestDomain(t, true, goodDomainConf())
+	defer done()
+	origDomainConf := td.d.Configuration()
+
+	var sentConfigs []string
+	td.tp.Functions.ConfigureDomain = func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+		sentConfigs = append(sentConfigs, cdr.ConfigJson)
+		return &prototk.ConfigureDomainResponse{DomainConfig: &prototk.DomainConfig{
+			AbiStateSchemasJson: []string{`{}`},
+		}}, nil
+	}
+
+	err := td.dm.ReconfigureDomain(td.ctx, "test1", map[string]any{"some": "newconf"})
+	assert.Regexp(t, "PD011667.*abiStateSchemasJson", err)
+
+	// The previous configuration is restored in the domain
+	require.Len(t, sentConfigs, 2)
+	assert.JSONEq(t, `{"some":"newconf"}`, sentConfigs[0])
+	assert.JSONEq(t, `{"some":"conf"}`, sentConfigs[1])
+	assert.Same(t, origDomainConf, td.d.Configuration())
+	assert.Equal(t, map[string]any{"some": "conf"}, td.dm.conf.Domains["test1"].Config)
+}
+
+func TestDomainReconfigureError(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+
+	td.tp.Functions.ConfigureDomain = func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+		return nil, fmt.Errorf("pop")
+	}
+
+	err := td.dm.ReconfigureDomain(td.ctx, "test1", map[string]any{"some": "newconf"})
+	assert.Regexp(t, "pop", err)
+
+	td.tp.Functions.ConfigureDomain = func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+		return &prototk.ConfigureDomainResponse{DomainConfig: goodDomainConf(), NegotiatedVersion: maxDomainProtocolVersion + 1}, nil
+	}
+	err = td.dm.ReconfigureDomain(td.ctx, "test1", map[string]any{"some": "newconf"})
+	assert.Regexp(t, "PD011669", err)
+
+	err = td.dm.ReconfigureDomain(td.ctx, "unknown", map[string]any{})
+	assert.Regexp(t, "PD011600", err)
+}
+
+func TestDomainReconfigureNotInitialized(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf(), mockSchemas())
+	defer done()
+
+	td.d.initialized.Store(false)
+	err := td.dm.ReconfigureDomain(td.ctx, "test1", map[string]any{"some": "newconf"})
+	assert.Regexp(t, "PD011601", err)
+}
+
+func TestChangedConfigFields(t *testing.T) {
+	assert.Empty(t, changedConfigFields(map[string]any{"a": 1, "b": []any{"x"}}, map[string]any{"b": []any{"x"}, "a": 1}))
+	assert.Equal(t, []string{"a", "c", "d"}, changedConfigFields(
+		map[string]any{"a": 1, "b": "same", "c": "removed"},
+		map[string]any{"a": 2, "b": "same", "d": "added"},
+	))
+}
+
+func TestPingDomain(t *testing.T) {
+	td, done := newTestDomain(t, false, goodDomainConf())
+	defer done()
+	td.dm.pingTimeout = 10 * time.Millisecond
+
+	err := td.dm.PingDomain(td.ctx, "test1")
+	require.NoError(t, err)
+
+	err = td.dm.PingDomain(td.ctx, "unknown")
+	assert.Regexp(t, "PD011600", err)
+
+	// A plugin that does not respond fails the ping once the timeout is reached
+	td.tp.ping = func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	err = td.dm.PingDomain(td.ctx, "test1")
+	assert.Regexp(t, "PD011668.*test1.*10ms", err)
+}
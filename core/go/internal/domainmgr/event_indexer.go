@@ -18,6 +18,7 @@ package domainmgr
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/google/uuid"
@@ -137,6 +138,38 @@ func (dm *domainManager) notifyTransactions(txCompletions txCompletionsOrdered)
 
 }
 
+// Published to WebSocket clients subscribed with pld_subscribe to the "domain:<name>:confirmed" topic,
+// once the confirmation of states for a private smart contract has been committed
+type statesConfirmedEvent struct {
+	Domain          string                `json:"domain"`
+	ContractAddress pldtypes.EthAddress   `json:"contractAddress"`
+	States          []*confirmedStateInfo `json:"states"`
+}
+
+type confirmedStateInfo struct {
+	ID          pldtypes.HexBytes `json:"id"`
+	Transaction uuid.UUID         `json:"transaction"`
+}
+
+func newStatesConfirmedEvent(domainName string, addr pldtypes.EthAddress, stateConfirms []*pldapi.StateConfirmRecord) *statesConfirmedEvent {
+	ev := &statesConfirmedEvent{
+		Domain:          domainName,
+		ContractAddress: addr,
+		States:          make([]*confirmedStateInfo, len(stateConfirms)),
+	}
+	for i, sc := range stateConfirms {
+		ev.States[i] = &confirmedStateInfo{ID: sc.State, Transaction: sc.Transaction}
+	}
+	return ev
+}
+
+func (d *domain) publishStatesConfirmed(events []*statesConfirmedEvent) {
+	topic := fmt.Sprintf("domain:%s:confirmed", d.name)
+	for _, ev := range events {
+		d.dm.rpcServer.Publish(topic, ev)
+	}
+}
+
 func (d *domain) batchEventsByAddress(ctx context.Context, dbTX persistence.DBTX, batchID string, events []*pldapi.EventWithData) (map[pldtypes.EthAddress]*pscEventBatch, error) {
 
 	batches := make(map[pldtypes.EthAddress]*pscEventBatch)
@@ -196,11 +229,15 @@ func (d *domain) handleEventBatch(ctx context.Context, dbTX persistence.DBTX, ba
 	if err != nil {
 		return err
 	}
+	var statesConfirmed []*statesConfirmedEvent
 	for addr, batch := range batchesByAddress {
-		res, err := d.handleEventBatchForContract(ctx, dbTX, addr, batch)
+		res, stateConfirms, err := d.handleEventBatchForContract(ctx, dbTX, addr, batch)
 		if err != nil {
 			return err
 		}
+		if len(stateConfirms) > 0 {
+			statesConfirmed = append(statesConfirmed, newStatesConfirmedEvent(d.name, addr, stateConfirms))
+		}
 		for _, txCompletionEvent := range res.TransactionsComplete {
 			var txHash pldtypes.Bytes32
 			txID, err := d.recoverTransactionID(ctx, txCompletionEvent.TransactionId)
@@ -258,6 +295,7 @@ func (d *domain) handleEventBatch(ctx context.Context, dbTX persistence.DBTX, ba
 
 	dbTX.AddPostCommit(func(txCtx context.Context) {
 		d.dm.notifyTransactions(txCompletions)
+		d.publishStatesConfirmed(statesConfirmed)
 	})
 	return nil
 }
@@ -271,7 +309,7 @@ func (d *domain) recoverTransactionID(ctx context.Context, txIDString string) (*
 	return &txUUID, nil
 }
 
-func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persistence.DBTX, addr pldtypes.EthAddress, batch *pscEventBatch) (*prototk.HandleEventBatchResponse, error) {
+func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persistence.DBTX, addr pldtypes.EthAddress, batch *pscEventBatch) (*prototk.HandleEventBatchResponse, []*pldapi.StateConfirmRecord, error) {
 
 	// We have a domain context for queries, but we never flush it to DB - as the only updates
 	// we allow in this function are those performed within our dbTX.
@@ -281,16 +319,16 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 	batch.StateQueryContext = c.id
 
 	var res *prototk.HandleEventBatchResponse
-	res, err := d.api.HandleEventBatch(ctx, &batch.HandleEventBatchRequest)
+	res, err := d.api().HandleEventBatch(ctx, &batch.HandleEventBatchRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	stateSpends := make([]*pldapi.StateSpendRecord, len(res.SpentStates))
 	for i, state := range res.SpentStates {
 		txUUID, stateID, err := d.prepareIndexRecord(ctx, state.TransactionId, state.Id)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		stateSpends[i] = &pldapi.StateSpendRecord{DomainName: d.name, State: stateID, Transaction: txUUID}
 	}
@@ -299,7 +337,7 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 	for i, state := range res.ReadStates {
 		txUUID, stateID, err := d.prepareIndexRecord(ctx, state.TransactionId, state.Id)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		stateReads[i] = &pldapi.StateReadRecord{DomainName: d.name, State: stateID, Transaction: txUUID}
 	}
@@ -308,7 +346,7 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 	for i, state := range res.ConfirmedStates {
 		txUUID, stateID, err := d.prepareIndexRecord(ctx, state.TransactionId, state.Id)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		stateConfirms[i] = &pldapi.StateConfirmRecord{DomainName: d.name, State: stateID, Transaction: txUUID}
 	}
@@ -317,7 +355,7 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 	for i, state := range res.InfoStates {
 		txUUID, stateID, err := d.prepareIndexRecord(ctx, state.TransactionId, state.Id)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		stateInfoRecords[i] = &pldapi.StateInfoRecord{DomainName: d.name, State: stateID, Transaction: txUUID}
 	}
@@ -328,16 +366,16 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 		if state.Id != nil {
 			id, err = pldtypes.ParseHexBytes(ctx, *state.Id)
 			if err != nil {
-				return nil, i18n.NewError(ctx, msgs.MsgDomainInvalidStateID, *state.Id)
+				return nil, nil, i18n.NewError(ctx, msgs.MsgDomainInvalidStateID, *state.Id)
 			}
 		}
 		txUUID, err := d.recoverTransactionID(ctx, state.TransactionId)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		schemaID, err := pldtypes.ParseBytes32(state.SchemaId)
 		if err != nil {
-			return nil, i18n.NewError(ctx, msgs.MsgDomainInvalidSchemaID, state.SchemaId)
+			return nil, nil, i18n.NewError(ctx, msgs.MsgDomainInvalidSchemaID, state.SchemaId)
 		}
 		newStates = append(newStates, &components.StateUpsertOutsideContext{
 			ID:              id,
@@ -355,17 +393,17 @@ func (d *domain) handleEventBatchForContract(ctx context.Context, dbTX persisten
 		// These states are trusted as they come from the domain on our local node (no need to go back round VerifyStateHashes for customer hash functions)
 		_, err = d.dm.stateStore.WritePreVerifiedStates(ctx, dbTX, d.name, newStates)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	// Then any finalizations of those states
 	if len(stateSpends) > 0 || len(stateReads) > 0 || len(stateConfirms) > 0 || len(stateInfoRecords) > 0 {
 		if err := d.dm.stateStore.WriteStateFinalizations(ctx, dbTX, stateSpends, stateReads, stateConfirms, stateInfoRecords); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
-	return res, err
+	return res, stateConfirms, err
 }
 
 func (d *domain) prepareIndexRecord(ctx context.Context, txIDStr, stateIDStr string) (uuid.UUID, pldtypes.HexBytes, error) {
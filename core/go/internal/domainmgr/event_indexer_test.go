@@ -275,6 +275,15 @@ func TestHandleEventBatch(t *testing.T) {
 
 		mc.txManager.On("SendTransactions", mock.Anything, mock.Anything, mock.Anything).Return([]uuid.UUID{txID}, nil)
 
+		mc.rpcServer.On("Publish", "domain:test1:confirmed", &statesConfirmedEvent{
+			Domain:          "test1",
+			ContractAddress: *contract2,
+			States: []*confirmedStateInfo{
+				{ID: pldtypes.MustParseHexBytes(stateConfirmed), Transaction: txID},
+				{ID: pldtypes.MustParseHexBytes(fakeHash1), Transaction: txID},
+			},
+		}).Return()
+
 	})
 	defer done()
 	d := td.d
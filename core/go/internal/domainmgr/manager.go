@@ -19,12 +19,14 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"time"
 
 	_ "embed"
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
@@ -67,12 +69,14 @@ func NewDomainManager(bgCtx context.Context, conf *pldconf.DomainManagerConfig)
 	}
 	log.L(bgCtx).Infof("Domains configured: %v", allDomains)
 	return &domainManager{
-		bgCtx:            bgCtx,
-		conf:             conf,
-		domainsByName:    make(map[string]*domain),
-		domainsByAddress: make(map[pldtypes.EthAddress]*domain),
-		privateTxWaiter:  inflight.NewInflightManager[uuid.UUID, *components.ReceiptInput](uuid.Parse),
-		contractCache:    cache.NewCache[pldtypes.EthAddress, *domainContract](&conf.DomainManager.ContractCache, pldconf.ContractCacheDefaults),
+		bgCtx:              bgCtx,
+		conf:               conf,
+		domainsByName:      make(map[string]*domain),
+		domainsByAddress:   make(map[pldtypes.EthAddress]*domain),
+		privateTxWaiter:    inflight.NewInflightManager[uuid.UUID, *components.ReceiptInput](uuid.Parse),
+		contractCache:      cache.NewCache[pldtypes.EthAddress, *domainContract](&conf.DomainManager.ContractCache, pldconf.ContractCacheDefaults),
+		pingTimeout:        confutil.DurationMin(conf.DomainManager.PingTimeout, 0, *pldconf.DomainManagerDefaults.PingTimeout),
+		reloadDrainTimeout: confutil.DurationMin(conf.DomainManager.ReloadDrainTimeout, 0, *pldconf.DomainManagerDefaults.ReloadDrainTimeout),
 	}
 }
 
@@ -85,18 +89,21 @@ type domainManager struct {
 	stateStore       components.StateManager
 	privateTxManager components.PrivateTxManager
 	txManager        components.TXManager
-	transportMgr     components.TransportManager
+	localNodeName    string
 	blockIndexer     blockindexer.BlockIndexer
 	keyManager       components.KeyManager
 	ethClientFactory ethclient.EthClientFactory
 	domainSigner     *domainSigner
 	rpcModule        *rpcserver.RPCModule
+	rpcServer        rpcserver.RPCServer
 
 	domainsByName    map[string]*domain
 	domainsByAddress map[pldtypes.EthAddress]*domain
 
-	privateTxWaiter *inflight.InflightManager[uuid.UUID, *components.ReceiptInput]
-	contractCache   cache.Cache[pldtypes.EthAddress, *domainContract]
+	privateTxWaiter    *inflight.InflightManager[uuid.UUID, *components.ReceiptInput]
+	contractCache      cache.Cache[pldtypes.EthAddress, *domainContract]
+	pingTimeout        time.Duration
+	reloadDrainTimeout time.Duration
 }
 
 type event_PaladinRegisterSmartContract_V0 struct {
@@ -121,7 +128,12 @@ func (dm *domainManager) PostInit(c components.AllComponents) error {
 	dm.ethClientFactory = c.EthClientFactory()
 	dm.blockIndexer = c.BlockIndexer()
 	dm.keyManager = c.KeyManager()
-	dm.transportMgr = c.TransportManager()
+	dm.rpcServer = c.RPCServer()
+	if transportMgr, ok := c.TransportManager(); ok {
+		dm.localNodeName = transportMgr.LocalNodeName()
+	} else {
+		log.L(dm.bgCtx).Warnf("Transport manager not available - all private smart contracts will be treated as local")
+	}
 
 	// Register ourselves as a signing on the key manager
 	dm.domainSigner = &domainSigner{dm: dm}
@@ -159,6 +171,8 @@ func (dm *domainManager) cleanupDomain(d *domain) {
 }
 
 func (dm *domainManager) ConfiguredDomains() map[string]*pldconf.PluginConfig {
+	dm.mux.Lock()
+	defer dm.mux.Unlock()
 	pluginConf := make(map[string]*pldconf.PluginConfig)
 	for name, conf := range dm.conf.Domains {
 		pluginConf[name] = &conf.Plugin
@@ -167,6 +181,16 @@ func (dm *domainManager) ConfiguredDomains() map[string]*pldconf.PluginConfig {
 }
 
 func (dm *domainManager) DomainRegistered(name string, toDomain components.DomainManagerToDomain) (fromDomain plugintk.DomainCallbacks, err error) {
+	// A new connection for a domain that is already initialized is a hot-reload of the plugin,
+	// where we keep the domain (and the requests in-flight) and just switch over the connection
+	dm.mux.Lock()
+	existing := dm.domainsByName[name]
+	dm.mux.Unlock()
+	if existing != nil && existing.Initialized() {
+		go existing.reload(toDomain)
+		return existing, nil
+	}
+
 	d, err := dm.registerDomain(name, toDomain)
 	if err != nil {
 		return nil, err
@@ -217,6 +241,29 @@ func (dm *domainManager) GetDomainByName(ctx context.Context, name string) (comp
 	return domain, nil
 }
 
+func (dm *domainManager) ReconfigureDomain(ctx context.Context, name string, config map[string]any) error {
+	d, err := dm.getDomainByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	return d.reconfigure(ctx, config)
+}
+
+// The stored config is used if the plugin restarts and registers again, so it must reflect the latest reconfiguration
+func (dm *domainManager) setDomainConfig(name string, conf *pldconf.DomainConfig) {
+	dm.mux.Lock()
+	defer dm.mux.Unlock()
+	dm.conf.Domains[name] = conf
+}
+
+func (dm *domainManager) PingDomain(ctx context.Context, name string) error {
+	d, err := dm.getDomainByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	return d.ping(ctx)
+}
+
 func (dm *domainManager) getDomainByName(ctx context.Context, name string) (*domain, error) {
 	dm.mux.Lock()
 	defer dm.mux.Unlock()
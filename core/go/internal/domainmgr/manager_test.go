@@ -29,6 +29,7 @@ import (
 	"github.com/kaleido-io/paladin/core/mocks/blockindexermocks"
 	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
+	"github.com/kaleido-io/paladin/core/mocks/rpcservermocks"
 
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
@@ -50,6 +51,7 @@ type mockComponents struct {
 	txManager        *componentsmocks.TXManager
 	privateTxManager *componentsmocks.PrivateTxManager
 	transportMgr     *componentsmocks.TransportManager
+	rpcServer        *rpcservermocks.RPCServer
 }
 
 func newTestDomainManager(t *testing.T, realDB bool, conf *pldconf.DomainManagerConfig, extraSetup ...func(mc *mockComponents)) (context.Context, *domainManager, *mockComponents, func()) {
@@ -65,6 +67,7 @@ func newTestDomainManager(t *testing.T, realDB bool, conf *pldconf.DomainManager
 		txManager:        componentsmocks.NewTXManager(t),
 		privateTxManager: componentsmocks.NewPrivateTxManager(t),
 		transportMgr:     componentsmocks.NewTransportManager(t),
+		rpcServer:        rpcservermocks.NewRPCServer(t),
 	}
 
 	// Blockchain stuff is always mocked
@@ -77,7 +80,8 @@ func newTestDomainManager(t *testing.T, realDB bool, conf *pldconf.DomainManager
 	allComponents.On("KeyManager").Return(mc.keyManager)
 	allComponents.On("TxManager").Return(mc.txManager)
 	allComponents.On("PrivateTxManager").Return(mc.privateTxManager)
-	allComponents.On("TransportManager").Return(mc.transportMgr)
+	allComponents.On("TransportManager").Return(mc.transportMgr, true)
+	allComponents.On("RPCServer").Return(mc.rpcServer)
 	mc.transportMgr.On("LocalNodeName").Return("node1").Maybe()
 
 	var p persistence.Persistence
@@ -185,6 +189,7 @@ func TestDomainMissingRegistryAddress(t *testing.T) {
 		txManager:        componentsmocks.NewTXManager(t),
 		privateTxManager: componentsmocks.NewPrivateTxManager(t),
 		transportMgr:     componentsmocks.NewTransportManager(t),
+		rpcServer:        rpcservermocks.NewRPCServer(t),
 	}
 	componentsmocks := componentsmocks.NewAllComponents(t)
 	componentsmocks.On("EthClientFactory").Return(mc.ethClientFactory)
@@ -196,7 +201,9 @@ func TestDomainMissingRegistryAddress(t *testing.T) {
 	componentsmocks.On("KeyManager").Return(mc.keyManager)
 	componentsmocks.On("TxManager").Return(mc.txManager)
 	componentsmocks.On("PrivateTxManager").Return(mc.privateTxManager)
-	componentsmocks.On("TransportManager").Return(mc.transportMgr)
+	componentsmocks.On("TransportManager").Return(mc.transportMgr, true)
+	componentsmocks.On("RPCServer").Return(mc.rpcServer)
+	mc.transportMgr.On("LocalNodeName").Return("node1")
 
 	mp, err := mockpersistence.NewSQLMockProvider()
 	require.NoError(t, err)
@@ -45,7 +45,6 @@ type PrivateSmartContract struct {
 type domainContract struct {
 	dm     *domainManager
 	d      *domain
-	api    components.DomainManagerToDomain
 	info   *PrivateSmartContract   // from the DB
 	config *prototk.ContractConfig // from init processing in the domain
 }
@@ -65,11 +64,10 @@ func (d *domain) initSmartContract(ctx context.Context, def *PrivateSmartContrac
 	dc := &domainContract{
 		dm:   d.dm,
 		d:    d,
-		api:  d.api,
 		info: def,
 	}
 
-	res, err := d.api.InitContract(ctx, &prototk.InitContractRequest{
+	res, err := d.api().InitContract(ctx, &prototk.InitContractRequest{
 		ContractAddress: def.Address.String(),
 		ContractConfig:  def.ConfigBytes,
 	})
@@ -148,7 +146,7 @@ func (dc *domainContract) InitTransaction(ctx context.Context, tx *components.Pr
 
 	// Do the request with the domain
 	log.L(ctx).Infof("Initializing transaction=%s domain=%s contract-address=%s", tx.ID, dc.d.name, txSpec.ContractInfo.ContractAddress)
-	res, err := dc.api.InitTransaction(ctx, &prototk.InitTransactionRequest{
+	res, err := dc.d.api().InitTransaction(ctx, &prototk.InitTransactionRequest{
 		Transaction: txSpec,
 	})
 	if err != nil {
@@ -173,7 +171,7 @@ func setLocalNode(localNode, lookup string) string {
 }
 
 func (dc *domainContract) fullyQualifyAssemblyIdentities(res *prototk.AssembleTransactionResponse) {
-	localNode := dc.dm.transportMgr.LocalNodeName()
+	localNode := dc.dm.localNodeName
 	for _, ap := range res.AttestationPlan {
 		for i := range ap.Parties {
 			ap.Parties[i] = setLocalNode(localNode, ap.Parties[i])
@@ -222,7 +220,7 @@ func (dc *domainContract) AssembleTransaction(dCtx components.DomainContext, rea
 	// Now we have the required verifiers, we can ask the domain to do the heavy lifting
 	// and assemble the transaction (using the state store interface we provide)
 	log.L(dCtx.Ctx()).Infof("Assembling transaction=%s domain=%s contract-address=%s", tx.ID, dc.d.name, preAssembly.TransactionSpecification.ContractInfo.ContractAddress)
-	res, err := dc.api.AssembleTransaction(dCtx.Ctx(), &prototk.AssembleTransactionRequest{
+	res, err := dc.d.api().AssembleTransaction(dCtx.Ctx(), &prototk.AssembleTransactionRequest{
 		StateQueryContext: c.id,
 		Transaction:       preAssembly.TransactionSpecification,
 		ResolvedVerifiers: preAssembly.Verifiers,
@@ -459,7 +457,7 @@ func (dc *domainContract) EndorseTransaction(dCtx components.DomainContext, read
 	// Run the endorsement
 	log.L(dCtx.Ctx()).Infof("Running endorsement transaction=%s domain=%s contract-address=%s",
 		req.TransactionSpecification.TransactionId, dc.d.name, req.TransactionSpecification.ContractInfo.ContractAddress)
-	res, err := dc.api.EndorseTransaction(dCtx.Ctx(), &prototk.EndorseTransactionRequest{
+	res, err := dc.d.api().EndorseTransaction(dCtx.Ctx(), &prototk.EndorseTransactionRequest{
 		StateQueryContext:   c.id,
 		Transaction:         req.TransactionSpecification,
 		ResolvedVerifiers:   req.Verifiers,
@@ -500,7 +498,7 @@ func (dc *domainContract) PrepareTransaction(dCtx components.DomainContext, read
 	// Run the prepare
 	contractAddr := preAssembly.TransactionSpecification.ContractInfo.ContractAddress
 	log.L(dCtx.Ctx()).Infof("Preparing transaction=%s domain=%s contract-address=%s", tx.ID, dc.d.name, contractAddr)
-	res, err := dc.api.PrepareTransaction(dCtx.Ctx(), &prototk.PrepareTransactionRequest{
+	res, err := dc.d.api().PrepareTransaction(dCtx.Ctx(), &prototk.PrepareTransactionRequest{
 		StateQueryContext: c.id,
 		Transaction:       preAssembly.TransactionSpecification,
 		InputStates:       dc.d.toEndorsableList(postAssembly.InputStates),
@@ -582,7 +580,7 @@ func (dc *domainContract) InitCall(ctx context.Context, callTx *components.Resol
 	}
 
 	// Call the domain
-	res, err := dc.api.InitCall(ctx, &prototk.InitCallRequest{
+	res, err := dc.d.api().InitCall(ctx, &prototk.InitCallRequest{
 		Transaction: txSpec,
 	})
 	if err != nil {
@@ -605,7 +603,7 @@ func (dc *domainContract) ExecCall(dCtx components.DomainContext, readTX persist
 	defer c.close()
 
 	// Call the domain
-	res, err := dc.api.ExecCall(dCtx.Ctx(), &prototk.ExecCallRequest{
+	res, err := dc.d.api().ExecCall(dCtx.Ctx(), &prototk.ExecCallRequest{
 		StateQueryContext: c.id,
 		ResolvedVerifiers: verifiers,
 		Transaction:       txSpec,
@@ -739,7 +737,7 @@ func (dc *domainContract) WrapPrivacyGroupEVMTX(ctx context.Context, pg *pldapi.
 	}
 
 	// Call the domain to do the work
-	res, err := dc.api.WrapPrivacyGroupEVMTX(ctx, &prototk.WrapPrivacyGroupEVMTXRequest{
+	res, err := dc.d.api().WrapPrivacyGroupEVMTX(ctx, &prototk.WrapPrivacyGroupEVMTXRequest{
 		PrivacyGroup: mapPrivacyGroupToProto(pg.ID, pg.GenesisStateData()),
 		Transaction: &prototk.PrivacyGroupEVMTX{
 			ContractInfo: &prototk.ContractInfo{
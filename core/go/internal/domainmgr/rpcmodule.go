@@ -32,7 +32,8 @@ func (dm *domainManager) buildRPCModule() {
 		Add("domain_getDomain", dm.rpcGetDomain()).
 		Add("domain_getDomainByAddress", dm.rpcGetDomainByAddress()).
 		Add("domain_querySmartContracts", dm.rpcQuerySmartContracts()).
-		Add("domain_getSmartContractByAddress", dm.rpcGetSmartContractByAddress())
+		Add("domain_getSmartContractByAddress", dm.rpcGetSmartContractByAddress()).
+		Add("domain_reconfigure", dm.rpcReconfigure())
 }
 
 func (dm *domainManager) rpcQueryTransactions() rpcserver.RPCHandler {
@@ -58,6 +59,15 @@ func (dm *domainManager) rpcGetDomain() rpcserver.RPCHandler {
 	})
 }
 
+func (dm *domainManager) rpcReconfigure() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod2(func(ctx context.Context, name string, config map[string]any) (bool, error) {
+		if err := dm.ReconfigureDomain(ctx, name, config); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+}
+
 func (dm *domainManager) rpcGetDomainByAddress() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context, address pldtypes.EthAddress) (*pldapi.Domain, error) {
 		domain, err := dm.getDomainByAddress(ctx, &address)
@@ -45,6 +45,7 @@ type Traverser[T any] interface {
 	IsGreaterThan(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue driver.Value) Traverser[T]
 	IsGreaterThanOrEqual(e *query.OpSingleVal, fieldName string, field FieldResolver, testValue driver.Value) Traverser[T]
 	IsIn(e *query.OpMultiVal, fieldName string, field FieldResolver, testValues []driver.Value) Traverser[T]
+	IsBetween(e *query.OpMultiVal, fieldName string, field FieldResolver, from, to driver.Value) Traverser[T]
 }
 
 var allMods = []string{"not", "caseInsensitive"}
@@ -190,7 +191,7 @@ func (qt *queryTraverser[T]) addSimpleFilters(t Traverser[T], jf *query.Statemen
 		}
 		t = t.IsLike(e, e.Field, field, testValue)
 	}
-	for _, e := range jf.Null {
+	for _, e := range joinNullAndNotNull(jf.Null, jf.IsNull, jf.IsNotNull) {
 		field, err := resolveField(qt.ctx, qt.fieldSet, e.Field)
 		if err != nil {
 			return t.WithError(err)
@@ -223,6 +224,18 @@ func joinInAndNin(in, nin []*query.OpMultiVal) []*query.OpMultiVal {
 	return res
 }
 
+func joinNullAndNotNull(null, isNull, isNotNull []*query.Op) []*query.Op {
+	res := make([]*query.Op, len(null)+len(isNull)+len(isNotNull))
+	copy(res, null)
+	copy(res[len(null):], isNull)
+	negs := res[len(null)+len(isNull):]
+	copy(negs, isNotNull)
+	for _, n := range negs {
+		n.Not = true
+	}
+	return res
+}
+
 func (qt *queryTraverser[T]) BuildAndFilter(t Traverser[T], jf *query.Statements) Traverser[T] {
 	t = t.NewRoot()
 	t = qt.addSimpleFilters(t, jf)
@@ -279,6 +292,19 @@ func (qt *queryTraverser[T]) BuildAndFilter(t Traverser[T], jf *query.Statements
 		}
 		t = t.IsIn(e, e.Field, field, testValues)
 	}
+	for _, e := range jf.Between {
+		if len(e.Values) != 2 {
+			return t.WithError(i18n.NewError(qt.ctx, msgs.MsgFiltersBetweenValueCount, e.Field, len(e.Values)))
+		}
+		field, testValues, err := resolveFieldAndValues(qt.ctx, qt.fieldSet, e.Field, e.Values)
+		if err != nil {
+			return t.WithError(err)
+		}
+		if e.CaseInsensitive {
+			return t.WithError(i18n.NewError(qt.ctx, msgs.MsgFiltersJSONQueryOpUnsupportedMod, "between", justCaseInsensitive))
+		}
+		t = t.IsBetween(e, e.Field, field, testValues[0], testValues[1])
+	}
 	if len(jf.Or) > 0 {
 		var ors []T
 		for _, child := range jf.Or {
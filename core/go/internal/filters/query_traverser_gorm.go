@@ -158,3 +158,12 @@ func (t *gormTraverser) IsIn(e *query.OpMultiVal, fieldName string, field FieldR
 	}
 	return t
 }
+
+func (t *gormTraverser) IsBetween(e *query.OpMultiVal, fieldName string, field FieldResolver, from, to driver.Value) Traverser[*gormTraverser] {
+	if e.Not {
+		t.db = t.db.Where(fmt.Sprintf("%s NOT BETWEEN ? AND ?", field.SQLColumn()), from, to)
+	} else {
+		t.db = t.db.Where(fmt.Sprintf("%s BETWEEN ? AND ?", field.SQLColumn()), from, to)
+	}
+	return t
+}
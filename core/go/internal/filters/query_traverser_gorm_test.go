@@ -292,6 +292,29 @@ func TestBuildQueryJSONEqual(t *testing.T) {
 	assert.Equal(t, "SELECT count(*) FROM \"test\" WHERE created = 981173106000000000 AND tag != 'abc' AND LOWER(tag) = LOWER('ABC') AND LOWER(tag) != LOWER('abc') AND correl_id IS NOT NULL LIMIT 10", generatedSQL)
 }
 
+func TestBuildQueryJSONIsNullIsNotNull(t *testing.T) {
+
+	var qf query.QueryJSON
+	err := json.Unmarshal([]byte(`{
+		"isNull": [{ "field": "cid" }],
+		"isNotNull": [{ "field": "tag" }]
+	}`), &qf)
+	require.NoError(t, err)
+
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	generatedSQL := p.P.DB().ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var count int64
+		db := BuildGORM(context.Background(), &qf, tx.Table("test"), FieldMap{
+			"tag": StringField("tag"),
+			"cid": Int256Field("correl_id"),
+		}).Count(&count)
+		require.NoError(t, db.Error)
+		return db
+	})
+	assert.Equal(t, "SELECT count(*) FROM \"test\" WHERE correl_id IS NULL AND tag IS NOT NULL", generatedSQL)
+}
+
 func TestBuildQueryJSONLike(t *testing.T) {
 
 	var qf query.QueryJSON
@@ -496,6 +519,54 @@ func TestBuildQueryJSONIn(t *testing.T) {
 	assert.Equal(t, "SELECT count(*) FROM \"test\" WHERE tag IN ('a','b','c') AND tag NOT IN ('x','y','z') LIMIT 10", generatedSQL)
 }
 
+func TestBuildQueryJSONBetween(t *testing.T) {
+
+	var qf query.QueryJSON
+	err := json.Unmarshal([]byte(`{
+		"limit": 10,
+		"between": [
+			{
+				"field": "sequence",
+				"values": [100, 200]
+			},
+			{
+				"not": true,
+				"field": "created",
+				"values": [1726545933211347000, "2024-09-17T04:05:33.211347001Z"]
+			}
+		]
+	}`), &qf)
+	require.NoError(t, err)
+
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	generatedSQL := p.P.DB().ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var count int64
+		db := BuildGORM(context.Background(), &qf, tx.Table("test"), FieldMap{
+			"sequence": Int64Field("seq"),
+			"created":  TimestampField("created"),
+		}).Count(&count)
+		require.NoError(t, db.Error)
+		return db
+	})
+	assert.Equal(t, "SELECT count(*) FROM \"test\" WHERE (seq BETWEEN 100 AND 200) AND (created NOT BETWEEN 1726545933211347000 AND 1726545933211347001) LIMIT 10", generatedSQL)
+}
+
+func TestBuildQueryJSONBetweenValueCount(t *testing.T) {
+
+	var qf query.QueryJSON
+	err := json.Unmarshal([]byte(`{"between": [{"field": "sequence", "values": [100]}]}`), &qf)
+	require.NoError(t, err)
+
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	var count int64
+	db := BuildGORM(context.Background(), &qf, p.P.DB().Table("test"), FieldMap{
+		"sequence": Int64Field("seq"),
+	}).Count(&count)
+	assert.Regexp(t, "PD010722", db.Error)
+}
+
 func TestBuildQueryJSONBadModifiers(t *testing.T) {
 
 	p, err := mockpersistence.NewSQLMockProvider()
@@ -530,6 +601,9 @@ func TestBuildQueryJSONBadModifiers(t *testing.T) {
 	err = testJSON(`{"or": [{"in": [{"caseInsensitive": true, "field": "tag", "value": ""}]}] }`)
 	assert.Regexp(t, "PD010702", err)
 
+	err = testJSON(`{"between": [{"caseInsensitive": true, "field": "tag", "values": ["a","b"]}]}`)
+	assert.Regexp(t, "PD010702", err)
+
 }
 
 func TestBuildQueryJSONBadFields(t *testing.T) {
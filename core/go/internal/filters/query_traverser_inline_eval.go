@@ -19,6 +19,7 @@ package filters
 import (
 	"context"
 	"database/sql/driver"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -88,6 +89,23 @@ func (t *inlineEval) NewRoot() Traverser[*inlineEval] {
 	return &inlineEval{inlineEvalRoot: t.inlineEvalRoot, matches: true}
 }
 
+// Value sets built from Go structs can hold pointers for optional fields. A nil pointer
+// is treated as SQL NULL, and otherwise we compare against the value pointed to.
+func (t *inlineEval) getValue(fieldName string, field FieldResolver) (driver.Value, error) {
+	v, err := t.valueSet.GetValue(t.ctx, fieldName, field)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return rv.Elem().Interface(), nil
+	}
+	return v, nil
+}
+
 func (t *inlineEval) T() *inlineEval {
 	return t
 }
@@ -140,7 +158,7 @@ func (t *inlineEval) doCompare(e *query.Op, fieldName string, field FieldResolve
 	compareInt64 func(s1, s2 int64) bool,
 ) *inlineEval {
 	// Get the actual value to compare against
-	actualValue, err := t.valueSet.GetValue(t.ctx, fieldName, field)
+	actualValue, err := t.getValue(fieldName, field)
 	if err != nil {
 		return t.withError(err)
 	}
@@ -218,7 +236,7 @@ func (t *inlineEval) int64LikeNotSupported(s1, s2 int64) bool {
 
 func (t *inlineEval) IsNull(e *query.Op, fieldName string, field FieldResolver) Traverser[*inlineEval] {
 	var valMatches bool
-	actualValue, err := t.valueSet.GetValue(t.ctx, fieldName, field)
+	actualValue, err := t.getValue(fieldName, field)
 	if err != nil {
 		return t.withError(err)
 	}
@@ -297,3 +315,37 @@ func (t *inlineEval) IsIn(e *query.OpMultiVal, fieldName string, field FieldReso
 	t.matches = t.matches && isIn
 	return t
 }
+
+func (t *inlineEval) IsBetween(e *query.OpMultiVal, fieldName string, field FieldResolver, from, to driver.Value) Traverser[*inlineEval] {
+	// Do not negate the check in the individual compares
+	withoutNegate := e.Op
+	withoutNegate.Not = false
+
+	comp := t.NewRoot().T().doCompare(&withoutNegate, fieldName, field, from,
+		func(caseInsensitive bool, s1, s2 string) bool {
+			return strings.Compare(s1, s2) >= 0
+		},
+		func(s1, s2 int64) bool {
+			return s1 >= s2
+		},
+	)
+	if comp.err == nil {
+		comp = comp.doCompare(&withoutNegate, fieldName, field, to,
+			func(caseInsensitive bool, s1, s2 string) bool {
+				return strings.Compare(s1, s2) <= 0
+			},
+			func(s1, s2 int64) bool {
+				return s1 <= s2
+			},
+		)
+	}
+	if comp.err != nil {
+		return t.withError(comp.err)
+	}
+	isBetween := comp.matches
+	if e.Not {
+		isBetween = !isBetween
+	}
+	t.matches = t.matches && isBetween
+	return t
+}
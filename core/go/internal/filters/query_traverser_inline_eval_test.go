@@ -224,6 +224,55 @@ func TestEvalQueryNotNull(t *testing.T) {
 
 }
 
+func TestEvalQueryIsNullIsNotNullPointers(t *testing.T) {
+
+	var qf *query.QueryJSON
+	err := json.Unmarshal([]byte(`{
+		"isNull": [{"field": "int64Field"}],
+		"isNotNull": [{"field": "stringField"}]
+	}`), &qf)
+	require.NoError(t, err)
+
+	// Nil pointers are treated as null
+	present := "any"
+	match, err := EvalQuery(context.Background(), qf, allTypesFieldMap, PassthroughValueSet{
+		"int64Field":  (*int64)(nil),
+		"stringField": &present,
+	})
+	require.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = EvalQuery(context.Background(), qf, allTypesFieldMap, PassthroughValueSet{
+		"int64Field":  (*int64)(nil),
+		"stringField": (*string)(nil),
+	})
+	require.NoError(t, err)
+	assert.False(t, match)
+
+	// Non-nil pointers are compared using the value they point to
+	val := int64(12345)
+	match, err = EvalQuery(context.Background(), qf, allTypesFieldMap, PassthroughValueSet{
+		"int64Field":  &val,
+		"stringField": &present,
+	})
+	require.NoError(t, err)
+	assert.False(t, match)
+
+	var qfEq *query.QueryJSON
+	err = json.Unmarshal([]byte(`{"eq": [{"field": "int64Field", "value": 12345}]}`), &qfEq)
+	require.NoError(t, err)
+	match, err = EvalQuery(context.Background(), qfEq, allTypesFieldMap, PassthroughValueSet{
+		"int64Field": &val,
+	})
+	require.NoError(t, err)
+	assert.True(t, match)
+	match, err = EvalQuery(context.Background(), qfEq, allTypesFieldMap, PassthroughValueSet{
+		"int64Field": (*int64)(nil),
+	})
+	require.NoError(t, err)
+	assert.False(t, match)
+}
+
 func TestEvalQueryMatchStringCaseInsensitive(t *testing.T) {
 	var qf *query.QueryJSON
 	err := json.Unmarshal([]byte(`{"eq": [{"field": "stringField", "value": "test1", "caseInsensitive": true}]}`), &qf)
@@ -756,6 +805,49 @@ func TestEvalQueryMatchIn(t *testing.T) {
 
 }
 
+func TestEvalQueryMatchBetween(t *testing.T) {
+
+	var qf *query.QueryJSON
+	err := json.Unmarshal([]byte(`{"between": [{"field": "int64Field", "values": ["111","222"]}]}`), &qf)
+	require.NoError(t, err)
+
+	for value, expected := range map[string]bool{
+		`110`:     false,
+		`111`:     true,
+		`"0xDE"`:  true,
+		`222`:     true,
+		`223`:     false,
+		`"0x100"`: false,
+	} {
+		match, err := EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{
+			"int64Field": pldtypes.RawJSON(value),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, match, value)
+	}
+
+	err = json.Unmarshal([]byte(`{"between": [{"field": "stringField", "values": ["bbb","ddd"], "not": true}]}`), &qf)
+	require.NoError(t, err)
+
+	match, err := EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{
+		"stringField": pldtypes.RawJSON(`"aaa"`),
+	})
+	require.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{
+		"stringField": pldtypes.RawJSON(`"ccc"`),
+	})
+	require.NoError(t, err)
+	assert.False(t, match)
+
+	_, err = EvalQuery(context.Background(), qf, allTypesFieldMap, ResolvingValueSet{
+		"stringField": pldtypes.RawJSON(`false`),
+	})
+	assert.Regexp(t, "PD010705", err)
+
+}
+
 func TestEvalQueryAndOr(t *testing.T) {
 	var qf *query.QueryJSON
 	err := json.Unmarshal([]byte(`{
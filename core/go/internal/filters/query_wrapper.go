@@ -56,7 +56,8 @@ func (qw *QueryWrapper[PT, T]) Run(ctx context.Context, dbTX persistence.DBTX) (
 	if dbTX == nil {
 		dbTX = qw.P.NOTX()
 	}
-	q := dbTX.DB().WithContext(ctx)
+	q, cancel := persistence.QueryContext(ctx, dbTX.DB())
+	defer cancel()
 	if qw.Table != "" {
 		q = q.Table(qw.Table)
 	}
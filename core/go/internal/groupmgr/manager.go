@@ -32,6 +32,7 @@ import (
 	"gorm.io/gorm/clause"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
@@ -134,11 +135,34 @@ func (gm *groupManager) PostInit(c components.AllComponents) error {
 	gm.txManager = c.TxManager()
 	gm.domainManager = c.DomainManager()
 	gm.p = c.Persistence()
-	gm.transportManager = c.TransportManager()
-	gm.registryManager = c.RegistryManager()
+	// In embedded mode there is no transport or registry, so existing groups and messages can
+	// be queried, but new groups cannot be created and messages cannot be sent
+	var transportAvailable, registryAvailable bool
+	gm.transportManager, transportAvailable = c.TransportManager()
+	gm.registryManager, registryAvailable = c.RegistryManager()
+	if !transportAvailable || !registryAvailable {
+		log.L(gm.bgCtx).Warnf("Privacy group distribution disabled (transport=%t registry=%t)", transportAvailable, registryAvailable)
+	}
 	return gm.loadMessageListeners()
 }
 
+func (gm *groupManager) checkDistributionAvailable(ctx context.Context) error {
+	if gm.transportManager == nil {
+		return i18n.NewError(ctx, msgs.MsgComponentManagerNotAvailable, "transport", "privacy groups")
+	}
+	if gm.registryManager == nil {
+		return i18n.NewError(ctx, msgs.MsgComponentManagerNotAvailable, "registry", "privacy groups")
+	}
+	return nil
+}
+
+func (gm *groupManager) localNodeName() string {
+	if gm.transportManager == nil {
+		return ""
+	}
+	return gm.transportManager.LocalNodeName()
+}
+
 func (gm *groupManager) Start() error {
 	gm.startMessageListeners()
 	return nil
@@ -151,7 +175,7 @@ func (gm *groupManager) Stop() {
 }
 
 func (gm *groupManager) validateMembers(ctx context.Context, members []string, checkConnectivity bool) (remoteMembers map[string][]string, err error) {
-	localNode := gm.transportManager.LocalNodeName()
+	localNode := gm.localNodeName()
 	remoteMembers = make(map[string][]string)
 	if len(members) == 0 {
 		return nil, i18n.NewError(ctx, msgs.MsgPGroupsNoMembers)
@@ -247,6 +271,9 @@ func (gm *groupManager) validateGroupGenesisSet(ctx context.Context, domainName
 }
 
 func (gm *groupManager) CreateGroup(ctx context.Context, dbTX persistence.DBTX, spec *pldapi.PrivacyGroupInput) (group *pldapi.PrivacyGroup, err error) {
+	if err := gm.checkDistributionAvailable(ctx); err != nil {
+		return nil, err
+	}
 	pgGenesis := &pldapi.PrivacyGroupGenesisState{
 		GenesisSalt: pldtypes.RandBytes32(),
 		Name:        spec.Name,
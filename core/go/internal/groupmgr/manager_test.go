@@ -19,6 +19,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -60,8 +61,8 @@ func newMockComponents(t *testing.T, realDB bool) *mockComponents {
 	mc.txManager = componentsmocks.NewTXManager(t)
 
 	mc.c.On("DomainManager").Return(mc.domainManager).Maybe()
-	mc.c.On("TransportManager").Return(mc.transportManager).Maybe()
-	mc.c.On("RegistryManager").Return(mc.registryManager).Maybe()
+	mc.c.On("TransportManager").Return(mc.transportManager, true).Maybe()
+	mc.c.On("RegistryManager").Return(mc.registryManager, true).Maybe()
 	mc.c.On("TxManager").Return(mc.txManager).Maybe()
 
 	if realDB {
@@ -820,3 +821,34 @@ func TestStoreReceivedGroupFailValidationBadName(t *testing.T) {
 	require.NoError(t, err)
 
 }
+
+func TestNoTransportDistributionDisabled(t *testing.T) {
+	ctx, gm, _, done := newTestGroupManager(t, true, &pldconf.GroupManagerConfig{}, func(mc *mockComponents, conf *pldconf.GroupManagerConfig) {
+		mc.c.ExpectedCalls = slices.DeleteFunc(mc.c.ExpectedCalls, func(c *mock.Call) bool {
+			return c.Method == "TransportManager" || c.Method == "RegistryManager"
+		})
+		mc.c.On("TransportManager").Return(nil, false)
+		mc.c.On("RegistryManager").Return(nil, false)
+	})
+	defer done()
+
+	// Reads still work
+	groups, err := gm.QueryGroups(ctx, gm.p.NOTX(), query.NewQueryBuilder().Limit(1).Query())
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	_, err = gm.CreateGroup(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupInput{
+		Domain:  "domain1",
+		Members: []string{"me@node1"},
+	})
+	assert.Regexp(t, "PD010036.*transport", err)
+
+	_, err = gm.SendMessage(ctx, gm.p.NOTX(), &pldapi.PrivacyGroupMessageInput{
+		Domain: "domain1",
+		Group:  pldtypes.RandBytes(32),
+	})
+	assert.Regexp(t, "PD010036.*transport", err)
+
+	gm.transportManager = componentsmocks.NewTransportManager(t)
+	assert.Regexp(t, "PD010036.*registry", gm.checkDistributionAvailable(ctx))
+}
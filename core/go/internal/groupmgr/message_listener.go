@@ -376,7 +376,7 @@ func (gm *groupManager) buildListenerDBQuery(spec *pldapi.PrivacyGroupMessageLis
 	}
 
 	if spec.Options.ExcludeLocal {
-		q = q.Where("node <> ?", gm.transportManager.LocalNodeName())
+		q = q.Where("node <> ?", gm.localNodeName())
 	}
 
 	// Note we do post-filter on topic (no DB filter) as it's a regular expression
@@ -409,7 +409,7 @@ func (l *messageListener) checkMatch(r *persistedMessage) bool {
 		matches = matches && (l.topicMatch.MatchString(r.Topic))
 	}
 	if spec.Options.ExcludeLocal {
-		matches = matches && (l.gm.transportManager.LocalNodeName() != r.Node)
+		matches = matches && (l.gm.localNodeName() != r.Node)
 	}
 
 	// Note we don't factor sequence into the tap - as the notification does not contain the DB-generated sequence
@@ -77,6 +77,9 @@ func (gm *persistedMessage) preValidate(ctx context.Context) error {
 }
 
 func (gm *groupManager) SendMessage(ctx context.Context, dbTX persistence.DBTX, msg *pldapi.PrivacyGroupMessageInput) (*uuid.UUID, error) {
+	if err := gm.checkDistributionAvailable(ctx); err != nil {
+		return nil, err
+	}
 
 	pg, err := gm.GetGroupByID(ctx, dbTX, msg.Domain, msg.Group)
 	if err != nil {
@@ -94,7 +97,7 @@ func (gm *groupManager) SendMessage(ctx context.Context, dbTX persistence.DBTX,
 		Group:    msg.Group,
 		Sent:     now,
 		Received: now,
-		Node:     gm.transportManager.LocalNodeName(),
+		Node:     gm.localNodeName(),
 		ID:       msgID,
 		CID:      msg.CorrelationID,
 		Topic:    msg.Topic,
@@ -68,9 +68,12 @@ func (ir *identityResolver) PreInit(c components.PreInitComponents) (*components
 }
 
 func (ir *identityResolver) PostInit(c components.AllComponents) error {
-	ir.nodeName = c.TransportManager().LocalNodeName()
 	ir.keyManager = c.KeyManager()
-	ir.transportManager = c.TransportManager()
+	// Without a transport manager, we can only resolve local identities
+	var ok bool
+	if ir.transportManager, ok = c.TransportManager(); ok {
+		ir.nodeName = ir.transportManager.LocalNodeName()
+	}
 	return nil
 }
 
@@ -153,6 +156,11 @@ func (ir *identityResolver) ResolveVerifierAsync(ctx context.Context, lookup str
 	} else {
 		log.L(ctx).Debugf("resolving verifier via remote node %s", lookup)
 
+		if ir.transportManager == nil {
+			failed(ctx, i18n.NewError(ctx, msgs.MsgComponentManagerNotAvailable, "transport", "remote identity resolution"))
+			return
+		}
+
 		resolveVerifierRequest := &pbIdentityResolver.ResolveVerifierRequest{
 			Lookup:       lookup,
 			Algorithm:    algorithm,
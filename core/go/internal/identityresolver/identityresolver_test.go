@@ -50,6 +50,21 @@ func TestResolveVerifier(t *testing.T) {
 	<-done
 }
 
+func TestResolveVerifierRemoteNoTransport(t *testing.T) {
+	capacity := 100
+	ir := NewIdentityResolver(context.Background(), &pldconf.IdentityResolverConfig{
+		VerifierCache: pldconf.CacheConfig{Capacity: &capacity},
+	})
+	mc := componentsmocks.NewAllComponents(t)
+	mc.On("KeyManager").Return(componentsmocks.NewKeyManager(t))
+	mc.On("TransportManager").Return(nil, false)
+	err := ir.PostInit(mc)
+	assert.NoError(t, err)
+
+	_, err = ir.ResolveVerifier(context.Background(), "something@remote", algorithms.Curve_SECP256K1, verifiers.ETH_ADDRESS)
+	assert.Regexp(t, "PD010036", err)
+}
+
 func TestResolveVerifierAsync(t *testing.T) {
 	r := &identityResolver{}
 	resolved := func(ctx context.Context, verifier string) {
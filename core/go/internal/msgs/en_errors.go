@@ -76,6 +76,14 @@ var (
 	MsgComponentDebugServerStartError      = pde("PD010033", "Error starting debug server")
 	MsgComponentGroupManagerInitError      = pde("PD010034", "Error initializing privacy group manager")
 	MsgComponentGroupManagerStartError     = pde("PD010035", "Error starting group manager ")
+	MsgComponentManagerNotAvailable        = pde("PD010036", "The %s manager is not available, and is required for %s")
+	MsgConfigValueRequired                 = pde("PD010037", "A value is required")
+	MsgConfigValueBelowMinimum             = pde("PD010038", "Value %d is below the minimum of %d")
+	MsgConfigValueInvalidDuration          = pde("PD010039", "Invalid duration '%s'")
+	MsgConfigValueNotAllowed               = pde("PD010040", "Value '%s' is not one of the allowed values %v")
+	MsgConfigUnknownKey                    = pde("PD010041", "Unknown configuration key, which will be ignored")
+	MsgConfigValidationFailed              = pde("PD010042", "Configuration file %s is invalid")
+	MsgConfigValueInvalidRegexp            = pde("PD010043", "Invalid regular expression '%s': %s")
 
 	// States PD0101XX
 	MsgStateInvalidLength             = pde("PD010101", "Invalid hash len expected=%d actual=%d")
@@ -109,6 +117,26 @@ var (
 	MsgStateFlushInProgress           = pde("PD010131", "A flush is already in progress for this domain context")
 	MsgDomainContextImportInvalidJSON = pde("PD010132", "Attempted to import state locks but the JSON could not be parsed")
 	MsgDomainContextImportBadStates   = pde("PD010133", "Attempted to import state failed")
+	MsgStateSchemaDependencyCycle     = pde("PD010134", "Cycle detected in ABI schema dependencies involving '%s'")
+	MsgStateDeleteNotPending          = pde("PD010135", "State %s cannot be deleted as it has been confirmed or spent")
+	MsgStateDeleteLocked              = pde("PD010136", "State %s cannot be deleted as it is locked by transaction %s")
+	MsgStateDeleteForceRequired       = pde("PD010137", "allowForce must be set to delete a state regardless of its status")
+	MsgStateQueryTokenNotFound        = pde("PD010138", "Query token %s not found for domain '%s' or has expired")
+	MsgStateQueryTokenDomainContext   = pde("PD010139", "Query tokens cannot be used with a domain context status qualifier")
+	MsgStateLabelFieldInt64Overflow   = pde("PD010140", "Value %s for label field %s is out of range for a 64-bit integer label. Labels on uint64 or larger types must use a string label")
+	MsgStateQueryCursorInvalid        = pde("PD010141", "Invalid query cursor. A cursor can only be used with the same sort instructions as the query that returned it")
+	MsgStateQueryCursorNoSortValue    = pde("PD010142", "State %s has no value for sort field '%s', so a query cursor cannot be built")
+	MsgStateDomainContextDryRun       = pde("PD010143", "Domain context %s is a dry-run copy and cannot write to the database")
+	MsgStateInvalidID                 = pde("PD010144", "Invalid state ID '%s'")
+	MsgStateSchemaMigrationTypeChange = pde("PD010145", "Schema %s cannot be migrated to %s as the type has changed. Only indexed labels can be added to an existing schema")
+	MsgStateSchemaMigrationLabelLost  = pde("PD010146", "Schema %s cannot be migrated to %s as label '%s' is not indexed in the new schema")
+	MsgStateSchemaMigrationNoChange   = pde("PD010147", "Schema %s already has the same labels as the new schema")
+	MsgStateSchemaAlreadyMigrated     = pde("PD010148", "Schema %s has already been migrated to schema %s")
+	MsgStateQueryAcrossContractsDC    = pde("PD010149", "Domain context status qualifier '%s' cannot be used to query across contracts")
+	MsgStateTransferInvalid           = pde("PD010150", "State transfer %d must have a transaction ID and a source state")
+	MsgStateTransferSourceSpent       = pde("PD010151", "State %s cannot be transferred as it has already been spent by transaction %s")
+	MsgStateTransferSourceLocked      = pde("PD010152", "State %s cannot be transferred as it is locked for spending by transaction %s")
+	MsgStateTransferSourceUnconfirmed = pde("PD010153", "State %s cannot be transferred as it has not been confirmed")
 
 	// Persistence PD0102XX
 	MsgPersistenceInvalidType          = pde("PD010200", "Invalid persistence type: %s")
@@ -180,6 +208,7 @@ var (
 	MsgFiltersValueInvalidHexBytes32      = pde("PD010719", "Failed to parse value as 32 byte hex string (parsedBytes=%d)")
 	MsgFiltersValueInvalidUUID            = pde("PD010720", "Failed to parse value as UUID: %v")
 	MsgFiltersQueryLimitRequired          = pde("PD010721", "limit is required on all queries")
+	MsgFiltersBetweenValueCount           = pde("PD010722", "between requires exactly two values for field '%s' (received=%d)")
 
 	// Plugin controller PD0112XX
 	MsgPluginLoaderUUIDError   = pde("PD011200", "Plugin loader UUID incorrect")
@@ -190,6 +219,7 @@ var (
 	MsgPluginBadResponseBody   = pde("PD011205", "%s %s returned invalid response body %T")
 	MsgPluginError             = pde("PD011206", "%s %s returned error: %s")
 	MsgPluginLoadFailed        = pde("PD011207", "Plugin load failed: %s")
+	MsgPluginNoReadyInstances  = pde("PD011208", "No instances of %s plugin %s are available")
 
 	// BlockIndexer PD0113XX
 	MsgBlockIndexerInvalidFromBlock         = pde("PD011300", "Invalid from block '%s' (must be 'latest' or number)")
@@ -223,6 +253,8 @@ var (
 	MsgEthClientReturnValueNotDecoded   = pde("PD011515", "Error return value for custom error: %s")
 	MsgEthClientReturnValueNotAvailable = pde("PD011516", "Error return value unavailable")
 	MsgEthClientNoConnection            = pde("PD011517", "No JSON/RPC connection is available to this client")
+	MsgEthClientChainIDChanged          = pde("PD011518", "ChainID changed after WebSocket reconnect from %d to %d. The node is now connected to a different network")
+	MsgEthClientWSPoolSize              = pde("PD011519", "WebSocket connection pool size must be at least 1: %d")
 
 	// DomainManager module PD0116XX
 	MsgDomainNotFound                         = pde("PD011600", "Domain %q not found")
@@ -288,6 +320,10 @@ var (
 	MsgDomainInvalidPGroupGenesisABI          = pde("PD011664", "Domain generated an invalid privacy group genesis ABI parameter schema")
 	MsgDomainInvalidPGroupTxTypeNotPrivate    = pde("PD011665", "Resulting wrapped function call for privacy group must be a private transaction (type=%s)")
 	MsgDomainInvalidPGroupTxCannotRedirect    = pde("PD011666", "Resulting wrapped function call must target the same smart contract (contract=%s,addr=%s)")
+	MsgDomainReconfigureNotAllowed            = pde("PD011667", "Domain %s cannot change %s without a restart")
+	MsgDomainPingFailed                       = pde("PD011668", "Domain %s did not respond to ping within %s")
+	MsgDomainProtocolVersionUnsupported       = pde("PD011669", "Domain %s plugin negotiated protocol version %d, but this node supports versions %d to %d. Upgrade the domain plugin or the Paladin node so their supported versions overlap")
+	MsgDomainContractNotInDomain              = pde("PD011670", "Smart contract %s does not belong to domain %s")
 
 	// Entrypoint PD0117XX
 	MsgEntrypointUnknownRunMode = pde("PD011700", "Unknown run mode '%s'")
@@ -332,6 +368,7 @@ var (
 	MsgPrivateTxMgrFunctionNotProvided           = pde("PD011836", "Function abi not provided in transaction input")
 	MsgPrivateTxMgrAssembleRequestInvalid        = pde("PD011837", "Assemble request is invalid for transaction %s")
 	MsgPrivateTxMgrAssembleTxnNotFound           = pde("PD011838", "Transaction %s not found in local node")
+	MsgPrivateTxMgrTransactionLifecycleTimeout   = pde("PD011839", "Transaction did not complete within the transaction lifecycle timeout of %s")
 
 	// Public Transaction Manager PD0119XX
 	MsgSubmitFailedWrongHashReturned   = pde("PD011905", "Submission of transaction with calculatedHash '%s' returned hash '%s'")
@@ -360,6 +397,12 @@ var (
 	MsgUpdateGasPriceLower             = pde("PD011938", "Gas price cannot be lowered for transaction (current=%s requested=%s)")
 	MsgUpdateMaxFeePerGasLower         = pde("PD011939", "Max fee per gas cannot be lowered for transaction (current=%s requested=%s)")
 	MsgUpdateNoFixedPricing            = pde("PD011940", "Cannot unset gas price for transaction with fixed gas pricing")
+	MsgPublicTxMgrInvalidLimit         = pde("PD011941", "Invalid limit %d - must be greater than zero")
+	MsgPublicTxMgrInvalidOffset        = pde("PD011942", "Invalid offset %d - must not be negative")
+	MsgPublicTxMgrPendingTxNotFound    = pde("PD011943", "No pending public transaction found from %s with nonce %d")
+	MsgPublicTxMgrInvalidGasLimit      = pde("PD011944", "Invalid gas limit %d - must be greater than zero")
+	MsgPublicTxMgrBatchTooLarge        = pde("PD011945", "Batch of %d transactions exceeds the maximum batch size %d")
+	MsgPublicTxMgrBatchNilTransaction  = pde("PD011946", "Transaction %d in the batch is null")
 
 	// TransportManager module PD0120XX
 	MsgTransportInvalidMessage                 = pde("PD012000", "Invalid message")
@@ -384,18 +427,30 @@ var (
 	MsgTransportStateSchemaNotAvailableLocally = pde("PD012020", "State schema not available locally: domain=%s,id=%s")
 	MsgTransportMessageNotAvailableLocally     = pde("PD012021", "Message not available locally: id=%s")
 	MsgTransportPrivacyGroupStateStorageFailed = pde("PD012022", "Storage of privacy group state failed: id=%s")
+	MsgTransportSigningKeyMissing              = pde("PD012023", "messageSigning.keyIdentifier must be set when message signing is enabled")
+	MsgTransportAuthorSignatureMissing         = pde("PD012024", "Message %s from node '%s' is not signed")
+	MsgTransportAuthorSignatureInvalid         = pde("PD012025", "Message %s from node '%s' has an invalid signature")
+	MsgTransportInvalidStateEncoding           = pde("PD012026", "Invalid stateEncoding '%s' (must be 'json' or 'cbor')")
+	MsgTransportInvalidStateCompressionLevel   = pde("PD012027", "Invalid stateCompressionLevel %d (must be between 0 and 9)")
+	MsgTransportStateDataDecodeFailed          = pde("PD012028", "Failed to decode state data with encoding '%s' compression '%s'")
+	MsgTransportMulticastFailed                = pde("PD012029", "Failed to send multicast message %s to %d of %d nodes: %s")
+	MsgTransportSequenceMissing                = pde("PD012030", "Message %s from node '%s' does not have a sequence")
+	MsgTransportMessageReplayed                = pde("PD012031", "Message %s from node '%s' has sequence %d that has already been seen, or is too old (highest=%d)")
+	MsgTransportReplayProtectionUnsigned       = pde("PD012032", "replayProtection can only be enabled when messageSigning is also enabled, as the sequence of an unsigned message cannot be trusted")
+	MsgTransportStateDataTooLarge              = pde("PD012033", "Decompressed state data exceeds the maximum size of %d bytes")
 
 	// RegistryManager module PD0121XX
-	MsgRegistryNodeEntiresNotFound     = pde("PD012100", "No entries found for node '%s'")
-	MsgRegistryNotFound                = pde("PD012101", "Registry %q not found")
-	MsgRegistryInvalidEventSource      = pde("PD012102", "Events source %d is invalid")
-	MsgRegistryInvalidEntryID          = pde("PD012103", "Invalid entry ID '%s'")
-	MsgRegistryInvalidEntryName        = pde("PD012104", "Invalid entry name '%s'")
-	MsgRegistryInvalidPropertyName     = pde("PD012105", "Invalid property name '%s'")
-	MsgRegistryInvalidParentID         = pde("PD012106", "Invalid parent ID '%s'")
-	MsgRegistryQueryLimitRequired      = pde("PD012107", "Limit is required on all queries")
-	MsgRegistryTransportPropertyRegexp = pde("PD012108", "transports.propertyRegexp for registry '%s' is invalid")
-	MsgRegistryDollarPrefixReserved    = pde("PD012109", "Name '%s' is invalid. Dollar ('$') prefix is allowed only for reserved properties, and then is required (pluginReserved=%t)")
+	MsgRegistryNodeEntiresNotFound        = pde("PD012100", "No entries found for node '%s'")
+	MsgRegistryNotFound                   = pde("PD012101", "Registry %q not found")
+	MsgRegistryInvalidEventSource         = pde("PD012102", "Events source %d is invalid")
+	MsgRegistryInvalidEntryID             = pde("PD012103", "Invalid entry ID '%s'")
+	MsgRegistryInvalidEntryName           = pde("PD012104", "Invalid entry name '%s'")
+	MsgRegistryInvalidPropertyName        = pde("PD012105", "Invalid property name '%s'")
+	MsgRegistryInvalidParentID            = pde("PD012106", "Invalid parent ID '%s'")
+	MsgRegistryQueryLimitRequired         = pde("PD012107", "Limit is required on all queries")
+	MsgRegistryTransportPropertyRegexp    = pde("PD012108", "transports.propertyRegexp for registry '%s' is invalid")
+	MsgRegistryDollarPrefixReserved       = pde("PD012109", "Name '%s' is invalid. Dollar ('$') prefix is allowed only for reserved properties, and then is required (pluginReserved=%t)")
+	MsgRegistryNodeSigningAddressNotFound = pde("PD012110", "No signing address registered for node '%s'")
 
 	// TxMgr module PD0122XX
 	MsgTxMgrInvalidABI                            = pde("PD012201", "ABI is invalid")
@@ -0,0 +1,299 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// A domainPool fronts the instances of a domain plugin that is configured with multiple replicas.
+// It registers with the domain manager once, on behalf of all the instances, and balances requests
+// across the instances that are connected and initialized using round-robin.
+//
+// The domain manager configures and initializes the domain through the pool. Instances that connect
+// after that (including instances that restart) are brought up to date by replaying the same
+// configure and init requests, before they are added to the rotation.
+type domainPool struct {
+	pm      *pluginManager
+	name    string
+	manager plugintk.DomainCallbacks
+
+	mux          sync.Mutex
+	connected    []*domainBridge
+	ready        []*domainBridge
+	next         int
+	initialized  bool
+	configureReq *prototk.ConfigureDomainRequest
+	initReq      *prototk.InitDomainRequest
+}
+
+func (pm *pluginManager) connectDomainReplica(br *domainBridge) error {
+	pm.mux.Lock()
+	dp := pm.domainPools[br.pluginName]
+	if dp == nil {
+		dp = &domainPool{pm: pm, name: br.pluginName}
+		pm.domainPools[br.pluginName] = dp
+	}
+	pm.mux.Unlock()
+	br.pool = dp
+
+	dp.mux.Lock()
+	defer dp.mux.Unlock()
+	dp.connected = append(dp.connected, br)
+	if dp.manager == nil {
+		manager, err := pm.domainManager.DomainRegistered(dp.name, dp)
+		if err != nil {
+			dp.connected = slices.DeleteFunc(dp.connected, func(b *domainBridge) bool { return b == br })
+			return err
+		}
+		dp.manager = manager
+	}
+	br.manager = dp.manager
+	if dp.initialized {
+		// We cannot make requests to the plugin from the bridge factory, as responses are
+		// received on the same routine that called us
+		go dp.replayInit(br, dp.configureReq, dp.initReq)
+	}
+	return nil
+}
+
+// Brings an instance that connected after the domain was initialized up to date
+func (dp *domainPool) replayInit(br *domainBridge, configureReq *prototk.ConfigureDomainRequest, initReq *prototk.InitDomainRequest) {
+	ctx := log.WithLogField(dp.pm.bgCtx, "plugin", br.pluginId)
+	_, err := br.ConfigureDomain(ctx, configureReq)
+	if err == nil {
+		_, err = br.InitDomain(ctx, initReq)
+	}
+	if err != nil {
+		// The instance stays out of rotation. The domain itself is unaffected
+		log.L(ctx).Errorf("Failed to initialize replica of domain %s: %s", dp.name, err)
+		return
+	}
+	dp.mux.Lock()
+	if slices.Contains(dp.connected, br) {
+		dp.ready = append(dp.ready, br)
+	}
+	dp.mux.Unlock()
+	br.plugin.notifyInitialized()
+}
+
+func (dp *domainPool) removeInstance(br *domainBridge) {
+	dp.mux.Lock()
+	defer dp.mux.Unlock()
+	isBridge := func(b *domainBridge) bool { return b == br }
+	dp.connected = slices.DeleteFunc(dp.connected, isBridge)
+	dp.ready = slices.DeleteFunc(dp.ready, isBridge)
+	log.L(dp.pm.bgCtx).Infof("Replica of domain %s disconnected (%d remain)", dp.name, len(dp.connected))
+}
+
+func (dp *domainPool) nextReady(ctx context.Context, exclude []*domainBridge) (*domainBridge, error) {
+	dp.mux.Lock()
+	defer dp.mux.Unlock()
+	for range dp.ready {
+		br := dp.ready[dp.next%len(dp.ready)]
+		dp.next++
+		if !slices.Contains(exclude, br) {
+			return br, nil
+		}
+	}
+	return nil, i18n.NewError(ctx, msgs.MsgPluginNoReadyInstances, prototk.PluginInfo_DOMAIN, dp.name)
+}
+
+// Sends the request to the next ready instance. If that instance disconnects before it responds,
+// the request is retried on the next instance.
+func poolRequest[Req, Res any](ctx context.Context, dp *domainPool, req *Req, fn func(*domainBridge, context.Context, *Req) (*Res, error)) (*Res, error) {
+	var tried []*domainBridge
+	for {
+		br, err := dp.nextReady(ctx, tried)
+		if err != nil {
+			return nil, err
+		}
+		res, err := fn(br, ctx, req)
+		if err == nil || !br.isStopped() || ctx.Err() != nil {
+			return res, err
+		}
+		log.L(ctx).Warnf("Replica of domain %s disconnected during request, retrying on another instance: %s", dp.name, err)
+		tried = append(tried, br)
+	}
+}
+
+// Before initialization, configure and init are sent to every connected instance
+func poolBroadcast[Req, Res any](ctx context.Context, dp *domainPool, instances []*domainBridge, req *Req, fn func(*domainBridge, context.Context, *Req) (*Res, error)) (res *Res, err error) {
+	if len(instances) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPluginNoReadyInstances, prototk.PluginInfo_DOMAIN, dp.name)
+	}
+	for _, br := range instances {
+		if res, err = fn(br, ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (dp *domainPool) Initialized() {
+	dp.mux.Lock()
+	dp.initialized = true
+	// Only instances that were sent the configure and init requests are ready - any
+	// that connected during initialization get the requests replayed
+	var toNotify, toReplay []*domainBridge
+	for _, br := range dp.connected {
+		if br.configureReq == dp.configureReq && br.initReq == dp.initReq {
+			toNotify = append(toNotify, br)
+		} else {
+			toReplay = append(toReplay, br)
+		}
+	}
+	dp.ready = toNotify
+	configureReq, initReq := dp.configureReq, dp.initReq
+	dp.mux.Unlock()
+
+	for _, br := range toNotify {
+		br.plugin.notifyInitialized()
+	}
+	for _, br := range toReplay {
+		go dp.replayInit(br, configureReq, initReq)
+	}
+}
+
+func (dp *domainPool) ConfigureDomain(ctx context.Context, req *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+	dp.mux.Lock()
+	dp.configureReq = req
+	instances := slices.Clone(dp.connected)
+	dp.mux.Unlock()
+	return poolBroadcast(ctx, dp, instances, req, func(br *domainBridge, ctx context.Context, req *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+		res, err := br.ConfigureDomain(ctx, req)
+		if err == nil {
+			dp.mux.Lock()
+			br.configureReq = req
+			dp.mux.Unlock()
+		}
+		return res, err
+	})
+}
+
+func (dp *domainPool) InitDomain(ctx context.Context, req *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error) {
+	dp.mux.Lock()
+	dp.initReq = req
+	// Only the instances that processed the current configuration can be initialized
+	var instances []*domainBridge
+	for _, br := range dp.connected {
+		if br.configureReq == dp.configureReq {
+			instances = append(instances, br)
+		}
+	}
+	dp.mux.Unlock()
+	return poolBroadcast(ctx, dp, instances, req, func(br *domainBridge, ctx context.Context, req *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error) {
+		res, err := br.InitDomain(ctx, req)
+		if err == nil {
+			dp.mux.Lock()
+			br.initReq = req
+			dp.mux.Unlock()
+		}
+		return res, err
+	})
+}
+
+func (dp *domainPool) InitDeploy(ctx context.Context, req *prototk.InitDeployRequest) (*prototk.InitDeployResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).InitDeploy)
+}
+
+func (dp *domainPool) PrepareDeploy(ctx context.Context, req *prototk.PrepareDeployRequest) (*prototk.PrepareDeployResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).PrepareDeploy)
+}
+
+func (dp *domainPool) InitContract(ctx context.Context, req *prototk.InitContractRequest) (*prototk.InitContractResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).InitContract)
+}
+
+func (dp *domainPool) InitTransaction(ctx context.Context, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).InitTransaction)
+}
+
+func (dp *domainPool) AssembleTransaction(ctx context.Context, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).AssembleTransaction)
+}
+
+func (dp *domainPool) EndorseTransaction(ctx context.Context, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).EndorseTransaction)
+}
+
+func (dp *domainPool) PrepareTransaction(ctx context.Context, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).PrepareTransaction)
+}
+
+func (dp *domainPool) HandleEventBatch(ctx context.Context, req *prototk.HandleEventBatchRequest) (*prototk.HandleEventBatchResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).HandleEventBatch)
+}
+
+func (dp *domainPool) Sign(ctx context.Context, req *prototk.SignRequest) (*prototk.SignResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).Sign)
+}
+
+func (dp *domainPool) GetVerifier(ctx context.Context, req *prototk.GetVerifierRequest) (*prototk.GetVerifierResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).GetVerifier)
+}
+
+func (dp *domainPool) ValidateStateHashes(ctx context.Context, req *prototk.ValidateStateHashesRequest) (*prototk.ValidateStateHashesResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).ValidateStateHashes)
+}
+
+func (dp *domainPool) InitCall(ctx context.Context, req *prototk.InitCallRequest) (*prototk.InitCallResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).InitCall)
+}
+
+func (dp *domainPool) ExecCall(ctx context.Context, req *prototk.ExecCallRequest) (*prototk.ExecCallResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).ExecCall)
+}
+
+func (dp *domainPool) BuildReceipt(ctx context.Context, req *prototk.BuildReceiptRequest) (*prototk.BuildReceiptResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).BuildReceipt)
+}
+
+func (dp *domainPool) ConfigurePrivacyGroup(ctx context.Context, req *prototk.ConfigurePrivacyGroupRequest) (*prototk.ConfigurePrivacyGroupResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).ConfigurePrivacyGroup)
+}
+
+func (dp *domainPool) InitPrivacyGroup(ctx context.Context, req *prototk.InitPrivacyGroupRequest) (*prototk.InitPrivacyGroupResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).InitPrivacyGroup)
+}
+
+// Every connected instance is pinged, so one that has stopped responding is detected even while
+// the others are serving requests
+func (dp *domainPool) Ping(ctx context.Context, req *prototk.PingRequest) (*prototk.PingResponse, error) {
+	dp.mux.Lock()
+	instances := slices.Clone(dp.connected)
+	dp.mux.Unlock()
+	if len(instances) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPluginNoReadyInstances, prototk.PluginInfo_DOMAIN, dp.name)
+	}
+	for _, br := range instances {
+		if _, err := br.Ping(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return &prototk.PingResponse{}, nil
+}
+
+func (dp *domainPool) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.WrapPrivacyGroupEVMTXRequest) (*prototk.WrapPrivacyGroupEVMTXResponse, error) {
+	return poolRequest(ctx, dp, req, (*domainBridge).WrapPrivacyGroupEVMTX)
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainReplicasLoadBalanced(t *testing.T) {
+
+	var instanceCount atomic.Int32
+	var mux sync.Mutex
+	configured := map[string]int{}
+	initialized := map[string]int{}
+	served := map[string]int{}
+
+	waitForAPI := make(chan components.DomainManagerToDomain, 1)
+	tdm := &testDomainManager{
+		domains: map[string]plugintk.Plugin{
+			"domain1": plugintk.NewDomain(func(callbacks plugintk.DomainCallbacks) plugintk.DomainAPI {
+				instance := fmt.Sprintf("instance%d", instanceCount.Add(1))
+				return &plugintk.DomainAPIBase{Functions: &plugintk.DomainAPIFunctions{
+					ConfigureDomain: func(ctx context.Context, cdr *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
+						assert.Equal(t, int64(12345), cdr.ChainId)
+						mux.Lock()
+						defer mux.Unlock()
+						configured[instance]++
+						return &prototk.ConfigureDomainResponse{DomainConfig: &prototk.DomainConfig{}}, nil
+					},
+					InitDomain: func(ctx context.Context, idr *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error) {
+						mux.Lock()
+						defer mux.Unlock()
+						initialized[instance]++
+						return &prototk.InitDomainResponse{}, nil
+					},
+					GetVerifier: func(ctx context.Context, gvr *prototk.GetVerifierRequest) (*prototk.GetVerifierResponse, error) {
+						mux.Lock()
+						defer mux.Unlock()
+						served[instance]++
+						return &prototk.GetVerifierResponse{Verifier: instance}, nil
+					},
+				}}
+			}),
+		},
+		replicas: map[string]int{"domain1": 2},
+	}
+	tdm.domainRegistered = func(name string, toDomain components.DomainManagerToDomain) (plugintk.DomainCallbacks, error) {
+		assert.Equal(t, "domain1", name)
+		waitForAPI <- toDomain // would block on a second registration
+		return tdm, nil
+	}
+
+	ctx, pc, done := newTestDomainPluginManager(t, &testManagers{
+		testDomainManager: tdm,
+	})
+	defer done()
+
+	// We get a single registration for the pool
+	domainAPI := <-waitForAPI
+	dp, ok := domainAPI.(*domainPool)
+	require.True(t, ok)
+
+	_, err := domainAPI.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{ChainId: int64(12345)})
+	require.NoError(t, err)
+	_, err = domainAPI.InitDomain(ctx, &prototk.InitDomainRequest{})
+	require.NoError(t, err)
+	domainAPI.Initialized()
+
+	// Whether the second instance connected before or after initialization, it ends up ready
+	require.Eventually(t, func() bool {
+		dp.mux.Lock()
+		defer dp.mux.Unlock()
+		return len(dp.ready) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, pc.WaitForInit(ctx))
+
+	mux.Lock()
+	assert.Equal(t, map[string]int{"instance1": 1, "instance2": 1}, configured)
+	assert.Equal(t, map[string]int{"instance1": 1, "instance2": 1}, initialized)
+	mux.Unlock()
+
+	for i := 0; i < 4; i++ {
+		_, err := domainAPI.GetVerifier(ctx, &prototk.GetVerifierRequest{Algorithm: "algo1"})
+		require.NoError(t, err)
+	}
+	mux.Lock()
+	assert.Equal(t, map[string]int{"instance1": 2, "instance2": 2}, served)
+	mux.Unlock()
+
+	// Ping is answered by every connected instance without calling the domain
+	_, err = domainAPI.Ping(ctx, &prototk.PingRequest{})
+	require.NoError(t, err)
+
+}
+
+func TestDomainPoolRetryOnStoppedInstance(t *testing.T) {
+	ctx := context.Background()
+
+	br1, br2 := &domainBridge{}, &domainBridge{}
+	dp := &domainPool{
+		pm:    &pluginManager{bgCtx: ctx},
+		name:  "domain1",
+		ready: []*domainBridge{br1, br2},
+	}
+
+	res, err := poolRequest(ctx, dp, &prototk.SignRequest{}, func(br *domainBridge, ctx context.Context, req *prototk.SignRequest) (*prototk.SignResponse, error) {
+		if br == br1 {
+			br1.stopped.Store(true)
+			return nil, errors.New("pop")
+		}
+		return &prototk.SignResponse{Payload: []byte("signed")}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signed"), res.Payload)
+
+	// Errors from a running instance are returned without retry
+	dp.next = 1
+	_, err = poolRequest(ctx, dp, &prototk.SignRequest{}, func(br *domainBridge, ctx context.Context, req *prototk.SignRequest) (*prototk.SignResponse, error) {
+		assert.Equal(t, br2, br)
+		return nil, errors.New("bang")
+	})
+	assert.Regexp(t, "bang", err)
+
+	// If every instance disconnects, we run out of instances to try
+	_, err = poolRequest(ctx, dp, &prototk.SignRequest{}, func(br *domainBridge, ctx context.Context, req *prototk.SignRequest) (*prototk.SignResponse, error) {
+		br.stopped.Store(true)
+		return nil, errors.New("pop")
+	})
+	assert.Regexp(t, "PD011208", err)
+
+}
+
+func TestDomainPoolNoInstances(t *testing.T) {
+	ctx := context.Background()
+
+	br1 := &domainBridge{pool: &domainPool{}}
+	dp := br1.pool
+	dp.pm = &pluginManager{bgCtx: ctx}
+	dp.name = "domain1"
+	dp.connected = []*domainBridge{br1}
+	dp.ready = []*domainBridge{br1}
+
+	br1.pluginStopped()
+	assert.True(t, br1.isStopped())
+	assert.Empty(t, dp.connected)
+	assert.Empty(t, dp.ready)
+
+	_, err := dp.GetVerifier(ctx, &prototk.GetVerifierRequest{})
+	assert.Regexp(t, "PD011208", err)
+
+	_, err = dp.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{})
+	assert.Regexp(t, "PD011208", err)
+
+	_, err = dp.InitDomain(ctx, &prototk.InitDomainRequest{})
+	assert.Regexp(t, "PD011208", err)
+
+	_, err = dp.Ping(ctx, &prototk.PingRequest{})
+	assert.Regexp(t, "PD011208", err)
+
+}
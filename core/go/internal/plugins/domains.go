@@ -16,6 +16,7 @@ package plugins
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
@@ -35,6 +36,10 @@ func (pm *pluginManager) ConnectDomain(stream prototk.PluginController_ConnectDo
 				pluginId:   plugin.id.String(),
 				toPlugin:   toPlugin,
 			}
+			if plugin.replicas > 1 {
+				// Replicas register once with the domain manager, through a shared pool
+				return br, pm.connectDomainReplica(br)
+			}
 			br.manager, err = pm.domainManager.DomainRegistered(plugin.name, br)
 			if err != nil {
 				return nil, err
@@ -51,6 +56,23 @@ type domainBridge struct {
 	pluginId   string
 	toPlugin   managerToPlugin[prototk.DomainMessage]
 	manager    plugintk.DomainCallbacks
+
+	// only set when the plugin is configured with multiple replicas
+	pool         *domainPool
+	configureReq *prototk.ConfigureDomainRequest
+	initReq      *prototk.InitDomainRequest
+	stopped      atomic.Bool
+}
+
+func (br *domainBridge) pluginStopped() {
+	br.stopped.Store(true)
+	if br.pool != nil {
+		br.pool.removeInstance(br)
+	}
+}
+
+func (br *domainBridge) isStopped() bool {
+	return br.stopped.Load()
 }
 
 // DomainManager calls this when it is satisfied the domain is fully initialized.
@@ -125,6 +147,15 @@ func (br *domainBridge) RequestReply(ctx context.Context, reqMsg plugintk.Plugin
 				}
 			},
 		)
+	case *prototk.DomainMessage_PushStateUpdate:
+		return callManagerImpl(ctx, req.PushStateUpdate,
+			br.manager.PushStateUpdate,
+			func(resMsg *prototk.DomainMessage, res *prototk.PushStateUpdateResponse) {
+				resMsg.ResponseToDomain = &prototk.DomainMessage_PushStateUpdateRes{
+					PushStateUpdateRes: res,
+				}
+			},
+		)
 	default:
 		return nil, i18n.NewError(ctx, msgs.MsgPluginBadRequestBody, req)
 	}
@@ -400,6 +431,21 @@ func (br *domainBridge) InitPrivacyGroup(ctx context.Context, req *prototk.InitP
 	return
 }
 
+func (br *domainBridge) Ping(ctx context.Context, req *prototk.PingRequest) (res *prototk.PingResponse, err error) {
+	err = br.toPlugin.RequestReply(ctx,
+		func(dm plugintk.PluginMessage[prototk.DomainMessage]) {
+			dm.Message().RequestToDomain = &prototk.DomainMessage_Ping{Ping: req}
+		},
+		func(dm plugintk.PluginMessage[prototk.DomainMessage]) bool {
+			if r, ok := dm.Message().ResponseFromDomain.(*prototk.DomainMessage_PingRes); ok {
+				res = r.PingRes
+			}
+			return res != nil
+		},
+	)
+	return
+}
+
 func (br *domainBridge) WrapPrivacyGroupEVMTX(ctx context.Context, req *prototk.WrapPrivacyGroupEVMTXRequest) (res *prototk.WrapPrivacyGroupEVMTXResponse, err error) {
 	err = br.toPlugin.RequestReply(ctx,
 		func(dm plugintk.PluginMessage[prototk.DomainMessage]) {
@@ -39,6 +39,7 @@ import (
 
 type testDomainManager struct {
 	domains             map[string]plugintk.Plugin
+	replicas            map[string]int
 	domainRegistered    func(name string, toDomain components.DomainManagerToDomain) (fromDomain plugintk.DomainCallbacks, err error)
 	findAvailableStates func(context.Context, *prototk.FindAvailableStatesRequest) (*prototk.FindAvailableStatesResponse, error)
 	encodeData          func(context.Context, *prototk.EncodeDataRequest) (*prototk.EncodeDataResponse, error)
@@ -47,6 +48,7 @@ type testDomainManager struct {
 	sendTransaction     func(context.Context, *prototk.SendTransactionRequest) (*prototk.SendTransactionResponse, error)
 	localNodeName       func(context.Context, *prototk.LocalNodeNameRequest) (*prototk.LocalNodeNameResponse, error)
 	getStates           func(context.Context, *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error)
+	pushStateUpdate     func(context.Context, *prototk.PushStateUpdateRequest) (*prototk.PushStateUpdateResponse, error)
 }
 
 func (tp *testDomainManager) FindAvailableStates(ctx context.Context, req *prototk.FindAvailableStatesRequest) (*prototk.FindAvailableStatesResponse, error) {
@@ -77,6 +79,10 @@ func (tp *testDomainManager) GetStatesByID(ctx context.Context, req *prototk.Get
 	return tp.getStates(ctx, req)
 }
 
+func (tp *testDomainManager) PushStateUpdate(ctx context.Context, req *prototk.PushStateUpdateRequest) (*prototk.PushStateUpdateResponse, error) {
+	return tp.pushStateUpdate(ctx, req)
+}
+
 func domainConnectFactory(ctx context.Context, client prototk.PluginControllerClient) (grpc.BidiStreamingClient[prototk.DomainMessage, prototk.DomainMessage], error) {
 	return client.ConnectDomain(context.Background())
 }
@@ -96,6 +102,9 @@ func (tp *testDomainManager) mock(t *testing.T) *componentsmocks.DomainManager {
 			Type:    string(pldtypes.LibraryTypeCShared),
 			Library: "/tmp/not/applicable",
 		}
+		if replicas, ok := tp.replicas[name]; ok {
+			pluginMap[name].Replicas = &replicas
+		}
 	}
 	mdm.On("ConfiguredDomains").Return(pluginMap).Maybe()
 	mdr := mdm.On("DomainRegistered", mock.Anything, mock.Anything).Maybe()
@@ -332,6 +341,13 @@ func TestDomainRequestsOK(t *testing.T) {
 		}, nil
 	}
 
+	tdm.pushStateUpdate = func(ctx context.Context, psr *prototk.PushStateUpdateRequest) (*prototk.PushStateUpdateResponse, error) {
+		assert.Equal(t, "0x05d936207F04D81a85881b72A0D17854Ee8BE45A", psr.ContractAddress)
+		return &prototk.PushStateUpdateResponse{
+			StateIds: []string{"0x1234"},
+		}, nil
+	}
+
 	ctx, pc, done := newTestDomainPluginManager(t, &testManagers{
 		testDomainManager: tdm,
 	})
@@ -514,6 +530,12 @@ func TestDomainRequestsOK(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.Len(t, gsr.States, 1)
+
+	psr, err := callbacks.PushStateUpdate(ctx, &prototk.PushStateUpdateRequest{
+		ContractAddress: "0x05d936207F04D81a85881b72A0D17854Ee8BE45A",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0x1234"}, psr.StateIds)
 }
 
 func TestDomainRegisterFail(t *testing.T) {
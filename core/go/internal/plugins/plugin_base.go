@@ -47,6 +47,11 @@ type pluginToManager[M any] interface {
 	RequestReply(ctx context.Context, req plugintk.PluginMessage[M]) (resFn func(plugintk.PluginMessage[M]), err error)
 }
 
+// bridges can implement this to be notified when the plugin instance they are bound to disconnects
+type pluginStoppedListener interface {
+	pluginStopped()
+}
+
 // each type of plugin implements a bridge that is just the specific set of operations mapped
 // down on the toPlugin and fromPlugin interfaces as appropriate
 type pluginBridgeFactory[M any] func(plugin *plugin[M], toPlugin managerToPlugin[M]) (fromPlugin pluginToManager[M], err error)
@@ -57,6 +62,9 @@ type plugin[M any] struct {
 	id   uuid.UUID
 	def  *prototk.PluginLoad
 
+	replica  int // index of this instance, when multiple replicas of the plugin are loaded
+	replicas int
+
 	initializing bool
 	registered   bool
 	initialized  bool
@@ -147,6 +155,9 @@ func (ph *pluginHandler[M]) serve() error {
 			plugin.notifyStopped()
 		}
 		ph.close()
+		if listener, ok := ph.pluginToManager.(pluginStoppedListener); ok {
+			listener.pluginStopped()
+		}
 	}()
 	// We are the receiving routine for the gRPC stream (we do NOT send)
 	for {
@@ -175,6 +186,9 @@ func (ph *pluginHandler[M]) serve() error {
 			}
 			// Update the context for us, and for request/reply, to include details of the plugin
 			debugInfo := fmt.Sprintf("%s[%s/%s]", plugin.def.Plugin.PluginType, plugin.name, plugin.id)
+			if plugin.replicas > 1 {
+				debugInfo = fmt.Sprintf("%s[%s/%d/%s]", plugin.def.Plugin.PluginType, plugin.name, plugin.replica, plugin.id)
+			}
 			ph.ctx = log.WithLogField(ph.ctx, "plugin", debugInfo) // dirty write - as it's just debug info being added
 			serverCtx = log.WithLogField(serverCtx, "plugin", debugInfo)
 			// We now have the plugin ready for use
@@ -239,6 +253,8 @@ func (ph *pluginHandler[M]) send(msg plugintk.PluginMessage[M]) {
 func (ph *pluginHandler[M]) close() {
 	ph.cancelCtx()
 	<-ph.senderDone
+	// Fail any requests still waiting for a response from the plugin
+	ph.inflight.Close()
 }
 
 // Go routine started for each request
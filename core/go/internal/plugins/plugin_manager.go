@@ -57,9 +57,11 @@ type pluginManager struct {
 
 	domainManager components.DomainManager
 	domainPlugins map[uuid.UUID]*plugin[prototk.DomainMessage]
+	domainPools   map[string]*domainPool
 
 	transportManager components.TransportManager
 	transportPlugins map[uuid.UUID]*plugin[prototk.TransportMessage]
+	transportMetrics *transportMetrics
 
 	registryManager components.RegistryManager
 	registryPlugins map[uuid.UUID]*plugin[prototk.RegistryMessage]
@@ -84,7 +86,9 @@ func NewPluginManager(bgCtx context.Context,
 		shutdownTimeout: confutil.DurationMin(conf.GRPC.ShutdownTimeout, 0, *pldconf.DefaultGRPCConfig.ShutdownTimeout),
 
 		domainPlugins:    make(map[uuid.UUID]*plugin[prototk.DomainMessage]),
+		domainPools:      make(map[string]*domainPool),
 		transportPlugins: make(map[uuid.UUID]*plugin[prototk.TransportMessage]),
+		transportMetrics: newTransportMetrics(bgCtx),
 		registryPlugins:  make(map[uuid.UUID]*plugin[prototk.RegistryMessage]),
 
 		serverDone:           make(chan error),
@@ -136,8 +140,9 @@ func (pm *pluginManager) PreInit(pic components.PreInitComponents) (*components.
 
 func (pm *pluginManager) PostInit(c components.AllComponents) error {
 	pm.domainManager = c.DomainManager()
-	pm.transportManager = c.TransportManager()
-	pm.registryManager = c.RegistryManager()
+	// Transport and registry plugins are only loaded if those managers are available
+	pm.transportManager, _ = c.TransportManager()
+	pm.registryManager, _ = c.RegistryManager()
 
 	if err := pm.ReloadPluginList(); err != nil {
 		return err
@@ -210,17 +215,21 @@ func (pm *pluginManager) LoaderID() uuid.UUID {
 func (pm *pluginManager) ReloadPluginList() (err error) {
 	for name, dp := range pm.domainManager.ConfiguredDomains() {
 		if err == nil {
-			err = initPlugin(pm.bgCtx, pm, pm.domainPlugins, name, prototk.PluginInfo_DOMAIN, dp)
+			err = initPlugin(pm.bgCtx, pm, pm.domainPlugins, name, prototk.PluginInfo_DOMAIN, dp, confutil.IntMin(dp.Replicas, 1, 1))
 		}
 	}
-	for name, tp := range pm.transportManager.ConfiguredTransports() {
-		if err == nil {
-			err = initPlugin(pm.bgCtx, pm, pm.transportPlugins, name, prototk.PluginInfo_TRANSPORT, tp)
+	if pm.transportManager != nil {
+		for name, tp := range pm.transportManager.ConfiguredTransports() {
+			if err == nil {
+				err = initPlugin(pm.bgCtx, pm, pm.transportPlugins, name, prototk.PluginInfo_TRANSPORT, tp, 1)
+			}
 		}
 	}
-	for name, tp := range pm.registryManager.ConfiguredRegistries() {
-		if err == nil {
-			err = initPlugin(pm.bgCtx, pm, pm.registryPlugins, name, prototk.PluginInfo_REGISTRY, tp)
+	if pm.registryManager != nil {
+		for name, tp := range pm.registryManager.ConfiguredRegistries() {
+			if err == nil {
+				err = initPlugin(pm.bgCtx, pm, pm.registryPlugins, name, prototk.PluginInfo_REGISTRY, tp, 1)
+			}
 		}
 	}
 	if err != nil {
@@ -291,28 +300,36 @@ func (pm *pluginManager) LoadFailed(ctx context.Context, req *prototk.PluginLoad
 	return &prototk.EmptyResponse{}, nil
 }
 
-func initPlugin[CB any](ctx context.Context, pm *pluginManager, pluginMap map[uuid.UUID]*plugin[CB], name string, pType prototk.PluginInfo_PluginType, conf *pldconf.PluginConfig) (err error) {
+func initPlugin[CB any](ctx context.Context, pm *pluginManager, pluginMap map[uuid.UUID]*plugin[CB], name string, pType prototk.PluginInfo_PluginType, conf *pldconf.PluginConfig, replicas int) (err error) {
 	pm.mux.Lock()
 	defer pm.mux.Unlock()
-	plugin := &plugin[CB]{pc: pm, id: uuid.New(), name: name}
 	if err := pldtypes.ValidateSafeCharsStartEndAlphaNum(ctx, name, pldtypes.DefaultNameMaxLen, "name"); err != nil {
 		return err
 	}
-	plugin.def = &prototk.PluginLoad{
-		Plugin: &prototk.PluginInfo{
-			Id:         plugin.id.String(),
-			Name:       name,
-			PluginType: pType,
-		},
-		LibLocation: conf.Library,
-		Class:       conf.Class,
-	}
 	pluginType, err := pldtypes.LibraryType(conf.Type).Enum().Validate()
-	if err == nil {
-		plugin.def.LibType, err = MapLibraryTypeToProto(pluginType.Enum())
+	if err != nil {
+		return err
+	}
+	libType, err := MapLibraryTypeToProto(pluginType.Enum())
+	if err != nil {
+		return err
+	}
+	// Each replica is loaded as a separate instance of the same plugin, with its own ID
+	for replica := 0; replica < replicas; replica++ {
+		plugin := &plugin[CB]{pc: pm, id: uuid.New(), name: name, replica: replica, replicas: replicas}
+		plugin.def = &prototk.PluginLoad{
+			Plugin: &prototk.PluginInfo{
+				Id:         plugin.id.String(),
+				Name:       name,
+				PluginType: pType,
+			},
+			LibLocation: conf.Library,
+			Class:       conf.Class,
+			LibType:     libType,
+		}
 		pluginMap[plugin.id] = plugin
 	}
-	return err
+	return nil
 }
 
 func (pm *pluginManager) tapLoadingProgressed() {
@@ -74,11 +74,11 @@ func (tm *testManagers) componentsmocks(t *testing.T) *componentsmocks.AllCompon
 	if tm.testTransportManager == nil {
 		tm.testTransportManager = &testTransportManager{}
 	}
-	mc.On("TransportManager").Return(tm.testTransportManager.mock(t)).Maybe()
+	mc.On("TransportManager").Return(tm.testTransportManager.mock(t), true).Maybe()
 	if tm.testRegistryManager == nil {
 		tm.testRegistryManager = &testRegistryManager{}
 	}
-	mc.On("RegistryManager").Return(tm.testRegistryManager.mock(t)).Maybe()
+	mc.On("RegistryManager").Return(tm.testRegistryManager.mock(t), true).Maybe()
 	return mc
 }
 
@@ -18,11 +18,36 @@ import (
 	"context"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/protobuf/proto"
 )
 
+const metricAttrTransport = "transport"
+
+// Metrics recorded by every transport bridge, with the transport name as an attribute.
+type transportMetrics struct {
+	oversizedMessages metric.Int64Counter
+}
+
+func newTransportMetrics(ctx context.Context) *transportMetrics {
+	meter := otel.Meter("github.com/kaleido-io/paladin/core/internal/plugins")
+	tm := &transportMetrics{}
+	var err error
+	tm.oversizedMessages, err = meter.Int64Counter("paladin.transports.messages.oversized",
+		metric.WithDescription("Number of messages received from transport plugins dropped for exceeding the maximum size"))
+	if err != nil {
+		// the instruments are still safe to use, but might not record
+		log.L(ctx).Errorf("Failed to create transport metrics: %s", err)
+	}
+	return tm
+}
+
 // The gRPC stream connected to by Transport plugins
 func (pm *pluginManager) ConnectTransport(stream prototk.PluginController_ConnectTransportServer) error {
 	handler := newPluginHandler(pm, prototk.PluginInfo_TRANSPORT, pm.transportPlugins, stream,
@@ -34,23 +59,27 @@ func (pm *pluginManager) ConnectTransport(stream prototk.PluginController_Connec
 				pluginName: plugin.name,
 				pluginId:   plugin.id.String(),
 				toPlugin:   toPlugin,
+				metrics:    pm.transportMetrics,
 			}
 			br.manager, err = pm.transportManager.TransportRegistered(plugin.name, plugin.id, br)
 			if err != nil {
 				return nil, err
 			}
+			br.maxMessageBytes = pm.transportManager.MaxMessageBytes()
 			return br, nil
 		})
 	return handler.serve()
 }
 
 type TransportBridge struct {
-	plugin     *plugin[prototk.TransportMessage]
-	pluginType string
-	pluginName string
-	pluginId   string
-	toPlugin   managerToPlugin[prototk.TransportMessage]
-	manager    plugintk.TransportCallbacks
+	plugin          *plugin[prototk.TransportMessage]
+	pluginType      string
+	pluginName      string
+	pluginId        string
+	toPlugin        managerToPlugin[prototk.TransportMessage]
+	manager         plugintk.TransportCallbacks
+	maxMessageBytes int64
+	metrics         *transportMetrics
 }
 
 // TransportManager calls this when it is satisfied the Transport is fully initialized.
@@ -73,7 +102,7 @@ func (br *TransportBridge) RequestReply(ctx context.Context, reqMsg plugintk.Plu
 		)
 	case *prototk.TransportMessage_ReceiveMessage:
 		return callManagerImpl(ctx, req.ReceiveMessage,
-			br.manager.ReceiveMessage,
+			br.receiveMessage,
 			func(resMsg *prototk.TransportMessage, res *prototk.ReceiveMessageResponse) {
 				resMsg.ResponseToTransport = &prototk.TransportMessage_ReceiveMessageRes{
 					ReceiveMessageRes: res,
@@ -85,6 +114,17 @@ func (br *TransportBridge) RequestReply(ctx context.Context, reqMsg plugintk.Plu
 	}
 }
 
+// Messages over the size limit are dropped before they reach the transport manager. As with any
+// other message the transport manager drops, we do not return an error to the transport plugin.
+func (br *TransportBridge) receiveMessage(ctx context.Context, req *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+	if size := int64(proto.Size(req.Message)); size > br.maxMessageBytes {
+		log.L(ctx).Warnf("Dropping message from transport %s of size %d bytes that exceeds the limit of %d bytes", br.pluginName, size, br.maxMessageBytes)
+		br.metrics.oversizedMessages.Add(ctx, 1, metric.WithAttributes(attribute.String(metricAttrTransport, br.pluginName)))
+		return &prototk.ReceiveMessageResponse{}, nil
+	}
+	return br.manager.ReceiveMessage(ctx, req)
+}
+
 func (br *TransportBridge) ConfigureTransport(ctx context.Context, req *prototk.ConfigureTransportRequest) (res *prototk.ConfigureTransportResponse, err error) {
 	err = br.toPlugin.RequestReply(ctx,
 		func(dm plugintk.PluginMessage[prototk.TransportMessage]) {
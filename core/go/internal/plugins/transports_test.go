@@ -41,6 +41,7 @@ type testTransportManager struct {
 	transportRegistered func(name string, id uuid.UUID, toTransport components.TransportManagerToTransport) (fromTransport plugintk.TransportCallbacks, err error)
 	resolveTarget       func(context.Context, *prototk.GetTransportDetailsRequest) (*prototk.GetTransportDetailsResponse, error)
 	receiveMessage      func(context.Context, *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error)
+	maxMessageBytes     int64
 }
 
 func transportConnectFactory(ctx context.Context, client prototk.PluginControllerClient) (grpc.BidiStreamingClient[prototk.TransportMessage, prototk.TransportMessage], error) {
@@ -65,6 +66,11 @@ func (tp *testTransportManager) mock(t *testing.T) *componentsmocks.TransportMan
 		}
 	}
 	mdm.On("ConfiguredTransports").Return(pluginMap).Maybe()
+	maxMessageBytes := tp.maxMessageBytes
+	if maxMessageBytes == 0 {
+		maxMessageBytes = 1024 * 1024
+	}
+	mdm.On("MaxMessageBytes").Return(maxMessageBytes).Maybe()
 	mdr := mdm.On("TransportRegistered", mock.Anything, mock.Anything, mock.Anything).Maybe()
 	mdr.Run(func(args mock.Arguments) {
 		m2p, err := tp.transportRegistered(args[0].(string), args[1].(uuid.UUID), args[2].(components.TransportManagerToTransport))
@@ -159,10 +165,13 @@ func TestTransportRequestsOK(t *testing.T) {
 			TransportDetails: "node1_details",
 		}, nil
 	}
+	receivedCount := 0
 	ttm.receiveMessage = func(ctx context.Context, req *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
 		assert.Equal(t, "body1", string(req.Message.Payload))
+		receivedCount++
 		return &prototk.ReceiveMessageResponse{}, nil
 	}
+	ttm.maxMessageBytes = 100
 
 	ctx, pc, done := newTestTransportPluginManager(t, &testManagers{
 		testTransportManager: ttm,
@@ -212,6 +221,17 @@ func TestTransportRequestsOK(t *testing.T) {
 	})
 	require.NoError(t, err)
 	assert.NotNil(t, rms)
+	assert.Equal(t, 1, receivedCount)
+
+	// Messages over the limit are dropped without an error to the transport
+	rms, err = callbacks.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		Message: &prototk.PaladinMsg{
+			Payload: make([]byte, 101),
+		},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, rms)
+	assert.Equal(t, 1, receivedCount)
 
 }
 
@@ -384,3 +404,45 @@ func TestTransportRegisterPartialSuccess(t *testing.T) {
 		t.Fatal("transport failure callback never fired")
 	}
 }
+
+func TestFromTransportRequestBadReq(t *testing.T) {
+
+	waitForResponse := make(chan struct{}, 1)
+
+	msgID := uuid.NewString()
+	ttm := &testTransportManager{
+		transports: map[string]plugintk.Plugin{
+			"transport1": &mockPlugin[prototk.TransportMessage]{
+				t:              t,
+				connectFactory: transportConnectFactory,
+				headerAccessor: transportHeaderAccessor,
+				sendRequest: func(transportID string) *prototk.TransportMessage {
+					return &prototk.TransportMessage{
+						Header: &prototk.Header{
+							PluginId:    transportID,
+							MessageId:   msgID,
+							MessageType: prototk.Header_REQUEST_FROM_PLUGIN,
+							// Missing payload
+						},
+					}
+				},
+				handleResponse: func(tm *prototk.TransportMessage) {
+					assert.Equal(t, msgID, *tm.Header.CorrelationId)
+					assert.Regexp(t, "PD011203", *tm.Header.ErrorMessage)
+					close(waitForResponse)
+				},
+			},
+		},
+	}
+	ttm.transportRegistered = func(name string, id uuid.UUID, toTransport components.TransportManagerToTransport) (plugintk.TransportCallbacks, error) {
+		return ttm, nil
+	}
+
+	_, _, done := newTestTransportPluginManager(t, &testManagers{
+		testTransportManager: ttm,
+	})
+	defer done()
+
+	<-waitForResponse
+
+}
@@ -52,6 +52,7 @@ type privateTxManager struct {
 	sequencersLock       sync.RWMutex
 	endorsementGatherers map[string]ptmgrtypes.EndorsementGatherer
 	components           components.AllComponents
+	transportManager     components.TransportManager
 	nodeName             string
 	subscribers          []components.PrivateTxEventSubscriber
 	subscribersLock      sync.Mutex
@@ -76,8 +77,14 @@ func (p *privateTxManager) PreInit(c components.PreInitComponents) (*components.
 
 func (p *privateTxManager) PostInit(c components.AllComponents) error {
 	p.components = c
-	p.nodeName = p.components.TransportManager().LocalNodeName()
-	p.syncPoints = syncpoints.NewSyncPoints(p.ctx, &p.config.Writer, c.Persistence(), c.TxManager(), c.PublicTxManager(), c.TransportManager())
+	var ok bool
+	if p.transportManager, ok = c.TransportManager(); ok {
+		p.nodeName = p.transportManager.LocalNodeName()
+	} else {
+		// Embedded mode - private transactions are rejected on submission, but the rest of the engine runs
+		log.L(p.ctx).Warnf("Transport manager not available - private transaction submission disabled")
+	}
+	p.syncPoints = syncpoints.NewSyncPoints(p.ctx, &p.config.Writer, c.Persistence(), c.TxManager(), c.PublicTxManager(), p.transportManager)
 	return nil
 }
 
@@ -135,7 +142,7 @@ func (p *privateTxManager) getSequencerForContract(ctx context.Context, dbTX per
 		defer p.sequencersLock.Unlock()
 		//double check in case another goroutine has created the sequencer while we were waiting for the write lock
 		if p.sequencers[contractAddr.String()] == nil {
-			transportWriter := NewTransportWriter(domainAPI.Domain().Name(), &contractAddr, p.nodeName, p.components.TransportManager())
+			transportWriter := NewTransportWriter(domainAPI.Domain().Name(), &contractAddr, p.nodeName, p.transportManager)
 			publisher := NewPublisher(p, contractAddr.String())
 
 			endorsementGatherer, err := p.getEndorsementGathererForContract(ctx, dbTX, contractAddr)
@@ -195,14 +202,24 @@ func (p *privateTxManager) getEndorsementGathererForContract(ctx context.Context
 	}
 	if p.endorsementGatherers[contractAddr.String()] == nil {
 		// TODO: Consider scope of state in privateTxManager threading model
-		dCtx := p.components.StateManager().NewDomainContext(p.ctx /* background context */, domainSmartContract.Domain(), contractAddr)
+		dCtx := p.components.StateManager().NewLongLivedDomainContext(p.ctx /* background context */, domainSmartContract.Domain(), contractAddr)
 		endorsementGatherer := NewEndorsementGatherer(p.components.Persistence(), domainSmartContract, dCtx, p.components.KeyManager())
 		p.endorsementGatherers[contractAddr.String()] = endorsementGatherer
 	}
 	return p.endorsementGatherers[contractAddr.String()], nil
 }
 
+func (p *privateTxManager) checkTransportAvailable(ctx context.Context) error {
+	if p.transportManager == nil {
+		return i18n.NewError(ctx, msgs.MsgComponentManagerNotAvailable, "transport", "private transactions")
+	}
+	return nil
+}
+
 func (p *privateTxManager) HandleNewTx(ctx context.Context, dbTX persistence.DBTX, txi *components.ValidatedTransaction) error {
+	if err := p.checkTransportAvailable(ctx); err != nil {
+		return err
+	}
 	tx := txi.Transaction
 	if tx.To == nil {
 		if txi.Transaction.SubmitMode.V() != pldapi.SubmitModeAuto {
@@ -428,7 +445,8 @@ func (p *privateTxManager) evaluateDeployment(ctx context.Context, domain compon
 
 	publicTXs := []*components.PublicTxSubmission{
 		{
-			Bindings: []*components.PaladinTXReference{{TransactionID: tx.ID, TransactionType: pldapi.TransactionTypePrivate.Enum()}},
+			Bindings:                  []*components.PaladinTXReference{{TransactionID: tx.ID, TransactionType: pldapi.TransactionTypePrivate.Enum()}},
+			RequiredConfirmationDepth: domain.RequiredConfirmationDepth(),
 			PublicTxInput: pldapi.PublicTxInput{
 				From:            resolvedAddrs[0],
 				PublicTxOptions: pldapi.PublicTxOptions{}, // TODO: Consider propagation from paladin transaction input
@@ -658,7 +676,7 @@ func (p *privateTxManager) handleEndorsementRequest(ctx context.Context, message
 		return
 	}
 
-	err = p.components.TransportManager().Send(ctx, &components.FireAndForgetMessageSend{
+	err = p.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
 		MessageType: "EndorsementResponse",
 		Payload:     endorsementResponseBytes,
 		Node:        replyTo,
@@ -773,7 +791,7 @@ func (p *privateTxManager) sendAssembleError(ctx context.Context, node string, a
 
 	log.L(ctx).Infof("Sending Assemble Error: ContractAddress: %s, TransactionId: %s, AssembleRequestId %s, Error: %s", contractAddress, transactionID, assembleRequestId, assembleError.ErrorMessage)
 
-	err = p.components.TransportManager().Send(ctx, &components.FireAndForgetMessageSend{
+	err = p.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
 		MessageType: "AssembleError",
 		Payload:     assembleErrorBytes,
 		Node:        node,
@@ -856,7 +874,7 @@ func (p *privateTxManager) handleAssembleRequest(ctx context.Context, messagePay
 		return
 	}
 
-	err = p.components.TransportManager().Send(ctx, &components.FireAndForgetMessageSend{
+	err = p.transportManager.Send(ctx, &components.FireAndForgetMessageSend{
 		MessageType: "AssembleResponse",
 		Payload:     assembleResponseBytes,
 		Node:        replyTo,
@@ -981,7 +999,7 @@ func (p *privateTxManager) NotifyFailedPublicTx(ctx context.Context, dbTX persis
 func (p *privateTxManager) PrivateTransactionConfirmed(ctx context.Context, receipt *components.TxCompletion) {
 	log.L(ctx).Infof("private TX manager notified of transaction confirmation %s deploy=%t",
 		receipt.TransactionID, receipt.PSC == nil)
-	if receipt.PSC != nil {
+	if receipt.PSC != nil && p.transportManager != nil {
 		seq, err := p.getSequencerForContract(ctx, p.components.Persistence().NOTX(), receipt.PSC.Address(), receipt.PSC)
 		if err != nil {
 			log.L(ctx).Errorf("failed to obtain sequence to process receipts on contract %s: %s", receipt.PSC.Address(), err)
@@ -101,6 +101,26 @@ func TestPrivateTxManagerInit(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPrivateTxManagerInitNoTransport(t *testing.T) {
+
+	privateTxManager := NewPrivateTransactionMgr(context.Background(), &pldconf.PrivateTxManagerConfig{})
+	mc := componentsmocks.NewAllComponents(t)
+	mc.On("TransportManager").Return(nil, false)
+	mc.On("Persistence").Return(nil)
+	mc.On("TxManager").Return(nil)
+	mc.On("PublicTxManager").Return(nil)
+	err := privateTxManager.PostInit(mc)
+	require.NoError(t, err)
+
+	err = privateTxManager.HandleNewTx(context.Background(), nil, &components.ValidatedTransaction{})
+	assert.Regexp(t, "PD010036.*transport", err)
+
+	// Confirmations are ignored, as no sequencer can exist
+	privateTxManager.PrivateTransactionConfirmed(context.Background(), &components.TxCompletion{
+		PSC: componentsmocks.NewDomainSmartContract(t),
+	})
+}
+
 func TestPrivateTxManagerInvalidTransactionMissingDomain(t *testing.T) {
 	t.Skip("This test is not valid because the code accepts empty domain. TODO: remove this test or change the code and migrate any consumers")
 	ctx := context.Background()
@@ -2672,6 +2692,8 @@ type dependencyMocks struct {
 
 func (m *dependencyMocks) mockDomain(domainAddress *pldtypes.EthAddress) {
 	m.stateStore.On("NewDomainContext", mock.Anything, m.domain, *domainAddress, mock.Anything).Return(m.domainContext).Maybe()
+	m.stateStore.On("NewLongLivedDomainContext", mock.Anything, m.domain, *domainAddress, mock.Anything).Return(m.domainContext).Maybe()
+	m.domainContext.On("Close").Return().Maybe()
 	m.domainMgr.On("GetSmartContractByAddress", mock.Anything, mock.Anything, *domainAddress).Maybe().Return(m.domainSmartContract, nil)
 	m.domain.On("Configuration").Return(&prototk.DomainConfig{}).Maybe()
 }
@@ -2875,7 +2897,7 @@ func NewPrivateTransactionMgrForPackageTesting(t *testing.T, nodeName string) (p
 	}
 	mocks.allComponents.On("StateManager").Return(mocks.stateStore).Maybe()
 	mocks.allComponents.On("DomainManager").Return(mocks.domainMgr).Maybe()
-	mocks.allComponents.On("TransportManager").Return(mocks.transportManager).Maybe()
+	mocks.allComponents.On("TransportManager").Return(mocks.transportManager, true).Maybe()
 	mocks.transportManager.On("LocalNodeName").Return(nodeName)
 	mocks.allComponents.On("KeyManager").Return(mocks.keyManager).Maybe()
 	mocks.allComponents.On("TxManager").Return(mocks.txManager).Maybe()
@@ -2885,6 +2907,7 @@ func NewPrivateTransactionMgrForPackageTesting(t *testing.T, nodeName string) (p
 	mocks.domainSmartContract.On("LockStates", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	mocks.domainMgr.On("GetDomainByName", mock.Anything, "domain1").Return(mocks.domain, nil).Maybe()
 	mocks.domain.On("Name").Return("domain1").Maybe()
+	mocks.domain.On("RequiredConfirmationDepth").Return(uint(0)).Maybe()
 	mocks.keyManager.On("KeyResolverForDBTXLazyDB", mock.Anything).Return(mocks.keyResolver).Maybe()
 
 	mocks.domainContext.On("Ctx").Return(ctx).Maybe()
@@ -3058,6 +3081,7 @@ func mockDomainSmartContractAndCtx(t *testing.T, m *dependencyMocks) (*component
 
 	mDomain := componentsmocks.NewDomain(t)
 	mDomain.On("Name").Return("domain1").Maybe()
+	mDomain.On("RequiredConfirmationDepth").Return(uint(0)).Maybe()
 
 	mPSC := componentsmocks.NewDomainSmartContract(t)
 	mPSC.On("Address").Return(contractAddr).Maybe()
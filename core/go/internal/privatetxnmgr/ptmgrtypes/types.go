@@ -132,6 +132,10 @@ type TransactionFlow interface {
 	InputStateIDs(ctx context.Context) []string
 	OutputStateIDs(ctx context.Context) []string
 	Signer(ctx context.Context) string
+
+	// FailIfExpired reverts the transaction if it has not reached a terminal state within the
+	// given lifetime, returning true if it did so
+	FailIfExpired(ctx context.Context, lifetime time.Duration) bool
 }
 
 type Clock interface {
@@ -86,6 +86,8 @@ type Sequencer struct {
 	initiated    time.Time     // when sequencer is created
 	evalInterval time.Duration // between how long the sequencer will do an evaluation to check & remove transactions that missed events
 
+	lifecycleTimeout time.Duration // how long a transaction can be in flight before it is failed
+
 	maxConcurrentProcess        int
 	incompleteTxProcessMapMutex sync.Mutex
 	incompleteTxSProcessMap     map[string]ptmgrtypes.TransactionFlow // a map of all known transactions that are not completed
@@ -150,6 +152,7 @@ func NewSequencer(
 		initiated:            time.Now(),
 		contractAddress:      contractAddress,
 		evalInterval:         confutil.DurationMin(sequencerConfig.EvaluationInterval, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.EvaluationInterval),
+		lifecycleTimeout:     confutil.DurationMin(sequencerConfig.TransactionLifecycleTimeout, 1*time.Millisecond, *pldconf.PrivateTxManagerDefaults.Sequencer.TransactionLifecycleTimeout),
 		maxConcurrentProcess: confutil.Int(sequencerConfig.MaxConcurrentProcess, *pldconf.PrivateTxManagerDefaults.Sequencer.MaxConcurrentProcess),
 		state:                SequencerStateNew,
 		stateEntryTime:       time.Now(),
@@ -202,8 +205,8 @@ func NewSequencer(
 	}
 
 	// create 2 domain contexts. One to keep track of all transactions that we are coordinating and one for assembling transactions on behalf of a remote coordinator
-	newSequencer.coordinatorDomainContext = allComponents.StateManager().NewDomainContext(newSequencer.ctx /* background context */, domainSmartContract.Domain(), contractAddress)
-	newSequencer.delegateDomainContext = allComponents.StateManager().NewDomainContext(newSequencer.ctx /* background context */, domainSmartContract.Domain(), contractAddress)
+	newSequencer.coordinatorDomainContext = allComponents.StateManager().NewLongLivedDomainContext(newSequencer.ctx /* background context */, domainSmartContract.Domain(), contractAddress)
+	newSequencer.delegateDomainContext = allComponents.StateManager().NewLongLivedDomainContext(newSequencer.ctx /* background context */, domainSmartContract.Domain(), contractAddress)
 
 	newSequencer.assembleCoordinator = NewAssembleCoordinator(
 		ctx,
@@ -238,6 +241,21 @@ func (s *Sequencer) getTransactionProcessor(txID string) ptmgrtypes.TransactionF
 	return transactionProcessor
 }
 
+func (s *Sequencer) failExpiredTransactions(ctx context.Context) {
+	s.incompleteTxProcessMapMutex.Lock()
+	transactionProcessors := make([]ptmgrtypes.TransactionFlow, 0, len(s.incompleteTxSProcessMap))
+	for _, tp := range s.incompleteTxSProcessMap {
+		transactionProcessors = append(transactionProcessors, tp)
+	}
+	s.incompleteTxProcessMapMutex.Unlock()
+
+	for _, tp := range transactionProcessors {
+		if tp.FailIfExpired(ctx, s.lifecycleTimeout) {
+			log.L(ctx).Warnf("Transaction %s failed after exceeding lifecycle timeout %s", tp.ID(ctx), s.lifecycleTimeout)
+		}
+	}
+}
+
 func (s *Sequencer) removeTransactionProcessor(txID string) {
 	s.incompleteTxProcessMapMutex.Lock()
 	defer s.incompleteTxProcessMapMutex.Unlock()
@@ -329,6 +347,15 @@ func (s *Sequencer) Stop() {
 
 }
 
+func (s *Sequencer) closeDomainContexts() {
+	if s.coordinatorDomainContext != nil {
+		s.coordinatorDomainContext.Close()
+	}
+	if s.delegateDomainContext != nil {
+		s.delegateDomainContext.Close()
+	}
+}
+
 func (s *Sequencer) TriggerSequencerEvaluation() {
 	// try to send an item in `processNow` channel, which has a buffer of 1
 	// if it already has an item in the channel, this function does nothing
@@ -36,6 +36,8 @@ func (s *Sequencer) evaluationLoop() {
 	log.L(ctx).Infof("Sequencer for contract address %s started evaluation loop based on interval %s", s.contractAddress, s.evalInterval)
 
 	defer close(s.sequencerLoopDone)
+	// The sequencer owns its domain contexts for as long as the loop runs
+	defer s.closeDomainContexts()
 
 	ticker := time.NewTicker(s.evalInterval)
 	for {
@@ -48,6 +50,7 @@ func (s *Sequencer) evaluationLoop() {
 			s.handleTransactionEvent(ctx, pendingEvent)
 		case <-s.orchestrationEvalRequestChan:
 		case <-ticker.C:
+			s.failExpiredTransactions(ctx)
 		case <-ctx.Done():
 			log.L(ctx).Infof("Sequencer loop exit due to canceled context, it processed %d transaction during its lifetime.", s.totalCompleted)
 			return
@@ -79,7 +79,7 @@ func newSequencerForTesting(t *testing.T, ctx context.Context, domainAddress *pl
 	}
 	mocks.allComponents.On("StateManager").Return(mocks.stateStore).Maybe()
 	mocks.allComponents.On("DomainManager").Return(mocks.domainMgr).Maybe()
-	mocks.allComponents.On("TransportManager").Return(mocks.transportManager).Maybe()
+	mocks.allComponents.On("TransportManager").Return(mocks.transportManager, true).Maybe()
 	mocks.allComponents.On("KeyManager").Return(mocks.keyManager).Maybe()
 	mocks.allComponents.On("TxManager").Return(mocks.txManager).Maybe()
 	mocks.allComponents.On("PublicTxManager").Return(mocks.pubTxManager).Maybe()
@@ -89,12 +89,15 @@ func newSequencerForTesting(t *testing.T, ctx context.Context, domainAddress *pl
 	mocks.allComponents.On("Persistence").Return(p).Maybe()
 	mocks.endorsementGatherer.On("DomainContext").Return(mocks.domainContext).Maybe()
 	mocks.domainSmartContract.On("Domain").Return(mocks.domain).Maybe()
+	mocks.domain.On("RequiredConfirmationDepth").Return(uint(0)).Maybe()
 	mocks.domainSmartContract.On("Address").Return(*domainAddress).Maybe()
 	mocks.domainSmartContract.On("ContractConfig").Return(&prototk.ContractConfig{
 		CoordinatorSelection: prototk.ContractConfig_COORDINATOR_ENDORSER,
 	})
 
 	mocks.stateStore.On("NewDomainContext", mock.Anything, mocks.domain, *domainAddress, mock.Anything).Return(mocks.domainContext).Maybe()
+	mocks.stateStore.On("NewLongLivedDomainContext", mock.Anything, mocks.domain, *domainAddress, mock.Anything).Return(mocks.domainContext).Maybe()
+	mocks.domainContext.On("Close").Return().Maybe()
 	//mocks.domain.On("Configuration").Return(&prototk.DomainConfig{}).Maybe()
 
 	syncPoints := syncpoints.NewSyncPoints(ctx, &pldconf.FlushWriterConfig{}, p, mocks.txManager, mocks.pubTxManager, mocks.transportManager)
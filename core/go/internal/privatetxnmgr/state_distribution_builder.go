@@ -27,10 +27,14 @@ import (
 )
 
 func newStateDistributionBuilder(c components.AllComponents, tx *components.PrivateTransaction) *stateDistributionBuilder {
+	localNode := ""
+	if transportMgr, ok := c.TransportManager(); ok {
+		localNode = transportMgr.LocalNodeName()
+	}
 	return &stateDistributionBuilder{
 		tx: tx,
 		StateDistributionSet: components.StateDistributionSet{
-			LocalNode: c.TransportManager().LocalNodeName(),
+			LocalNode: localNode,
 			Remote:    []*components.StateDistributionWithData{},
 			Local:     []*components.StateDistributionWithData{},
 		},
@@ -32,7 +32,7 @@ func newTestStateDistributionBuilder(t *testing.T, tx *components.PrivateTransac
 	mt.On("LocalNodeName").Return("node1")
 
 	mc := componentsmocks.NewAllComponents(t)
-	mc.On("TransportManager").Return(mt)
+	mc.On("TransportManager").Return(mt, true)
 
 	return context.Background(), newStateDistributionBuilder(mc, tx)
 }
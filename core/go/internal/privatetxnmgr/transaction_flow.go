@@ -49,7 +49,9 @@ func NewTransactionFlow(
 	environment ptmgrtypes.SequencerEnvironment,
 ) ptmgrtypes.TransactionFlow {
 
+	clock := ptmgrtypes.RealClock()
 	return &transactionFlow{
+		created:                     clock.Now(),
 		stageErrorRetry:             10 * time.Second,
 		domainAPI:                   domainAPI,
 		domainContext:               domainContext,
@@ -71,7 +73,7 @@ func NewTransactionFlow(
 		localCoordinator:            true,
 		dispatched:                  false,
 		prepared:                    false,
-		clock:                       ptmgrtypes.RealClock(),
+		clock:                       clock,
 		requestTimeout:              requestTimeout,
 		selectCoordinator:           selectCoordinator,
 		assembleCoordinator:         assembleCoordinator,
@@ -86,6 +88,7 @@ type endorsementRequest struct {
 	idempotencyKey string
 }
 type transactionFlow struct {
+	created                     time.Time
 	stageErrorRetry             time.Duration
 	components                  components.AllComponents
 	nodeName                    string
@@ -138,6 +141,17 @@ func (tf *transactionFlow) Dispatched(_ context.Context) bool {
 	return tf.dispatched
 }
 
+func (tf *transactionFlow) FailIfExpired(ctx context.Context, lifetime time.Duration) bool {
+	tf.statusLock.Lock()
+	defer tf.statusLock.Unlock()
+	// Once dispatched, the outcome is decided by the base ledger so we cannot fail it here
+	if tf.complete || tf.dispatched || tf.finalizeRequired || tf.clock.Now().Before(tf.created.Add(lifetime)) {
+		return false
+	}
+	tf.revertTransaction(ctx, i18n.ExpandWithCode(ctx, i18n.MessageKey(msgs.MsgPrivateTxMgrTransactionLifecycleTimeout), lifetime))
+	return true
+}
+
 func (tf *transactionFlow) IsEndorsed(ctx context.Context) bool {
 	return !tf.hasOutstandingEndorsementRequests(ctx)
 }
@@ -17,6 +17,7 @@ package privatetxnmgr
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -77,7 +78,7 @@ func newTransactionFlowForTesting(t *testing.T, ctx context.Context, transaction
 	contractAddress := pldtypes.RandAddress()
 	mocks.allComponents.On("StateManager").Return(mocks.stateStore).Maybe()
 	mocks.allComponents.On("DomainManager").Return(mocks.domainMgr).Maybe()
-	mocks.allComponents.On("TransportManager").Return(mocks.transportManager).Maybe()
+	mocks.allComponents.On("TransportManager").Return(mocks.transportManager, true).Maybe()
 	mocks.allComponents.On("KeyManager").Return(mocks.keyManager).Maybe()
 	mocks.endorsementGatherer.On("DomainContext").Return(mocks.domainContext).Maybe()
 	mocks.domainSmartContract.On("Address").Return(*contractAddress).Maybe()
@@ -1094,6 +1095,94 @@ func TestTimedOutEndorsementRequest(t *testing.T) {
 	tp.Action(ctx)
 }
 
+func TestTransactionLifecycleTimeout(t *testing.T) {
+	// An endorser that never responds leaves the transaction waiting on endorsement forever, with the
+	// request being retried on every timeout. The lifecycle timeout is the backstop that fails it.
+
+	ctx := context.Background()
+	newTxID := uuid.New()
+
+	aliceIdentityLocator := "alice@node1"
+	bobIdentityLocator := "bob@node2"
+
+	testContractAddress := *pldtypes.RandAddress()
+	testTx := &components.PrivateTransaction{
+		ID:      newTxID,
+		Domain:  "domain1",
+		Address: testContractAddress,
+		PreAssembly: &components.TransactionPreAssembly{
+			TransactionSpecification: &prototk.TransactionSpecification{
+				From:          aliceIdentityLocator,
+				TransactionId: newTxID.String(),
+			},
+			Verifiers: []*prototk.ResolvedVerifier{
+				{
+					Lookup:       bobIdentityLocator,
+					Algorithm:    algorithms.ECDSA_SECP256K1,
+					VerifierType: verifiers.ETH_ADDRESS,
+					Verifier:     pldtypes.RandAddress().String(),
+				},
+			},
+		},
+		PostAssembly: &components.TransactionPostAssembly{
+			AttestationPlan: []*prototk.AttestationRequest{
+				{
+					Name:            "foo",
+					AttestationType: prototk.AttestationType_ENDORSE,
+					Algorithm:       algorithms.ECDSA_SECP256K1,
+					VerifierType:    verifiers.ETH_ADDRESS,
+					PayloadType:     signpayloads.OPAQUE_TO_RSV,
+					Parties:         []string{bobIdentityLocator},
+				},
+			},
+		},
+	}
+
+	tp, mocks := newTransactionFlowForTesting(t, ctx, testTx, "node1")
+	mocks.coordinatorSelector.On("SelectCoordinatorNode", mock.Anything, mock.Anything, mock.Anything).Return(int64(0), "node1", nil)
+
+	fakeClock := &fakeClock{timePassed: 0}
+	tp.clock = fakeClock
+
+	mocks.transportWriter.On("SendEndorsementRequest",
+		mock.Anything, mock.Anything, bobIdentityLocator, "node2", testContractAddress.String(), newTxID.String(),
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	).Return(nil).Once()
+	tp.Action(ctx)
+	mocks.transportWriter.AssertExpectations(t)
+
+	lifetime := 30 * time.Minute
+
+	// Not yet expired
+	fakeClock.timePassed = lifetime - 1*time.Second
+	assert.False(t, tp.FailIfExpired(ctx, lifetime))
+
+	var onCommit func(context.Context)
+	mocks.syncPoints.On("QueueTransactionFinalize", mock.Anything, "domain1", mock.Anything, aliceIdentityLocator, newTxID,
+		mock.MatchedBy(func(revertReason string) bool {
+			return strings.Contains(revertReason, "PD011839")
+		}), mock.Anything, mock.Anything,
+	).Run(func(args mock.Arguments) {
+		onCommit = args.Get(6).(func(context.Context))
+	}).Once()
+
+	fakeClock.timePassed = lifetime + 1*time.Second
+	assert.True(t, tp.FailIfExpired(ctx, lifetime))
+	require.NotNil(t, onCommit)
+
+	// Only failed once, while the finalize is in progress
+	assert.False(t, tp.FailIfExpired(ctx, lifetime))
+
+	// Once the receipt is committed the transaction is removed from the domain context
+	finalized := make(chan struct{})
+	mocks.domainContext.On("ResetTransactions", []uuid.UUID{newTxID}).Once()
+	mocks.publisher.On("PublishTransactionFinalizedEvent", mock.Anything, newTxID.String()).Run(func(args mock.Arguments) {
+		close(finalized)
+	}).Once()
+	onCommit(ctx)
+	<-finalized
+}
+
 func TestEndorsementResponseAfterRevert(t *testing.T) {
 	// We send out 2 endorsement requests , the first one back causes a revert
 	// the second one back should be ignored
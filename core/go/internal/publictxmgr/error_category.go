@@ -0,0 +1,153 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// ErrorCategory determines how the in-flight stage controller reacts to an error returned from a stage
+type ErrorCategory string
+
+const (
+	// ErrorCategoryTransient errors are retried with the normal stage retry back-off
+	ErrorCategoryTransient ErrorCategory = "transient"
+	// ErrorCategoryPermanentReject errors will never succeed on retry, so the transaction is suspended
+	ErrorCategoryPermanentReject ErrorCategory = "permanent_reject"
+	// ErrorCategoryNeedsReprice errors trigger a new gas price retrieval with a gas price bump
+	ErrorCategoryNeedsReprice ErrorCategory = "needs_reprice"
+)
+
+// Error strings (lower case) from the common EVM implementations (Geth/Erigon, Besu, Nethermind)
+// that mean the node will reject the transaction regardless of how many times it is retried.
+//
+// Note "nonce too low" is normally handled on submission as a tracking outcome before categorization.
+var permanentRejectErrors = []string{
+	"nonce too low",
+	"intrinsic gas too low",                // geth
+	"intrinsic gas exceeds gas limit",      // besu
+	"exceeds block gas limit",              // geth + besu
+	"gaslimitexceeded",                     // nethermind
+	"intrinsicgastoolow",                   // nethermind
+	"invalid sender",                       // geth
+	"invalid signature",                    // besu
+	"invalid chain id",                     // geth
+	"wrong chain id",                       // besu
+	"oversized data",                       // geth
+	"max initcode size exceeded",           // geth
+	"transaction type not supported",       // geth
+	"tx type not supported",                // geth
+	"max priority fee per gas higher than", // geth + besu
+}
+
+// Error strings (lower case) that mean the gas price needs to be increased before the transaction will be accepted
+var needsRepriceErrors = []string{
+	"transaction underpriced",  // geth + besu (also covers "replacement transaction underpriced")
+	"less than block base fee", // geth
+	"below configured minimum", // besu (gas price or priority fee)
+	"feetoolow",                // nethermind
+	"fee too low",              // erigon
+}
+
+// categorizeError determines the ErrorCategory of a stage error by matching known error strings,
+// defaulting to transient for anything we do not recognize.
+func categorizeError(err error) ErrorCategory {
+	if err == nil {
+		return ""
+	}
+	errString := strings.ToLower(err.Error())
+	for _, s := range needsRepriceErrors {
+		if strings.Contains(errString, s) {
+			return ErrorCategoryNeedsReprice
+		}
+	}
+	for _, s := range permanentRejectErrors {
+		if strings.Contains(errString, s) {
+			return ErrorCategoryPermanentReject
+		}
+	}
+	return ErrorCategoryTransient
+}
+
+// compileReplacementUnderpricedErrors parses the configured patterns. These are checked when the
+// configuration is validated, so any that do not compile here are skipped with a warning.
+func compileReplacementUnderpricedErrors(ctx context.Context, patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log.L(ctx).Warnf("Ignoring invalid replacement underpriced error pattern '%s': %s", p, err)
+			continue
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+// isReplacementUnderpriced returns true if the node rejected a transaction that replaces one already in its pool,
+// because the new gas price is not enough higher than the original. Re-submitting with a price the gas price
+// client considers sufficient will keep failing, so the existing price needs to be bumped.
+func (ptm *pubTxManager) isReplacementUnderpriced(err error) bool {
+	if err == nil {
+		return false
+	}
+	errString := err.Error()
+	for _, re := range ptm.replacementErrors {
+		if re.MatchString(errString) {
+			return true
+		}
+	}
+	return false
+}
+
+// OrchestratorError is returned when the orchestrator fails to poll for, or allocate nonces to, new transactions.
+// Retryable errors (such as a DB or network failure) are retried with back-off until they succeed.
+// Other errors, such as the node rejecting the request for the signing address, will not succeed on retry
+// so are returned immediately, and the orchestrator tries again on its next polling interval.
+type OrchestratorError struct {
+	err       error
+	retryable bool
+}
+
+func newOrchestratorError(err error) *OrchestratorError {
+	retryable := true
+	var rpcErr rpcclient.ErrorRPC
+	if errors.As(err, &rpcErr) {
+		switch rpcclient.RPCCode(rpcErr.RPCError().Code) {
+		case rpcclient.RPCCodeInvalidRequest, rpcclient.RPCCodeMethodNotFound, rpcclient.RPCCodeInvalidParams:
+			retryable = false
+		}
+	}
+	return &OrchestratorError{err: err, retryable: retryable}
+}
+
+func (oe *OrchestratorError) Error() string {
+	return oe.err.Error()
+}
+
+func (oe *OrchestratorError) Unwrap() error {
+	return oe.err
+}
+
+func (oe *OrchestratorError) IsRetryable() bool {
+	return oe.retryable
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategorizeError(t *testing.T) {
+	assert.Equal(t, ErrorCategory(""), categorizeError(nil))
+	assert.Equal(t, ErrorCategoryTransient, categorizeError(fmt.Errorf("connection refused")))
+	assert.Equal(t, ErrorCategoryTransient, categorizeError(fmt.Errorf("insufficient funds for gas * price + value")))
+	assert.Equal(t, ErrorCategoryPermanentReject, categorizeError(fmt.Errorf("nonce too low")))
+	assert.Equal(t, ErrorCategoryPermanentReject, categorizeError(fmt.Errorf("intrinsic gas too low: have 100, want 21000")))
+	assert.Equal(t, ErrorCategoryPermanentReject, categorizeError(fmt.Errorf("Intrinsic gas exceeds gas limit")))
+	assert.Equal(t, ErrorCategoryPermanentReject, categorizeError(fmt.Errorf("IntrinsicGasTooLow")))
+	assert.Equal(t, ErrorCategoryNeedsReprice, categorizeError(fmt.Errorf("replacement transaction underpriced")))
+	assert.Equal(t, ErrorCategoryNeedsReprice, categorizeError(fmt.Errorf("max fee per gas less than block base fee")))
+	assert.Equal(t, ErrorCategoryNeedsReprice, categorizeError(fmt.Errorf("Gas price below configured minimum gas price")))
+	assert.Equal(t, ErrorCategoryNeedsReprice, categorizeError(fmt.Errorf("FeeTooLowToCompete")))
+}
+
+func TestIsReplacementUnderpriced(t *testing.T) {
+	ptm := &pubTxManager{
+		replacementErrors: compileReplacementUnderpricedErrors(context.Background(), pldconf.PublicTxManagerDefaults.GasPrice.ReplacementUnderpricedErrors),
+	}
+	assert.False(t, ptm.isReplacementUnderpriced(nil))
+	assert.False(t, ptm.isReplacementUnderpriced(fmt.Errorf("transaction underpriced")))
+	assert.True(t, ptm.isReplacementUnderpriced(fmt.Errorf("replacement transaction underpriced")))
+	assert.True(t, ptm.isReplacementUnderpriced(fmt.Errorf("Replacement transaction underpriced")))
+	assert.True(t, ptm.isReplacementUnderpriced(fmt.Errorf("REPLACEMENT_UNDERPRICED")))
+	assert.True(t, ptm.isReplacementUnderpriced(fmt.Errorf("could not replace existing tx")))
+	assert.True(t, ptm.isReplacementUnderpriced(fmt.Errorf("ReplacementNotAllowed")))
+
+	ptm.replacementErrors = compileReplacementUnderpricedErrors(context.Background(), []string{"under(priced", "^custom"})
+	assert.Len(t, ptm.replacementErrors, 1)
+	assert.True(t, ptm.isReplacementUnderpriced(fmt.Errorf("custom replacement error")))
+	assert.False(t, ptm.isReplacementUnderpriced(fmt.Errorf("replacement transaction underpriced")))
+}
+
+func TestOrchestratorErrorRetryable(t *testing.T) {
+	oe := newOrchestratorError(fmt.Errorf("pop"))
+	assert.True(t, oe.IsRetryable())
+	assert.Equal(t, "pop", oe.Error())
+
+	rpcErr := &rpcclient.RPCError{Code: int64(rpcclient.RPCCodeInternalError), Message: "node failure"}
+	assert.True(t, newOrchestratorError(rpcErr).IsRetryable())
+
+	rpcErr = &rpcclient.RPCError{Code: int64(rpcclient.RPCCodeInvalidParams), Message: "invalid address"}
+	oe = newOrchestratorError(fmt.Errorf("wrapped: %w", rpcErr))
+	assert.False(t, oe.IsRetryable())
+	assert.True(t, errors.Is(oe, rpcErr))
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+)
+
+// ReloadGasConfig replaces the fixed gas price used by the shared gas price client, without restarting any
+// orchestrators (which would drain the in-flight transactions). Transactions that have already been submitted
+// and are waiting for a receipt then check their price on the next poll, rather than at the resubmit interval.
+func (ptm *pubTxManager) ReloadGasConfig(ctx context.Context, newConf *pldconf.GasPriceConfig) error {
+	if err := ptm.gasPriceClient.Reload(ctx, newConf); err != nil {
+		return err
+	}
+
+	ptm.inFlightOrchestratorMux.Lock()
+	defer ptm.inFlightOrchestratorMux.Unlock()
+	log.L(ctx).Infof("Gas price configuration reloaded, requesting a re-price check from %d orchestrators", len(ptm.inFlightOrchestrators))
+	for _, oc := range ptm.inFlightOrchestrators {
+		oc.requestRepriceCheck()
+	}
+	return nil
+}
+
+func (oc *orchestrator) requestRepriceCheck() {
+	oc.updateMux.Lock()
+	defer oc.updateMux.Unlock()
+	oc.repriceCheckRequested = true
+	oc.MarkInFlightTxStale()
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadGasConfigRepricesIdleTransactions(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.GasPrice.FixedGasPrice = 10
+	})
+	defer done()
+	o.pubTxManager.inFlightOrchestrators = map[pldtypes.EthAddress]*orchestrator{o.signingAddress: o}
+
+	// one transaction submitted and waiting for a receipt, and one that has not yet been submitted
+	submitted, submittedState := newInflightTransaction(o, 1)
+	submitted.testOnlyNoActionMode = true
+	submittedState.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing:      &pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(10)},
+		TransactionHash: confutil.P(pldtypes.RandBytes32()),
+		LastSubmit:      confutil.P(pldtypes.TimestampNow()),
+	})
+	submittedState.GetCurrentGeneration(ctx).SetValidatedTransactionHashMatchState(ctx, true)
+	unsubmitted, _ := newInflightTransaction(o, 2)
+	o.inFlightTxs = []*inFlightTransactionStageController{submitted, unsubmitted}
+
+	err := o.pubTxManager.ReloadGasConfig(ctx, &pldconf.GasPriceConfig{FixedGasPrice: 20})
+	require.NoError(t, err)
+	assert.True(t, o.repriceCheckRequested)
+	gpo, err := o.gasPriceClient.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), gpo.GasPrice.Int().Int64())
+
+	o.handleUpdates(ctx)
+	assert.False(t, o.repriceCheckRequested)
+	assert.True(t, submitted.repriceCheckRequired)
+	assert.False(t, unsubmitted.repriceCheckRequired)
+
+	// rather than tracking the receipt until the resubmit interval, the price is retrieved again
+	o.resubmitInterval = time.Hour
+	submitted.startNewStage(ctx, nil)
+	assert.Equal(t, InFlightTxStageRetrieveGasPrice, submittedState.GetStage(ctx))
+	assert.False(t, submitted.repriceCheckRequired)
+}
+
+func TestReloadGasConfigInvalid(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	o.pubTxManager.inFlightOrchestrators = map[pldtypes.EthAddress]*orchestrator{o.signingAddress: o}
+
+	err := o.pubTxManager.ReloadGasConfig(ctx, &pldconf.GasPriceConfig{FixedGasPrice: "wrong"})
+	assert.Regexp(t, "PD011917", err)
+	assert.False(t, o.repriceCheckRequested)
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"golang.org/x/crypto/sha3"
+)
+
+// gasEstimateCache avoids repeated eth_estimateGas calls for transactions that are identical other than
+// their nonce and gas pricing, such as a domain repeatedly submitting the same function call.
+type gasEstimateCache struct {
+	cache cache.Cache[pldtypes.Bytes32, *cachedGasEstimate]
+	ttl   time.Duration
+}
+
+type cachedGasEstimate struct {
+	gasLimit pldtypes.HexUint64
+	expiry   time.Time
+}
+
+func newGasEstimateCache(conf *pldconf.GasEstimateCacheConfig) *gasEstimateCache {
+	defs := &pldconf.PublicTxManagerDefaults.GasLimit.EstimateCache
+	return &gasEstimateCache{
+		cache: cache.NewCache[pldtypes.Bytes32, *cachedGasEstimate](&conf.CacheConfig, &defs.CacheConfig),
+		ttl:   confutil.DurationMin(conf.TTL, 0, *defs.TTL),
+	}
+}
+
+// The estimate depends on the sender and value as well as the target and call data, so all are included in the key
+func gasEstimateKey(ethTx *ethsigner.Transaction) pldtypes.Bytes32 {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(ethTx.From)
+	if ethTx.To != nil {
+		hash.Write(ethTx.To[:])
+	}
+	if ethTx.Value != nil {
+		hash.Write(ethTx.Value.BigInt().Bytes())
+	}
+	hash.Write(ethTx.Data)
+	return pldtypes.Bytes32(hash.Sum(nil))
+}
+
+func (gec *gasEstimateCache) get(key pldtypes.Bytes32) (pldtypes.HexUint64, bool) {
+	if gec.ttl == 0 {
+		return 0, false
+	}
+	cached, ok := gec.cache.Get(key)
+	if !ok || time.Now().After(cached.expiry) {
+		return 0, false
+	}
+	return cached.gasLimit, true
+}
+
+func (gec *gasEstimateCache) set(key pldtypes.Bytes32, gasLimit pldtypes.HexUint64) {
+	if gec.ttl > 0 {
+		gec.cache.Set(key, &cachedGasEstimate{gasLimit: gasLimit, expiry: time.Now().Add(gec.ttl)})
+	}
+}
+
+// invalidate removes the cached estimate for a transaction, for example because it was rejected for having too little gas
+func (gec *gasEstimateCache) invalidate(ethTx *ethsigner.Transaction) {
+	gec.cache.Delete(gasEstimateKey(ethTx))
+}
+
+// estimateGas returns a cached estimate for the transaction if there is one, otherwise calls the node and caches the result
+func (ptm *pubTxManager) estimateGas(ctx context.Context, ethTx *ethsigner.Transaction) (ethclient.EstimateGasResult, error) {
+	key := gasEstimateKey(ethTx)
+	if gasLimit, ok := ptm.gasEstimateCache.get(key); ok {
+		log.L(ctx).Tracef("Using cached gas estimate %d for transaction: %+v", gasLimit, ethTx)
+		return ethclient.EstimateGasResult{GasLimit: gasLimit}, nil
+	}
+	gasEstimateResult, err := ptm.ethClient.EstimateGasNoResolve(ctx, ethTx)
+	if err == nil {
+		ptm.gasEstimateCache.set(key, gasEstimateResult.GasLimit)
+	}
+	return gasEstimateResult, err
+}
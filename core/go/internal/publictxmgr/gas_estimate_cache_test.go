@@ -0,0 +1,111 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/pkg/ethclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateGasCached(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	from := *pldtypes.RandAddress()
+	to := pldtypes.RandAddress()
+	ethTx := buildEthTX(from, nil, to, pldtypes.HexBytes("some data"), &pldapi.PublicTxOptions{})
+
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: 12345}, nil).Once()
+
+	// Second call is served from the cache, even with different gas pricing
+	res, err := ptm.estimateGas(ctx, ethTx)
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(12345), res.GasLimit)
+	res, err = ptm.estimateGas(ctx, buildEthTX(from, nil, to, pldtypes.HexBytes("some data"), &pldapi.PublicTxOptions{
+		PublicTxGasPricing: pldapi.PublicTxGasPricing{GasPrice: pldtypes.Int64ToInt256(100)},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(12345), res.GasLimit)
+
+	// Different data is not
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: 23456}, nil).Once()
+	res, err = ptm.estimateGas(ctx, buildEthTX(from, nil, to, pldtypes.HexBytes("other data"), &pldapi.PublicTxOptions{}))
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(23456), res.GasLimit)
+
+	// Invalidated after a rejection
+	ptm.gasEstimateCache.invalidate(ethTx)
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: 34567}, nil).Once()
+	res, err = ptm.estimateGas(ctx, ethTx)
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(34567), res.GasLimit)
+}
+
+func TestEstimateGasCacheErrorNotCached(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	ethTx := buildEthTX(*pldtypes.RandAddress(), nil, pldtypes.RandAddress(), nil, &pldapi.PublicTxOptions{})
+
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{}, fmt.Errorf("pop")).Once()
+	_, err := ptm.estimateGas(ctx, ethTx)
+	assert.Regexp(t, "pop", err)
+
+	m.ethClient.On("EstimateGasNoResolve", mock.Anything, mock.Anything, mock.Anything).
+		Return(ethclient.EstimateGasResult{GasLimit: 12345}, nil).Once()
+	res, err := ptm.estimateGas(ctx, ethTx)
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(12345), res.GasLimit)
+}
+
+func TestGasEstimateCacheExpiry(t *testing.T) {
+	gec := newGasEstimateCache(&pldconf.GasEstimateCacheConfig{TTL: confutil.P("1ms")})
+	key := gasEstimateKey(buildEthTX(*pldtypes.RandAddress(), nil, nil, nil, &pldapi.PublicTxOptions{
+		Value: pldtypes.Uint64ToUint256(1),
+	}))
+
+	gec.set(key, 12345)
+	gasLimit, ok := gec.get(key)
+	assert.True(t, ok)
+	assert.Equal(t, pldtypes.HexUint64(12345), gasLimit)
+
+	time.Sleep(5 * time.Millisecond)
+	_, ok = gec.get(key)
+	assert.False(t, ok)
+}
+
+func TestGasEstimateCacheDisabled(t *testing.T) {
+	gec := newGasEstimateCache(&pldconf.GasEstimateCacheConfig{TTL: confutil.P("0")})
+	key := gasEstimateKey(buildEthTX(*pldtypes.RandAddress(), nil, nil, nil, &pldapi.PublicTxOptions{}))
+
+	gec.set(key, 12345)
+	_, ok := gec.get(key)
+	assert.False(t, ok)
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
@@ -42,6 +43,7 @@ type GasPriceClient interface {
 	ParseGasPriceJSON(ctx context.Context, input pldtypes.RawJSON) (gpo *pldapi.PublicTxGasPricing, err error)
 	GetGasPriceObject(ctx context.Context) (gasPrice *pldapi.PublicTxGasPricing, err error)
 	Init(ctx context.Context, cAPI ethclient.EthClient)
+	Reload(ctx context.Context, conf *pldconf.GasPriceConfig) error
 }
 
 // The hybrid gas price client retrieves gas price using the following methods in order and will return as soon as the method succeeded unless there is an override
@@ -50,6 +52,8 @@ type GasPriceClient interface {
 //   - Gas Oracle
 //   - Node gas_Price
 type HybridGasPriceClient struct {
+	// protects the fixed gas price and zero gas price flag, which can be changed by a config reload
+	mux             sync.RWMutex
 	hasZeroGasPrice bool
 	fixedGasPrice   pldtypes.RawJSON
 	ethClient       ethclient.EthClient
@@ -57,16 +61,21 @@ type HybridGasPriceClient struct {
 }
 
 func (hGpc *HybridGasPriceClient) HasZeroGasPrice(ctx context.Context) bool {
+	hGpc.mux.RLock()
+	defer hGpc.mux.RUnlock()
 	return hGpc.hasZeroGasPrice
 }
 
 func (hGpc *HybridGasPriceClient) GetFixedGasPriceJSON(ctx context.Context) (gasPrice pldtypes.RawJSON) {
+	hGpc.mux.RLock()
+	defer hGpc.mux.RUnlock()
 	return hGpc.fixedGasPrice
 }
 
 func (hGpc *HybridGasPriceClient) SetFixedGasPriceIfConfigured(ctx context.Context, ethTx *ethsigner.Transaction) {
-	if hGpc.fixedGasPrice != nil {
-		gpo, _ := hGpc.ParseGasPriceJSON(ctx, hGpc.fixedGasPrice)
+	fixedGasPrice := hGpc.GetFixedGasPriceJSON(ctx)
+	if fixedGasPrice != nil {
+		gpo, _ := hGpc.ParseGasPriceJSON(ctx, fixedGasPrice)
 		if gpo.GasPrice != nil {
 			ethTx.GasPrice = (*ethtypes.HexInteger)(gpo.GasPrice)
 		}
@@ -93,9 +102,10 @@ func (hGpc *HybridGasPriceClient) GetGasPriceObject(ctx context.Context) (gasPri
 func (hGpc *HybridGasPriceClient) getGasPriceJSON(ctx context.Context) (gasPriceJSON pldtypes.RawJSON, err error) {
 
 	//  fixed price overrides everything
-	if !hGpc.fixedGasPrice.IsNil() {
+	fixedGasPrice := hGpc.GetFixedGasPriceJSON(ctx)
+	if !fixedGasPrice.IsNil() {
 		log.L(ctx).Debugf("Retrieving gas price from fixed gas price")
-		gasPriceJSON = hGpc.fixedGasPrice
+		gasPriceJSON = fixedGasPrice
 		return
 	}
 
@@ -130,14 +140,38 @@ func (hGpc *HybridGasPriceClient) Init(ctx context.Context, ethClient ethclient.
 		log.L(ctx).Warnf("Cannot get gas price due to %+v", err)
 	}
 
-	if gpo != nil && ((gpo.GasPrice != nil && gpo.GasPrice.Int().Sign() == 0) ||
-		(gpo.MaxFeePerGas != nil && gpo.MaxFeePerGas.Int().Sign() == 0 &&
-			gpo.MaxPriorityFeePerGas != nil && gpo.MaxPriorityFeePerGas.Int().Sign() == 0)) {
+	if isZeroGasPrice(gpo) {
+		hGpc.mux.Lock()
+		defer hGpc.mux.Unlock()
 		hGpc.hasZeroGasPrice = true
 		hGpc.fixedGasPrice = gasPriceJson
 	}
 }
 
+func isZeroGasPrice(gpo *pldapi.PublicTxGasPricing) bool {
+	return gpo != nil && ((gpo.GasPrice != nil && gpo.GasPrice.Int().Sign() == 0) ||
+		(gpo.MaxFeePerGas != nil && gpo.MaxFeePerGas.Int().Sign() == 0 &&
+			gpo.MaxPriorityFeePerGas != nil && gpo.MaxPriorityFeePerGas.Int().Sign() == 0))
+}
+
+// Reload atomically replaces the fixed gas price with the one in the new configuration, so that all
+// subsequent gas price retrievals use it. The cached node gas price is discarded at the same time.
+func (hGpc *HybridGasPriceClient) Reload(ctx context.Context, conf *pldconf.GasPriceConfig) error {
+	fixedGasPrice := fixedGasPriceJSON(conf)
+	gpo, err := hGpc.ParseGasPriceJSON(ctx, fixedGasPrice)
+	if err != nil {
+		return err
+	}
+
+	hGpc.mux.Lock()
+	defer hGpc.mux.Unlock()
+	log.L(ctx).Infof("Reloading gas price configuration: fixedGasPrice=%s (previously %s)", fixedGasPrice, hGpc.fixedGasPrice)
+	hGpc.fixedGasPrice = fixedGasPrice
+	hGpc.hasZeroGasPrice = isZeroGasPrice(gpo)
+	hGpc.gasPriceCache.Delete("gasPrice")
+	return nil
+}
+
 func (hGpc *HybridGasPriceClient) DeleteCache(ctx context.Context) {
 	hGpc.gasPriceCache.Delete("gasPrice")
 }
@@ -148,14 +182,19 @@ func NewGasPriceClient(ctx context.Context, conf *pldconf.PublicTxManagerConfig)
 	gasPriceClient := &HybridGasPriceClient{}
 	// initialize gas oracle
 	// set fixed gas price
-	b, _ := json.Marshal(conf.GasPrice.FixedGasPrice)
-	if b != nil && string(b) != `null` {
-		gasPriceClient.fixedGasPrice = pldtypes.RawJSON(b)
-	}
+	gasPriceClient.fixedGasPrice = fixedGasPriceJSON(&conf.GasPrice)
 	gasPriceClient.gasPriceCache = gasPriceCache
 	return gasPriceClient
 }
 
+func fixedGasPriceJSON(conf *pldconf.GasPriceConfig) pldtypes.RawJSON {
+	b, _ := json.Marshal(conf.FixedGasPrice)
+	if b != nil && string(b) != `null` {
+		return pldtypes.RawJSON(b)
+	}
+	return nil
+}
+
 func (hGpc *HybridGasPriceClient) ParseGasPriceJSON(ctx context.Context, input pldtypes.RawJSON) (gpo *pldapi.PublicTxGasPricing, err error) {
 	gpo = &pldapi.PublicTxGasPricing{}
 	if input == nil {
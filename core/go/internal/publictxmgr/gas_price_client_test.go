@@ -174,3 +174,30 @@ func TestGasPriceClient(t *testing.T) {
 	assert.Regexp(t, "doesn't work", err)
 	assert.Nil(t, gpo)
 }
+
+func TestGasPriceClientReload(t *testing.T) {
+	ctx := context.Background()
+
+	gasPriceClient := NewGasPriceClient(ctx, &pldconf.PublicTxManagerConfig{
+		GasPrice: pldconf.GasPriceConfig{FixedGasPrice: 0},
+	})
+	hgc := gasPriceClient.(*HybridGasPriceClient)
+	hgc.Init(ctx, ethclientmocks.NewEthClient(t))
+	assert.True(t, hgc.HasZeroGasPrice(ctx))
+	hgc.gasPriceCache.Set("gasPrice", pldtypes.RawJSON(`"0x01"`))
+
+	err := hgc.Reload(ctx, &pldconf.GasPriceConfig{FixedGasPrice: map[string]any{"maxFeePerGas": 20, "maxPriorityFeePerGas": 2}})
+	require.NoError(t, err)
+	assert.False(t, hgc.HasZeroGasPrice(ctx))
+	_, cached := hgc.gasPriceCache.Get("gasPrice")
+	assert.False(t, cached)
+	gpo, err := hgc.GetGasPriceObject(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), gpo.MaxFeePerGas.Int().Int64())
+	assert.Equal(t, int64(2), gpo.MaxPriorityFeePerGas.Int().Int64())
+
+	// invalid config leaves the existing price in place
+	err = hgc.Reload(ctx, &pldconf.GasPriceConfig{FixedGasPrice: "not a number"})
+	assert.Regexp(t, "PD011917", err)
+	assert.JSONEq(t, `{"maxFeePerGas": 20, "maxPriorityFeePerGas": 2}`, hgc.GetFixedGasPriceJSON(ctx).String())
+}
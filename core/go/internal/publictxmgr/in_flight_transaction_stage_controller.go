@@ -69,6 +69,18 @@ type inFlightTransactionStageController struct {
 
 	newStatus *InFlightStatus
 
+	// set when a stage error requires the gas price to be increased before the next submission
+	repriceRequired bool
+
+	// set alongside repriceRequired when the node rejected a replacement for the transaction in its pool as underpriced
+	replacementBumpRequired bool
+
+	// set when the gas configuration has been reloaded, to check the price of a submitted transaction without waiting for the resubmit interval
+	repriceCheckRequired bool
+
+	// the number of times the transaction has been resubmitted after the resubmit interval, for metrics
+	resubmissions int
+
 	updates   []*DBPublicTxn
 	updateMux sync.Mutex
 
@@ -326,7 +338,9 @@ func (it *inFlightTransactionStageController) processRetrieveGasPriceStageOutput
 			// if failed to get gas price, persist the error
 			rsc.StageOutputsToBePersisted.UpdateSubStatus(BaseTxActionRetrieveGasPrice, nil, pldtypes.RawJSON(`{"error":"`+stageOutput.GasPriceOutput.Err.Error()+`"}`))
 		} else {
-			gpo := it.calculateNewGasPrice(ctx, rsc.InMemoryTx.GetGasPriceObject(), stageOutput.GasPriceOutput.GasPriceObject)
+			gpo := it.calculateNewGasPrice(ctx, rsc.InMemoryTx.GetGasPriceObject(), stageOutput.GasPriceOutput.GasPriceObject, it.repriceRequired, it.replacementBumpRequired)
+			it.repriceRequired = false
+			it.replacementBumpRequired = false
 			gpoJSON, _ := json.Marshal(gpo)
 			rsc.StageOutputsToBePersisted.TxUpdates = &BaseTXUpdates{GasPricing: gpo}
 			rsc.StageOutputsToBePersisted.UpdateSubStatus(BaseTxActionRetrieveGasPrice, pldtypes.RawJSON(gpoJSON), nil)
@@ -340,10 +354,8 @@ func (it *inFlightTransactionStageController) processSigningStageOutput(ctx cont
 	// first check whether we've already completed the action and just waiting for required persistence to go to the next stage
 	if rsIn.PersistenceOutput != nil {
 		if rsc.StageOutput.SignOutput.Err != nil {
-			// wait for the stale transaction timeout to re-trigger the signing provided this is the current generation
-			rsc.StageErrored = true
-		}
-		if rsIn.PersistenceOutput.PersistenceError == nil && !rsc.StageErrored {
+			it.handleStageError(ctx, generation, rsc)
+		} else if rsIn.PersistenceOutput.PersistenceError == nil {
 			// we've persisted successfully, move to the next stage inline as signed message is not persisted
 			log.L(ctx).Debugf("Signed message is not nil: %t", rsc.StageOutput.SignOutput.SignedMessage != nil)
 			generation.SetTransientPreviousStageOutputs(&TransientPreviousStageOutputs{
@@ -359,6 +371,7 @@ func (it *inFlightTransactionStageController) processSigningStageOutput(ctx cont
 		generation.ClearRunningStageContext(ctx)
 	} else {
 		rsc.StageOutput.SignOutput = rsIn.SignOutput
+		rsc.StageOutput.ErrorCategory = rsIn.ErrorCategory
 
 		rsc.SetNewPersistenceUpdateOutput()
 		if rsIn.SignOutput.Err != nil {
@@ -386,7 +399,6 @@ func (it *inFlightTransactionStageController) processSigningStageOutput(ctx cont
 			rsc.InMemoryTx.GetGasPriceObject()
 			gasPriceJSON, _ := json.Marshal(rsc.InMemoryTx.GetGasPriceObject())
 			rsc.StageOutputsToBePersisted.TxUpdates.NewSubmission = &DBPubTxnSubmission{
-				from:            rsc.InMemoryTx.GetFrom().String(),
 				PublicTxnID:     rsc.InMemoryTx.GetPubTxnID(),
 				Created:         pldtypes.TimestampNow(),
 				TransactionHash: *rsc.StageOutput.SignOutput.TxHash,
@@ -407,8 +419,7 @@ func (it *inFlightTransactionStageController) processSubmittingStageOutput(ctx c
 			if rsc.StageOutput.SubmitOutput.ErrorReason == string(ethclient.ErrorReasonInsufficientFunds) {
 				it.balanceManager.NotifyAddressBalanceChanged(ctx, it.signingAddress)
 			}
-			// wait for the stale transaction timeout to re-trigger the submission provided this is the current generation
-			rsc.StageErrored = true
+			it.handleStageError(ctx, generation, rsc)
 		} else if stageOutput.PersistenceOutput.PersistenceError == nil {
 			// we've persisted successfully, it's safe to move to the next stage based on the latest state of the managed transaction
 			generation.SetValidatedTransactionHashMatchState(ctx, true)
@@ -421,6 +432,7 @@ func (it *inFlightTransactionStageController) processSubmittingStageOutput(ctx c
 		generation.ClearRunningStageContext(ctx)
 	} else {
 		rsc.StageOutput.SubmitOutput = stageOutput.SubmitOutput
+		rsc.StageOutput.ErrorCategory = stageOutput.ErrorCategory
 		// transaction submitted
 		rsc.SetNewPersistenceUpdateOutput()
 		if stageOutput.SubmitOutput.Err != nil {
@@ -470,6 +482,34 @@ func (it *inFlightTransactionStageController) processSubmittingStageOutput(ctx c
 	return
 }
 
+// handleStageError routes a failed stage according to the category of its error, once the error has been persisted
+func (it *inFlightTransactionStageController) handleStageError(ctx context.Context, generation InFlightTransactionStateGeneration, rsc *RunningStageContext) {
+	switch rsc.StageOutput.ErrorCategory {
+	case ErrorCategoryNeedsReprice:
+		// go straight back to retrieving the gas price, with an increase applied
+		log.L(ctx).Debugf("Transaction with ID %s requires a gas price increase after error in stage %s", rsc.InMemoryTx.GetSignerNonce(), rsc.Stage)
+		it.repriceRequired = true
+		it.replacementBumpRequired = rsc.StageOutput.SubmitOutput != nil && rsc.StageOutput.SubmitOutput.SubmissionOutcome == SubmissionOutcomeReplacementUnderpriced
+		generation.ClearRunningStageContext(ctx)
+	case ErrorCategoryPermanentReject:
+		// the nonce is already assigned, so the transaction is suspended rather than failed to allow it to be updated and resumed
+		if err := it.persistSuspendedFlag(ctx, it.signingAddress, it.stateManager.GetNonce(), true); err != nil {
+			log.L(ctx).Errorf("Failed to suspend transaction with ID %s: %s", rsc.InMemoryTx.GetSignerNonce(), err)
+			rsc.StageErrored = true
+			return
+		}
+		log.L(ctx).Errorf("Transaction with ID %s suspended as it was permanently rejected in stage %s", rsc.InMemoryTx.GetSignerNonce(), rsc.Stage)
+		// the rejection might be because the gas limit was too low, so we must not reuse the same estimate when it is updated
+		it.gasEstimateCache.invalidate(rsc.InMemoryTx.BuildEthTX())
+		suspending := InFlightStatusSuspending
+		it.newStatus = &suspending
+		generation.ClearRunningStageContext(ctx)
+	default:
+		// wait for the stage retry timeout to re-trigger the stage provided this is the current generation
+		rsc.StageErrored = true
+	}
+}
+
 func (it *inFlightTransactionStageController) processStatusUpdateStageOutput(ctx context.Context, generation InFlightTransactionStateGeneration, rsc *RunningStageContext, stageOutput *StageOutput) (err error) {
 	// only requires persistence output for this stage
 	if stageOutput.PersistenceOutput != nil {
@@ -501,6 +541,10 @@ func (it *inFlightTransactionStageController) startNewStage(ctx context.Context,
 		// if there isn't any running context and the transaction status is no longer in pending
 		// we can wait for the transaction orchestrator to remove it from the in-flight transaction queue. It's either paused or completed
 		log.L(ctx).Debugf("Transaction with ID %s is waiting for removal in status: %s.", it.stateManager.GetSignerNonce(), it.stateManager.GetInFlightStatus())
+	} else if it.repriceRequired {
+		// the last submission was rejected for being underpriced
+		log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price as a gas price increase is required.", it.stateManager.GetSignerNonce())
+		it.TriggerNewStageRun(ctx, InFlightTxStageRetrieveGasPrice, BaseTxSubStatusStale)
 	} else if it.stateManager.GetGasPriceObject() == nil {
 		// no gas price fetched, go and fetch gas price
 		log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price as no gas price available.", it.stateManager.GetSignerNonce())
@@ -526,9 +570,14 @@ func (it *inFlightTransactionStageController) startNewStage(ctx context.Context,
 		} else {
 			// once we validated the transaction hash matched the transaction state
 			lastSubmitTime := it.stateManager.GetLastSubmitTime()
-			if lastSubmitTime != nil && time.Since(lastSubmitTime.Time()) > it.resubmitInterval {
+			if it.repriceCheckRequired {
+				log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price as the gas configuration was reloaded.", it.stateManager.GetSignerNonce())
+				it.repriceCheckRequired = false
+				it.TriggerNewStageRun(ctx, InFlightTxStageRetrieveGasPrice, BaseTxSubStatusStale)
+			} else if lastSubmitTime != nil && time.Since(lastSubmitTime.Time()) > it.resubmitInterval {
 				// do a resubmission when exceeded the resubmit interval
 				log.L(ctx).Debugf("Transaction with ID %s entering retrieve gas price as exceeded resubmit interval of %s.", it.stateManager.GetSignerNonce(), it.resubmitInterval.String())
+				it.resubmissions++
 				it.TriggerNewStageRun(ctx, InFlightTxStageRetrieveGasPrice, BaseTxSubStatusStale)
 			} else {
 				// check and track the existing transaction hash
@@ -541,7 +590,11 @@ func (it *inFlightTransactionStageController) startNewStage(ctx context.Context,
 	}
 }
 
-func (it *inFlightTransactionStageController) calculateNewGasPrice(ctx context.Context, existingGpo *pldapi.PublicTxGasPricing, newGpo *pldapi.PublicTxGasPricing) *pldapi.PublicTxGasPricing {
+// calculateNewGasPrice increases the existing gas price by the configured percentage if it is already above the new one,
+// or equal to it when forceIncrease is set because the node rejected the existing price.
+// A replacementBump is required when the node rejected the transaction as a replacement for the one in its pool, which
+// increases by the replacement percentage, and includes the priority fee as nodes require both fees to be increased.
+func (it *inFlightTransactionStageController) calculateNewGasPrice(ctx context.Context, existingGpo *pldapi.PublicTxGasPricing, newGpo *pldapi.PublicTxGasPricing, forceIncrease, replacementBump bool) *pldapi.PublicTxGasPricing {
 	if existingGpo == nil {
 		log.L(ctx).Debugf("First time assigning gas price to transaction with ID: %s, gas price object: %+v.", it.stateManager.GetSignerNonce(), newGpo)
 		return newGpo
@@ -550,34 +603,46 @@ func (it *inFlightTransactionStageController) calculateNewGasPrice(ctx context.C
 	// The change is not made here to InMemoryTx, but rather pushed to TxUpdates for persisting.
 	// So we need to make sure we don't edit the in-memory existing object by passing it to calculateNewGasPrice
 
-	if newGpo.GasPrice != nil && existingGpo.GasPrice != nil && existingGpo.GasPrice.Int().Cmp(newGpo.GasPrice.Int()) == 1 {
-		// existing gas price already above the new gas price, increase using percentage
+	increasePercent := it.gasPriceIncreasePercent
+	if replacementBump {
+		increasePercent = it.replacementBumpPercent
+		forceIncrease = true
+	}
+
+	needsIncrease := func(existing, latest *pldtypes.HexUint256) bool {
+		cmp := existing.Int().Cmp(latest.Int())
+		return cmp == 1 || (forceIncrease && cmp == 0)
+	}
+
+	increase := func(existing *pldtypes.HexUint256) *big.Int {
 		newPercentage := big.NewInt(100)
-		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(it.gasPriceIncreasePercent)))
-		newGasPrice := new(big.Int).Mul(existingGpo.GasPrice.Int(), newPercentage)
-		newGasPrice = newGasPrice.Div(newGasPrice, big.NewInt(100))
-		if it.gasPriceIncreaseMax != nil && newGasPrice.Cmp(it.gasPriceIncreaseMax) == 1 {
-			newGasPrice.Set(it.gasPriceIncreaseMax)
+		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(increasePercent)))
+		increased := new(big.Int).Mul(existing.Int(), newPercentage)
+		increased = increased.Div(increased, big.NewInt(100))
+		if it.gasPriceIncreaseMax != nil && increased.Cmp(it.gasPriceIncreaseMax) == 1 {
+			increased.Set(it.gasPriceIncreaseMax)
 		}
+		return increased
+	}
+
+	if newGpo.GasPrice != nil && existingGpo.GasPrice != nil && needsIncrease(existingGpo.GasPrice, newGpo.GasPrice) {
+		// existing gas price already above the new gas price, increase using percentage
 		newGpo = &pldapi.PublicTxGasPricing{
-			GasPrice:             (*pldtypes.HexUint256)(newGasPrice),
+			GasPrice:             (*pldtypes.HexUint256)(increase(existingGpo.GasPrice)),
 			MaxFeePerGas:         existingGpo.MaxFeePerGas,         // copy over unchanged (although expected to be unset)
 			MaxPriorityFeePerGas: existingGpo.MaxPriorityFeePerGas, //   "
 		}
-	} else if newGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas.Int().Cmp(newGpo.MaxFeePerGas.Int()) == 1 {
+	} else if newGpo.MaxFeePerGas != nil && existingGpo.MaxFeePerGas != nil && needsIncrease(existingGpo.MaxFeePerGas, newGpo.MaxFeePerGas) {
 		// existing MaxFeePerGas already above the new MaxFeePerGas, increase using percentage
-		newPercentage := big.NewInt(100)
-
-		newPercentage = newPercentage.Add(newPercentage, big.NewInt(int64(it.gasPriceIncreasePercent)))
-		newMaxFeePerGas := new(big.Int).Mul(existingGpo.MaxFeePerGas.Int(), newPercentage)
-		newMaxFeePerGas = newMaxFeePerGas.Div(newMaxFeePerGas, big.NewInt(100))
-		if it.gasPriceIncreaseMax != nil && newMaxFeePerGas.Cmp(it.gasPriceIncreaseMax) == 1 {
-			newMaxFeePerGas.Set(it.gasPriceIncreaseMax)
+		newMaxFeePerGas := increase(existingGpo.MaxFeePerGas)
+		maxPriorityFeePerGas := existingGpo.MaxPriorityFeePerGas
+		if replacementBump && maxPriorityFeePerGas != nil {
+			maxPriorityFeePerGas = (*pldtypes.HexUint256)(increase(maxPriorityFeePerGas))
 		}
 		newGpo = &pldapi.PublicTxGasPricing{
 			GasPrice:             existingGpo.GasPrice, // copy over unchanged (although expected to be unset)
 			MaxFeePerGas:         (*pldtypes.HexUint256)(newMaxFeePerGas),
-			MaxPriorityFeePerGas: existingGpo.MaxPriorityFeePerGas,
+			MaxPriorityFeePerGas: maxPriorityFeePerGas,
 		}
 	}
 
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 
@@ -505,3 +506,121 @@ func TestTriggerSubmitTx(t *testing.T) {
 	assert.NotEmpty(t, currentGeneration.bufferedStageOutputs[0].SubmitOutput.SubmissionTime)
 	assert.Equal(t, SubmissionOutcomeFailedRequiresRetry, currentGeneration.bufferedStageOutputs[0].SubmitOutput.SubmissionOutcome)
 }
+
+func TestProduceLatestInFlightStageContextSubmitErrorCategories(t *testing.T) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	defer done()
+	it, mTS := newInflightTransaction(o, 1)
+	it.testOnlyNoActionMode = true
+	it.gasPriceIncreasePercent = 50 // increase 50 percent
+	mTS.statusUpdater = &mockStatusUpdater{
+		updateSubStatus: func(ctx context.Context, imtx InMemoryTxStateReadOnly, subStatus BaseTxSubStatus, action BaseTxAction, info, err pldtypes.RawJSON, actionOccurred *pldtypes.Timestamp) error {
+			return nil
+		},
+	}
+	mTS.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing: &pldapi.PublicTxGasPricing{
+			GasPrice: pldtypes.Uint64ToUint256(10),
+		},
+	})
+	currentGeneration := it.stateManager.GetCurrentGeneration(ctx).(*inFlightTransactionStateGeneration)
+
+	submitAndPersist := func(submissionErr error) {
+		it.TriggerNewStageRun(ctx, InFlightTxStageSubmitting, BaseTxSubStatusReceived)
+		currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
+		it.stateManager.GetCurrentGeneration(ctx).AddSubmitOutput(ctx, nil, confutil.P(pldtypes.TimestampNow()), SubmissionOutcomeFailedRequiresRetry, ethclient.MapError(submissionErr), submissionErr)
+		it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{PreviousNonceCostUnknown: true})
+		currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
+		it.stateManager.GetCurrentGeneration(ctx).AddPersistenceOutput(ctx, InFlightTxStageSubmitting, time.Now(), nil)
+		it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{PreviousNonceCostUnknown: true})
+	}
+
+	// transient errors wait for the stage retry timeout
+	submitAndPersist(fmt.Errorf("connection refused"))
+	rsc := it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	assert.Equal(t, InFlightTxStageSubmitting, rsc.Stage)
+	assert.True(t, rsc.StageErrored)
+	assert.Equal(t, ErrorCategoryTransient, rsc.StageOutput.ErrorCategory)
+
+	// underpriced errors go straight to retrieving the gas price, and bump it even if unchanged
+	submitAndPersist(fmt.Errorf("replacement transaction underpriced"))
+	rsc = it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	assert.Equal(t, InFlightTxStageRetrieveGasPrice, rsc.Stage)
+	assert.Equal(t, BaseTxSubStatusStale, rsc.SubStatus)
+	assert.True(t, it.repriceRequired)
+	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
+	it.stateManager.GetCurrentGeneration(ctx).AddGasPriceOutput(ctx, &pldapi.PublicTxGasPricing{
+		GasPrice: pldtypes.Uint64ToUint256(10),
+	}, nil)
+	it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{PreviousNonceCostUnknown: true})
+	assert.Equal(t, "15", rsc.StageOutputsToBePersisted.TxUpdates.GasPricing.GasPrice.Int().String())
+	assert.False(t, it.repriceRequired)
+
+	// permanent rejections suspend the transaction
+	m.db.ExpectExec("UPDATE.*public_txns").WillReturnResult(sqlmock.NewResult(0, 1))
+	submitAndPersist(fmt.Errorf("intrinsic gas too low"))
+	require.NoError(t, m.db.ExpectationsWereMet())
+	rsc = it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	assert.Equal(t, InFlightTxStageStatusUpdate, rsc.Stage)
+	assert.Equal(t, InFlightStatusSuspending, *it.newStatus)
+
+	// a failure to suspend is retried
+	it.newStatus = nil
+	m.db.ExpectExec("UPDATE.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	submitAndPersist(fmt.Errorf("intrinsic gas too low"))
+	rsc = it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	assert.Equal(t, InFlightTxStageSubmitting, rsc.Stage)
+	assert.True(t, rsc.StageErrored)
+	assert.Nil(t, it.newStatus)
+
+}
+
+func TestProduceLatestInFlightStageContextSubmitReplacementUnderpriced(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t)
+	defer done()
+	it, mTS := newInflightTransaction(o, 1)
+	it.testOnlyNoActionMode = true
+	it.gasPriceIncreasePercent = 0
+	it.replacementBumpPercent = 10
+	it.gasPriceIncreaseMax = big.NewInt(105)
+	mTS.statusUpdater = &mockStatusUpdater{
+		updateSubStatus: func(ctx context.Context, imtx InMemoryTxStateReadOnly, subStatus BaseTxSubStatus, action BaseTxAction, info, err pldtypes.RawJSON, actionOccurred *pldtypes.Timestamp) error {
+			return nil
+		},
+	}
+	mTS.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing: &pldapi.PublicTxGasPricing{
+			MaxFeePerGas:         pldtypes.Uint64ToUint256(100),
+			MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(50),
+		},
+	})
+	currentGeneration := it.stateManager.GetCurrentGeneration(ctx).(*inFlightTransactionStateGeneration)
+
+	// a replacement error that is not in the built-in list still goes straight to retrieving the gas price
+	submissionErr := fmt.Errorf("could not replace existing tx")
+	it.TriggerNewStageRun(ctx, InFlightTxStageSubmitting, BaseTxSubStatusReceived)
+	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
+	it.stateManager.GetCurrentGeneration(ctx).AddSubmitOutput(ctx, nil, confutil.P(pldtypes.TimestampNow()), SubmissionOutcomeReplacementUnderpriced, ethclient.ErrorReasonTransactionUnderpriced, submissionErr)
+	it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{PreviousNonceCostUnknown: true})
+	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
+	it.stateManager.GetCurrentGeneration(ctx).AddPersistenceOutput(ctx, InFlightTxStageSubmitting, time.Now(), nil)
+	it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{PreviousNonceCostUnknown: true})
+	rsc := it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	assert.Equal(t, InFlightTxStageRetrieveGasPrice, rsc.Stage)
+	assert.True(t, it.repriceRequired)
+	assert.True(t, it.replacementBumpRequired)
+
+	// both fees are bumped by the replacement percentage, even though the increase percentage is zero,
+	// and capped by the increase max
+	currentGeneration.bufferedStageOutputs = make([]*StageOutput, 0)
+	it.stateManager.GetCurrentGeneration(ctx).AddGasPriceOutput(ctx, &pldapi.PublicTxGasPricing{
+		MaxFeePerGas:         pldtypes.Uint64ToUint256(100),
+		MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(1),
+	}, nil)
+	it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{PreviousNonceCostUnknown: true})
+	gpo := rsc.StageOutputsToBePersisted.TxUpdates.GasPricing
+	assert.Equal(t, "105", gpo.MaxFeePerGas.Int().String())
+	assert.Equal(t, "55", gpo.MaxPriorityFeePerGas.Int().String())
+	assert.False(t, it.repriceRequired)
+	assert.False(t, it.replacementBumpRequired)
+}
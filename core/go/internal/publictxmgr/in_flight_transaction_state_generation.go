@@ -210,8 +210,14 @@ func (v *inFlightTransactionStateGeneration) AddPersistenceOutput(ctx context.Co
 func (v *inFlightTransactionStateGeneration) AddSubmitOutput(ctx context.Context, txHash *pldtypes.Bytes32, submissionTime *pldtypes.Timestamp, submissionOutcome SubmissionOutcome, errorReason ethclient.ErrorReason, err error) {
 	start := time.Now()
 	log.L(ctx).Debugf("%s Setting submit output, submissionOutcome: %s, errReason: %s, err %+v", v.GetSignerNonce(), submissionOutcome, errorReason, err)
+	errorCategory := categorizeError(err)
+	if submissionOutcome == SubmissionOutcomeReplacementUnderpriced {
+		// the configured replacement errors are not all in our built-in list
+		errorCategory = ErrorCategoryNeedsReprice
+	}
 	v.AddStageOutputs(ctx, &StageOutput{
-		Stage: InFlightTxStageSubmitting,
+		Stage:         InFlightTxStageSubmitting,
+		ErrorCategory: errorCategory,
 		SubmitOutput: &SubmitOutputs{
 			SubmissionTime:    submissionTime,
 			SubmissionOutcome: submissionOutcome,
@@ -242,7 +248,8 @@ func (v *inFlightTransactionStateGeneration) AddSignOutput(ctx context.Context,
 	start := time.Now()
 	log.L(ctx).Debugf("%s Setting signed message, hash %s, signed message not nil %t, err %+v", v.GetSignerNonce(), txHash, signedMessage != nil, err)
 	v.AddStageOutputs(ctx, &StageOutput{
-		Stage: InFlightTxStageSigning,
+		Stage:         InFlightTxStageSigning,
+		ErrorCategory: categorizeError(err),
 		SignOutput: &SignOutputs{
 			SignedMessage: signedMessage,
 			TxHash:        txHash,
@@ -301,16 +308,23 @@ func (v *inFlightTransactionStateGeneration) PersistTxState(ctx context.Context)
 	if rsc.StageOutputsToBePersisted.TxUpdates != nil {
 
 		newSubmission := rsc.StageOutputsToBePersisted.TxUpdates.NewSubmission
-		if newSubmission != nil {
+		errorMessage := rsc.StageOutputsToBePersisted.TxUpdates.ErrorMessage
+		if newSubmission != nil || errorMessage != nil {
 			// This is the critical point where we must flush to persistence before we go any further - we have a new
 			// transaction record we've signed, and we want to move on to submit it to the blockchain.
 			// But if we do that without first recording the transaction hash, we cannot be sure we will be able
 			// to correlate back and complete the transaction when requested by the blockchain indexer.
+			// Any error message is written in the same operation, so it is available after a restart.
 			//
 			// This can be happening on lots of threads at the same time for different transactions,
 			// so we don't want to create an excessive number of DB transactions.
 			// Instead we use a pool of flush-writers that do the insertion in batches.
-			op := v.submissionWriter.Queue(ctx, newSubmission)
+			op := v.submissionWriter.Queue(ctx, &submissionWrite{
+				from:         v.GetFrom().String(),
+				pubTxnID:     v.GetPubTxnID(),
+				submission:   newSubmission,
+				errorMessage: errorMessage,
+			})
 			_, err := op.WaitFlushed(ctx)
 			if err != nil {
 				return rsc.Stage, time.Now(), err
@@ -21,12 +21,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/core/mocks/publictxmgrmocks"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type testInFlightTransactionStateVersionWithMocks struct {
@@ -321,7 +323,6 @@ func TestStateManagerTxPersistenceManagementUpdateErrors(t *testing.T) {
 
 	rsc.StageOutputsToBePersisted.TxUpdates = &BaseTXUpdates{
 		NewSubmission: &DBPubTxnSubmission{
-			from:            "0x12345",
 			TransactionHash: pldtypes.RandBytes32(),
 		},
 	}
@@ -333,4 +334,38 @@ func TestStateManagerTxPersistenceManagementUpdateErrors(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Regexp(t, "pop", err)
 
+	rsc.StageOutputsToBePersisted.TxUpdates = &BaseTXUpdates{
+		ErrorMessage: confutil.P("submit failed"),
+	}
+
+	m.db.ExpectBegin()
+	m.db.ExpectExec("UPDATE.*public_txns.*error_message").WillReturnError(fmt.Errorf("pop"))
+	m.db.ExpectRollback()
+	_, _, err = version.PersistTxState(ctx)
+	assert.NotNil(t, err)
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestStateManagerTxPersistErrorMessage(t *testing.T) {
+	ctx := context.Background()
+	testStateVersionWithMocks, m, done := newTestInFlightTransactionStateVersion(t)
+	defer done()
+
+	version := testStateVersionWithMocks.version
+	version.StartNewStageContext(ctx, InFlightTxStageQueued, BaseTxSubStatusTracking)
+	rsc := version.GetRunningStageContext(ctx)
+	rsc.SetNewPersistenceUpdateOutput()
+	rsc.StageOutputsToBePersisted.TxUpdates = &BaseTXUpdates{
+		ErrorMessage: confutil.P("submit failed"),
+	}
+
+	// the error message is written to the transaction, without a submission
+	m.db.ExpectBegin()
+	m.db.ExpectExec("UPDATE.*public_txns.*error_message").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.db.ExpectCommit()
+	_, _, err := version.PersistTxState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "submit failed", *version.(*inFlightTransactionStateGeneration).GetErrorMessage())
+	require.NoError(t, m.db.ExpectationsWereMet())
 }
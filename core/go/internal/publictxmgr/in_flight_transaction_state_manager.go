@@ -77,6 +77,10 @@ func (iftxs *inFlightTransactionState) CanBeRemoved(ctx context.Context) bool {
 
 func (iftxs *inFlightTransactionState) CanSubmit(ctx context.Context, cost *big.Int) bool {
 	log.L(ctx).Tracef("ProcessInFlightTransaction transaction entry, transaction orchestrator context: %+v, cost: %s", iftxs.orchestratorContext, cost.String())
+	if iftxs.orchestratorContext.SubmissionSlotUnavailable {
+		log.L(ctx).Debugf("ProcessInFlightTransaction cannot submit transaction, no submission slot available, orchestrator context: %+v", iftxs.orchestratorContext)
+		return false
+	}
 	if iftxs.orchestratorContext.AvailableToSpend == nil {
 		log.L(ctx).Tracef("ProcessInFlightTransaction transaction can be submitted for zero gas price chain, orchestrator context: %+v", iftxs.orchestratorContext)
 		return true
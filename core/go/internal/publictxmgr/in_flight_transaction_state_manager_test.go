@@ -90,4 +90,9 @@ func TestStateManagerStageManagementCanSubmit(t *testing.T) {
 
 	assert.False(t, stateManager.CanSubmit(context.Background(), big.NewInt(29)))
 
+	stateManager.SetOrchestratorContext(ctx, &OrchestratorContext{
+		SubmissionSlotUnavailable: true,
+	})
+	assert.False(t, stateManager.CanSubmit(context.Background(), big.NewInt(0)))
+
 }
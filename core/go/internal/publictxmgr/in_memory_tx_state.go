@@ -18,6 +18,7 @@ package publictxmgr
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"strconv"
 	"sync"
 	"time"
@@ -40,6 +41,7 @@ type managedTx struct {
 	TransactionHash *pldtypes.Bytes32         // the most recently submitted transaction hash (not guaranteed to be the one mined)
 	FirstSubmit     *pldtypes.Timestamp       // the time this runtime instance first did a submit JSON/RPC call (for success or failure)
 	LastSubmit      *pldtypes.Timestamp       // the last time runtime instance first did a submit JSON/RPC call (for success or failure)
+	ErrorMessage    *string                   // the most recent error processing the transaction (persisted, so recovered on restart)
 }
 
 type inMemoryTxState struct {
@@ -47,6 +49,12 @@ type inMemoryTxState struct {
 	mtx          *managedTx
 }
 
+// InMemoryTxStateSnapshot is an opaque copy of the in-memory state of a transaction, that shares no
+// memory with the state it was taken from. It can be restored any number of times.
+type InMemoryTxStateSnapshot struct {
+	mtx *managedTx
+}
+
 func gasPricingSet(gasPricing pldapi.PublicTxGasPricing) bool {
 	return gasPricing.GasPrice != nil || gasPricing.MaxFeePerGas != nil || gasPricing.MaxPriorityFeePerGas != nil
 }
@@ -57,6 +65,7 @@ func NewInMemoryTxStateManager(ctx context.Context, ptx *DBPublicTxn) InMemoryTx
 			ptx:            ptx,
 			InFlightStatus: InFlightStatusPending,
 			GasPricing:     recoverGasPriceOptions(ptx.FixedGasPricing),
+			ErrorMessage:   ptx.ErrorMessage,
 		},
 	}
 
@@ -140,6 +149,10 @@ func (imtxs *inMemoryTxState) ApplyInMemoryUpdates(ctx context.Context, txUpdate
 	if txUpdates.TransactionHash != nil {
 		mtx.TransactionHash = txUpdates.TransactionHash
 	}
+
+	if txUpdates.ErrorMessage != nil {
+		mtx.ErrorMessage = txUpdates.ErrorMessage
+	}
 }
 
 func (imtxs *inMemoryTxState) GetPubTxnID() uint64 {
@@ -170,6 +183,10 @@ func (imtxs *inMemoryTxState) GetNonce() uint64 {
 	return *imtxs.mtx.ptx.Nonce
 }
 
+func (imtxs *inMemoryTxState) GetErrorMessage() *string {
+	return imtxs.mtx.ErrorMessage
+}
+
 func (imtxs *inMemoryTxState) GetFrom() pldtypes.EthAddress {
 	return imtxs.mtx.ptx.From
 }
@@ -231,6 +248,91 @@ func (imtxs *inMemoryTxState) IsReadyToExit() bool {
 	return imtxs.mtx.InFlightStatus != InFlightStatusPending
 }
 
+func (imtxs *inMemoryTxState) Snapshot() InMemoryTxStateSnapshot {
+	imtxs.managedTxMux.Lock()
+	defer imtxs.managedTxMux.Unlock()
+	return InMemoryTxStateSnapshot{mtx: imtxs.mtx.deepCopy()}
+}
+
+// RestoreSnapshot rolls back all changes made since the snapshot was taken. The existing managed transaction
+// and persisted transaction objects are updated in place, so any references to them remain valid.
+func (imtxs *inMemoryTxState) RestoreSnapshot(snapshot InMemoryTxStateSnapshot) {
+	imtxs.managedTxMux.Lock()
+	defer imtxs.managedTxMux.Unlock()
+	restored := snapshot.mtx.deepCopy()
+	ptx := imtxs.mtx.ptx
+	*ptx = *restored.ptx
+	*imtxs.mtx = *restored
+	imtxs.mtx.ptx = ptx
+}
+
+func (mtx *managedTx) deepCopy() *managedTx {
+	return &managedTx{
+		ptx:                 mtx.ptx.deepCopy(),
+		unflushedSubmission: mtx.unflushedSubmission.deepCopy(),
+		InFlightStatus:      mtx.InFlightStatus,
+		GasPricing: pldapi.PublicTxGasPricing{
+			GasPrice:             copyHexUint256(mtx.GasPricing.GasPrice),
+			MaxFeePerGas:         copyHexUint256(mtx.GasPricing.MaxFeePerGas),
+			MaxPriorityFeePerGas: copyHexUint256(mtx.GasPricing.MaxPriorityFeePerGas),
+		},
+		TransactionHash: copyPtr(mtx.TransactionHash),
+		FirstSubmit:     copyPtr(mtx.FirstSubmit),
+		LastSubmit:      copyPtr(mtx.LastSubmit),
+		ErrorMessage:    copyPtr(mtx.ErrorMessage),
+	}
+}
+
+func (ptx *DBPublicTxn) deepCopy() *DBPublicTxn {
+	c := *ptx
+	c.Nonce = copyPtr(ptx.Nonce)
+	c.To = copyPtr(ptx.To)
+	c.FixedGasPricing = copyBytes(ptx.FixedGasPricing)
+	c.Value = copyHexUint256(ptx.Value)
+	c.Data = copyBytes(ptx.Data)
+	c.ErrorMessage = copyPtr(ptx.ErrorMessage)
+	c.Completed = copyPtr(ptx.Completed)
+	c.Binding = copyPtr(ptx.Binding)
+	if ptx.Submissions != nil {
+		c.Submissions = make([]*DBPubTxnSubmission, len(ptx.Submissions))
+		for i, sub := range ptx.Submissions {
+			c.Submissions[i] = sub.deepCopy()
+		}
+	}
+	return &c
+}
+
+func (sub *DBPubTxnSubmission) deepCopy() *DBPubTxnSubmission {
+	if sub == nil {
+		return nil
+	}
+	c := *sub
+	c.GasPricing = copyBytes(sub.GasPricing)
+	return &c
+}
+
+func copyPtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func copyBytes[T ~[]byte](b T) T {
+	if b == nil {
+		return nil
+	}
+	return append(T{}, b...)
+}
+
+func copyHexUint256(v *pldtypes.HexUint256) *pldtypes.HexUint256 {
+	if v == nil {
+		return nil
+	}
+	return (*pldtypes.HexUint256)(new(big.Int).Set(v.Int()))
+}
+
 func NewRunningStageContext(ctx context.Context, stage InFlightTxStage, substatus BaseTxSubStatus, imtxs InMemoryTxStateManager) *RunningStageContext {
 	return &RunningStageContext{
 		Stage:          stage,
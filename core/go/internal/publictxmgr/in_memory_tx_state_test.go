@@ -24,6 +24,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const testTransactionData string = "0x7369676e6564206d657373616765"
@@ -109,6 +110,7 @@ func TestSettersAndGetters(t *testing.T) {
 	assert.Equal(t, oldGasPrice.Int(), imts.GetGasPriceObject().GasPrice.Int())
 	assert.Equal(t, oldTime, *imts.GetFirstSubmit())
 	assert.Equal(t, oldGasLimit.Uint64(), imts.GetGasLimit())
+	assert.Equal(t, oldErrorMessage, *imts.GetErrorMessage())
 	assert.False(t, imts.IsReadyToExit())
 
 	// dup flush
@@ -148,6 +150,7 @@ func TestSettersAndGetters(t *testing.T) {
 	assert.Nil(t, imts.GetGasPriceObject().MaxFeePerGas)
 	assert.Nil(t, imts.GetGasPriceObject().MaxPriorityFeePerGas)
 	assert.Equal(t, newTime, imts.GetFirstSubmit())
+	assert.Equal(t, newErrorMessage, *imts.GetErrorMessage())
 	assert.Equal(t, &DBPubTxnSubmission{
 		TransactionHash: newTxHash,
 	}, imts.GetUnflushedSubmission())
@@ -175,3 +178,78 @@ func TestSettersAndGetters(t *testing.T) {
 	assert.Equal(t, maxPriorityFeePerGas.Int(), imts.GetGasPriceObject().MaxPriorityFeePerGas.Int())
 
 }
+
+func TestErrorMessageRecoveredFromPersistedTx(t *testing.T) {
+	imts := NewInMemoryTxStateManager(context.Background(), &DBPublicTxn{
+		From:         *pldtypes.RandAddress(),
+		ErrorMessage: confutil.P("persisted message"),
+	})
+	assert.Equal(t, "persisted message", *imts.GetErrorMessage())
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	ctx := context.Background()
+	oldTime := pldtypes.TimestampNow()
+	oldTxHash := pldtypes.RandBytes32()
+	oldGasPrice := pldtypes.Uint64ToUint256(10)
+	oldErrorMessage := "old message"
+	ptx := &DBPublicTxn{
+		From:            *pldtypes.RandAddress(),
+		To:              pldtypes.RandAddress(),
+		Nonce:           confutil.P(uint64(1)),
+		Gas:             2000,
+		Value:           pldtypes.Uint64ToUint256(200),
+		Data:            pldtypes.MustParseHexBytes(testTransactionData),
+		FixedGasPricing: pldtypes.RawJSON(`{"gasPrice":"0x0a"}`),
+		Submissions: []*DBPubTxnSubmission{
+			{TransactionHash: oldTxHash, Created: oldTime, GasPricing: pldtypes.RawJSON(`{"gasPrice":"0x0a"}`)},
+		},
+	}
+
+	imts := NewInMemoryTxStateManager(ctx, ptx)
+	imts.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing:   &pldapi.PublicTxGasPricing{GasPrice: oldGasPrice},
+		ErrorMessage: &oldErrorMessage,
+	})
+	snapshot := imts.Snapshot()
+	ethTxBefore := imts.BuildEthTX()
+
+	// make speculative changes, including in-place changes to the data we hold references to
+	newTxHash := pldtypes.RandBytes32()
+	newErrorMessage := "new message"
+	imts.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		GasPricing:        &pldapi.PublicTxGasPricing{MaxFeePerGas: pldtypes.Uint64ToUint256(20), MaxPriorityFeePerGas: pldtypes.Uint64ToUint256(2)},
+		TransactionHash:   &newTxHash,
+		LastSubmit:        confutil.P(pldtypes.TimestampNow()),
+		ErrorMessage:      &newErrorMessage,
+		NewSubmission:     &DBPubTxnSubmission{TransactionHash: newTxHash},
+		FlushedSubmission: &DBPubTxnSubmission{TransactionHash: newTxHash},
+		InFlightStatus:    confutil.P(InFlightStatusSuspending),
+	})
+	imts.UpdateTransaction(&DBPublicTxn{Gas: 3000, Data: pldtypes.HexBytes{0x01}, FixedGasPricing: pldtypes.RawJSON(`{"maxFeePerGas":"0x14","maxPriorityFeePerGas":"0x02"}`)})
+	imts.GetGasPriceObject().MaxFeePerGas.Int().SetInt64(99)
+	ptx.Submissions[1].GasPricing[0] = '['
+	assert.Len(t, ptx.Submissions, 2)
+
+	imts.RestoreSnapshot(snapshot)
+	assert.Equal(t, ethTxBefore, imts.BuildEthTX())
+	assert.Equal(t, oldTxHash, *imts.GetTransactionHash())
+	assert.Nil(t, imts.GetUnflushedSubmission())
+	assert.Equal(t, oldTime, *imts.GetLastSubmitTime())
+	assert.Equal(t, oldErrorMessage, *imts.GetErrorMessage())
+	assert.Equal(t, InFlightStatusPending, imts.GetInFlightStatus())
+	assert.Equal(t, &pldapi.PublicTxGasPricing{GasPrice: oldGasPrice}, imts.GetGasPriceObject())
+
+	// the persisted transaction is restored in place, so existing references see the rollback
+	assert.Same(t, ptx, imts.(*inMemoryTxState).mtx.ptx)
+	assert.Equal(t, uint64(2000), ptx.Gas)
+	require.Len(t, ptx.Submissions, 1)
+	assert.Equal(t, oldTxHash, ptx.Submissions[0].TransactionHash)
+
+	// changes after a restore do not affect the snapshot, so it can be restored again
+	imts.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{TransactionHash: &newTxHash})
+	imts.GetGasPriceObject().GasPrice.Int().SetInt64(99)
+	imts.RestoreSnapshot(snapshot)
+	assert.Equal(t, oldTxHash, *imts.GetTransactionHash())
+	assert.Equal(t, int64(10), imts.GetGasPriceObject().GasPrice.Int().Int64())
+}
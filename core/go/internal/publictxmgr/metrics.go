@@ -17,8 +17,14 @@ package publictxmgr
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type PublicTxManagerMetricsManager interface {
@@ -61,3 +67,49 @@ func (thm *publicTxEngineMetrics) RecordCompletedTransactionCountMetrics(ctx con
 	log.L(ctx).Tracef("RecordCompletedTransactionCountMetrics")
 	// TODO
 }
+
+const metricAttrSigningAddress = "signing_address"
+
+// OrchestratorMetrics are recorded by every orchestrator, with the signing address as an attribute.
+// They are recorded against the global OpenTelemetry meter provider.
+type OrchestratorMetrics struct {
+	inFlight         metric.Int64Gauge
+	resubmissions    metric.Int64Histogram
+	confirmationTime metric.Float64Histogram
+}
+
+func NewOrchestratorMetrics(ctx context.Context) *OrchestratorMetrics {
+	meter := otel.Meter("github.com/kaleido-io/paladin/core/internal/publictxmgr")
+	om := &OrchestratorMetrics{}
+	var err, instErr error
+	om.inFlight, instErr = meter.Int64Gauge("paladin.publictxmgr.orchestrator.inflight",
+		metric.WithDescription("Number of transactions in flight in the orchestrator"))
+	err = errors.Join(err, instErr)
+	om.resubmissions, instErr = meter.Int64Histogram("paladin.publictxmgr.orchestrator.resubmissions",
+		metric.WithDescription("Number of times each confirmed transaction was resubmitted"),
+		metric.WithExplicitBucketBoundaries(0, 1, 2, 5, 10, 20, 50))
+	err = errors.Join(err, instErr)
+	om.confirmationTime, instErr = meter.Float64Histogram("paladin.publictxmgr.orchestrator.confirmation_time",
+		metric.WithDescription("Time from first submission to confirmation of each transaction"),
+		metric.WithUnit("s"))
+	err = errors.Join(err, instErr)
+	if err != nil {
+		// the instruments are still safe to use, but might not record
+		log.L(ctx).Errorf("Failed to create orchestrator metrics: %s", err)
+	}
+	return om
+}
+
+func (om *OrchestratorMetrics) RecordInFlight(ctx context.Context, signingAddress pldtypes.EthAddress, count int) {
+	om.inFlight.Record(ctx, int64(count), metric.WithAttributes(attribute.String(metricAttrSigningAddress, signingAddress.String())))
+}
+
+// RecordConfirmed records the resubmissions and confirmation time of a transaction when it is confirmed.
+// The confirmation time is only recorded if we have a record of submitting the transaction.
+func (om *OrchestratorMetrics) RecordConfirmed(ctx context.Context, signingAddress pldtypes.EthAddress, resubmissions int, firstSubmit *pldtypes.Timestamp) {
+	attrs := metric.WithAttributes(attribute.String(metricAttrSigningAddress, signingAddress.String()))
+	om.resubmissions.Record(ctx, int64(resubmissions), attrs)
+	if firstSubmit != nil {
+		om.confirmationTime.Record(ctx, time.Since(firstSubmit.Time()).Seconds(), attrs)
+	}
+}
@@ -18,6 +18,15 @@ package publictxmgr
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 func TestMetrics(t *testing.T) {
@@ -32,3 +41,42 @@ func TestMetrics(t *testing.T) {
 	btem.RecordInFlightTxQueueMetrics(ctx, nil, 1)
 	btem.RecordCompletedTransactionCountMetrics(ctx, "test")
 }
+
+func TestOrchestratorMetrics(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	prevProvider := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	defer otel.SetMeterProvider(prevProvider)
+
+	om := NewOrchestratorMetrics(ctx)
+	signer := *pldtypes.RandAddress()
+	om.RecordInFlight(ctx, signer, 5)
+	om.RecordConfirmed(ctx, signer, 2, confutil.P(pldtypes.Timestamp(time.Now().Add(-10*time.Second).UnixNano())))
+	om.RecordConfirmed(ctx, signer, 0, nil)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	byName := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		byName[m.Name] = m.Data
+	}
+
+	inFlight := byName["paladin.publictxmgr.orchestrator.inflight"].(metricdata.Gauge[int64])
+	require.Len(t, inFlight.DataPoints, 1)
+	assert.Equal(t, int64(5), inFlight.DataPoints[0].Value)
+	addr, _ := inFlight.DataPoints[0].Attributes.Value(metricAttrSigningAddress)
+	assert.Equal(t, signer.String(), addr.AsString())
+
+	resubmissions := byName["paladin.publictxmgr.orchestrator.resubmissions"].(metricdata.Histogram[int64])
+	require.Len(t, resubmissions.DataPoints, 1)
+	assert.Equal(t, uint64(2), resubmissions.DataPoints[0].Count)
+	assert.Equal(t, int64(2), resubmissions.DataPoints[0].Sum)
+
+	// only recorded when we know the submission time
+	confirmationTime := byName["paladin.publictxmgr.orchestrator.confirmation_time"].(metricdata.Histogram[float64])
+	require.Len(t, confirmationTime.DataPoints, 1)
+	assert.Equal(t, uint64(1), confirmationTime.DataPoints[0].Count)
+	assert.GreaterOrEqual(t, confirmationTime.DataPoints[0].Sum, float64(10))
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"gorm.io/gorm/clause"
+)
+
+// gas for a zero-value transfer to an externally owned account
+const nonceGapFillGas = 21000
+
+// fillNonceGap checks the chain for nonces below the lowest in-flight nonce that have not been used by any transaction
+// mined or pending in the node's mempool, and that we have no transaction for. The in-flight transactions can never be
+// mined until these are used, so we fill each with a zero-value transfer to the signing address itself, which is
+// processed just like any other transaction.
+// The pending count is used so that we never submit a filler that would replace a transaction still in the mempool.
+//
+// Must be called holding the inFlightTxsMux
+func (oc *orchestrator) fillNonceGap(ctx context.Context) error {
+	lowestInFlight := oc.inFlightTxs[0].stateManager.GetNonce()
+	for _, it := range oc.inFlightTxs[1:] {
+		lowestInFlight = min(lowestInFlight, it.stateManager.GetNonce())
+	}
+
+	txCount, err := oc.ethClient.GetPendingTransactionCount(ctx, oc.signingAddress)
+	if err != nil {
+		return err
+	}
+	pendingNonce := txCount.Uint64()
+	if pendingNonce >= lowestInFlight {
+		return nil
+	}
+
+	// If we have any transactions using these nonces (for example because they are suspended) we must not
+	// replace them - resuming or updating those is the right way to resolve the gap
+	var existing int64
+	err = oc.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Where(`"from" = ?`, oc.signingAddress).
+		Where("nonce >= ?", pendingNonce).
+		Where("nonce < ?", lowestInFlight).
+		Count(&existing).
+		Error
+	if err != nil {
+		return err
+	}
+	if existing > 0 {
+		log.L(ctx).Warnf("Nonce gap for signing address %s between pending nonce %d and in-flight nonce %d contains %d transactions that are not in flight (suspended?)",
+			oc.signingAddress, pendingNonce, lowestInFlight, existing)
+		return nil
+	}
+
+	log.L(ctx).Warnf("Nonce gap detected for signing address %s: next nonce on chain (including pending) is %d, but the lowest in-flight nonce is %d. Submitting %d gap-filling transactions",
+		oc.signingAddress, pendingNonce, lowestInFlight, lowestInFlight-pendingNonce)
+	to := oc.signingAddress
+	fillers := make([]*DBPublicTxn, 0, lowestInFlight-pendingNonce)
+	for nonce := pendingNonce; nonce < lowestInFlight; nonce++ {
+		fillers = append(fillers, &DBPublicTxn{
+			From:  oc.signingAddress,
+			Nonce: &nonce,
+			To:    &to,
+			Gas:   nonceGapFillGas,
+			Value: pldtypes.Uint64ToUint256(0),
+		})
+	}
+	err = oc.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "pub_txn_id"}}}).
+		Create(fillers).
+		Error
+	if err != nil {
+		return err
+	}
+
+	// The fillers have lower nonces than anything else in flight, so go to the front of the queue.
+	// They are not picked up by polling, as we only poll for nonces higher than those in flight.
+	newInFlight := make([]*inFlightTransactionStageController, 0, len(fillers)+len(oc.inFlightTxs))
+	for _, ptx := range fillers {
+		log.L(ctx).Infof("Gap-filling transaction added for %s:%d (pubTxnId=%d)", ptx.From, *ptx.Nonce, ptx.PublicTxnID)
+		newInFlight = append(newInFlight, NewInFlightTransactionStageController(oc.pubTxManager, oc, ptx))
+	}
+	oc.inFlightTxs = append(newInFlight, oc.inFlightTxs...)
+	return nil
+}
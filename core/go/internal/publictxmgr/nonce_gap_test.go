@@ -0,0 +1,151 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOrchestratorWithInFlight(t *testing.T, nonces ...uint64) (context.Context, *orchestrator, *mocksAndTestControl, func()) {
+	ctx, o, m, done := newTestOrchestrator(t)
+	for _, nonce := range nonces {
+		it, _ := newInflightTransaction(o, nonce)
+		o.inFlightTxs = append(o.inFlightTxs, it)
+	}
+	return ctx, o, m, done
+}
+
+func inFlightNonces(o *orchestrator) []uint64 {
+	nonces := make([]uint64, len(o.inFlightTxs))
+	for i, it := range o.inFlightTxs {
+		nonces[i] = it.stateManager.GetNonce()
+	}
+	return nonces
+}
+
+func TestFillNonceGap(t *testing.T) {
+	ctx, o, m, done := newTestOrchestratorWithInFlight(t, 5, 6)
+	defer done()
+
+	m.ethClient.On("GetPendingTransactionCount", mock.Anything, o.signingAddress).Return(confutil.P(pldtypes.HexUint64(3)), nil)
+	m.db.ExpectQuery("SELECT count.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	m.db.ExpectQuery("INSERT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id"}).AddRow(100).AddRow(101))
+
+	err := o.fillNonceGap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3, 4, 5, 6}, inFlightNonces(o))
+
+	filler := o.inFlightTxs[0].stateManager
+	assert.Equal(t, uint64(100), filler.GetPubTxnID())
+	assert.Equal(t, o.signingAddress, *filler.GetTo())
+	assert.Equal(t, uint64(nonceGapFillGas), filler.GetGasLimit())
+	assert.Zero(t, filler.GetValue().Int().Sign())
+}
+
+func TestFillNonceGapNoGap(t *testing.T) {
+	ctx, o, m, done := newTestOrchestratorWithInFlight(t, 5, 6)
+	defer done()
+
+	m.ethClient.On("GetPendingTransactionCount", mock.Anything, o.signingAddress).Return(confutil.P(pldtypes.HexUint64(5)), nil)
+
+	err := o.fillNonceGap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{5, 6}, inFlightNonces(o))
+}
+
+func TestFillNonceGapExistingTransactions(t *testing.T) {
+	ctx, o, m, done := newTestOrchestratorWithInFlight(t, 6, 5)
+	defer done()
+
+	m.ethClient.On("GetPendingTransactionCount", mock.Anything, o.signingAddress).Return(confutil.P(pldtypes.HexUint64(4)), nil)
+	m.db.ExpectQuery("SELECT count.*public_txns").WithArgs(o.signingAddress, uint64(4), uint64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	err := o.fillNonceGap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{6, 5}, inFlightNonces(o))
+}
+
+func TestFillNonceGapErrors(t *testing.T) {
+	ctx, o, m, done := newTestOrchestratorWithInFlight(t, 5)
+	defer done()
+
+	m.ethClient.On("GetPendingTransactionCount", mock.Anything, o.signingAddress).Return(nil, fmt.Errorf("pop")).Once()
+	err := o.fillNonceGap(ctx)
+	assert.Regexp(t, "pop", err)
+
+	m.ethClient.On("GetPendingTransactionCount", mock.Anything, o.signingAddress).Return(confutil.P(pldtypes.HexUint64(4)), nil)
+	m.db.ExpectQuery("SELECT count.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	err = o.fillNonceGap(ctx)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT count.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	m.db.ExpectQuery("INSERT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	err = o.fillNonceGap(ctx)
+	assert.Regexp(t, "pop", err)
+	assert.Equal(t, []uint64{5}, inFlightNonces(o))
+}
+
+func TestMatchUpdateConfirmedGapFillingTransaction(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true)
+	defer done()
+
+	// A transaction with no bindings, as written when filling a nonce gap
+	from := *pldtypes.RandAddress()
+	nonce := uint64(3)
+	txHash := pldtypes.RandBytes32()
+	ptx := &DBPublicTxn{From: from, Nonce: &nonce, To: &from, Gas: nonceGapFillGas}
+	err := ptm.p.DB().Table("public_txns").Create(ptx).Error
+	require.NoError(t, err)
+	err = ptm.p.DB().Table("public_submissions").Create(&DBPubTxnSubmission{
+		PublicTxnID:     ptx.PublicTxnID,
+		TransactionHash: txHash,
+		Created:         pldtypes.TimestampNow(),
+	}).Error
+	require.NoError(t, err)
+
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		matches, err := ptm.MatchUpdateConfirmedTransactions(ctx, dbTX, []*blockindexer.IndexedTransactionNotify{{
+			IndexedTransaction: pldapi.IndexedTransaction{
+				Hash:        txHash,
+				BlockNumber: 12345,
+				From:        &from,
+				Nonce:       nonce,
+				Result:      pldapi.TXResult_SUCCESS.Enum(),
+			},
+		}})
+		// completed, but not returned as a match as there is no Paladin transaction
+		assert.Empty(t, matches)
+		return err
+	})
+	require.NoError(t, err)
+
+	completed, err := ptm.CheckTransactionCompleted(ctx, ptx.PublicTxnID)
+	require.NoError(t, err)
+	assert.True(t, completed)
+}
@@ -34,8 +34,11 @@ type DBPublicTxn struct {
 	Value           *pldtypes.HexUint256   `gorm:"column:value"`
 	Data            pldtypes.HexBytes      `gorm:"column:data"`
 	Suspended       bool                   `gorm:"column:suspended"`                            // excluded from processing because it's suspended by user
+	ErrorMessage    *string                `gorm:"column:error_message"`                        // the most recent error processing the transaction
 	Completed       *DBPublicTxnCompletion `gorm:"foreignKey:pub_txn_id;references:pub_txn_id"` // excluded from processing because it's done
 	Submissions     []*DBPubTxnSubmission  `gorm:"-"`                                           // we do the aggregation, not GORM
+	// blocks that must be mined on top of the confirming block, before the completion is written
+	RequiredConfirmationDepth uint `gorm:"column:required_confirmation_depth"`
 	// Binding is used only on queries by transaction (GORM doesn't seem to allow us to define a separate struct for this)
 	Binding *DBPublicTxnBinding `gorm:"foreignKey:pub_txn_id;references:pub_txn_id;"`
 }
@@ -55,7 +58,6 @@ func (DBPublicTxnBinding) TableName() string {
 }
 
 type DBPubTxnSubmission struct {
-	from            string             `gorm:"-"` // just used to ensure we dispatch to same writer as the associated pubic TX
 	PublicTxnID     uint64             `gorm:"column:pub_txn_id"`
 	Created         pldtypes.Timestamp `gorm:"column:created;autoCreateTime:false"` // we set this as we track the record in memory too
 	TransactionHash pldtypes.Bytes32   `gorm:"column:tx_hash;primaryKey"`
@@ -78,16 +80,61 @@ func (DBPublicTxnCompletion) TableName() string {
 	return "public_completions"
 }
 
-func (s *DBPubTxnSubmission) WriteKey() string {
-	// Just use the from address as the write key, so all submissions on the same signing address get batched together
-	return s.from
+// A confirmation that is not yet deep enough in the chain to write the completion
+type DBPublicTxnPendingCompletion struct {
+	PublicTxnID     uint64             `gorm:"column:pub_txn_id;primaryKey"`
+	Created         pldtypes.Timestamp `gorm:"column:created;autoCreateTime:nano"`
+	TransactionHash pldtypes.Bytes32   `gorm:"column:tx_hash"`
+	RevertData      pldtypes.HexBytes  `gorm:"column:revert_data"`
+}
+
+func (DBPublicTxnPendingCompletion) TableName() string {
+	return "public_pending_completions"
+}
+
+// A submission confirmed on chain, with the binding of its public transaction if it has one
+type confirmedSubmission struct {
+	PublicTxnID               uint64                                 `gorm:"column:pub_txn_id"`
+	TransactionHash           pldtypes.Bytes32                       `gorm:"column:tx_hash"`
+	Transaction               *uuid.UUID                             `gorm:"column:transaction"`
+	TransactionType           *pldtypes.Enum[pldapi.TransactionType] `gorm:"column:tx_type"`
+	RequiredConfirmationDepth uint                                   `gorm:"column:required_confirmation_depth"`
 }
 
-type bindingsMatchingSubmission struct {
+// A pending confirmation that has reached its required depth, with the details of the indexed transaction
+type finalizedSubmission struct {
+	PublicTxnID      uint64                                     `gorm:"column:pub_txn_id"`
+	TransactionHash  pldtypes.Bytes32                           `gorm:"column:tx_hash"`
+	RevertData       pldtypes.HexBytes                          `gorm:"column:revert_data"`
+	Transaction      *uuid.UUID                                 `gorm:"column:transaction"`
+	TransactionType  *pldtypes.Enum[pldapi.TransactionType]     `gorm:"column:tx_type"`
+	BlockNumber      int64                                      `gorm:"column:block_number"`
+	TransactionIndex int64                                      `gorm:"column:transaction_index"`
+	From             pldtypes.EthAddress                        `gorm:"column:from"`
+	To               *pldtypes.EthAddress                       `gorm:"column:to"`
+	Nonce            uint64                                     `gorm:"column:nonce"`
+	ContractAddress  *pldtypes.EthAddress                       `gorm:"column:contract_address"`
+	Result           pldtypes.Enum[pldapi.EthTransactionResult] `gorm:"column:result"`
+}
+
+type reorgedCompletion struct {
 	DBPublicTxnBinding `gorm:"embedded"`
-	Submission         *DBPubTxnSubmission `gorm:"foreignKey:pub_txn_id;references:pub_txn_id;"`
+	TransactionHash    pldtypes.Bytes32    `gorm:"column:tx_hash"`
+	From               pldtypes.EthAddress `gorm:"column:from"`
+	Nonce              uint64              `gorm:"column:nonce"`
+	BlockNumber        int64               `gorm:"column:block_number"`
 }
 
 type txFromOnly struct {
 	From pldtypes.EthAddress
 }
+
+// signer_suspensions
+type DBSignerSuspension struct {
+	Signer  pldtypes.EthAddress `gorm:"column:signer;primaryKey"`
+	Created pldtypes.Timestamp  `gorm:"column:created;autoCreateTime:nano"`
+}
+
+func (DBSignerSuspension) TableName() string {
+	return "signer_suspensions"
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+func (ptm *pubTxManager) initRPC() {
+	ptm.rpcModule = rpcserver.NewRPCModule("publictx").
+		Add("publictx_listPending", ptm.rpcListPending()).
+		Add("publictx_overrideGasLimit", ptm.rpcOverrideGasLimit()).
+		Add("publictx_submitBatch", ptm.rpcSubmitBatch())
+}
+
+func (ptm *pubTxManager) rpcListPending() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod3(func(ctx context.Context,
+		address pldtypes.EthAddress,
+		limit int,
+		offset int,
+	) ([]*pldapi.PublicTx, error) {
+		return ptm.ListPendingTransactions(ctx, address, limit, offset)
+	})
+}
+
+func (ptm *pubTxManager) rpcOverrideGasLimit() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod3(func(ctx context.Context,
+		from pldtypes.EthAddress,
+		nonce pldtypes.HexUint64,
+		gas pldtypes.HexUint64,
+	) (bool, error) {
+		return true, ptm.OverrideGasLimit(ctx, from, nonce.Uint64(), gas.Uint64())
+	})
+}
+
+func (ptm *pubTxManager) rpcSubmitBatch() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		txs []*pldapi.PublicTxInput,
+	) ([]*pldapi.PublicTx, error) {
+		return ptm.SubmitBatch(ctx, txs)
+	})
+}
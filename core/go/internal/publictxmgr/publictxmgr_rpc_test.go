@@ -0,0 +1,205 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPendingTransactions(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	signer := *pldtypes.RandAddress()
+	otherSigner := *pldtypes.RandAddress()
+	ptxs := []*DBPublicTxn{
+		{From: signer, Nonce: confutil.P(uint64(2))},
+		{From: signer, Nonce: confutil.P(uint64(0))}, // will be completed
+		{From: signer}, // no nonce yet
+		{From: signer, Nonce: confutil.P(uint64(1))},
+		{From: otherSigner, Nonce: confutil.P(uint64(1))},
+	}
+	err := ptm.p.DB().Table("public_txns").Create(ptxs).Error
+	require.NoError(t, err)
+
+	// Two submissions for nonce 1, we should only get the latest
+	oldHash, latestHash := pldtypes.RandBytes32(), pldtypes.RandBytes32()
+	err = ptm.p.DB().Table("public_submissions").Create([]*DBPubTxnSubmission{
+		{PublicTxnID: ptxs[3].PublicTxnID, TransactionHash: oldHash, Created: pldtypes.Timestamp(1000)},
+		{PublicTxnID: ptxs[3].PublicTxnID, TransactionHash: latestHash, Created: pldtypes.Timestamp(2000)},
+	}).Error
+	require.NoError(t, err)
+	err = ptm.p.DB().Table("public_completions").Create(&DBPublicTxnCompletion{
+		PublicTxnID: ptxs[1].PublicTxnID, TransactionHash: pldtypes.RandBytes32(), Success: true,
+	}).Error
+	require.NoError(t, err)
+
+	pending, err := ptm.ListPendingTransactions(ctx, signer, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	assert.Equal(t, uint64(1), pending[0].Nonce.Uint64())
+	require.Len(t, pending[0].Submissions, 1)
+	assert.Equal(t, latestHash, pending[0].Submissions[0].TransactionHash)
+	assert.Equal(t, uint64(2), pending[1].Nonce.Uint64())
+	assert.Empty(t, pending[1].Submissions)
+	assert.Nil(t, pending[2].Nonce)
+
+	// Pagination via the JSON/RPC API
+	_, err = ptm.PreInit(nil)
+	require.NoError(t, err)
+	rpc, rpcDone := newTestRPCServer(t, ctx, ptm)
+	defer rpcDone()
+	var page []*pldapi.PublicTx
+	rpcErr := rpc.CallRPC(ctx, &page, "publictx_listPending", signer, 1, 1)
+	require.NoError(t, rpcErr)
+	require.Len(t, page, 1)
+	assert.Equal(t, uint64(2), page[0].Nonce.Uint64())
+}
+
+func newTestRPCServer(t *testing.T, ctx context.Context, ptm *pubTxManager) (rpcclient.Client, func()) {
+
+	s, err := rpcserver.NewRPCServer(ctx, &pldconf.RPCServerConfig{
+		HTTP: pldconf.RPCServerConfigHTTP{
+			HTTPServerConfig: pldconf.HTTPServerConfig{Address: confutil.P("127.0.0.1"), Port: confutil.P(0)},
+		},
+		WS: pldconf.RPCServerConfigWS{Disabled: true},
+	})
+	require.NoError(t, err)
+	err = s.Start()
+	require.NoError(t, err)
+
+	s.Register(ptm.rpcModule)
+
+	c := rpcclient.WrapRestyClient(resty.New().SetBaseURL(fmt.Sprintf("http://%s", s.HTTPAddr())))
+
+	return c, s.Stop
+
+}
+
+func TestListPendingTransactionsErrors(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	_, err := ptm.ListPendingTransactions(ctx, *pldtypes.RandAddress(), 0, 0)
+	assert.Regexp(t, "PD011941", err)
+
+	_, err = ptm.ListPendingTransactions(ctx, *pldtypes.RandAddress(), 10, -1)
+	assert.Regexp(t, "PD011942", err)
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	_, err = ptm.ListPendingTransactions(ctx, *pldtypes.RandAddress(), 10, 0)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestSubmitBatch(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	_, err := ptm.PreInit(nil)
+	require.NoError(t, err)
+	rpc, rpcDone := newTestRPCServer(t, ctx, ptm)
+	defer rpcDone()
+
+	signer := pldtypes.RandAddress()
+	batch := make([]*pldapi.PublicTxInput, 10)
+	for i := range batch {
+		batch[i] = &pldapi.PublicTxInput{
+			From: signer,
+			To:   pldtypes.RandAddress(),
+			Data: []byte(fmt.Sprintf("data %d", i)),
+			PublicTxOptions: pldapi.PublicTxOptions{
+				Gas: confutil.P(pldtypes.HexUint64(21000)),
+			},
+		}
+	}
+	var submitted []*pldapi.PublicTx
+	rpcErr := rpc.CallRPC(ctx, &submitted, "publictx_submitBatch", []*pldapi.PublicTxInput{batch[0], nil})
+	assert.Regexp(t, "PD011946", rpcErr)
+
+	rpcErr = rpc.CallRPC(ctx, &submitted, "publictx_submitBatch", batch)
+	require.NoError(t, rpcErr)
+	require.Len(t, submitted, len(batch))
+	for i, tx := range submitted {
+		assert.Equal(t, batch[i].Data, tx.Data)
+		if i > 0 {
+			assert.Greater(t, *tx.LocalID, *submitted[i-1].LocalID)
+		}
+	}
+
+	// Have the orchestrator allocate the nonces, as it would on its next poll
+	o := NewOrchestrator(ptm, *signer, ptm.conf, ptm.orchestratorMetrics)
+	o.nextNonce = confutil.P(uint64(100))
+	o.lastNonceAlloc = time.Now()
+	var txns []*DBPublicTxn
+	err = ptm.p.DB().Table("public_txns").Where(`"from" = ?`, signer).Order("pub_txn_id").Find(&txns).Error
+	require.NoError(t, err)
+	err = o.allocateNonces(ctx, txns)
+	require.NoError(t, err)
+
+	pending, err := ptm.ListPendingTransactions(ctx, *signer, 100, 0)
+	require.NoError(t, err)
+	require.Len(t, pending, len(batch))
+	for i, tx := range pending {
+		assert.Equal(t, uint64(100+i), tx.Nonce.Uint64())
+		assert.Equal(t, batch[i].Data, tx.Data)
+	}
+}
+
+func TestSubmitBatchErrors(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+		conf.Manager.MaxSubmitBatchSize = confutil.P(2)
+	})
+	defer done()
+
+	signer := pldtypes.RandAddress()
+	tx := &pldapi.PublicTxInput{
+		From:            signer,
+		PublicTxOptions: pldapi.PublicTxOptions{Gas: confutil.P(pldtypes.HexUint64(21000))},
+	}
+	_, err := ptm.SubmitBatch(ctx, []*pldapi.PublicTxInput{tx, tx, tx})
+	assert.Regexp(t, "PD011945", err)
+
+	// A single invalid transaction fails the whole batch
+	_, err = ptm.SubmitBatch(ctx, []*pldapi.PublicTxInput{tx, {}})
+	assert.Regexp(t, "PD011936", err)
+
+	_, err = ptm.SubmitBatch(ctx, []*pldapi.PublicTxInput{tx, nil})
+	assert.Regexp(t, "PD011946", err)
+
+	pending, err := ptm.ListPendingTransactions(ctx, *signer, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"gorm.io/gorm/clause"
+)
+
+// SuspendSigner persists a suspension for the signing address. The DB record is the source of truth,
+// and is checked in the polling queries of both the engine and the orchestrator - so no new
+// transactions are picked up, but those already in-flight continue to be tracked to completion.
+func (ptm *pubTxManager) SuspendSigner(ctx context.Context, address pldtypes.EthAddress) error {
+	log.L(ctx).Infof("Suspending signer %s", address)
+	return ptm.p.DB().
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "signer"}},
+			DoNothing: true, // suspending an already suspended signer is a no-op
+		}).
+		Create(&DBSignerSuspension{Signer: address}).
+		Error
+}
+
+func (ptm *pubTxManager) ResumeSigner(ctx context.Context, address pldtypes.EthAddress) error {
+	log.L(ctx).Infof("Resuming signer %s", address)
+	err := ptm.p.DB().
+		WithContext(ctx).
+		Where(`"signer" = ?`, address).
+		Delete(&DBSignerSuspension{}).
+		Error
+	if err != nil {
+		return err
+	}
+
+	// Wake up any existing orchestrator, and the engine to create one if needed
+	ptm.inFlightOrchestratorMux.Lock()
+	oc := ptm.inFlightOrchestrators[address]
+	ptm.inFlightOrchestratorMux.Unlock()
+	if oc != nil {
+		oc.MarkInFlightTxStale()
+	}
+	ptm.MarkInFlightOrchestratorsStale()
+	return nil
+}
+
+func (ptm *pubTxManager) GetSignerStatus(ctx context.Context, address pldtypes.EthAddress) (pldapi.SignerStatus, error) {
+	var suspensions []*DBSignerSuspension
+	err := ptm.p.DB().
+		WithContext(ctx).
+		Where(`"signer" = ?`, address).
+		Limit(1).
+		Find(&suspensions).
+		Error
+	if err != nil {
+		return "", err
+	}
+	if len(suspensions) == 0 {
+		return pldapi.SignerStatusActive, nil
+	}
+
+	// Suspended signers are "draining" until the in-flight transactions have completed
+	inFlight := 0
+	ptm.inFlightOrchestratorMux.Lock()
+	oc := ptm.inFlightOrchestrators[address]
+	ptm.inFlightOrchestratorMux.Unlock()
+	if oc != nil {
+		oc.inFlightTxsMux.Lock()
+		inFlight = len(oc.inFlightTxs)
+		oc.inFlightTxsMux.Unlock()
+	}
+	if inFlight > 0 {
+		return pldapi.SignerStatusDraining, nil
+	}
+	return pldapi.SignerStatusSuspended, nil
+}
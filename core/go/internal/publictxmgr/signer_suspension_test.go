@@ -0,0 +1,89 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package publictxmgr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuspendResumeSigner(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	signer := *pldtypes.RandAddress()
+
+	status, err := ptm.GetSignerStatus(ctx, signer)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SignerStatusActive, status)
+
+	err = ptm.SuspendSigner(ctx, signer)
+	require.NoError(t, err)
+	err = ptm.SuspendSigner(ctx, signer) // idempotent
+	require.NoError(t, err)
+
+	status, err = ptm.GetSignerStatus(ctx, signer)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SignerStatusSuspended, status)
+
+	// While there are transactions in-flight, we are draining
+	oc := &orchestrator{
+		signingAddress:   signer,
+		inFlightTxs:      []*inFlightTransactionStageController{{}},
+		InFlightTxsStale: make(chan bool, 1),
+		metrics:          ptm.orchestratorMetrics,
+	}
+	ptm.inFlightOrchestrators = map[pldtypes.EthAddress]*orchestrator{signer: oc}
+	status, err = ptm.GetSignerStatus(ctx, signer)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SignerStatusDraining, status)
+
+	err = ptm.ResumeSigner(ctx, signer)
+	require.NoError(t, err)
+	assert.Len(t, oc.InFlightTxsStale, 1)
+	assert.Len(t, ptm.inFlightOrchestratorStale, 1)
+
+	status, err = ptm.GetSignerStatus(ctx, signer)
+	require.NoError(t, err)
+	assert.Equal(t, pldapi.SignerStatusActive, status)
+}
+
+func TestSuspendResumeSignerDBErrors(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	m.db.ExpectExec("INSERT.*signer_suspensions").WillReturnError(fmt.Errorf("pop"))
+	err := ptm.SuspendSigner(ctx, *pldtypes.RandAddress())
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectExec("DELETE.*signer_suspensions").WillReturnError(fmt.Errorf("pop"))
+	err = ptm.ResumeSigner(ctx, *pldtypes.RandAddress())
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*signer_suspensions").WillReturnError(fmt.Errorf("pop"))
+	_, err = ptm.GetSignerStatus(ctx, *pldtypes.RandAddress())
+	assert.Regexp(t, "pop", err)
+}
@@ -28,8 +28,22 @@ import (
 
 type noResult struct{}
 
+// submissionWrite is the persisted state of a transaction that must be written before it proceeds - a new
+// submission that must be recorded before it is sent to the blockchain, and/or the latest error message
+type submissionWrite struct {
+	from         string // just used to ensure we dispatch to same writer as the associated public TX
+	pubTxnID     uint64
+	submission   *DBPubTxnSubmission
+	errorMessage *string
+}
+
+func (w *submissionWrite) WriteKey() string {
+	// Just use the from address as the write key, so all submissions on the same signing address get batched together
+	return w.from
+}
+
 type submissionWriter struct {
-	flushwriter.Writer[*DBPubTxnSubmission, *noResult]
+	flushwriter.Writer[*submissionWrite, *noResult]
 }
 
 func newSubmissionWriter(bgCtx context.Context, p persistence.Persistence, conf *pldconf.PublicTxManagerConfig) *submissionWriter {
@@ -38,18 +52,39 @@ func newSubmissionWriter(bgCtx context.Context, p persistence.Persistence, conf
 	return sw
 }
 
-func (sw *submissionWriter) runBatch(ctx context.Context, tx persistence.DBTX, values []*DBPubTxnSubmission) ([]flushwriter.Result[*noResult], error) {
-	err := tx.DB().
-		Table("public_submissions").
-		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "tx_hash"}},
-			DoNothing: true, // immutable
-		}).
-		Create(values).
-		Error
-	if err != nil {
-		return nil, err
+func (sw *submissionWriter) runBatch(ctx context.Context, tx persistence.DBTX, values []*submissionWrite) ([]flushwriter.Result[*noResult], error) {
+	submissions := make([]*DBPubTxnSubmission, 0, len(values))
+	for _, v := range values {
+		if v.submission != nil {
+			submissions = append(submissions, v.submission)
+		}
+	}
+	if len(submissions) > 0 {
+		err := tx.DB().
+			Table("public_submissions").
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "tx_hash"}},
+				DoNothing: true, // immutable
+			}).
+			Create(submissions).
+			Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Errors are infrequent, so we simply update each in turn
+	for _, v := range values {
+		if v.errorMessage != nil {
+			err := tx.DB().
+				Table("public_txns").
+				Where("pub_txn_id = ?", v.pubTxnID).
+				Update("error_message", *v.errorMessage).
+				Error
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	// We don't actually provide any result, so just build an array of nil results
-	return make([]flushwriter.Result[*noResult], len(values)), err
+	return make([]flushwriter.Result[*noResult], len(values)), nil
 }
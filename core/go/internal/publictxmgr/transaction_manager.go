@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"math/big"
+	"regexp"
 	"sync"
 	"time"
 
@@ -40,6 +41,7 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
@@ -85,6 +87,7 @@ type pubTxManager struct {
 	keymgr           components.KeyManager
 	rootTxMgr        components.TXManager
 	ethClientFactory ethclient.EthClientFactory
+	rpcModule        *rpcserver.RPCModule
 	// gas price
 	gasPriceClient   GasPriceClient
 	submissionWriter *submissionWriter
@@ -105,6 +108,7 @@ type pubTxManager struct {
 	retry                    *retry.Retry
 	enginePollingInterval    time.Duration
 	nonceCacheTimeout        time.Duration
+	maxSubmitBatchSize       int
 	engineLoopDone           chan struct{}
 
 	activityRecordCache     cache.Cache[uint64, *txActivityRecords]
@@ -113,12 +117,17 @@ type pubTxManager struct {
 	// balance manager
 	balanceManager BalanceManager
 
+	orchestratorMetrics *OrchestratorMetrics
+
 	// orchestrator config
 	gasPriceIncreaseMax     *big.Int
 	gasPriceIncreasePercent int
+	replacementErrors       []*regexp.Regexp
+	replacementBumpPercent  int
 
 	// gas limit config
 	gasEstimateFactor float64
+	gasEstimateCache  *gasEstimateCache
 
 	// updates
 	updates   []*transactionUpdate
@@ -136,6 +145,10 @@ func NewPublicTransactionManager(ctx context.Context, conf *pldconf.PublicTxMana
 	gasPriceClient := NewGasPriceClient(ctx, conf)
 	gasPriceIncreaseMax := confutil.BigIntOrNil(conf.GasPrice.IncreaseMax)
 	gasEstimateFactor := confutil.Float64Min(conf.GasLimit.GasEstimateFactor, 1.0, *pldconf.PublicTxManagerDefaults.GasLimit.GasEstimateFactor)
+	replacementUnderpricedErrors := conf.GasPrice.ReplacementUnderpricedErrors
+	if replacementUnderpricedErrors == nil {
+		replacementUnderpricedErrors = pldconf.PublicTxManagerDefaults.GasPrice.ReplacementUnderpricedErrors
+	}
 
 	log.L(ctx).Debugf("Enterprise transaction handler created")
 
@@ -154,17 +167,25 @@ func NewPublicTransactionManager(ctx context.Context, conf *pldconf.PublicTxMana
 		orchestratorIdleTimeout:     confutil.DurationMin(conf.Manager.OrchestratorIdleTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.OrchestratorIdleTimeout),
 		enginePollingInterval:       confutil.DurationMin(conf.Manager.Interval, 50*time.Millisecond, *pldconf.PublicTxManagerDefaults.Manager.Interval),
 		nonceCacheTimeout:           confutil.DurationMin(conf.Manager.NonceCacheTimeout, 0, *pldconf.PublicTxManagerDefaults.Manager.NonceCacheTimeout),
+		maxSubmitBatchSize:          confutil.IntMin(conf.Manager.MaxSubmitBatchSize, 1, *pldconf.PublicTxManagerDefaults.Manager.MaxSubmitBatchSize),
 		retry:                       retry.NewRetryIndefinite(&conf.Manager.Retry),
 		gasPriceIncreaseMax:         gasPriceIncreaseMax,
 		gasPriceIncreasePercent:     confutil.Int(conf.GasPrice.IncreasePercentage, *pldconf.PublicTxManagerDefaults.GasPrice.IncreasePercentage),
+		replacementErrors:           compileReplacementUnderpricedErrors(ctx, replacementUnderpricedErrors),
+		replacementBumpPercent:      confutil.IntMin(conf.GasPrice.ReplacementGasBumpPercent, 0, *pldconf.PublicTxManagerDefaults.GasPrice.ReplacementGasBumpPercent),
 		activityRecordCache:         cache.NewCache[uint64, *txActivityRecords](&conf.Manager.ActivityRecords.CacheConfig, &pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.CacheConfig),
 		maxActivityRecordsPerTx:     confutil.Int(conf.Manager.ActivityRecords.RecordsPerTransaction, *pldconf.PublicTxManagerDefaults.Manager.ActivityRecords.RecordsPerTransaction),
 		gasEstimateFactor:           gasEstimateFactor,
+		gasEstimateCache:            newGasEstimateCache(&conf.GasLimit.EstimateCache),
+		orchestratorMetrics:         NewOrchestratorMetrics(ctx),
 	}
 }
 
 func (ptm *pubTxManager) PreInit(pic components.PreInitComponents) (result *components.ManagerInitResult, err error) {
-	return &components.ManagerInitResult{}, nil
+	ptm.initRPC()
+	return &components.ManagerInitResult{
+		RPCModules: []*rpcserver.RPCModule{ptm.rpcModule},
+	}, nil
 }
 
 // Post-init allows the manager to cross-bind to other components, or the Engine
@@ -251,6 +272,32 @@ func (ptm *pubTxManager) SingleTransactionSubmit(ctx context.Context, txi *compo
 	return tx, err
 }
 
+func (ptm *pubTxManager) SubmitBatch(ctx context.Context, txis []*pldapi.PublicTxInput) (txs []*pldapi.PublicTx, err error) {
+	if len(txis) > ptm.maxSubmitBatchSize {
+		return nil, i18n.NewError(ctx, msgs.MsgPublicTxMgrBatchTooLarge, len(txis), ptm.maxSubmitBatchSize)
+	}
+	submissions := make([]*components.PublicTxSubmission, len(txis))
+	for i, txi := range txis {
+		if txi == nil {
+			return nil, i18n.NewError(ctx, msgs.MsgPublicTxMgrBatchNilTransaction, i)
+		}
+		submissions[i] = &components.PublicTxSubmission{PublicTxInput: *txi}
+	}
+	// Validation can involve calls to the blockchain node (gas estimation), so is performed before the DB transaction
+	for _, txi := range submissions {
+		if err := ptm.ValidateTransaction(ctx, ptm.p.NOTX(), txi); err != nil {
+			return nil, err
+		}
+	}
+	// The transactions are inserted together, in order, so the orchestrator for each signer
+	// allocates them consecutive nonces in the order they were supplied
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		txs, err = ptm.WriteNewTransactions(ctx, dbTX, submissions)
+		return err
+	})
+	return txs, err
+}
+
 func (ptm *pubTxManager) ValidateTransaction(ctx context.Context, dbTX persistence.DBTX, txi *components.PublicTxSubmission) error {
 	log.L(ctx).Tracef("PrepareSubmission transaction: %+v", txi)
 
@@ -262,7 +309,7 @@ func (ptm *pubTxManager) ValidateTransaction(ctx context.Context, dbTX persisten
 	var txType InFlightTxOperation
 
 	if txi.Gas == nil || *txi.Gas == 0 {
-		gasEstimateResult, err := ptm.ethClient.EstimateGasNoResolve(ctx, buildEthTX(
+		gasEstimateResult, err := ptm.estimateGas(ctx, buildEthTX(
 			*txi.From,
 			nil, /* nonce not assigned at this point */
 			txi.To,
@@ -306,6 +353,8 @@ func (ptm *pubTxManager) WriteNewTransactions(ctx context.Context, dbTX persiste
 			Value:           txi.Value,
 			Data:            txi.Data,
 			FixedGasPricing: pldtypes.JSONString(txi.PublicTxGasPricing),
+
+			RequiredConfirmationDepth: txi.RequiredConfirmationDepth,
 		}
 	}
 	// All the nonce processing to this point should have ensured we do not have a conflict on nonces.
@@ -480,6 +529,40 @@ func (ptm *pubTxManager) CheckTransactionCompleted(ctx context.Context, pubTxnID
 	return false, nil
 }
 
+// ListPendingTransactions returns the transactions for a signing address that are not yet complete, in nonce
+// order (with those that do not yet have a nonce assigned last). Only the latest submission is included
+// for each transaction, as that is the one most likely to be mined.
+func (ptm *pubTxManager) ListPendingTransactions(ctx context.Context, address pldtypes.EthAddress, limit, offset int) ([]*pldapi.PublicTx, error) {
+	if limit <= 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPublicTxMgrInvalidLimit, limit)
+	}
+	if offset < 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgPublicTxMgrInvalidOffset, offset)
+	}
+	q := ptm.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Joins("Completed").
+		Where(`"Completed"."tx_hash" IS NULL`).
+		Where(`"from" = ?`, address).
+		Order(`"public_txns"."nonce" IS NULL, "public_txns"."nonce", "public_txns"."pub_txn_id"`).
+		Limit(limit).
+		Offset(offset)
+	ptxs, err := ptm.runTransactionQuery(ctx, ptm.p.NOTX(), false, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]*pldapi.PublicTx, len(ptxs))
+	for i, ptx := range ptxs {
+		pending[i] = mapPersistedTransaction(ptx)
+		if len(ptx.Submissions) > 0 {
+			// submissions are returned most recent first
+			pending[i].Submissions = []*pldapi.PublicTxSubmissionData{mapPersistedSubmissionData(ptx.Submissions[0])}
+		}
+	}
+	return pending, nil
+}
+
 func (ptm *pubTxManager) runTransactionQuery(ctx context.Context, dbTX persistence.DBTX, bindings bool, scopeToTxns []uuid.UUID, q *gorm.DB) (ptxs []*DBPublicTxn, err error) {
 	if bindings {
 		// We'll get one row per binding
@@ -604,7 +687,7 @@ func (ptm *pubTxManager) UpdateTransaction(ctx context.Context, id uuid.UUID, pu
 
 	if tx.Gas == nil || *tx.Gas == 0 {
 		ethTx := buildEthTX(*from, nil, tx.To, publicTxData, &tx.PublicTxOptions)
-		gasEstimateResult, err := ptm.ethClient.EstimateGasNoResolve(ctx, ethTx)
+		gasEstimateResult, err := ptm.estimateGas(ctx, ethTx)
 		if err != nil {
 			log.L(ctx).Errorf("EstimateGas error estimating gas for transaction: %+v, request: (%+v)", err, ethTx)
 			if ethclient.MapSubmissionRejected(err) {
@@ -651,6 +734,65 @@ func (ptm *pubTxManager) UpdateTransaction(ctx context.Context, id uuid.UUID, pu
 	return err
 }
 
+// OverrideGasLimit replaces the gas limit of a pending transaction, for cases where the original estimate
+// turns out to be insufficient. The in-flight transaction picks up the new limit and is re-signed and
+// resubmitted on the next orchestrator cycle. Setting the limit the transaction already has is a no-op.
+func (ptm *pubTxManager) OverrideGasLimit(ctx context.Context, from pldtypes.EthAddress, nonce uint64, gas uint64) error {
+	if gas == 0 {
+		return i18n.NewError(ctx, msgs.MsgPublicTxMgrInvalidGasLimit, gas)
+	}
+
+	ptxs := []*DBPublicTxn{}
+	err := ptm.p.DB().
+		WithContext(ctx).
+		Table("public_txns").
+		Joins("Completed").
+		Where(`"Completed"."tx_hash" IS NULL`).
+		Where(`"from" = ?`, from).
+		Where(`"public_txns"."nonce" = ?`, nonce).
+		Limit(1).
+		Find(&ptxs).
+		Error
+	if err != nil {
+		return err
+	}
+	if len(ptxs) == 0 {
+		return i18n.NewError(ctx, msgs.MsgPublicTxMgrPendingTxNotFound, from, nonce)
+	}
+	ptx := ptxs[0]
+	if ptx.Gas == gas {
+		log.L(ctx).Infof("Gas limit override for transaction %s:%d unchanged at %d", from, nonce, gas)
+		return nil
+	}
+
+	newPtx := &DBPublicTxn{
+		From:            ptx.From,
+		To:              ptx.To,
+		Gas:             gas,
+		Value:           ptx.Value,
+		Data:            ptx.Data,
+		FixedGasPricing: ptx.FixedGasPricing,
+	}
+
+	ptm.updateMux.Lock()
+	defer ptm.updateMux.Unlock()
+
+	err = ptm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return ptm.writeUpdatedTransaction(ctx, dbTX, ptx.PublicTxnID, from, newPtx)
+	})
+	if err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Gas limit override for transaction %s:%d from %d to %d", from, nonce, ptx.Gas, gas)
+
+	ptm.dispatchUpdate(&transactionUpdate{
+		pubTXID: ptx.PublicTxnID,
+		from:    &from,
+		newPtx:  newPtx,
+	})
+	return nil
+}
+
 func (ptm *pubTxManager) UpdateSubStatus(ctx context.Context, imtx InMemoryTxStateReadOnly, subStatus BaseTxSubStatus, action BaseTxAction, info pldtypes.RawJSON, err pldtypes.RawJSON, actionOccurred *pldtypes.Timestamp) error {
 	// TODO: Choose after testing the right way to treat these records - if text is right or not
 	if err == nil {
@@ -750,13 +892,16 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 	for i, itx := range itxs {
 		txHashes[i] = itx.Hash
 	}
-	var lookups []*bindingsMatchingSubmission
+	// Submissions without a binding are our own gap-filling transactions, which are completed
+	// but not returned as matches
+	var lookups []*confirmedSubmission
 	err := dbTX.DB().
-		Table("public_txn_bindings").
-		Select(`"transaction"`, `"tx_type"`, `"Submission"."pub_txn_id"`, `"Submission"."tx_hash"`).
-		Joins("Submission").
-		Where(`"Submission"."tx_hash" IN (?)`, txHashes).
-		Find(&lookups).
+		Table(`"public_submissions" AS s`).
+		Select(`s."pub_txn_id"`, `s."tx_hash"`, `b."transaction"`, `b."tx_type"`, `t."required_confirmation_depth"`).
+		Joins(`JOIN "public_txns" AS t ON t."pub_txn_id" = s."pub_txn_id"`).
+		Joins(`LEFT JOIN "public_txn_bindings" AS b ON b."pub_txn_id" = s."pub_txn_id"`).
+		Where(`s."tx_hash" IN (?)`, txHashes).
+		Scan(&lookups).
 		Error
 	if err != nil {
 		return nil, err
@@ -766,17 +911,40 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 	// the results in the original order
 	results := make([]*components.PublicTxMatch, 0, len(lookups))
 	completions := make([]*DBPublicTxnCompletion, 0, len(lookups))
+	var pending []*DBPublicTxnPendingCompletion
+	var unbound []*blockindexer.IndexedTransactionNotify
 	for _, txi := range itxs {
 		for _, match := range lookups {
-			if txi.Hash.Equals(&match.Submission.TransactionHash) {
-				// matched results in the order of the inputs
-				results = append(results, &components.PublicTxMatch{
-					PaladinTXReference: components.PaladinTXReference{
-						TransactionID:   match.Transaction,
-						TransactionType: match.TransactionType,
-					},
-					IndexedTransactionNotify: txi,
-				})
+			if txi.Hash.Equals(&match.TransactionHash) {
+				if match.RequiredConfirmationDepth > 0 {
+					// Nothing is completed until the chain is deep enough on top of this block, which
+					// MatchFinalizedTransactions checks as each new block is indexed
+					log.L(ctx).Infof("Public transaction %s:%d (pubTxnID=%d) confirmed in block %d, waiting for %d confirmations",
+						txi.From, txi.Nonce, match.PublicTxnID, txi.BlockNumber, match.RequiredConfirmationDepth)
+					pending = append(pending, &DBPublicTxnPendingCompletion{
+						PublicTxnID:     match.PublicTxnID,
+						TransactionHash: txi.Hash,
+						RevertData:      txi.RevertReason,
+					})
+					break
+				}
+				if match.Transaction != nil {
+					// matched results in the order of the inputs
+					results = append(results, &components.PublicTxMatch{
+						PaladinTXReference: components.PaladinTXReference{
+							TransactionID:   *match.Transaction,
+							TransactionType: *match.TransactionType,
+						},
+						IndexedTransactionNotify: txi,
+					})
+				} else {
+					unbound = append(unbound, txi)
+				}
+				// A failure with no revert data might have run out of gas. We don't have the call data to hand
+				// to identify the cached estimate it used, so we clear them all as this is rare.
+				if txi.Result.V() != pldapi.TXResult_SUCCESS && len(txi.RevertReason) == 0 {
+					ptm.gasEstimateCache.cache.Clear()
+				}
 				// completions to insert, in the order of the inputs
 				completions = append(completions, &DBPublicTxnCompletion{
 					PublicTxnID:     match.PublicTxnID,
@@ -789,9 +957,106 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 		}
 	}
 
+	if len(pending) > 0 {
+		err := dbTX.DB().
+			Table("public_pending_completions").
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "pub_txn_id"}},
+				DoNothing: true,
+			}).
+			Create(pending).
+			Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ptm.writeCompletions(ctx, dbTX, completions, unbound); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Called by the block indexer (via the TX manager) as each batch of blocks is indexed, with the highest block
+// in the batch. Completes any transactions with a required confirmation depth, once the chain is deep enough on
+// top of the block they were confirmed in. Matches are returned in the order the transactions were confirmed.
+func (ptm *pubTxManager) MatchFinalizedTransactions(ctx context.Context, dbTX persistence.DBTX, headBlock int64) ([]*components.PublicTxMatch, error) {
+	var finalized []*finalizedSubmission
+	err := dbTX.DB().
+		WithContext(ctx).
+		Raw(`SELECT p."pub_txn_id", p."tx_hash", p."revert_data", b."transaction", b."tx_type", `+
+			`i."block_number", i."transaction_index", i."from", i."to", i."nonce", i."contract_address", i."result" `+
+			`FROM "public_pending_completions" AS p `+
+			`JOIN "public_txns" AS t ON t."pub_txn_id" = p."pub_txn_id" `+
+			`JOIN "indexed_transactions" AS i ON i."hash" = p."tx_hash" `+
+			`LEFT JOIN "public_txn_bindings" AS b ON b."pub_txn_id" = p."pub_txn_id" `+
+			`WHERE i."block_number" + t."required_confirmation_depth" < ? `+
+			`ORDER BY i."block_number", i."transaction_index"`, headBlock).
+		Scan(&finalized).
+		Error
+	if err != nil || len(finalized) == 0 {
+		return nil, err
+	}
+
+	results := make([]*components.PublicTxMatch, 0, len(finalized))
+	completions := make([]*DBPublicTxnCompletion, len(finalized))
+	pubTxnIDs := make([]uint64, len(finalized))
+	var unbound []*blockindexer.IndexedTransactionNotify
+	for i, f := range finalized {
+		log.L(ctx).Infof("Public transaction %s:%d (pubTxnID=%d) confirmed in block %d is final at block %d", f.From, f.Nonce, f.PublicTxnID, f.BlockNumber, headBlock)
+		txi := &blockindexer.IndexedTransactionNotify{
+			IndexedTransaction: pldapi.IndexedTransaction{
+				Hash:             f.TransactionHash,
+				BlockNumber:      f.BlockNumber,
+				TransactionIndex: f.TransactionIndex,
+				From:             &f.From,
+				To:               f.To,
+				Nonce:            f.Nonce,
+				ContractAddress:  f.ContractAddress,
+				Result:           f.Result,
+			},
+			RevertReason: f.RevertData,
+		}
+		if f.Transaction != nil {
+			results = append(results, &components.PublicTxMatch{
+				PaladinTXReference: components.PaladinTXReference{
+					TransactionID:   *f.Transaction,
+					TransactionType: *f.TransactionType,
+				},
+				IndexedTransactionNotify: txi,
+			})
+		} else {
+			unbound = append(unbound, txi)
+		}
+		pubTxnIDs[i] = f.PublicTxnID
+		completions[i] = &DBPublicTxnCompletion{
+			PublicTxnID:     f.PublicTxnID,
+			TransactionHash: f.TransactionHash,
+			Success:         f.Result.V() == pldapi.TXResult_SUCCESS,
+			RevertData:      f.RevertData,
+		}
+	}
+
+	err = dbTX.DB().
+		WithContext(ctx).
+		Table("public_pending_completions").
+		Where("pub_txn_id IN (?)", pubTxnIDs).
+		Delete(nil).
+		Error
+	if err == nil {
+		err = ptm.writeCompletions(ctx, dbTX, completions, unbound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (ptm *pubTxManager) writeCompletions(ctx context.Context, dbTX persistence.DBTX, completions []*DBPublicTxnCompletion, unbound []*blockindexer.IndexedTransactionNotify) error {
 	if len(completions) > 0 {
 		// We have some completions to persis - in the same order as the confirmations that came in
 		err := dbTX.DB().
+			WithContext(ctx).
 			Table("public_completions").
 			Clauses(clause.OnConflict{
 				Columns:   []clause.Column{{Name: "pub_txn_id"}},
@@ -800,11 +1065,19 @@ func (ptm *pubTxManager) MatchUpdateConfirmedTransactions(ctx context.Context, d
 			Create(completions).
 			Error
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return results, nil
+	if len(unbound) > 0 {
+		// Nobody else is interested in these, so we notify our orchestrators directly
+		dbTX.AddPostCommit(func(ctx context.Context) {
+			for _, txi := range unbound {
+				_ = ptm.dispatchAction(ctx, *txi.From, txi.Nonce, ActionCompleted)
+			}
+		})
+	}
+	return nil
 }
 
 // We've got to be super careful not to block this thread, so we treat this just like a suspend/resume
@@ -814,3 +1087,82 @@ func (ptm *pubTxManager) NotifyConfirmPersisted(ctx context.Context, confirms []
 		_ = ptm.dispatchAction(ctx, *conf.From, conf.Nonce, ActionCompleted)
 	}
 }
+
+// Called by the block indexer (via the TX manager) before it removes blocks orphaned by a reorg from its index.
+// We remove the completions for any of our transactions that were confirmed in those blocks, which returns them to
+// pending with their existing nonce so they are tracked (and resubmitted if required) until confirmed again.
+func (ptm *pubTxManager) MatchRevertReorgedTransactions(ctx context.Context, dbTX persistence.DBTX, fromBlock int64) ([]*components.PublicTxMatch, error) {
+	// Confirmations still waiting for their required depth in those blocks are simply discarded
+	err := dbTX.DB().
+		WithContext(ctx).
+		Table("public_pending_completions").
+		Where(`"tx_hash" IN (?)`, dbTX.DB().
+			Table("indexed_transactions").
+			Select("hash").
+			Where("block_number >= ?", fromBlock)).
+		Delete(nil).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var reorged []*reorgedCompletion
+	err = dbTX.DB().
+		WithContext(ctx).
+		Raw(`SELECT c."pub_txn_id", c."tx_hash", b."transaction", b."tx_type", i."from", i."nonce", i."block_number" `+
+			`FROM "public_completions" AS c `+
+			`JOIN "public_txn_bindings" AS b ON b."pub_txn_id" = c."pub_txn_id" `+
+			`JOIN "indexed_transactions" AS i ON i."hash" = c."tx_hash" `+
+			`WHERE i."block_number" >= ?`, fromBlock).
+		Scan(&reorged).
+		Error
+	if err != nil || len(reorged) == 0 {
+		return nil, err
+	}
+
+	results := make([]*components.PublicTxMatch, len(reorged))
+	pubTxnIDs := make([]uint64, len(reorged))
+	for i, r := range reorged {
+		log.L(ctx).Infof("Public transaction %s:%d (pubTxnID=%d) confirmed in block %d reverted to pending by reorg", r.From, r.Nonce, r.PublicTxnID, r.BlockNumber)
+		pubTxnIDs[i] = r.PublicTxnID
+		results[i] = &components.PublicTxMatch{
+			PaladinTXReference: components.PaladinTXReference{
+				TransactionID:   r.Transaction,
+				TransactionType: r.TransactionType,
+			},
+			IndexedTransactionNotify: &blockindexer.IndexedTransactionNotify{
+				IndexedTransaction: pldapi.IndexedTransaction{
+					Hash:        r.TransactionHash,
+					BlockNumber: r.BlockNumber,
+					From:        &r.From,
+					Nonce:       r.Nonce,
+				},
+			},
+		}
+	}
+	err = dbTX.DB().
+		WithContext(ctx).
+		Table("public_completions").
+		Where("pub_txn_id IN (?)", pubTxnIDs).
+		Delete(nil).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Orchestrators only poll for transactions with a nonce after those they have in flight, so we stop any
+// orchestrator for an affected signing address. It is replaced on the next poll, and reloads all pending
+// transactions for the address - including those returned to pending by the reorg.
+func (ptm *pubTxManager) NotifyReorgPersisted(ctx context.Context, reorged []*components.PublicTxMatch) {
+	ptm.inFlightOrchestratorMux.Lock()
+	defer ptm.inFlightOrchestratorMux.Unlock()
+	for _, r := range reorged {
+		if oc, orchestratorInFlight := ptm.inFlightOrchestrators[*r.From]; orchestratorInFlight {
+			log.L(ctx).Infof("Stopping orchestrator for signing address %s after reorg", r.From)
+			oc.Stop()
+		}
+	}
+	ptm.MarkInFlightOrchestratorsStale()
+}
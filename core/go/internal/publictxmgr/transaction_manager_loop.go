@@ -138,7 +138,8 @@ func (ptm *pubTxManager) poll(ctx context.Context) (polled int, total int) {
 			// (raw SQL as couldn't convince gORM to build this)
 			const dbQueryBase = `SELECT DISTINCT t."from" FROM "public_txns" AS t ` +
 				`LEFT JOIN "public_completions" AS c ON t."pub_txn_id" = c."pub_txn_id" ` +
-				`WHERE c."pub_txn_id" IS NULL AND "suspended" IS FALSE`
+				`WHERE c."pub_txn_id" IS NULL AND "suspended" IS FALSE ` +
+				`AND t."from" NOT IN (SELECT "signer" FROM "signer_suspensions")`
 
 			const dbQueryNothingInFlight = dbQueryBase + ` LIMIT ?`
 			if len(inFlightSigningAddresses) == 0 {
@@ -161,7 +162,7 @@ func (ptm *pubTxManager) poll(ctx context.Context) (polled int, total int) {
 
 		for _, r := range additionalNonInFlightSigners {
 			if _, exist := ptm.inFlightOrchestrators[r.From]; !exist {
-				oc := NewOrchestrator(ptm, r.From, ptm.conf)
+				oc := NewOrchestrator(ptm, r.From, ptm.conf, ptm.orchestratorMetrics)
 				ptm.inFlightOrchestrators[r.From] = oc
 				stateCounts[string(oc.state)] = stateCounts[string(oc.state)] + 1
 				_, _ = oc.Start(ptm.ctx)
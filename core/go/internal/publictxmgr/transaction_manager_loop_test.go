@@ -56,6 +56,7 @@ func TestNewEnginePollingStoppingAnOrchestratorForFairnessControl(t *testing.T)
 		stateEntryTime:              time.Now().Add(1 * time.Hour).Add(-1 * time.Minute),
 		InFlightTxsStale:            make(chan bool, 1),
 		stopProcess:                 make(chan bool, 1),
+		metrics:                     ble.orchestratorMetrics,
 	}
 	ble.inFlightOrchestrators = map[pldtypes.EthAddress]*orchestrator{
 		*testSigningAddr1: existingOrchestrator, // already has an orchestrator for 0x1
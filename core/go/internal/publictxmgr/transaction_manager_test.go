@@ -386,6 +386,198 @@ func TestTransactionLifecycleRealKeyMgrAndDB(t *testing.T) {
 	}
 	ticker.Stop()
 
+	// Simulate a reorg of the block the transactions were confirmed in, after the block indexer
+	// has written the block (which it does in the same DB TX as the confirmations)
+	err = ptm.p.DB().Table("indexed_blocks").Create(&pldapi.IndexedBlock{Number: 11223344, Hash: pldtypes.RandBytes32()}).Error
+	require.NoError(t, err)
+	for i, confirmation := range gatheredConfirmations {
+		indexedTX := confirmation.IndexedTransaction
+		indexedTX.TransactionIndex = int64(i)
+		err = ptm.p.DB().Table("indexed_transactions").Create(&indexedTX).Error
+		require.NoError(t, err)
+	}
+	reorged, err := ptm.MatchRevertReorgedTransactions(ctx, ptm.p.NOTX(), 11223344)
+	require.NoError(t, err)
+	assert.Len(t, reorged, len(txs))
+
+	// They are all back to pending, keeping their nonces
+	byTxn, err = ptm.QueryPublicTxForTransactions(ctx, ptm.p.NOTX(), txIDs,
+		query.NewQueryBuilder().Null("transactionHash").Query())
+	require.NoError(t, err)
+	for _, tx := range txs {
+		queryTxs := byTxn[tx.Bindings[0].TransactionID]
+		require.Len(t, queryTxs, 1)
+		assert.NotNil(t, queryTxs[0].Nonce)
+	}
+	ptm.NotifyReorgPersisted(ctx, reorged)
+
+	// Nothing to do for a reorg of blocks that do not contain our transactions
+	reorged, err = ptm.MatchRevertReorgedTransactions(ctx, ptm.p.NOTX(), 11223344)
+	require.NoError(t, err)
+	assert.Empty(t, reorged)
+
+}
+
+func TestMatchRevertReorgedTransactionsFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectExec("DELETE.*public_pending_completions").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.db.ExpectQuery("SELECT.*public_completions").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ptm.MatchRevertReorgedTransactions(ctx, ptm.p.NOTX(), 100)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMatchRevertReorgedTransactionsPendingDeleteFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectExec("DELETE.*public_pending_completions").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ptm.MatchRevertReorgedTransactions(ctx, ptm.p.NOTX(), 100)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMatchRevertReorgedTransactionsDeleteFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectExec("DELETE.*public_pending_completions").WillReturnResult(sqlmock.NewResult(0, 0))
+	m.db.ExpectQuery("SELECT.*public_completions").WillReturnRows(sqlmock.NewRows([]string{
+		"pub_txn_id", "tx_hash", "transaction", "tx_type", "from", "nonce", "block_number",
+	}).AddRow(12345, pldtypes.RandBytes32(), uuid.New(), pldapi.TransactionTypePublic.Enum(), pldtypes.RandAddress(), 1, 100))
+	m.db.ExpectExec("DELETE.*public_completions").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ptm.MatchRevertReorgedTransactions(ctx, ptm.p.NOTX(), 100)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMatchConfirmedTransactionsWithConfirmationDepth(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true)
+	defer done()
+
+	from := *pldtypes.RandAddress()
+	nonce := uint64(1)
+	txID := uuid.New()
+	txHash := pldtypes.RandBytes32()
+	ptx := &DBPublicTxn{From: from, Nonce: &nonce, To: &from, Gas: 21000, RequiredConfirmationDepth: 5}
+	err := ptm.p.DB().Table("public_txns").Create(ptx).Error
+	require.NoError(t, err)
+	err = ptm.p.DB().Table("public_txn_bindings").Create(&DBPublicTxnBinding{
+		PublicTxnID:     ptx.PublicTxnID,
+		Transaction:     txID,
+		TransactionType: pldapi.TransactionTypePublic.Enum(),
+	}).Error
+	require.NoError(t, err)
+	err = ptm.p.DB().Table("public_submissions").Create(&DBPubTxnSubmission{
+		PublicTxnID:     ptx.PublicTxnID,
+		TransactionHash: txHash,
+		Created:         pldtypes.TimestampNow(),
+	}).Error
+	require.NoError(t, err)
+
+	// The confirmation is recorded, but nothing is completed or matched
+	confirmation := &blockindexer.IndexedTransactionNotify{
+		IndexedTransaction: pldapi.IndexedTransaction{
+			Hash:        txHash,
+			BlockNumber: 100,
+			From:        &from,
+			Nonce:       nonce,
+			Result:      pldapi.TXResult_FAILURE.Enum(),
+		},
+		RevertReason: pldtypes.HexBytes("revert"),
+	}
+	matches, err := ptm.MatchUpdateConfirmedTransactions(ctx, ptm.p.NOTX(), []*blockindexer.IndexedTransactionNotify{confirmation})
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+	completed, err := ptm.CheckTransactionCompleted(ctx, ptx.PublicTxnID)
+	require.NoError(t, err)
+	assert.False(t, completed)
+
+	// The block indexer writes the transaction after its pre-commit handlers
+	err = ptm.p.DB().Table("indexed_blocks").Create(&pldapi.IndexedBlock{Number: 100, Hash: pldtypes.RandBytes32()}).Error
+	require.NoError(t, err)
+	err = ptm.p.DB().Table("indexed_transactions").Create(&confirmation.IndexedTransaction).Error
+	require.NoError(t, err)
+
+	// Not yet deep enough
+	matches, err = ptm.MatchFinalizedTransactions(ctx, ptm.p.NOTX(), 105)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	// A reorg of the block discards the pending confirmation
+	reorged, err := ptm.MatchRevertReorgedTransactions(ctx, ptm.p.NOTX(), 100)
+	require.NoError(t, err)
+	assert.Empty(t, reorged)
+	matches, err = ptm.MatchFinalizedTransactions(ctx, ptm.p.NOTX(), 106)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+
+	// Confirmed again on the new chain, it is completed once the chain is deep enough
+	_, err = ptm.MatchUpdateConfirmedTransactions(ctx, ptm.p.NOTX(), []*blockindexer.IndexedTransactionNotify{confirmation})
+	require.NoError(t, err)
+	matches, err = ptm.MatchFinalizedTransactions(ctx, ptm.p.NOTX(), 106)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, txID, matches[0].TransactionID)
+	assert.Equal(t, txHash, matches[0].Hash)
+	assert.Equal(t, int64(100), matches[0].BlockNumber)
+	assert.Equal(t, from, *matches[0].From)
+	assert.Equal(t, pldapi.TXResult_FAILURE, matches[0].Result.V())
+	assert.Equal(t, pldtypes.HexBytes("revert"), matches[0].RevertReason)
+
+	completed, err = ptm.CheckTransactionCompleted(ctx, ptx.PublicTxnID)
+	require.NoError(t, err)
+	assert.True(t, completed)
+
+	// Only completed once
+	matches, err = ptm.MatchFinalizedTransactions(ctx, ptm.p.NOTX(), 107)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestMatchFinalizedTransactionsFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_pending_completions").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ptm.MatchFinalizedTransactions(ctx, ptm.p.NOTX(), 100)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMatchFinalizedTransactionsDeleteFail(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	m.db.ExpectQuery("SELECT.*public_pending_completions").WillReturnRows(sqlmock.NewRows([]string{
+		"pub_txn_id", "tx_hash", "from", "nonce", "block_number",
+	}).AddRow(12345, pldtypes.RandBytes32(), pldtypes.RandAddress(), 1, 100))
+	m.db.ExpectExec("DELETE.*public_pending_completions").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ptm.MatchFinalizedTransactions(ctx, ptm.p.NOTX(), 100)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestNotifyReorgPersistedStopsOrchestrator(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, false)
+	defer done()
+
+	signer := pldtypes.RandAddress()
+	oc := &orchestrator{stopProcess: make(chan bool, 1), metrics: ptm.orchestratorMetrics}
+	ptm.inFlightOrchestrators = map[pldtypes.EthAddress]*orchestrator{*signer: oc}
+
+	ptm.NotifyReorgPersisted(ctx, []*components.PublicTxMatch{
+		{IndexedTransactionNotify: &blockindexer.IndexedTransactionNotify{
+			IndexedTransaction: pldapi.IndexedTransaction{From: signer, Nonce: 1},
+		}},
+		{IndexedTransactionNotify: &blockindexer.IndexedTransactionNotify{
+			IndexedTransaction: pldapi.IndexedTransaction{From: pldtypes.RandAddress(), Nonce: 1},
+		}},
+	})
+
+	assert.Len(t, oc.stopProcess, 1)
 }
 
 func fakeTxManagerInsert(t *testing.T, db *gorm.DB, txID uuid.UUID, fromStr string) {
@@ -754,3 +946,79 @@ func TestGasEstimateFactor(t *testing.T) {
 	require.NoError(t, ptm.ValidateTransaction(ctx, ptm.p.NOTX(), tx))
 	assert.Equal(t, pldtypes.MustParseHexUint64("0xc5f0"), *tx.Gas)
 }
+
+func TestOverrideGasLimit(t *testing.T) {
+	ctx, ptm, _, done := newTestPublicTxManager(t, true, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	signer := *pldtypes.RandAddress()
+	ptxs := []*DBPublicTxn{
+		{From: signer, Nonce: confutil.P(uint64(0)), Gas: 1000}, // will be completed
+		{From: signer, Nonce: confutil.P(uint64(1)), Gas: 1000, Data: pldtypes.HexBytes{0xfe, 0xed}},
+	}
+	err := ptm.p.DB().Table("public_txns").Create(ptxs).Error
+	require.NoError(t, err)
+	err = ptm.p.DB().Table("public_completions").Create(&DBPublicTxnCompletion{
+		PublicTxnID: ptxs[0].PublicTxnID, TransactionHash: pldtypes.RandBytes32(), Success: true,
+	}).Error
+	require.NoError(t, err)
+
+	// Completed transactions cannot be modified
+	err = ptm.OverrideGasLimit(ctx, signer, 0, 2000)
+	assert.Regexp(t, "PD011943", err)
+
+	// Same value is a no-op
+	err = ptm.OverrideGasLimit(ctx, signer, 1, 1000)
+	require.NoError(t, err)
+	assert.Empty(t, ptm.updates)
+
+	// Via the JSON/RPC API
+	_, err = ptm.PreInit(nil)
+	require.NoError(t, err)
+	rpc, rpcDone := newTestRPCServer(t, ctx, ptm)
+	defer rpcDone()
+	var ok bool
+	rpcErr := rpc.CallRPC(ctx, &ok, "publictx_overrideGasLimit", signer, pldtypes.HexUint64(1), pldtypes.HexUint64(5000))
+	require.NoError(t, rpcErr)
+	assert.True(t, ok)
+
+	pending, err := ptm.ListPendingTransactions(ctx, signer, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, uint64(5000), pending[0].Gas.Uint64())
+	assert.Equal(t, pldtypes.HexBytes{0xfe, 0xed}, pending[0].Data)
+
+	// The update is dispatched to the in-flight transaction with the full transaction details
+	require.Len(t, ptm.updates, 1)
+	assert.Equal(t, ptxs[1].PublicTxnID, ptm.updates[0].pubTXID)
+	assert.Equal(t, uint64(5000), ptm.updates[0].newPtx.Gas)
+	assert.Equal(t, pldtypes.HexBytes{0xfe, 0xed}, ptm.updates[0].newPtx.Data)
+
+	// Idempotent
+	err = ptm.OverrideGasLimit(ctx, signer, 1, 5000)
+	require.NoError(t, err)
+	assert.Len(t, ptm.updates, 1)
+}
+
+func TestOverrideGasLimitErrors(t *testing.T) {
+	ctx, ptm, m, done := newTestPublicTxManager(t, false, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		mocks.disableManagerStart = true
+	})
+	defer done()
+
+	err := ptm.OverrideGasLimit(ctx, *pldtypes.RandAddress(), 1, 0)
+	assert.Regexp(t, "PD011944", err)
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	err = ptm.OverrideGasLimit(ctx, *pldtypes.RandAddress(), 1, 1000)
+	assert.Regexp(t, "pop", err)
+
+	m.db.ExpectQuery("SELECT.*public_txns").WillReturnRows(sqlmock.NewRows([]string{"pub_txn_id", "gas"}).AddRow(12345, 1000))
+	m.db.ExpectBegin()
+	m.db.ExpectExec("UPDATE.*public_txns").WillReturnError(fmt.Errorf("pop"))
+	err = ptm.OverrideGasLimit(ctx, *pldtypes.RandAddress(), 1, 2000)
+	assert.Regexp(t, "pop", err)
+	assert.Empty(t, ptm.updates)
+}
@@ -119,11 +119,13 @@ type orchestrator struct {
 	unavailableBalanceHandlingStrategy OrchestratorBalanceCheckUnavailableBalanceHandlingStrategy
 
 	// in flight txs array
-	maxInFlightTxs       int
-	inFlightTxs          []*inFlightTransactionStageController // a queue of all the in flight transactions
-	inFlightTxsMux       sync.Mutex
-	orchestratorLoopDone chan struct{}
-	InFlightTxsStale     chan bool
+	maxInFlightTxs int
+	// maximum number of transactions in the signing/submitting stages at once, 0 means no limit
+	maxParallelSubmissions int
+	inFlightTxs            []*inFlightTransactionStageController // a queue of all the in flight transactions
+	inFlightTxsMux         sync.Mutex
+	orchestratorLoopDone   chan struct{}
+	InFlightTxsStale       chan bool
 
 	// input channels
 	stopProcess chan bool // a channel to tell the current transaction orchestrator to stop processing all events and mark itself as to be deleted
@@ -139,11 +141,18 @@ type orchestrator struct {
 	lastNonceAlloc time.Time
 	nextNonce      *uint64
 
+	nonceGapCheckInterval time.Duration
+	lastNonceGapCheck     time.Time
+
 	// updates
 	updates   []*transactionUpdate
 	updateMux sync.Mutex
+	// set under updateMux when the gas configuration is reloaded, so idle transactions are re-priced on the next poll
+	repriceCheckRequested bool
 
 	timeLineLoggingMaxEntries int
+
+	metrics *OrchestratorMetrics
 }
 
 const veryShortMinimum = 50 * time.Millisecond
@@ -152,6 +161,7 @@ func NewOrchestrator(
 	ptm *pubTxManager,
 	signingAddress pldtypes.EthAddress,
 	conf *pldconf.PublicTxManagerConfig,
+	metrics *OrchestratorMetrics,
 ) *orchestrator {
 	ctx := ptm.ctx
 
@@ -160,6 +170,7 @@ func NewOrchestrator(
 		orchestratorBirthTime:       time.Now(),
 		orchestratorPollingInterval: confutil.DurationMin(conf.Orchestrator.Interval, veryShortMinimum, *pldconf.PublicTxManagerDefaults.Orchestrator.Interval),
 		maxInFlightTxs:              confutil.IntMin(conf.Orchestrator.MaxInFlight, 1, *pldconf.PublicTxManagerDefaults.Orchestrator.MaxInFlight),
+		maxParallelSubmissions:      confutil.IntMin(conf.Orchestrator.MaxParallelSubmissions, 0, *pldconf.PublicTxManagerDefaults.Orchestrator.MaxParallelSubmissions),
 		signingAddress:              signingAddress,
 		state:                       OrchestratorStateNew,
 		stateEntryTime:              time.Now(),
@@ -180,6 +191,9 @@ func NewOrchestrator(
 		ethClient:                  ptm.ethClient,
 		bIndexer:                   ptm.bIndexer,
 		timeLineLoggingMaxEntries:  conf.Orchestrator.TimeLineLoggingMaxEntries,
+		nonceGapCheckInterval:      confutil.DurationMin(conf.Orchestrator.NonceGapCheckInterval, 0, *pldconf.PublicTxManagerDefaults.Orchestrator.NonceGapCheckInterval),
+		lastNonceGapCheck:          time.Now(),
+		metrics:                    metrics,
 	}
 
 	log.L(ctx).Debugf("NewOrchestrator for signing address %s created: %+v", newOrchestrator.signingAddress, newOrchestrator)
@@ -192,6 +206,8 @@ func (oc *orchestrator) orchestratorLoop() {
 	log.L(ctx).Infof("Orchestrator for signing address %s started polling based on interval %s", oc.signingAddress, oc.orchestratorPollingInterval)
 
 	defer close(oc.orchestratorLoopDone)
+	// once we exit, nothing is in flight for this signing address until a new orchestrator is started
+	defer oc.metrics.RecordInFlight(ctx, oc.signingAddress, 0)
 
 	if err := oc.initNextNonceFromDBRetry(ctx); err != nil {
 		log.L(ctx).Warnf("Context cancelled while obtaining highest nonce for %s: %s", oc.signingAddress, err)
@@ -216,7 +232,10 @@ func (oc *orchestrator) orchestratorLoop() {
 			return
 		}
 		oc.handleUpdates(ctx)
-		polled, total := oc.pollAndProcess(ctx)
+		polled, total, err := oc.pollAndProcess(ctx)
+		if err != nil && !err.IsRetryable() {
+			log.L(ctx).Errorf("Orchestrator for signing address %s failed to poll for transactions (will try again next polling interval): %s", oc.signingAddress, err)
+		}
 		log.L(ctx).Debugf("Orchestrator loop polled %d txs, there are %d txs in total", polled, total)
 	}
 
@@ -226,11 +245,26 @@ func (oc *orchestrator) handleUpdates(ctx context.Context) {
 	oc.updateMux.Lock()
 	updates := oc.updates
 	oc.updates = nil
+	repriceCheckRequested := oc.repriceCheckRequested
+	oc.repriceCheckRequested = false
 	oc.updateMux.Unlock()
 
 	oc.inFlightTxsMux.Lock()
 	defer oc.inFlightTxsMux.Unlock()
 
+	if repriceCheckRequested {
+		oc.hasZeroGasPrice = oc.gasPriceClient.HasZeroGasPrice(ctx)
+		for _, inflight := range oc.inFlightTxs {
+			// transactions that are part way through a stage will pick up the new gas price on their next submission,
+			// so only those that are waiting for a receipt need to be told to check their price now
+			if !inflight.stateManager.IsReadyToExit() &&
+				inflight.stateManager.GetTransactionHash() != nil &&
+				inflight.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx) == nil {
+				inflight.repriceCheckRequired = true
+			}
+		}
+	}
+
 	for _, update := range updates {
 		for _, inflight := range oc.inFlightTxs {
 			if inflight.stateManager.GetPubTxnID() == update.pubTXID {
@@ -348,7 +382,9 @@ func (oc *orchestrator) allocateNonces(ctx context.Context, txns []*DBPublicTxn)
 	return nil
 }
 
-func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total int) {
+// Returns an OrchestratorError if polling failed. Retryable errors are retried indefinitely, so will
+// only be returned if the context is cancelled.
+func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total int, _ *OrchestratorError) {
 	pollStart := time.Now()
 	oc.inFlightTxsMux.Lock()
 	defer oc.inFlightTxsMux.Unlock()
@@ -372,6 +408,9 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 		}
 		if p.stateManager.CanBeRemoved(ctx) {
 			oc.totalCompleted = oc.totalCompleted + 1
+			if p.stateManager.GetInFlightStatus() == InFlightStatusConfirmReceived {
+				oc.metrics.RecordConfirmed(ctx, oc.signingAddress, p.resubmissions, p.stateManager.GetFirstSubmit())
+			}
 			queueUpdated = true
 			log.L(ctx).Debugf("Orchestrator poll and process, marking %s as complete after: %s", p.stateManager.GetSignerNonce(), time.Since(p.stateManager.GetCreatedTime().Time()))
 			p.PrintTimeline()
@@ -403,6 +442,8 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 				Where(`"Completed"."tx_hash" IS NULL`).
 				Where("suspended IS FALSE").
 				Where(`"from" = ?`, oc.signingAddress).
+				// When the whole signer is suspended we only continue to track the ones already in-flight
+				Where(`"from" NOT IN (SELECT "signer" FROM "signer_suspensions")`).
 				Order(`"public_txns"."pub_txn_id"`).
 				Limit(spaces)
 			if len(oc.inFlightTxs) > 0 {
@@ -421,18 +462,26 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 		})
 		if err != nil {
 			log.L(ctx).Infof("Orchestrator poll and process: context cancelled while retrying")
-			return -1, len(oc.inFlightTxs)
+			return -1, len(oc.inFlightTxs), newOrchestratorError(err)
 		}
 
 		// Synchronously we ensure that we have a nonce for all of these.
 		// This is an indefinite retry, as we MUST not proceed until a nonce has been allocated+stored for every one
 		// of these transactions. Otherwise we might re-order transactions compared to their DB commit order
 		// (which is unacceptable for strict TX ordering).
+		var nonceErr *OrchestratorError
 		if err := oc.retry.Do(ctx, func(attempt int) (retryable bool, err error) {
-			return true, oc.allocateNonces(ctx, additional)
+			if err = oc.allocateNonces(ctx, additional); err != nil {
+				nonceErr = newOrchestratorError(err)
+				return nonceErr.IsRetryable(), nonceErr
+			}
+			return false, nil
 		}); err != nil {
-			log.L(ctx).Warnf("Orchestrator context cancelled while allocating nonce: %s", err)
-			return
+			log.L(ctx).Warnf("Orchestrator stopped allocating nonces: %s", err)
+			if nonceErr == nil {
+				nonceErr = newOrchestratorError(err) // context cancelled
+			}
+			return -1, len(oc.inFlightTxs), nonceErr
 		}
 
 		log.L(ctx).Debugf("Orchestrator poll and process: polled %d items, space: %d", len(additional), spaces)
@@ -455,6 +504,20 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 		oc.thMetrics.RecordInFlightTxQueueMetrics(ctx, stageCounts, oc.maxInFlightTxs-len(oc.inFlightTxs))
 	}
 	log.L(ctx).Debugf("Orchestrator polling from DB took %s", time.Since(pollStart))
+
+	// fill any nonces missing on chain below our in-flight transactions, which would otherwise stall them forever
+	if oc.nonceGapCheckInterval > 0 && len(oc.inFlightTxs) > 0 && time.Since(oc.lastNonceGapCheck) > oc.nonceGapCheckInterval {
+		oc.lastNonceGapCheck = time.Now()
+		if err := oc.fillNonceGap(ctx); err != nil {
+			log.L(ctx).Errorf("Failed to check for nonce gap for signing address %s: %s", oc.signingAddress, err)
+		} else if len(oc.inFlightTxs) != total {
+			queueUpdated = true
+			total = len(oc.inFlightTxs)
+		}
+	}
+
+	oc.metrics.RecordInFlight(ctx, oc.signingAddress, len(oc.inFlightTxs))
+
 	// now check and process each transaction
 
 	if total > 0 {
@@ -478,7 +541,7 @@ func (oc *orchestrator) pollAndProcess(ctx context.Context) (polled int, total i
 	}
 	log.L(ctx).Debugf("Orchestrator process loop took %s", time.Since(pollStart))
 
-	return polled, total
+	return polled, total, nil
 }
 
 // this function should only have one running instance at any given time
@@ -511,16 +574,38 @@ func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*
 	}
 
 	previousNonceCostUnknown := false
+	// when parallel submissions are limited, a transaction is only eligible for submission
+	// if there is a free slot and every lower nonce has already been dispatched
+	submissionsInProgress := 0
+	previousNonceNotDispatched := false
 	for i, it := range its {
 		log.L(ctx).Debugf("%s ProcessInFlightTransaction for signing address %s processing transaction with ID: %s, index: %d", now.String(), oc.signingAddress, it.stateManager.GetSignerNonce(), i)
 		var availableToSpend *big.Int
 		if !skipBalanceCheck {
 			availableToSpend = addressAccount.GetAvailableToSpend(ctx)
 		}
+		submissionSlotUnavailable := false
+		wasSubmitting := false
+		if oc.maxParallelSubmissions > 0 {
+			wasSubmitting = oc.isSubmissionInProgress(ctx, it)
+			if wasSubmitting {
+				submissionsInProgress++
+			}
+			submissionSlotUnavailable = previousNonceNotDispatched || submissionsInProgress >= oc.maxParallelSubmissions
+		}
 		triggerNextStageOutput := it.ProduceLatestInFlightStageContext(ctx, &OrchestratorContext{
-			AvailableToSpend:         availableToSpend,
-			PreviousNonceCostUnknown: previousNonceCostUnknown,
+			AvailableToSpend:          availableToSpend,
+			PreviousNonceCostUnknown:  previousNonceCostUnknown,
+			SubmissionSlotUnavailable: submissionSlotUnavailable,
 		})
+		if oc.maxParallelSubmissions > 0 && !wasSubmitting {
+			if oc.isSubmissionInProgress(ctx, it) {
+				// this transaction has just taken a submission slot
+				submissionsInProgress++
+			} else if it.stateManager.GetTransactionHash() == nil {
+				previousNonceNotDispatched = true
+			}
+		}
 		if !skipBalanceCheck {
 			if triggerNextStageOutput.Cost != nil {
 				_ = addressAccount.Spend(ctx, triggerNextStageOutput.Cost)
@@ -541,6 +626,11 @@ func (oc *orchestrator) ProcessInFlightTransactions(ctx context.Context, its []*
 	return waitingForBalance, nil
 }
 
+func (oc *orchestrator) isSubmissionInProgress(ctx context.Context, it *inFlightTransactionStageController) bool {
+	rsc := it.stateManager.GetCurrentGeneration(ctx).GetRunningStageContext(ctx)
+	return rsc != nil && (rsc.Stage == InFlightTxStageSigning || rsc.Stage == InFlightTxStageSubmitting)
+}
+
 func (oc *orchestrator) Start(ctx context.Context) (done <-chan struct{}, err error) {
 	oc.orchestratorLoopDone = make(chan struct{})
 	go oc.orchestratorLoop()
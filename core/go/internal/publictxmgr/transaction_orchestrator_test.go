@@ -27,6 +27,7 @@ import (
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -41,7 +42,7 @@ func newTestOrchestrator(t *testing.T, cbs ...func(mocks *mocksAndTestControl, c
 	})
 
 	signingAddress := pldtypes.EthAddress(pldtypes.RandBytes(20))
-	o := NewOrchestrator(ptm, signingAddress, ptm.conf)
+	o := NewOrchestrator(ptm, signingAddress, ptm.conf, ptm.orchestratorMetrics)
 
 	return ctx, o, m, done
 
@@ -117,8 +118,9 @@ func TestNewOrchestratorPollingContextCancelledWhileRetrying(t *testing.T) {
 	m.db.ExpectQuery("SELECT.*public_txn").WillReturnError(fmt.Errorf("pop"))
 
 	o.ctxCancel()
-	polled, _ := o.pollAndProcess(ctx)
+	polled, _, err := o.pollAndProcess(ctx)
 	assert.Equal(t, -1, polled)
+	assert.True(t, err.IsRetryable())
 
 }
 
@@ -198,3 +200,68 @@ func TestOrchestratorWaitingForBalance(t *testing.T) {
 	o.Stop()
 	<-oDone
 }
+
+func TestOrchestratorMaxParallelSubmissions(t *testing.T) {
+	ctx, o, _, done := newTestOrchestrator(t, func(m *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Orchestrator.MaxParallelSubmissions = confutil.P(2)
+	})
+	defer done()
+	assert.Equal(t, 2, o.maxParallelSubmissions)
+	o.hasZeroGasPrice = true
+
+	its := make([]*inFlightTransactionStageController, 4)
+	for i := range its {
+		it, txState := newInflightTransaction(o, uint64(i+1))
+		it.testOnlyNoActionMode = true
+		txState.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+			GasPricing: &pldapi.PublicTxGasPricing{
+				GasPrice: pldtypes.Int64ToInt256(0),
+			},
+		})
+		its[i] = it
+	}
+
+	// only the first two nonces are allowed to start signing
+	waitingForBalance, err := o.ProcessInFlightTransactions(ctx, its)
+	require.NoError(t, err)
+	assert.False(t, waitingForBalance)
+	assert.Equal(t, InFlightTxStageSigning, its[0].stateManager.GetStage(ctx))
+	assert.Equal(t, InFlightTxStageSigning, its[1].stateManager.GetStage(ctx))
+	assert.Equal(t, InFlightTxStage(""), its[2].stateManager.GetStage(ctx))
+	assert.Equal(t, InFlightTxStage(""), its[3].stateManager.GetStage(ctx))
+
+	// once the first completes submission a slot is freed for the next nonce in order
+	its[0].stateManager.GetCurrentGeneration(ctx).ClearRunningStageContext(ctx)
+	its[0].stateManager.GetCurrentGeneration(ctx).SetValidatedTransactionHashMatchState(ctx, true)
+	its[0].stateManager.ApplyInMemoryUpdates(ctx, &BaseTXUpdates{
+		TransactionHash: confutil.P(pldtypes.Bytes32(pldtypes.RandBytes32())),
+	})
+	_, err = o.ProcessInFlightTransactions(ctx, its)
+	require.NoError(t, err)
+	assert.Equal(t, InFlightTxStageSigning, its[1].stateManager.GetStage(ctx))
+	assert.Equal(t, InFlightTxStageSigning, its[2].stateManager.GetStage(ctx))
+	assert.Equal(t, InFlightTxStage(""), its[3].stateManager.GetStage(ctx))
+}
+
+func TestNewOrchestratorPollingNonceAllocationNotRetryable(t *testing.T) {
+
+	ctx, o, m, done := newTestOrchestrator(t, func(mocks *mocksAndTestControl, conf *pldconf.PublicTxManagerConfig) {
+		conf.Orchestrator.MaxInFlight = confutil.P(10)
+	})
+	defer done()
+
+	// Return a single transaction that needs a nonce
+	m.db.ExpectQuery("SELECT.*public_txn").WillReturnRows(sqlmock.NewRows([]string{"from"}).AddRow(o.signingAddress))
+	m.db.ExpectQuery("SELECT.*public_submissions").WillReturnRows(sqlmock.NewRows([]string{}))
+
+	// The node rejects the request, which will not succeed on retry - so we must only call once
+	m.ethClient.On("GetTransactionCount", mock.Anything, o.signingAddress).
+		Return(nil, &rpcclient.RPCError{Code: int64(rpcclient.RPCCodeInvalidParams), Message: "invalid address"}).Once()
+
+	polled, _, err := o.pollAndProcess(ctx)
+	assert.Equal(t, -1, polled)
+	require.NotNil(t, err)
+	assert.False(t, err.IsRetryable())
+	assert.Regexp(t, "invalid address", err)
+
+}
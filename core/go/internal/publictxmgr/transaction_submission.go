@@ -84,6 +84,14 @@ func (it *inFlightTransactionStageController) submitTX(ctx context.Context, sign
 			}
 			submissionErrorReason = ethclient.MapError(submissionError)
 			it.thMetrics.RecordOperationMetrics(ctx, string(InFlightTxOperationTransactionSend), string(GenericStatusFail), time.Since(sendStart).Seconds())
+			if it.isReplacementUnderpriced(submissionError) {
+				// Retrying with the latest price from the gas price client will not help, as the node
+				// requires an increase on the price of the transaction it already has in its pool
+				log.L(ctx).Debugf("Replacement transaction %s underpriced, gas price bump of %d%% required: %s", signerNonce, it.replacementBumpPercent, submissionError)
+				submissionErrorReason = ethclient.ErrorReasonTransactionUnderpriced
+				submissionOutcome = SubmissionOutcomeReplacementUnderpriced
+				return false, nil
+			}
 			// We have some simple rules for handling reasons from the connector, which could be enhanced by extending the connector.
 			switch submissionErrorReason {
 			case ethclient.ErrorReasonTransactionUnderpriced:
@@ -116,6 +124,12 @@ func (it *inFlightTransactionStageController) submitTX(ctx context.Context, sign
 				submissionErrorReason = ""
 				submissionOutcome = SubmissionOutcomeNonceTooLow
 			default:
+				if category := categorizeError(submissionError); category != ErrorCategoryTransient {
+					// no point retrying inline, the stage controller handles these
+					log.L(ctx).Errorf("Submission error for transaction ID %s with hash %s (%s): %s", signerNonce, txHash, category, submissionError)
+					submissionOutcome = SubmissionOutcomeFailedRequiresRetry
+					return false, nil
+				}
 				log.L(ctx).Errorf("Submission error for transaction ID %s with hash %s (requires retry): %s", signerNonce, txHash, submissionError)
 				submissionOutcome = SubmissionOutcomeFailedRequiresRetry
 				return true, submissionError
@@ -269,6 +269,21 @@ func TestTxSubmissionWithSignedMessageWithRetry(t *testing.T) {
 	assert.Equal(t, ethclient.ErrorReasonTransactionUnderpriced, errReason)
 	assert.Equal(t, SubmissionOutcomeFailedRequiresRetry, outCome)
 	assert.Equal(t, testTxHash, txHash.String())
+	// replacement underpriced, including errors not mapped to the underpriced reason
+	for _, replacementErr := range []string{"replacement transaction underpriced", "could not replace existing tx"} {
+		m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(nil, fmt.Errorf("%s", replacementErr)).Once()
+
+		txHash, _, errReason, outCome, err = it.submitTX(ctx,
+			[]byte(testTransactionData),
+			it.stateManager.GetTransactionHash(),
+			it.stateManager.GetSignerNonce(),
+			it.stateManager.GetLastSubmitTime(),
+			testCancel)
+		assert.Regexp(t, replacementErr, err)
+		assert.Equal(t, ethclient.ErrorReasonTransactionUnderpriced, errReason)
+		assert.Equal(t, SubmissionOutcomeReplacementUnderpriced, outCome)
+		assert.Equal(t, testTxHash, txHash.String())
+	}
 	// reverted
 	m.ethClient.On("SendRawTransaction", ctx, mock.Anything).Return(nil, fmt.Errorf("execution reverted")).Once()
 
@@ -219,6 +219,8 @@ const (
 
 	// error cases
 	SubmissionOutcomeFailedRequiresRetry SubmissionOutcome = "errRequiresRetry"
+	// the node rejected a replacement for a transaction in its pool, so the current gas price must be bumped before retry
+	SubmissionOutcomeReplacementUnderpriced SubmissionOutcome = "errReplacementUnderpriced"
 )
 
 type InMemoryTxStateReadOnly interface {
@@ -234,6 +236,8 @@ type InMemoryTxStateReadOnly interface {
 	GetGasPriceObject() *pldapi.PublicTxGasPricing
 	GetFirstSubmit() *pldtypes.Timestamp
 	GetLastSubmitTime() *pldtypes.Timestamp
+	// the most recent error processing the transaction, which is persisted so survives a restart
+	GetErrorMessage() *string
 	GetUnflushedSubmission() *DBPubTxnSubmission
 	GetInFlightStatus() InFlightStatus
 	GetSignerNonce() string
@@ -250,6 +254,9 @@ type InMemoryTxStateSetters interface {
 	ApplyInMemoryUpdates(ctx context.Context, txUpdates *BaseTXUpdates)
 	UpdateTransaction(newPtx *DBPublicTxn)
 	ResetTransactionHash()
+	// Snapshot takes a deep copy of the in-memory state, so speculative updates can be rolled back with RestoreSnapshot
+	Snapshot() InMemoryTxStateSnapshot
+	RestoreSnapshot(snapshot InMemoryTxStateSnapshot)
 }
 
 type StageOutput struct {
@@ -264,6 +271,9 @@ type StageOutput struct {
 	GasPriceOutput *GasPriceOutput
 
 	ConfirmationOutput *ConfirmationOutputs
+
+	// set when the stage action returned an error, to determine how it should be handled
+	ErrorCategory ErrorCategory
 }
 
 type SubmitOutputs struct {
@@ -356,6 +366,9 @@ type OrchestratorContext struct {
 	// input from transaction engine
 	AvailableToSpend         *big.Int
 	PreviousNonceCostUnknown bool
+	// set when the orchestrator has no free submission slot for this transaction,
+	// either because the parallel submission limit is reached or a lower nonce is yet to be dispatched
+	SubmissionSlotUnavailable bool
 }
 
 // output of some stages doesn't get written into the database
@@ -29,6 +29,7 @@ import (
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
 	"github.com/kaleido-io/paladin/toolkit/pkg/plugintk"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
@@ -49,7 +50,7 @@ type registryManager struct {
 
 	// Due to the high frequency of calls to the registry for node details, we maintain
 	// a cache of resolved nodes by name - which is a global index, across all registries.
-	transportDetailsCache cache.Cache[string, []*components.RegistryNodeTransportEntry]
+	nodeDetailsCache cache.Cache[string, *nodeDetails]
 
 	registriesByID   map[uuid.UUID]*registry
 	registriesByName map[string]*registry
@@ -62,7 +63,7 @@ func NewRegistryManager(bgCtx context.Context, conf *pldconf.RegistryManagerConf
 		registriesByID:           make(map[uuid.UUID]*registry),
 		registriesByName:         make(map[string]*registry),
 		registryTransportLookups: make(map[string]*transportLookup),
-		transportDetailsCache:    cache.NewCache[string, []*components.RegistryNodeTransportEntry](&conf.RegistryManager.RegistryCache, pldconf.RegistryCacheDefaults),
+		nodeDetailsCache:         cache.NewCache[string, *nodeDetails](&conf.RegistryManager.RegistryCache, pldconf.RegistryCacheDefaults),
 	}
 }
 
@@ -171,10 +172,29 @@ func (rm *registryManager) getRegistryNames() []string {
 }
 
 func (rm *registryManager) GetNodeTransports(ctx context.Context, node string) ([]*components.RegistryNodeTransportEntry, error) {
+	details, err := rm.getNodeDetails(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	return details.transports, nil
+}
+
+func (rm *registryManager) GetNodeSigningAddress(ctx context.Context, node string) (*pldtypes.EthAddress, error) {
+	details, err := rm.getNodeDetails(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	if details.signingAddress == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgRegistryNodeSigningAddressNotFound, node)
+	}
+	return details.signingAddress, nil
+}
+
+func (rm *registryManager) getNodeDetails(ctx context.Context, node string) (*nodeDetails, error) {
 	// Check cache
-	transports, present := rm.transportDetailsCache.Get(node)
+	details, present := rm.nodeDetailsCache.Get(node)
 	if present {
-		return transports, nil
+		return details, nil
 	}
 
 	regLookupsChecked := 0
@@ -182,16 +202,16 @@ func (rm *registryManager) GetNodeTransports(ctx context.Context, node string) (
 		tl := rm.registryTransportLookups[regName]
 		if tl != nil {
 			regLookupsChecked++
-			regTransports, err := tl.getNodeTransports(ctx, rm.p.NOTX() /* no TX needed */, r, node)
+			regDetails, err := tl.getNodeDetails(ctx, rm.p.NOTX() /* no TX needed */, r, node)
 			if err != nil {
 				return nil, err
 			}
 			// we only return entries from a single registry (we do not merge transports across registries)
 			// the requiredPrefix allows node partitioning across registries.
-			if len(regTransports) > 0 {
-				log.L(ctx).Infof("Node '%s' matched to %d transports in registry '%s'", node, len(regTransports), regName)
-				rm.transportDetailsCache.Set(node, regTransports)
-				return regTransports, nil
+			if regDetails != nil && len(regDetails.transports) > 0 {
+				log.L(ctx).Infof("Node '%s' matched to %d transports in registry '%s'", node, len(regDetails.transports), regName)
+				rm.nodeDetailsCache.Set(node, regDetails)
+				return regDetails, nil
 			}
 		}
 	}
@@ -346,7 +346,7 @@ func (r *registry) upsertRegistryRecords(ctx context.Context, dbTX persistence.D
 		// queries that resolve node transports to names.
 		//
 		// So instead we just zap the whole cache when we have an update.
-		r.rm.transportDetailsCache.Clear()
+		r.rm.nodeDetailsCache.Clear()
 	})
 	return nil
 }
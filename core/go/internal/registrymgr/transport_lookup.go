@@ -33,19 +33,27 @@ import (
 )
 
 type transportLookup struct {
-	regName           string
-	requiredPrefix    string
-	hierarchySplitter string
-	transportNameMap  map[string]string
-	propertyRegexp    *regexp.Regexp
+	regName                string
+	requiredPrefix         string
+	hierarchySplitter      string
+	transportNameMap       map[string]string
+	propertyRegexp         *regexp.Regexp
+	signingAddressProperty string
+}
+
+// The details we resolve and cache for each node
+type nodeDetails struct {
+	transports     []*components.RegistryNodeTransportEntry
+	signingAddress *pldtypes.EthAddress
 }
 
 func newTransportLookup(ctx context.Context, regName string, conf *pldconf.RegistryTransportsConfig) (tl *transportLookup, err error) {
 	tl = &transportLookup{
-		regName:           regName,
-		requiredPrefix:    confutil.StringNotEmpty(&conf.RequiredPrefix, pldconf.RegistryTransportsDefaults.RequiredPrefix),
-		hierarchySplitter: confutil.StringNotEmpty(&conf.HierarchySplitter, pldconf.RegistryTransportsDefaults.HierarchySplitter),
-		transportNameMap:  map[string]string{},
+		regName:                regName,
+		requiredPrefix:         confutil.StringNotEmpty(&conf.RequiredPrefix, pldconf.RegistryTransportsDefaults.RequiredPrefix),
+		hierarchySplitter:      confutil.StringNotEmpty(&conf.HierarchySplitter, pldconf.RegistryTransportsDefaults.HierarchySplitter),
+		transportNameMap:       map[string]string{},
+		signingAddressProperty: confutil.StringNotEmpty(&conf.SigningAddressProperty, pldconf.RegistryTransportsDefaults.SigningAddressProperty),
 	}
 
 	tl.propertyRegexp, err = regexp.Compile(
@@ -62,7 +70,7 @@ func newTransportLookup(ctx context.Context, regName string, conf *pldconf.Regis
 	return tl, nil
 }
 
-func (tl *transportLookup) getNodeTransports(ctx context.Context, dbTX persistence.DBTX, r *registry, fullLookup string) ([]*components.RegistryNodeTransportEntry, error) {
+func (tl *transportLookup) getNodeDetails(ctx context.Context, dbTX persistence.DBTX, r *registry, fullLookup string) (*nodeDetails, error) {
 
 	lookup := fullLookup
 	if tl.requiredPrefix != "" {
@@ -105,8 +113,17 @@ func (tl *transportLookup) getNodeTransports(ctx context.Context, dbTX persisten
 
 	// We now have a node that we trust with a matching name, go through the properties to find matching transports.
 	log.L(ctx).Infof("Node lookup '%s' matched to entry ID '%s' in registry '%s'", fullLookup, entry.ID, tl.regName)
-	var transports []*components.RegistryNodeTransportEntry
+	details := &nodeDetails{}
 	for k, v := range entry.Properties {
+		if k == tl.signingAddressProperty {
+			signingAddress, err := pldtypes.ParseEthAddress(v)
+			if err != nil {
+				log.L(ctx).Warnf("Ignoring invalid signing address '%s' in property '%s' of node '%s': %s", v, k, fullLookup, err)
+			} else {
+				details.signingAddress = signingAddress
+			}
+			continue
+		}
 		subMatch := tl.propertyRegexp.FindStringSubmatch(k)
 		if len(subMatch) != 2 {
 			log.L(ctx).Debugf("Property '%s' does not match regexp '%s'", k, tl.propertyRegexp)
@@ -118,12 +135,12 @@ func (tl *transportLookup) getNodeTransports(ctx context.Context, dbTX persisten
 			transportName = mappedName
 		}
 		log.L(ctx).Infof("Property '%s' matches transport %s (mappedName=%s,regexp='%s')", k, subMatch[1], transportName, tl.propertyRegexp)
-		transports = append(transports, &components.RegistryNodeTransportEntry{
+		details.transports = append(details.transports, &components.RegistryNodeTransportEntry{
 			Node:      fullLookup,
 			Registry:  tl.regName,
 			Transport: transportName,
 			Details:   v,
 		})
 	}
-	return transports, nil
+	return details, nil
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/require"
 )
@@ -136,6 +137,45 @@ func TestGetNodeTransportsCustomSettingsRealDB(t *testing.T) {
 
 }
 
+func TestGetNodeSigningAddressRealDB(t *testing.T) {
+	ctx, rm, tp, _, done := newTestRegistry(t, true)
+	defer done()
+
+	signingAddr := pldtypes.RandAddress()
+	node1Entry := &prototk.RegistryEntry{Id: randID(), Name: "node1", Location: randChainInfo(), Active: true}
+	node2Entry := &prototk.RegistryEntry{Id: randID(), Name: "node2", Location: randChainInfo(), Active: true}
+	node3Entry := &prototk.RegistryEntry{Id: randID(), Name: "node3", Location: randChainInfo(), Active: true}
+	_, err := tp.r.UpsertRegistryRecords(ctx, &prototk.UpsertRegistryRecordsRequest{
+		Entries: []*prototk.RegistryEntry{node1Entry, node2Entry, node3Entry},
+		Properties: []*prototk.RegistryProperty{
+			newPropFor(node1Entry.Id, "transport.grpc", "things and stuff"),
+			newPropFor(node1Entry.Id, "signingAddress", signingAddr.String()),
+			newPropFor(node2Entry.Id, "transport.grpc", "other things"),
+			newPropFor(node3Entry.Id, "transport.grpc", "more things"),
+			newPropFor(node3Entry.Id, "signingAddress", "not an address"),
+		},
+	})
+	require.NoError(t, err)
+
+	addr, err := rm.GetNodeSigningAddress(ctx, "node1")
+	require.NoError(t, err)
+	require.Equal(t, signingAddr, addr)
+
+	// The signing address property is not treated as a transport
+	transports, err := rm.GetNodeTransports(ctx, "node1")
+	require.NoError(t, err)
+	require.Len(t, transports, 1)
+
+	_, err = rm.GetNodeSigningAddress(ctx, "node2")
+	require.Regexp(t, "PD012110", err)
+
+	_, err = rm.GetNodeSigningAddress(ctx, "node3")
+	require.Regexp(t, "PD012110", err)
+
+	_, err = rm.GetNodeSigningAddress(ctx, "node4")
+	require.Regexp(t, "PD012100", err)
+}
+
 func TestGetNodeTransportsErr(t *testing.T) {
 	ctx, rm, _, m, done := newTestRegistry(t, false)
 	defer done()
@@ -26,7 +26,6 @@ import (
 
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/eip712"
-	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
@@ -207,6 +206,10 @@ func (as *abiSchema) mapValueToLabel(ctx context.Context, fieldName string, labe
 		if !ok {
 			return nil, nil, i18n.NewError(ctx, msgs.MsgStateLabelFieldUnexpectedValue, fieldName, f.Value, new(big.Int))
 		}
+		if !bigIntVal.IsInt64() {
+			// Would silently wrap, and give incorrect ordering in queries
+			return nil, nil, i18n.NewError(ctx, msgs.MsgStateLabelFieldInt64Overflow, bigIntVal.String(), fieldName)
+		}
 		return nil, &pldapi.StateInt64Label{Label: fieldName, Value: bigIntVal.Int64()}, nil
 	case labelTypeInt256:
 		bigIntVal, ok := f.Value.(*big.Int)
@@ -326,7 +329,7 @@ func (as *abiSchema) parseStateData(ctx context.Context, data pldtypes.RawJSON)
 
 // Take the state, parse the value into the type tree of this schema, and from that
 // build the label values to store in the DB for comparison appropriate to the type.
-func (as *abiSchema) ProcessState(ctx context.Context, contractAddress *pldtypes.EthAddress, data pldtypes.RawJSON, id pldtypes.HexBytes, customHashFunction bool) (*components.StateWithLabels, error) {
+func (as *abiSchema) ProcessState(ctx context.Context, contractAddress *pldtypes.EthAddress, data pldtypes.RawJSON, id pldtypes.HexBytes, customHashFunction, flagExternalID bool) (*components.StateWithLabels, error) {
 
 	// We need to re-serialize the data according to the ABI to:
 	// - Ensure it's valid
@@ -350,26 +353,26 @@ func (as *abiSchema) ProcessState(ctx context.Context, contractAddress *pldtypes
 	// - The hash is deterministic and reproducible by anyone with access to the unmasked state data
 	//
 	// Note this function only validates Paladin-default hashes, when customHashFunction is true
-	// the caller must have pre-verified the hash
+	// the caller must have pre-verified the hash. If the domain has opted in with flagExternalID,
+	// a supplied ID that does not match the hash Paladin would have calculated is recorded as an external ID.
+	var externalID bool
 	if customHashFunction {
 		if id == nil {
 			return nil, i18n.WrapError(ctx, err, msgs.MsgStateIDMissing)
 		}
+		if flagExternalID {
+			hash, hashErr := as.hashStateData(ctx, psd)
+			externalID = hashErr != nil || !id.Equals(hash)
+		}
 	} else {
-		// When Paladin is designated to create that hash, it uses a EIP-712 Typed Data V4 hash as this has
-		// the characteristics of:
-		// - Well proven and Ethereum standardized algorithm for hashing a complex structure
-		// - Deterministic order and type formatting of values
-		// - Only containing the data that is described in the associated the ABI
-		var hash ethtypes.HexBytes0xPrefix
-		hash, err = eip712.HashStruct(ctx, as.primaryType, psd.jsonTree, as.typeSet)
+		hash, err := as.hashStateData(ctx, psd)
 		if err != nil {
-			return nil, i18n.WrapError(ctx, err, msgs.MsgStateInvalidCalculatingHash)
+			return nil, err
 		}
-		if id != nil && !id.Equals(pldtypes.HexBytes(hash)) {
+		if id != nil && !id.Equals(hash) {
 			return nil, i18n.NewError(ctx, msgs.MsgStateHashMismatch, id, hash)
 		}
-		id = pldtypes.HexBytes(hash)
+		id = hash
 	}
 
 	for i := range psd.labels {
@@ -391,6 +394,7 @@ func (as *abiSchema) ProcessState(ctx context.Context, contractAddress *pldtypes
 				Schema:          as.Schema.ID,
 				ContractAddress: contractAddress,
 				Data:            jsonData,
+				ExternalID:      externalID,
 			},
 			Labels:      psd.labels,
 			Int64Labels: psd.int64Labels,
@@ -399,6 +403,19 @@ func (as *abiSchema) ProcessState(ctx context.Context, contractAddress *pldtypes
 	}, nil
 }
 
+// When Paladin is designated to create that hash, it uses a EIP-712 Typed Data V4 hash as this has
+// the characteristics of:
+// - Well proven and Ethereum standardized algorithm for hashing a complex structure
+// - Deterministic order and type formatting of values
+// - Only containing the data that is described in the associated the ABI
+func (as *abiSchema) hashStateData(ctx context.Context, psd *parsedStateData) (pldtypes.HexBytes, error) {
+	hash, err := eip712.HashStruct(ctx, as.primaryType, psd.jsonTree, as.typeSet)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgStateInvalidCalculatingHash)
+	}
+	return pldtypes.HexBytes(hash), nil
+}
+
 func (as *abiSchema) RecoverLabels(ctx context.Context, s *pldapi.State) (*components.StateWithLabels, error) {
 	psd, err := as.parseStateData(ctx, s.Data)
 	if err != nil {
@@ -19,6 +19,8 @@ package statemgr
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -35,7 +37,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func testABIParam(t *testing.T, jsonParam string) *abi.Parameter {
+func testABIParam(t testing.TB, jsonParam string) *abi.Parameter {
 	var a abi.Parameter
 	err := json.Unmarshal([]byte(jsonParam), &a)
 	require.NoError(t, err)
@@ -46,6 +48,7 @@ func mockDomain(t *testing.T, m *mockComponents, name string, customHashFunction
 	md := componentsmocks.NewDomain(t)
 	md.On("Name").Return(name).Maybe()
 	md.On("CustomHashFunction").Return(customHashFunction)
+	md.On("FlagExternalStateIDs").Return(false).Maybe()
 	m.domainManager.On("GetDomainByName", mock.Anything, name).Return(md, nil)
 	return md
 }
@@ -464,7 +467,7 @@ func TestABISchemaProcessStateInvalidType(t *testing.T) {
 	var err error
 	as.tc, err = as.definition.TypeComponentTreeCtx(ctx)
 	require.NoError(t, err)
-	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1": 12345}`), nil, false)
+	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1": 12345}`), nil, false, false)
 	assert.Regexp(t, "PD010103", err)
 }
 
@@ -496,7 +499,7 @@ func TestABISchemaProcessStateLabelMissing(t *testing.T) {
 	var err error
 	as.tc, err = as.definition.TypeComponentTreeCtx(ctx)
 	require.NoError(t, err)
-	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1": 12345}`), nil, false)
+	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1": 12345}`), nil, false, false)
 	assert.Regexp(t, "PD010110", err)
 }
 
@@ -532,7 +535,7 @@ func TestABISchemaProcessStateBadValue(t *testing.T) {
 	var err error
 	as.tc, err = as.definition.TypeComponentTreeCtx(ctx)
 	require.NoError(t, err)
-	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{!!! wrong`), nil, false)
+	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{!!! wrong`), nil, false, false)
 	assert.Regexp(t, "PD010116", err)
 }
 
@@ -557,7 +560,7 @@ func TestABISchemaProcessStateMismatchValue(t *testing.T) {
 	var err error
 	as.tc, err = as.definition.TypeComponentTreeCtx(ctx)
 	require.NoError(t, err)
-	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1":{}}`), nil, false)
+	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1":{}}`), nil, false, false)
 	assert.Regexp(t, "FF22030", err)
 }
 
@@ -582,7 +585,7 @@ func TestABISchemaProcessStateEIP712Failure(t *testing.T) {
 	var err error
 	as.tc, err = as.definition.TypeComponentTreeCtx(ctx)
 	require.NoError(t, err)
-	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1":"0x753A7decf94E48a05Fa1B342D8984acA9bFaf6B2"}`), nil, false)
+	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1":"0x753A7decf94E48a05Fa1B342D8984acA9bFaf6B2"}`), nil, false, false)
 	assert.Regexp(t, "FF22073", err)
 }
 
@@ -607,7 +610,7 @@ func TestABISchemaProcessStateDataFailure(t *testing.T) {
 	var err error
 	as.tc, err = as.definition.TypeComponentTreeCtx(ctx)
 	require.NoError(t, err)
-	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1":"0x753A7decf94E48a05Fa1B342D8984acA9bFaf6B2"}`), nil, false)
+	_, err = as.ProcessState(ctx, pldtypes.RandAddress(), pldtypes.RawJSON(`{"field1":"0x753A7decf94E48a05Fa1B342D8984acA9bFaf6B2"}`), nil, false, false)
 	assert.Regexp(t, "FF22073", err)
 }
 
@@ -642,7 +645,7 @@ func TestABISchemaInsertCustomHashNoID(t *testing.T) {
 	tc, err := as.definition.Components.TypeComponentTree()
 	require.NoError(t, err)
 	as.tc = tc
-	_, err = as.ProcessState(context.Background(), pldtypes.RandAddress(), pldtypes.RawJSON(`{}`), nil, true)
+	_, err = as.ProcessState(context.Background(), pldtypes.RandAddress(), pldtypes.RawJSON(`{}`), nil, true, false)
 	assert.Regexp(t, "PD010130", err)
 }
 
@@ -655,10 +658,39 @@ func TestABISchemaInsertStandardHashMismatch(t *testing.T) {
 	})
 	require.NoError(t, err)
 	_, err = as.ProcessState(context.Background(), pldtypes.RandAddress(),
-		pldtypes.RawJSON(`{}`), pldtypes.RandBytes(32), false)
+		pldtypes.RawJSON(`{}`), pldtypes.RandBytes(32), false, false)
 	assert.Regexp(t, "PD010129", err)
 }
 
+func TestABISchemaInsertCustomHashExternalID(t *testing.T) {
+	as, err := newABISchema(context.Background(), "domain1", &abi.Parameter{
+		Type:         "tuple",
+		Name:         "MyStruct",
+		InternalType: "struct MyStruct",
+		Components:   abi.ParameterArray{},
+	})
+	require.NoError(t, err)
+	s, err := as.ProcessState(context.Background(), pldtypes.RandAddress(),
+		pldtypes.RawJSON(`{}`), pldtypes.RandBytes(32), true, true)
+	require.NoError(t, err)
+	assert.True(t, s.State.ExternalID)
+
+	calculated, err := as.ProcessState(context.Background(), pldtypes.RandAddress(),
+		pldtypes.RawJSON(`{}`), nil, false, false)
+	require.NoError(t, err)
+	assert.False(t, calculated.State.ExternalID)
+	s, err = as.ProcessState(context.Background(), pldtypes.RandAddress(),
+		pldtypes.RawJSON(`{}`), calculated.State.ID, true, true)
+	require.NoError(t, err)
+	assert.False(t, s.State.ExternalID)
+
+	// Domains that have not opted in are never flagged
+	s, err = as.ProcessState(context.Background(), pldtypes.RandAddress(),
+		pldtypes.RawJSON(`{}`), pldtypes.RandBytes(32), true, false)
+	require.NoError(t, err)
+	assert.False(t, s.State.ExternalID)
+}
+
 func TestABISchemaInsertCustomHashBadData(t *testing.T) {
 	as := &abiSchema{
 		Schema: &pldapi.Schema{},
@@ -669,7 +701,7 @@ func TestABISchemaInsertCustomHashBadData(t *testing.T) {
 	tc, err := as.definition.Components.TypeComponentTree()
 	require.NoError(t, err)
 	as.tc = tc
-	_, err = as.ProcessState(context.Background(), pldtypes.RandAddress(), pldtypes.RawJSON(`{}`), pldtypes.RandBytes(32), false)
+	_, err = as.ProcessState(context.Background(), pldtypes.RandAddress(), pldtypes.RawJSON(`{}`), pldtypes.RandBytes(32), false, false)
 	assert.Regexp(t, "FF22040", err)
 }
 
@@ -728,3 +760,27 @@ func TestABISchemaMapValueToLabelTypeErrors(t *testing.T) {
 	assert.Regexp(t, "PD010109", err)
 
 }
+
+func TestMapValueToLabelInt64Overflow(t *testing.T) {
+	ctx := context.Background()
+	as := &abiSchema{}
+
+	tc, err := (&abi.Parameter{Name: "field1", Type: "uint256"}).TypeComponentTree()
+	require.NoError(t, err)
+
+	cv, err := tc.ParseExternal(fmt.Sprintf("%d", uint64(math.MaxInt64)))
+	require.NoError(t, err)
+	_, int64Label, err := as.mapValueToLabel(ctx, "field1", labelTypeInt64, cv)
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), int64Label.Value)
+
+	cv, err = tc.ParseExternal(fmt.Sprintf("%d", uint64(math.MaxInt64)+1))
+	require.NoError(t, err)
+	_, _, err = as.mapValueToLabel(ctx, "field1", labelTypeInt64, cv)
+	assert.Regexp(t, "PD010140.*9223372036854775808.*field1", err)
+
+	// The same value is stored without loss when the field uses the uint256 label type
+	label, _, err := as.mapValueToLabel(ctx, "field1", labelTypeUint256, cv)
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000000000000000000000000000000000000000008000000000000000", label.Value)
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// debugSQLLogger is set on the GORM session for state queries when debugSQL is enabled, to log
+// the SQL generated from the filter (with the label joins, and the bound parameters expanded)
+type debugSQLLogger struct{}
+
+func (l debugSQLLogger) LogMode(gormlogger.LogLevel) gormlogger.Interface {
+	return l
+}
+
+func (l debugSQLLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	log.L(ctx).Debugf(msg, data...)
+}
+
+func (l debugSQLLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	log.L(ctx).Debugf(msg, data...)
+}
+
+func (l debugSQLLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	log.L(ctx).Debugf(msg, data...)
+}
+
+func (l debugSQLLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if !log.IsDebugEnabled() {
+		return
+	}
+	sql, rows := fc()
+	durationMS := float64(time.Since(begin).Microseconds()) / 1000
+	if err != nil {
+		log.L(ctx).Debugf("State query failed duration_ms=%.3f err=%s sql: %s", durationMS, err, sql)
+		return
+	}
+	log.L(ctx).Debugf("State query duration_ms=%.3f rows=%d sql: %s", durationMS, rows, sql)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureDebugLogs(ctx context.Context) (context.Context, *logtest.Hook, func()) {
+	logger, hook := logtest.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	ctx = log.WithLogger(ctx, logrus.NewEntry(logger))
+	prevLevel := log.GetLevel()
+	log.SetLevel("debug")
+	return ctx, hook, func() { log.SetLevel(prevLevel) }
+}
+
+func stateQueryLogs(hook *logtest.Hook) []string {
+	var sqlLogs []string
+	for _, e := range hook.AllEntries() {
+		if strings.HasPrefix(e.Message, "State query") {
+			sqlLogs = append(sqlLogs, e.Message)
+		}
+	}
+	return sqlLogs
+}
+
+func TestDebugSQLLogsStateQueries(t *testing.T) {
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	ctx, hook, restore := captureDebugLogs(ctx)
+	defer restore()
+
+	jq := query.NewQueryBuilder().Equal("owner", "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180").GreaterThan("amount", 5).Query()
+
+	// Not logged unless enabled
+	_, err = ss.FindContractStates(ctx, ss.p.NOTX(), "domain1", nil, schemaID, jq, pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.Empty(t, stateQueryLogs(hook))
+
+	ss.debugSQL = true
+	_, err = ss.FindContractStates(ctx, ss.p.NOTX(), "domain1", nil, schemaID, jq, pldapi.StateStatusAll)
+	require.NoError(t, err)
+	sqlLogs := stateQueryLogs(hook)
+	require.Len(t, sqlLogs, 1)
+	// The label joins are included, with the parameters expanded
+	assert.Contains(t, sqlLogs[0], `INNER JOIN state_labels AS l0 ON l0.state = "states"."id" AND l0.label = "owner"`)
+	assert.Contains(t, sqlLogs[0], `INNER JOIN state_labels AS l1 ON l1.state = "states"."id" AND l1.label = "amount"`)
+	assert.Contains(t, sqlLogs[0], `l0.value = "000000000000000000000000f7b1c69f5690993f2c8ece56cc89d42b1e737180"`)
+	assert.Contains(t, sqlLogs[0], fmt.Sprintf(`states.schema = "%s"`, schemaID.HexString()))
+	assert.Regexp(t, "rows=0", sqlLogs[0])
+}
+
+func TestDebugSQLLogger(t *testing.T) {
+	ctx, hook, restore := captureDebugLogs(context.Background())
+	defer restore()
+
+	l := debugSQLLogger{}
+	assert.Equal(t, l, l.LogMode(0))
+	l.Info(ctx, "info %d", 1)
+	l.Warn(ctx, "warn %d", 2)
+	l.Error(ctx, "error %d", 3)
+	l.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, fmt.Errorf("pop"))
+
+	entries := hook.AllEntries()
+	require.Len(t, entries, 4)
+	assert.Equal(t, "info 1", entries[0].Message)
+	assert.Equal(t, "warn 2", entries[1].Message)
+	assert.Equal(t, "error 3", entries[2].Message)
+	assert.Regexp(t, "State query failed.*err=pop sql: SELECT 1", entries[3].Message)
+
+	// Nothing is generated when debug is not enabled
+	log.SetLevel("info")
+	l.Trace(ctx, time.Now(), func() (string, int64) { panic("not called") }, nil)
+	assert.Len(t, hook.AllEntries(), 4)
+}
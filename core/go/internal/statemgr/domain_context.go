@@ -20,7 +20,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
@@ -33,6 +36,8 @@ import (
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type domainContext struct {
@@ -42,12 +47,16 @@ type domainContext struct {
 	ss                 *stateManager
 	domainName         string
 	customHashFunction bool
+	flagExternalIDs    bool
 	contractAddress    pldtypes.EthAddress
 	stateLock          sync.Mutex
 	unFlushed          *pendingStateWrites
 	flushing           *pendingStateWrites
 	domainContexts     map[uuid.UUID]*domainContext
 	closed             bool
+	dryRun             bool
+	longLived          bool
+	lastUsed           pldtypes.Timestamp
 
 	// We track creatingStates states beyond the flush - until the transaction that created them is removed, or a full reset
 	// This is because the DB will never return them as "available"
@@ -61,21 +70,39 @@ type domainContext struct {
 
 // Very important that callers Close domain contexts they open
 func (ss *stateManager) NewDomainContext(ctx context.Context, domain components.Domain, contractAddress pldtypes.EthAddress) components.DomainContext {
+	return ss.newDomainContext(ctx, domain.Name(), domain.CustomHashFunction(), domainFlagsExternalIDs(domain), contractAddress, false)
+}
+
+// Long lived contexts are used by components like sequencers that hold them while they run, and hold the
+// in-memory state of transactions between uses - so they are closed by their owner, never by the idle GC
+func (ss *stateManager) NewLongLivedDomainContext(ctx context.Context, domain components.Domain, contractAddress pldtypes.EthAddress) components.DomainContext {
+	return ss.newDomainContext(ctx, domain.Name(), domain.CustomHashFunction(), domainFlagsExternalIDs(domain), contractAddress, true)
+}
+
+// Only relevant to custom hash domains, which must opt in as it costs an extra hash per state
+func domainFlagsExternalIDs(domain components.Domain) bool {
+	return domain.CustomHashFunction() && domain.FlagExternalStateIDs()
+}
+
+func (ss *stateManager) newDomainContext(ctx context.Context, domainName string, customHashFunction, flagExternalIDs bool, contractAddress pldtypes.EthAddress, longLived bool) *domainContext {
 	id := uuid.New()
-	log.L(ctx).Debugf("Domain context %s for domain %s contract %s closed", id, domain.Name(), contractAddress)
+	log.L(ctx).Debugf("Domain context %s for domain %s contract %s closed", id, domainName, contractAddress)
 
 	ss.domainContextLock.Lock()
 	defer ss.domainContextLock.Unlock()
 
 	dc := &domainContext{
-		Context:            log.WithLogField(ctx, "domain_ctx", fmt.Sprintf("%s_%s", domain.Name(), id)),
+		Context:            log.WithLogField(ctx, "domain_ctx", fmt.Sprintf("%s_%s", domainName, id)),
 		id:                 id,
 		ss:                 ss,
-		domainName:         domain.Name(),
-		customHashFunction: domain.CustomHashFunction(),
+		domainName:         domainName,
+		customHashFunction: customHashFunction,
+		flagExternalIDs:    flagExternalIDs,
 		contractAddress:    contractAddress,
+		longLived:          longLived,
 		creatingStates:     make(map[string]*components.StateWithLabels),
 		domainContexts:     make(map[uuid.UUID]*domainContext),
+		lastUsed:           pldtypes.TimestampNow(),
 	}
 	ss.domainContexts[id] = dc
 	return dc
@@ -162,21 +189,28 @@ func (dc *domainContext) mergeUnFlushed(schema components.Schema, dbStates []*pl
 
 	// Get the list of new un-flushed states, which are not already locked for spend
 	matches := make([]*components.StateWithLabels, 0, len(dc.creatingStates))
-	schemaId := schema.Persisted().ID
+	schemaIDs := append([]pldtypes.Bytes32{schema.Persisted().ID}, dc.ss.schemaAliasesFor(dc.domainName, schema.Persisted().ID)...)
+
+	// We build lookup maps for the spent states, and the DB states, only if we need them.
+	// This avoids the cost of an O(n*m) scan when there are many DB results.
+	var spentStates, dbStateIDs map[string]bool
+	// The label set is shared across all the states we evaluate, so resolvers are only looked up once
+	labelSet := dc.ss.labelSetFor(schema)
 	for _, state := range dc.creatingStates {
-		if !state.Schema.Equals(&schemaId) {
+		if !slices.Contains(schemaIDs, state.Schema) {
 			continue
 		}
 		if excludeSpent {
-			spent := false
-			for _, lock := range dc.txLocks {
-				if lock.StateID.Equals(state.ID) && lock.Type.V() == pldapi.StateLockTypeSpend {
-					spent = true
-					break
+			if spentStates == nil {
+				spentStates = make(map[string]bool)
+				for _, lock := range dc.txLocks {
+					if lock.Type.V() == pldapi.StateLockTypeSpend {
+						spentStates[string(lock.StateID)] = true
+					}
 				}
 			}
 			// Cannot return it if it's spent or locked for spending
-			if spent {
+			if spentStates[string(state.ID)] {
 				continue
 			}
 		}
@@ -186,20 +220,18 @@ func (dc *domainContext) mergeUnFlushed(schema components.Schema, dbStates []*pl
 		}
 
 		// Now we see if it matches the query
-		labelSet := dc.ss.labelSetFor(schema)
 		match, err := filters.EvalQuery(dc, query, labelSet, state.LabelValues)
 		if err != nil {
 			return nil, err
 		}
 		if match {
-			dup := false
-			for _, dbState := range dbStates {
-				if dbState.ID.Equals(state.ID) {
-					dup = true
-					break
+			if dbStateIDs == nil {
+				dbStateIDs = make(map[string]bool, len(dbStates))
+				for _, dbState := range dbStates {
+					dbStateIDs[string(dbState.ID)] = true
 				}
 			}
-			if !dup {
+			if !dbStateIDs[string(state.ID)] {
 				log.L(dc).Debugf("Matched state %s from un-flushed writes", &state.ID)
 				// Take a shallow copy, as we'll apply the locks as they exist right now
 				shallowCopy := *state
@@ -221,31 +253,53 @@ func (dc *domainContext) Info() components.DomainContextInfo {
 
 func (dc *domainContext) mergeInMemoryMatches(schema components.Schema, states []*pldapi.State, extras []*components.StateWithLabels, query *query.QueryJSON) (_ []*pldapi.State, err error) {
 
-	// Reconstitute the labels for all the loaded states into the front of an aggregate list
-	fullList := make([]*components.StateWithLabels, len(states), len(states)+len(extras))
-	persistedStateIDs := make(map[string]bool)
+	// Reconstitute the labels for all the loaded states
+	dbList := make([]*components.StateWithLabels, len(states))
+	persistedStateIDs := make(map[string]bool, len(states))
 	for i, s := range states {
-		if fullList[i], err = schema.RecoverLabels(dc, s); err != nil {
+		if dbList[i], err = schema.RecoverLabels(dc, s); err != nil {
 			return nil, err
 		}
-		persistedStateIDs[s.ID.String()] = true
+		persistedStateIDs[string(s.ID)] = true
 	}
 
-	// Copy the matches to the end of that same list
-	// However, we can't be certain that some of the states that were in the flushing list, haven't made it
+	// We can't be certain that some of the states that were in the flushing list, haven't made it
 	// to the DB yet - so we do need to de-dup here.
+	memList := make([]*components.StateWithLabels, 0, len(extras))
 	for _, s := range extras {
-		if !persistedStateIDs[s.ID.String()] {
-			fullList = append(fullList, s)
+		if !persistedStateIDs[string(s.ID)] {
+			memList = append(memList, s)
 		}
 	}
 
-	// Sort it in place - note we ensure we always have a sort instruction on the DB
-	sortInstructions := query.Sort
-	if err = filters.SortValueSetInPlace(dc, dc.ss.labelSetFor(schema), fullList, sortInstructions...); err != nil {
+	// Note we ensure we always have a sort instruction on the DB, and we add the state ID
+	// as a tie-breaker so the order does not depend on the order states were written
+	sortInstructions := stableSort(query.Sort)
+	sorter, err := filters.NewValueSetSorter(dc, dc.ss.labelSetFor(schema), memList, sortInstructions...)
+	if err != nil {
 		return nil, err
 	}
 
+	// The DB results are sorted by the same instructions, so when the in-memory matches are a small
+	// set relative to the DB results we only need to sort those, and merge them in.
+	// However, the DB might not have used exactly the same comparison rules as us (string collation
+	// for example), so we only do this if we confirm the DB results are in our sort order.
+	// Otherwise we fall back to a full sort.
+	var fullList []*components.StateWithLabels
+	sorter.Values = dbList
+	if len(memList)*gallopingMergeMinRatio <= len(dbList) && sort.IsSorted(sorter) && sorter.Error == nil {
+		sorter.Values = memList
+		sort.Sort(sorter)
+		fullList = gallopingMerge(dbList, memList, sorter.LessFunc)
+	} else {
+		fullList = append(dbList, memList...)
+		sorter.Values = fullList
+		sort.Sort(sorter)
+	}
+	if sorter.Error != nil {
+		return nil, sorter.Error
+	}
+
 	// We only want the states (not the labels needed during sort),
 	// and only up to the limit that might have been breached adding in our in-memory states
 	len := len(fullList)
@@ -260,6 +314,35 @@ func (dc *domainContext) mergeInMemoryMatches(schema components.Schema, states [
 
 }
 
+// the DB results must be at least this many times bigger than the in-memory matches to use gallopingMerge
+const gallopingMergeMinRatio = 4
+
+// gallopingMerge merges a (usually small) sorted list into a (usually large) sorted list, by
+// binary searching forwards through the large list for the insertion point of each item in the
+// small list. Items from the small list are inserted after any equal items in the large list.
+func gallopingMerge[T any](large, small []T, less func(i, j T) bool) []T {
+	merged := make([]T, 0, len(large)+len(small))
+	pos := 0
+	for _, item := range small {
+		remaining := large[pos:]
+		insertAt := sort.Search(len(remaining), func(i int) bool {
+			return less(item, remaining[i])
+		})
+		merged = append(merged, remaining[:insertAt]...)
+		merged = append(merged, item)
+		pos += insertAt
+	}
+	return append(merged, large[pos:]...)
+}
+
+func (dc *domainContext) FindAvailableStatesByIDs(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, ids []string) (components.Schema, []*pldapi.State, error) {
+	idsAny := make([]any, len(ids))
+	for i, id := range ids {
+		idsAny[i] = id
+	}
+	return dc.FindAvailableStates(dbTX, schemaID, query.NewQueryBuilder().In(".id", idsAny).Sort(".created").Query())
+}
+
 func (dc *domainContext) GetStatesByID(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, ids []string) (components.Schema, []*pldapi.State, error) {
 	idsAny := make([]any, len(ids))
 	for i, id := range ids {
@@ -307,6 +390,58 @@ func (dc *domainContext) FindAvailableStates(dbTX persistence.DBTX, schemaID pld
 	return schema, states, err
 }
 
+func (dc *domainContext) CountAvailableStates(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON) (uint64, error) {
+	schema, err := dc.ss.getSchemaByID(dc, dbTX, dc.domainName, schemaID, true)
+	if err != nil {
+		return 0, err
+	}
+
+	// Find the un-flushed states that match, and exclude them from the DB count along with the
+	// states being spent - so states that are both in memory and in the DB are only counted once
+	spending, _, _, err := dc.getUnFlushedSpends()
+	if err != nil {
+		return 0, err
+	}
+	dc.stateLock.Lock()
+	memMatches, err := dc.mergeUnFlushed(schema, nil, query, true /* exclude spent states */, false)
+	dc.stateLock.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	excluded := spending
+	for _, s := range memMatches {
+		excluded = append(excluded, s.ID)
+	}
+
+	dbCount, err := dc.ss.countAvailableStates(dc, dbTX, dc.domainName, &dc.contractAddress, schema, query, excluded)
+	if err != nil {
+		return 0, err
+	}
+	log.L(dc).Debugf("domainContext:CountAvailableStates db=%d unflushed=%d", dbCount, len(memMatches))
+	return uint64(dbCount) + uint64(len(memMatches)), nil
+}
+
+func (dc *domainContext) FindAvailableStatesPage(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON, cursor string) (components.Schema, []*pldapi.State, string, error) {
+	if query.Limit == nil || *query.Limit <= 0 {
+		return nil, nil, "", i18n.NewError(dc, msgs.MsgFiltersQueryLimitRequired)
+	}
+	pageQuery, err := applyQueryCursor(dc, query, cursor)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	schema, states, err := dc.FindAvailableStates(dbTX, schemaID, pageQuery)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	var nextCursor string
+	if len(states) == *query.Limit {
+		if nextCursor, err = newQueryCursor(dc, pageQuery.Sort, states[len(states)-1]); err != nil {
+			return nil, nil, "", err
+		}
+	}
+	return schema, states, nextCursor, nil
+}
+
 func (dc *domainContext) FindAvailableNullifiers(dbTX persistence.DBTX, schemaID pldtypes.Bytes32, query *query.QueryJSON) (components.Schema, []*pldapi.State, error) {
 
 	// Build a list of unflushed and spending nullifiers
@@ -346,7 +481,7 @@ func (dc *domainContext) upsertStates(dbTX persistence.DBTX, holdingLock bool, s
 			return nil, err
 		}
 
-		vs, err := schema.ProcessState(dc, &dc.contractAddress, ns.Data, ns.ID, dc.customHashFunction)
+		vs, err := schema.ProcessState(dc, &dc.contractAddress, ns.Data, ns.ID, dc.customHashFunction, dc.flagExternalIDs)
 		if err != nil {
 			return nil, err
 		}
@@ -497,6 +632,59 @@ func (dc *domainContext) ResetTransactions(transactions ...uuid.UUID) {
 	dc.txLocks = newLocks
 }
 
+func (dc *domainContext) MarkStatesUnspent(dbTX persistence.DBTX, transactionID uuid.UUID, stateIDs []string) error {
+	ids := make([]pldtypes.HexBytes, len(stateIDs))
+	for i, id := range stateIDs {
+		var err error
+		if ids[i], err = pldtypes.ParseHexBytes(dc, id); err != nil || len(ids[i]) == 0 {
+			return i18n.WrapError(dc, err, msgs.MsgStateInvalidID, id)
+		}
+	}
+
+	dc.stateLock.Lock()
+	defer dc.stateLock.Unlock()
+	if flushErr := dc.checkResetInitUnFlushed(); flushErr != nil {
+		return flushErr
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+	if dc.dryRun {
+		return i18n.NewError(dc, msgs.MsgStateDomainContextDryRun, dc.id)
+	}
+	err := dbTX.DB().
+		WithContext(dc).
+		Table("state_spend_records").
+		Where("domain_name = ?", dc.domainName).
+		Where(`"transaction" = ?`, transactionID).
+		Where("state IN (?)", ids).
+		Delete(nil).
+		Error
+	if err != nil {
+		return err
+	}
+
+	newLocks := make([]*pldapi.StateLock, 0, len(dc.txLocks))
+	for _, lock := range dc.txLocks {
+		if lock.Transaction == transactionID && lock.Type.V() == pldapi.StateLockTypeSpend && containsStateID(ids, lock.StateID) {
+			continue
+		}
+		newLocks = append(newLocks, lock)
+	}
+	dc.txLocks = newLocks
+	return nil
+}
+
+func containsStateID(ids []pldtypes.HexBytes, id pldtypes.HexBytes) bool {
+	for _, candidate := range ids {
+		if candidate.Equals(id) {
+			return true
+		}
+	}
+	return false
+}
+
 func (dc *domainContext) StateLocksByTransaction() map[uuid.UUID][]pldapi.StateLock {
 	dc.stateLock.Lock()
 	defer dc.stateLock.Unlock()
@@ -508,6 +696,27 @@ func (dc *domainContext) StateLocksByTransaction() map[uuid.UUID][]pldapi.StateL
 	return txLocksCopy
 }
 
+func (dc *domainContext) GetLockStatus(stateID string) ([]pldapi.StateLock, error) {
+	id, err := pldtypes.ParseHexBytes(dc, stateID)
+	if err != nil || len(id) == 0 {
+		return nil, i18n.WrapError(dc, err, msgs.MsgStateInvalidID, stateID)
+	}
+
+	dc.stateLock.Lock()
+	defer dc.stateLock.Unlock()
+	if flushErr := dc.checkResetInitUnFlushed(); flushErr != nil {
+		return nil, flushErr
+	}
+
+	var locks []pldapi.StateLock
+	for _, l := range dc.txLocks {
+		if l.StateID.Equals(id) {
+			locks = append(locks, *l)
+		}
+	}
+	return locks, nil
+}
+
 // Reset puts the world back to fresh - including completing any flush.
 //
 // Must be called after a flush error before the context can be used, as on a flush
@@ -541,13 +750,131 @@ func (dc *domainContext) Close() {
 	delete(dc.ss.domainContexts, dc.id)
 }
 
-func (dc *domainContext) Flush(dbTX persistence.DBTX) error {
-	ctx := dc.Ctx()
+func (dc *domainContext) DryRun(fn components.DomainContextFunction) error {
+	snapshot, err := dc.ExportSnapshot()
+	if err != nil {
+		return err
+	}
+	dryRunDC := dc.ss.newDomainContext(dc.Context, dc.domainName, dc.customHashFunction, dc.flagExternalIDs, dc.contractAddress, false)
+	dryRunDC.dryRun = true
+	defer dryRunDC.Close()
+	log.L(dc).Debugf("Domain context %s running dry-run copy %s", dc.id, dryRunDC.id)
+	if err := dryRunDC.ImportSnapshot(snapshot); err != nil {
+		return err
+	}
+	return fn(dryRunDC)
+}
+
+func (dc *domainContext) SimulateAssemble(fn components.DomainContextFunction) (created []*components.StateWithLabels, err error) {
+	err = dc.DryRun(func(dryRun components.DomainContext) error {
+		dryRunDC := dryRun.(*domainContext)
+
+		// The states imported from this context were already being created before the function ran
+		dryRunDC.stateLock.Lock()
+		existing := make(map[string]bool, len(dryRunDC.creatingStates))
+		for id := range dryRunDC.creatingStates {
+			existing[id] = true
+		}
+		dryRunDC.stateLock.Unlock()
+
+		if err := fn(dryRunDC); err != nil {
+			return err
+		}
+
+		dryRunDC.stateLock.Lock()
+		defer dryRunDC.stateLock.Unlock()
+		if dryRunDC.unFlushed == nil {
+			return nil // the function reset the context
+		}
+		// Return them in the order they were upserted. If a state was upserted more than once, the
+		// copy that is returned in queries is the last one in creatingStates.
+		for _, s := range dryRunDC.unFlushed.states {
+			id := s.ID.String()
+			if !existing[id] && dryRunDC.creatingStates[id] == s {
+				created = append(created, s)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// Checks under the state lock whether the context has no pending writes or in-memory state,
+// and has not been used since the idle timeout - closing it if so. Returns true if closed.
+func (dc *domainContext) closeIfIdle(idleTimeout time.Duration) bool {
+	dc.stateLock.Lock()
+	defer dc.stateLock.Unlock()
+
+	if dc.closed || dc.longLived || dc.flushing != nil || !dc.unFlushed.isEmpty() {
+		return false
+	}
+	// Locks and creating states are only held in memory, so would be lost if we closed the context
+	if len(dc.txLocks) > 0 || len(dc.creatingStates) > 0 {
+		return false
+	}
+	if time.Since(dc.lastUsed.Time()) < idleTimeout {
+		return false
+	}
+	log.L(dc).Infof("Domain context %s for domain %s contract %s idle since %s", dc.id, dc.domainName, dc.contractAddress, dc.lastUsed)
+	dc.closed = true
+	return true
+}
+
+// Domain contexts should be closed by the code that opens them, but as they are long lived
+// and a leak would be unbounded, we garbage collect any that sit idle for a long time.
+func (ss *stateManager) domainContextGC() {
+	defer close(ss.domainContextGCDone)
+
+	for {
+		select {
+		case <-ss.bgCtx.Done():
+			log.L(ss.bgCtx).Debugf("domain context GC exiting")
+			return
+		case <-time.After(ss.domainContextGCInterval):
+		}
+
+		ss.gcIdleDomainContexts()
+	}
+}
+
+func (ss *stateManager) gcIdleDomainContexts() {
+	ss.domainContextLock.Lock()
+	candidates := make([]*domainContext, 0, len(ss.domainContexts))
+	for _, dc := range ss.domainContexts {
+		candidates = append(candidates, dc)
+	}
+	ss.domainContextLock.Unlock()
+
+	var reaped []*domainContext
+	for _, dc := range candidates {
+		if dc.closeIfIdle(ss.domainContextIdleTimeout) {
+			reaped = append(reaped, dc)
+		}
+	}
+
+	ss.domainContextLock.Lock()
+	for _, dc := range reaped {
+		delete(ss.domainContexts, dc.id)
+	}
+	ss.domainContextLock.Unlock()
+	log.L(ss.bgCtx).Debugf("domain context GC before=%d reaped=%d", len(candidates), len(reaped))
+}
+
+func (dc *domainContext) Flush(dbTX persistence.DBTX) (err error) {
+	ctx, span := tracer.Start(dc.Ctx(), "DomainContext.Flush", trace.WithAttributes(
+		attribute.String(traceAttrDomain, dc.domainName),
+		attribute.String(traceAttrContractAddress, dc.contractAddress.String()),
+	))
+	defer func() { endSpan(span, err) }()
 	log.L(ctx).Infof("Flushing context domain=%s", dc.domainName)
 
 	// We hold the lock while we are doing the synchronous part of flushing
 	dc.stateLock.Lock()
 	defer dc.stateLock.Unlock()
+	dc.lastUsed = pldtypes.TimestampNow()
 
 	if dc.flushing != nil {
 		if dc.flushing.flushResult != nil {
@@ -559,6 +886,10 @@ func (dc *domainContext) Flush(dbTX persistence.DBTX) error {
 		return i18n.NewError(ctx, msgs.MsgStateFlushInProgress)
 	}
 
+	if dc.dryRun {
+		return i18n.NewError(ctx, msgs.MsgStateDomainContextDryRun, dc.id)
+	}
+
 	// Sync check if there's already an error
 	// Ok we're good to go async
 	dc.flushing = dc.unFlushed
@@ -569,6 +900,10 @@ func (dc *domainContext) Flush(dbTX persistence.DBTX) error {
 		log.L(ctx).Debugf("nothing pending to flush in domain context")
 		return nil
 	}
+	span.SetAttributes(
+		attribute.Int(traceAttrStateCount, len(dc.flushing.states)),
+		attribute.Int(traceAttrNullifierCount, len(dc.flushing.stateNullifiers)),
+	)
 
 	// Need to make sure we clean up after ourselves if we fail synchronously
 	var syncFlushError error
@@ -587,6 +922,74 @@ func (dc *domainContext) Flush(dbTX persistence.DBTX) error {
 	return nil
 }
 
+func (dc *domainContext) FlushAsync(dbTX persistence.DBTX, postCommit func(ctx context.Context) error) error {
+	if err := dc.Flush(dbTX); err != nil {
+		return err
+	}
+	dbTX.AddPostCommit(func(txCtx context.Context) {
+		dc.ss.queuePostCommit(txCtx, postCommit)
+	})
+	return nil
+}
+
+func (ss *stateManager) queuePostCommit(ctx context.Context, postCommit func(ctx context.Context) error) {
+	if ss.bgCtx.Err() != nil {
+		log.L(ctx).Warnf("state manager stopping - post-commit callback not run")
+		return
+	}
+	ss.postCommitLock.Lock()
+	ss.postCommitQueue = append(ss.postCommitQueue, postCommit)
+	queued := len(ss.postCommitQueue)
+	ss.postCommitLock.Unlock()
+	if queued > ss.postCommitWorkers {
+		log.L(ctx).Debugf("post-commit queue backlog: %d callbacks", queued)
+	}
+	ss.signalPostCommitWorker()
+}
+
+func (ss *stateManager) signalPostCommitWorker() {
+	select {
+	case ss.postCommitSignal <- struct{}{}:
+	default: // a worker is already due to wake
+	}
+}
+
+func (ss *stateManager) nextPostCommit() (postCommit func(ctx context.Context) error) {
+	ss.postCommitLock.Lock()
+	defer ss.postCommitLock.Unlock()
+	if len(ss.postCommitQueue) > 0 {
+		postCommit = ss.postCommitQueue[0]
+		ss.postCommitQueue[0] = nil
+		ss.postCommitQueue = ss.postCommitQueue[1:]
+		if len(ss.postCommitQueue) > 0 {
+			// wake another worker to process the remainder in parallel
+			ss.signalPostCommitWorker()
+		}
+	}
+	return postCommit
+}
+
+func (ss *stateManager) postCommitWorker() {
+	defer ss.postCommitDone.Done()
+
+	for {
+		if ss.bgCtx.Err() == nil {
+			if postCommit := ss.nextPostCommit(); postCommit != nil {
+				if err := postCommit(ss.bgCtx); err != nil {
+					log.L(ss.bgCtx).Errorf("post-commit callback failed: %s", err)
+				}
+				continue
+			}
+		}
+		select {
+		case <-ss.bgCtx.Done():
+			log.L(ss.bgCtx).Debugf("post-commit worker exiting")
+			return
+		case <-ss.postCommitSignal:
+		}
+	}
+}
+
 func (dc *domainContext) finalizer(ctx context.Context, commitError error) {
 	dc.stateLock.Lock()
 	defer dc.stateLock.Unlock()
@@ -601,11 +1004,13 @@ func (dc *domainContext) finalizer(ctx context.Context, commitError error) {
 }
 
 // MUST hold the lock to call this function
-// Simply checks there isn't an un-cleared error that means the caller must reset.
+// Simply checks there isn't an un-cleared error that means the caller must reset,
+// and records the use of the context to stop it being garbage collected.
 func (dc *domainContext) checkResetInitUnFlushed() error {
 	if dc.closed {
 		return i18n.NewError(dc, msgs.MsgStateDomainContextClosed)
 	}
+	dc.lastUsed = pldtypes.TimestampNow()
 	// Peek if there's a broken flush that needs a reset
 	if dc.flushing != nil {
 		select {
@@ -21,19 +21,36 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
+	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const fakeCoinABI = `{
@@ -138,6 +155,158 @@ func TestStateFlushNoWork(t *testing.T) {
 
 }
 
+func TestStateFlushAsync(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	tx1 := uuid.New()
+	data1 := fmt.Sprintf(`{"amount": 100, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))
+	_, err = dc.UpsertStates(ss.p.NOTX(), genWidget(t, schemas[0].ID(), &tx1, data1))
+	require.NoError(t, err)
+
+	// The callback is only called after commit, and an error is just logged
+	called := make(chan struct{})
+	err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return dc.FlushAsync(dbTX, func(ctx context.Context) error {
+			close(called)
+			return fmt.Errorf("pop")
+		})
+	})
+	require.NoError(t, err)
+	<-called
+
+	// The states were flushed synchronously
+	require.Nil(t, dc.flushing)
+	states, err := ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemas[0].ID(), query.NewQueryBuilder().Query(), &components.StateQueryOptions{StatusQualifier: pldapi.StateStatusAll})
+	require.NoError(t, err)
+	assert.Len(t, states, 1)
+
+}
+
+func TestStateFlushLogging(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	log.EnsureInit()
+	prevLevel := log.GetLevel()
+	log.SetLevel("trace")
+	defer log.SetLevel(prevLevel)
+	logger, hook := logtest.NewNullLogger()
+	logger.SetLevel(logrus.TraceLevel)
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+
+	contractAddress, dc := newTestDomainContext(t, log.WithLogger(ctx, logrus.NewEntry(logger)), ss, "domain1", false)
+	defer dc.Close()
+
+	tx1 := uuid.New()
+	data1 := fmt.Sprintf(`{"amount": 100, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))
+	states, err := dc.UpsertStates(ss.p.NOTX(), genWidget(t, schemas[0].ID(), &tx1, data1))
+	require.NoError(t, err)
+
+	err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return dc.Flush(dbTX)
+	})
+	require.NoError(t, err)
+
+	var flushed, flushedState *logrus.Entry
+	for _, e := range hook.AllEntries() {
+		if e.Level == logrus.DebugLevel && strings.HasPrefix(e.Message, "Flushed state batch") {
+			flushed = e
+		}
+		if e.Level == logrus.TraceLevel && strings.HasPrefix(e.Message, "Flushed state ") {
+			flushedState = e
+		}
+	}
+	require.NotNil(t, flushed)
+	assert.Regexp(t, fmt.Sprintf(`domain=domain1 contract=%s states=1 labels=2 locks=0 nullifiers=0 duration_ms=[0-9.]+`, contractAddress), flushed.Message)
+	require.NotNil(t, flushedState)
+	assert.Contains(t, flushedState.Message, fmt.Sprintf("id=%s", states[0].ID))
+
+}
+
+func TestStateFlushAsyncFlushError(t *testing.T) {
+
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	dc.flushing = &pendingStateWrites{flushResult: fmt.Errorf("pop")}
+	err := dc.FlushAsync(ss.p.NOTX(), func(ctx context.Context) error {
+		panic("not called")
+	})
+	assert.Regexp(t, "pop", err)
+
+}
+
+func TestQueuePostCommitStopped(t *testing.T) {
+
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	done()
+
+	ss.queuePostCommit(ctx, func(ctx context.Context) error {
+		panic("not called")
+	})
+	assert.Empty(t, ss.postCommitQueue)
+
+}
+
+func TestQueuePostCommitDoesNotBlockWhenWorkersBusy(t *testing.T) {
+
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	// Occupy every worker with a callback that blocks until we release it
+	release := make(chan struct{})
+	running := make(chan struct{}, ss.postCommitWorkers)
+	for i := 0; i < ss.postCommitWorkers; i++ {
+		ss.queuePostCommit(ctx, func(ctx context.Context) error {
+			running <- struct{}{}
+			<-release
+			return nil
+		})
+	}
+	for i := 0; i < ss.postCommitWorkers; i++ {
+		<-running
+	}
+
+	// Queue far more than the worker count - the caller must return for every one
+	const extra = 100
+	var completed sync.WaitGroup
+	completed.Add(extra)
+	queued := make(chan struct{})
+	go func() {
+		defer close(queued)
+		for i := 0; i < extra; i++ {
+			ss.queuePostCommit(ctx, func(ctx context.Context) error {
+				completed.Done()
+				return nil
+			})
+		}
+	}()
+	select {
+	case <-queued:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "queuePostCommit blocked with all workers busy")
+	}
+
+	// Once released, everything queued is processed
+	close(release)
+	completed.Wait()
+
+}
+
 func TestUpsertSchemaEmptyList(t *testing.T) {
 
 	ctx, ss, _, _, done := newDBMockStateManager(t)
@@ -191,6 +360,67 @@ func TestUpsertSchemaAndStates(t *testing.T) {
 
 }
 
+func TestUpsertStatesExternalID(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	md := componentsmocks.NewDomain(t)
+	md.On("Name").Return("domain1")
+	md.On("CustomHashFunction").Return(true)
+	md.On("FlagExternalStateIDs").Return(true)
+	dc := ss.NewDomainContext(ctx, md, *pldtypes.RandAddress()).(*domainContext)
+	defer dc.Close()
+
+	// Supply the ID Paladin would have calculated for one, and an external ID for the other
+	data1 := pldtypes.RawJSON(fmt.Sprintf(`{"amount": 100, "owner": "0x1eDfD974fE6828dE81a1a762df680111870B7cDD", "salt": "%s"}`, pldtypes.RandHex(32)))
+	data2 := pldtypes.RawJSON(fmt.Sprintf(`{"amount": 200, "owner": "0x1eDfD974fE6828dE81a1a762df680111870B7cDD", "salt": "%s"}`, pldtypes.RandHex(32)))
+	calculated, err := schemas[0].ProcessState(ctx, &dc.contractAddress, data1, nil, false, false)
+	require.NoError(t, err)
+	externalID := pldtypes.HexBytes(pldtypes.RandBytes(32))
+
+	txID := uuid.New()
+	states, err := dc.UpsertStates(ss.p.NOTX(),
+		&components.StateUpsert{ID: calculated.State.ID, Schema: schemaID, Data: data1, CreatedBy: &txID},
+		&components.StateUpsert{ID: externalID, Schema: schemaID, Data: data2, CreatedBy: &txID},
+	)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.False(t, states[0].ExternalID)
+	assert.Equal(t, externalID, states[1].ID)
+	assert.True(t, states[1].ExternalID)
+
+	checkAvailable := func() {
+		_, states, err := dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Sort("amount").Query())
+		require.NoError(t, err)
+		require.Len(t, states, 2)
+		assert.Equal(t, calculated.State.ID, states[0].ID)
+		assert.False(t, states[0].ExternalID)
+		assert.Equal(t, externalID, states[1].ID)
+		assert.True(t, states[1].ExternalID)
+	}
+
+	// In memory, then from the DB after flush
+	checkAvailable()
+	syncFlushContext(t, dc)
+	checkAvailable()
+
+	// Custom hash domains that have not opted in do not have external IDs flagged
+	_, dcNoFlag := newTestDomainContext(t, ctx, ss, "domain1", true)
+	defer dcNoFlag.Close()
+	data3 := pldtypes.RawJSON(fmt.Sprintf(`{"amount": 300, "owner": "0x1eDfD974fE6828dE81a1a762df680111870B7cDD", "salt": "%s"}`, pldtypes.RandHex(32)))
+	states, err = dcNoFlag.UpsertStates(ss.p.NOTX(),
+		&components.StateUpsert{ID: pldtypes.RandBytes(32), Schema: schemaID, Data: data3, CreatedBy: &txID},
+	)
+	require.NoError(t, err)
+	assert.False(t, states[0].ExternalID)
+
+}
+
 func TestStateLockErrorsTransaction(t *testing.T) {
 
 	ctx, ss, _, done := newDBTestStateManager(t)
@@ -238,6 +468,59 @@ func TestStateLockErrorsTransaction(t *testing.T) {
 	require.Regexp(t, "PD010118", err) // create lock for state not in context
 }
 
+func TestGetLockStatus(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	tx1, tx2 := uuid.New(), uuid.New()
+	states, err := dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{
+		Schema:    schemas[0].ID(),
+		Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": 10, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))),
+		CreatedBy: &tx1,
+	})
+	require.NoError(t, err)
+	stateID := states[0].ID
+
+	locks, err := dc.GetLockStatus(stateID.String())
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, pldapi.StateLockTypeCreate, locks[0].Type.V())
+	assert.Equal(t, tx1, locks[0].Transaction)
+
+	err = dc.AddStateLocks(&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: stateID, Transaction: tx2})
+	require.NoError(t, err)
+	locks, err = dc.GetLockStatus(stateID.HexString()) // with or without the 0x prefix
+	require.NoError(t, err)
+	require.Len(t, locks, 2)
+	assert.Equal(t, pldapi.StateLockTypeSpend, locks[1].Type.V())
+	assert.Equal(t, tx2, locks[1].Transaction)
+
+	// Copies are returned
+	locks[1].Transaction = tx1
+	assert.Equal(t, tx2, dc.StateLocksByTransaction()[tx2][0].Transaction)
+
+	locks, err = dc.GetLockStatus(pldtypes.RandHex(32))
+	require.NoError(t, err)
+	assert.Nil(t, locks)
+
+	_, err = dc.GetLockStatus("not hex")
+	assert.Regexp(t, "PD010144", err)
+
+	_, err = dc.GetLockStatus("")
+	assert.Regexp(t, "PD010144", err)
+
+	dc.Close()
+	_, err = dc.GetLockStatus(stateID.String())
+	assert.Regexp(t, "PD010122", err)
+}
+
 func TestStateContextMintSpendMint(t *testing.T) {
 
 	ctx, ss, _, done := newDBTestStateManager(t)
@@ -499,6 +782,15 @@ func checkPostCommit(t *testing.T, ss *stateManager, txID uuid.UUID, expectedSpe
 		require.Contains(t, infoIDs, sID.String())
 	}
 
+	// Streaming returns the same states, without grouping by record type
+	var streamed []*pldapi.StateBase
+	err = ss.StreamTransactionStates(ss.bgCtx, ss.p.NOTX(), txID, func(s *pldapi.StateBase) error {
+		streamed = append(streamed, s)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(txStates.Spent)+len(txStates.Read)+len(txStates.Confirmed)+len(txStates.Info), len(streamed))
+
 }
 
 func TestStateContextMintSpendWithNullifier(t *testing.T) {
@@ -740,6 +1032,35 @@ func TestDomainContextFlushErrorCapture(t *testing.T) {
 
 }
 
+func TestFlushSplitsBatches(t *testing.T) {
+
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+	ss.maxBatchStates = 2
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	op := dc.newPendingStateWrites()
+	for i := 0; i < 3; i++ {
+		stateID := pldtypes.HexBytes(pldtypes.RandBytes(32))
+		op.states = append(op.states, &components.StateWithLabels{State: &pldapi.State{StateBase: pldapi.StateBase{ID: stateID, DomainName: "domain1"}}})
+		op.stateNullifiers = append(op.stateNullifiers, &pldapi.StateNullifier{DomainName: "domain1", State: stateID, ID: pldtypes.RandBytes(32)})
+	}
+
+	// 3 states and 3 nullifiers are each written in two batches
+	db.ExpectBegin()
+	db.ExpectExec("INSERT.*states").WillReturnResult(driver.ResultNoRows)
+	db.ExpectExec("INSERT.*states").WillReturnResult(driver.ResultNoRows)
+	db.ExpectExec("INSERT.*state_nullifiers").WillReturnResult(driver.ResultNoRows)
+	db.ExpectExec("INSERT.*state_nullifiers").WillReturnError(fmt.Errorf("pop"))
+	db.ExpectRollback()
+	err := ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return op.exec(ctx, dbTX)
+	})
+	assert.Regexp(t, "pop", err)
+}
+
 func TestDCMergeUnFlushedWhileFlushing(t *testing.T) {
 
 	ctx, ss, _, _, done := newDBMockStateManager(t)
@@ -754,7 +1075,7 @@ func TestDCMergeUnFlushedWhileFlushing(t *testing.T) {
 
 	s1, err := schema.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 	tx1 := uuid.New()
 	_, err = dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{ID: s1.ID, Schema: schema.ID(), Data: s1.Data, CreatedBy: &tx1})
@@ -811,11 +1132,11 @@ func TestDSIMergeUnFlushedMultipleSchemas(t *testing.T) {
 
 	s1, err := schema1.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 	s2, err := schema2.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"tokenUri": "%s", "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32), pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32), pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	dc.creatingStates[s1.ID.String()] = s1
@@ -843,7 +1164,7 @@ func TestDSIMergeUnFlushedBadDBRecord(t *testing.T) {
 
 	s1, err := schema1.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	dc.creatingStates[s1.ID.String()] = s1
@@ -870,7 +1191,7 @@ func TestDCMergeUnFlushedWhileFlushingDedup(t *testing.T) {
 	// Add a first state that will be included in the query
 	s1, err := schema.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 10, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 	tx1 := uuid.New()
 	_, err = dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{ID: s1.ID, Schema: schema.ID(), Data: s1.Data, CreatedBy: &tx1})
@@ -879,7 +1200,7 @@ func TestDCMergeUnFlushedWhileFlushingDedup(t *testing.T) {
 	// We add a second state, that will be excluded from the query due to a spending lock
 	s2, err := schema.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 	_, err = dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{ID: s2.ID, Schema: schema.ID(), Data: s2.Data, CreatedBy: &tx1})
 	require.NoError(t, err)
@@ -923,7 +1244,7 @@ func TestDCMergeUnFlushedEvalError(t *testing.T) {
 
 	s1, err := schema.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 	tx1 := uuid.New()
 	_, err = dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{ID: s1.ID, Schema: schema.ID(), Data: s1.Data, CreatedBy: &tx1})
@@ -949,7 +1270,7 @@ func TestDCMergedInMemoryMatchesRecoverLabelsFail(t *testing.T) {
 
 	s1, err := schema.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 	s1.Data = pldtypes.RawJSON(`! wrong `)
 
@@ -978,7 +1299,7 @@ func TestDCMergedInMemoryMatchesSortFail(t *testing.T) {
 
 	s1, err := schema.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	// Insert state into our unflushed state list
@@ -1064,6 +1385,79 @@ func TestDCResetWithMixedTxns(t *testing.T) {
 
 }
 
+func TestDomainContextGCIdle(t *testing.T) {
+
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	_, dcIdle := newTestDomainContext(t, ctx, ss, "domain1", false)
+	_, dcActive := newTestDomainContext(t, ctx, ss, "domain1", false)
+	_, dcPending := newTestDomainContext(t, ctx, ss, "domain1", false)
+	_, dcLocked := newTestDomainContext(t, ctx, ss, "domain1", false)
+	_, dcCreating := newTestDomainContext(t, ctx, ss, "domain1", false)
+	md := componentsmocks.NewDomain(t)
+	md.On("Name").Return("domain1")
+	md.On("CustomHashFunction").Return(false)
+	dcLongLived := ss.NewLongLivedDomainContext(ctx, md, *pldtypes.RandAddress()).(*domainContext)
+	defer dcActive.Close()
+	defer dcPending.Close()
+	defer dcLocked.Close()
+	defer dcCreating.Close()
+	defer dcLongLived.Close()
+
+	// Use the contexts
+	err := dcLocked.AddStateLocks(
+		&pldapi.StateLock{StateID: pldtypes.HexBytes("state1"), Type: pldapi.StateLockTypeRead.Enum(), Transaction: uuid.New()})
+	require.NoError(t, err)
+	err = dcPending.UpsertNullifiers()
+	require.NoError(t, err)
+	dcPending.unFlushed.stateNullifiers = append(dcPending.unFlushed.stateNullifiers, &pldapi.StateNullifier{})
+	dcCreating.creatingStates["state2"] = &components.StateWithLabels{}
+
+	// All but one go stale, but only the one with no in-memory state is reaped
+	staleTime := pldtypes.Timestamp(time.Now().Add(-2 * ss.domainContextIdleTimeout).UnixNano())
+	for _, dc := range []*domainContext{dcIdle, dcPending, dcLocked, dcCreating, dcLongLived} {
+		dc.lastUsed = staleTime
+	}
+
+	ss.gcIdleDomainContexts()
+
+	dcList := ss.ListDomainContexts()
+	assert.Len(t, dcList, 5)
+	assert.NotContains(t, dcList, dcIdle.Info())
+	assert.Contains(t, dcList, dcActive.Info())
+	assert.Contains(t, dcList, dcPending.Info())
+	assert.Contains(t, dcList, dcLocked.Info())
+	assert.Contains(t, dcList, dcCreating.Info())
+	assert.Contains(t, dcList, dcLongLived.Info())
+
+	_, _, err = dcIdle.FindAvailableStates(ss.p.NOTX(), pldtypes.RandBytes32(), query.NewQueryBuilder().Query())
+	assert.Regexp(t, "PD010122", err)
+
+}
+
+func TestDomainContextGCLoop(t *testing.T) {
+	ctx := context.Background()
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	ss := NewStateManager(ctx, &pldconf.StateStoreConfig{
+		DomainContextGCInterval:  confutil.P("100ms"),
+		DomainContextIdleTimeout: confutil.P("0"),
+	}, p.P).(*stateManager)
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	p.Mock.ExpectQuery("SELECT.*schema_aliases").WillReturnRows(sqlmock.NewRows([]string{}))
+	err = ss.Start()
+	require.NoError(t, err)
+	defer ss.Stop()
+
+	assert.Eventually(t, func() bool {
+		return len(ss.ListDomainContexts()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestCheckEvalGTTimestamp(t *testing.T) {
 	ctx, ss, _, _, done := newDBMockStateManager(t)
 	defer done()
@@ -1104,6 +1498,138 @@ func TestCheckEvalGTTimestamp(t *testing.T) {
 
 }
 
+func TestCheckEvalBetweenTimestamp(t *testing.T) {
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	jq := query.NewQueryBuilder().Between(".created", 1726545933211347000, 1726545933211347002).Query()
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, fakeCoinABI))
+	require.NoError(t, err)
+	labelSet := dc.ss.labelSetFor(schema)
+
+	ls := filters.PassthroughValueSet{}
+
+	stateID := pldtypes.MustParseHexBytes("2eaf4727b7c7e9b3728b1344ac38ea6d8698603dc3b41d9458d7c011c20ce672")
+
+	for created, expected := range map[int64]bool{
+		1726545933211346999: false,
+		1726545933211347000: true, // bounds are inclusive
+		1726545933211347001: true,
+		1726545933211347002: true,
+		1726545933211347003: false,
+	} {
+		addStateBaseLabels(ls, stateID, pldtypes.TimestampFromUnix(created))
+		match, err := filters.EvalQuery(ctx, jq, labelSet, ls)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, match, created)
+	}
+
+}
+
+func TestFindAvailableStatesBetweenInt64Label(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, `{
+		"type": "tuple",
+		"internalType": "struct Sized",
+		"components": [
+			{ "name": "salt", "type": "bytes32" },
+			{ "name": "size", "type": "int64", "indexed": true }
+		]
+	}`)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	// The create locks held by the transaction make the states available
+	tx1 := uuid.New()
+	newSized := func(size int) *components.StateUpsert {
+		return &components.StateUpsert{
+			Schema:    schemaID,
+			Data:      pldtypes.RawJSON(fmt.Sprintf(`{"size": %d, "salt": "%s"}`, size, pldtypes.RandHex(32))),
+			CreatedBy: &tx1,
+		}
+	}
+
+	// Some states are in the DB, and some are only in memory, so both the SQL and the in-memory evaluation apply
+	flushed, err := dc.UpsertStates(ss.p.NOTX(), newSized(10), newSized(20), newSized(30))
+	require.NoError(t, err)
+	syncFlushContext(t, dc)
+	_, err = dc.UpsertStates(ss.p.NOTX(), newSized(40), newSized(50))
+	require.NoError(t, err)
+
+	checkSizes := func(jq *query.QueryJSON, expected ...int64) {
+		_, states, err := dc.FindAvailableStates(ss.p.NOTX(), schemaID, jq)
+		require.NoError(t, err)
+		var sizes []int64
+		for _, s := range states {
+			var sized struct {
+				Size pldtypes.HexUint256 `json:"size"`
+			}
+			require.NoError(t, json.Unmarshal(s.Data, &sized))
+			sizes = append(sizes, sized.Size.Int().Int64())
+		}
+		assert.Equal(t, expected, sizes)
+	}
+
+	checkSizes(query.NewQueryBuilder().Between("size", 20, 40).Sort("size").Query(), 20, 30, 40)
+	checkSizes(query.NewQueryBuilder().Between("size", 20, 40, query.Not).Sort("size").Query(), 10, 50)
+	checkSizes(query.NewQueryBuilder().Between("size", 60, 70).Sort("size").Query())
+	checkSizes(query.NewQueryBuilder().Between(".created", flushed[0].Created, flushed[2].Created).Between("size", 0, 20).Sort("size").Query(), 10, 20)
+}
+
+func TestFindAvailableStatesByID(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	tx1 := uuid.New()
+	newCoin := func() *components.StateUpsert {
+		return &components.StateUpsert{
+			Schema: schemaID,
+			Data: pldtypes.RawJSON(fmt.Sprintf(
+				`{"amount": 10, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
+				pldtypes.RandHex(32))),
+			CreatedBy: &tx1,
+		}
+	}
+
+	// One state is in the DB, and one is only in memory
+	flushed, err := dc.UpsertStates(ss.p.NOTX(), newCoin())
+	require.NoError(t, err)
+	syncFlushContext(t, dc)
+	unflushed, err := dc.UpsertStates(ss.p.NOTX(), newCoin())
+	require.NoError(t, err)
+
+	for _, s := range []*pldapi.State{flushed[0], unflushed[0]} {
+		// Upper case with a 0x prefix matches the lower case hex stored
+		_, states, err := dc.FindAvailableStates(ss.p.NOTX(), schemaID,
+			query.NewQueryBuilder().Equal(".id", "0x"+strings.ToUpper(s.ID.HexString())).Query())
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		assert.Equal(t, s.ID, states[0].ID)
+	}
+
+	_, _, err = dc.FindAvailableStates(ss.p.NOTX(), schemaID,
+		query.NewQueryBuilder().Equal(".id", "0xfeedbeef").Query())
+	assert.Regexp(t, "PD010719", err)
+}
+
 func TestExportSnapshot(t *testing.T) {
 
 	ctx, ss, _, _, done := newDBMockStateManager(t)
@@ -1122,12 +1648,12 @@ func TestExportSnapshot(t *testing.T) {
 
 	s1, err := schema1.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	s2, err := schema2.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"tokenUri": "%s", "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32), pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32), pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	transactionID1 := uuid.New()
@@ -1193,24 +1719,24 @@ func TestImportSnapshot(t *testing.T) {
 
 	s1, err := schema1.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	s2, err := schema1.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	s3ID := pldtypes.RandHex(32)
 
 	s4, err := schema1.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	s5, err := schema1.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
 		`{"amount": 20, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
-		pldtypes.RandHex(32))), nil, dc.customHashFunction)
+		pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
 	require.NoError(t, err)
 
 	transactionID1 := uuid.New()
@@ -1337,8 +1863,383 @@ func TestImportSnapshotJSONError(t *testing.T) {
 
 }
 
-func TestGetStatesByIDFail(t *testing.T) {
-	ctx, ss, db, _, done := newDBMockStateManager(t)
+func TestDryRun(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	newCoin := func(amount int) *components.StateUpsert {
+		return &components.StateUpsert{
+			Schema:    schemaID,
+			Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32))),
+			CreatedBy: confutil.P(uuid.New()),
+		}
+	}
+	states, err := dc.UpsertStates(ss.p.NOTX(), newCoin(10))
+	require.NoError(t, err)
+	state1 := states[0]
+
+	var dryRunID uuid.UUID
+	err = dc.DryRun(func(dryRunDC components.DomainContext) error {
+		dryRunID = dryRunDC.Info().ID
+		assert.NotEqual(t, dc.id, dryRunID)
+
+		// The copy starts with the same unflushed states
+		_, states, err := dryRunDC.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Query())
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		assert.Equal(t, state1.ID, states[0].ID)
+
+		// Spend the existing state, and create a new one
+		_, err = dryRunDC.UpsertStates(ss.p.NOTX(), newCoin(20))
+		require.NoError(t, err)
+		err = dryRunDC.AddStateLocks(&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: state1.ID, Transaction: uuid.New()})
+		require.NoError(t, err)
+		_, states, err = dryRunDC.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Query())
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		assert.Equal(t, int64(20), parseFakeCoin(t, states[0]).Amount.Int64())
+
+		err = dryRunDC.Flush(ss.p.NOTX())
+		assert.Regexp(t, "PD010143", err)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Nothing done in the dry run is visible in the original context
+	_, states, err = dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Query())
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, state1.ID, states[0].ID)
+	assert.Nil(t, ss.GetDomainContext(ctx, dryRunID))
+
+	// Errors from the function are returned
+	err = dc.DryRun(func(dryRunDC components.DomainContext) error {
+		return fmt.Errorf("pop")
+	})
+	assert.Regexp(t, "pop", err)
+
+	dc.Close()
+	err = dc.DryRun(func(dryRunDC components.DomainContext) error {
+		assert.Fail(t, "should not be called")
+		return nil
+	})
+	assert.Regexp(t, "PD010122", err)
+}
+
+func TestSimulateAssemble(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	txID := uuid.New()
+	newCoin := func(amount int) *components.StateUpsert {
+		return &components.StateUpsert{
+			Schema:    schemaID,
+			Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32))),
+			CreatedBy: &txID,
+		}
+	}
+	states, err := dc.UpsertStates(ss.p.NOTX(), newCoin(10))
+	require.NoError(t, err)
+	state1 := states[0]
+
+	var coin20, coin30 *components.StateUpsert
+	created, err := dc.SimulateAssemble(func(dryRunDC components.DomainContext) error {
+		// Spend the existing state, and create two new ones - one upserted twice
+		err := dryRunDC.AddStateLocks(&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: state1.ID, Transaction: uuid.New()})
+		require.NoError(t, err)
+		coin20, coin30 = newCoin(20), newCoin(30)
+		_, err = dryRunDC.UpsertStates(ss.p.NOTX(), coin20, coin30)
+		require.NoError(t, err)
+		_, err = dryRunDC.UpsertStates(ss.p.NOTX(), coin20)
+		require.NoError(t, err)
+		// Re-upserting a state the context was already creating is not reported
+		_, err = dryRunDC.UpsertStates(ss.p.NOTX(), &components.StateUpsert{ID: state1.ID, Schema: schemaID, Data: state1.Data, CreatedBy: &txID})
+		require.NoError(t, err)
+		// A state without a create lock is not reported
+		_, err = dryRunDC.UpsertStates(ss.p.NOTX(), &components.StateUpsert{Schema: schemaID, Data: newCoin(40).Data})
+		return err
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.Equal(t, int64(30), parseFakeCoin(t, created[0].State).Amount.Int64())
+	assert.Equal(t, int64(20), parseFakeCoin(t, created[1].State).Amount.Int64())
+	assert.NotNil(t, created[0].LabelValues)
+
+	// Nothing was locked in the original context
+	_, states, err = dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Query())
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, state1.ID, states[0].ID)
+	assert.Len(t, dc.txLocks, 1)
+
+	// Nothing is returned if the function resets the copy
+	created, err = dc.SimulateAssemble(func(dryRunDC components.DomainContext) error {
+		_, err = dryRunDC.UpsertStates(ss.p.NOTX(), newCoin(50))
+		require.NoError(t, err)
+		dryRunDC.Reset()
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, created)
+
+	// Errors from the function are returned
+	_, err = dc.SimulateAssemble(func(dryRunDC components.DomainContext) error {
+		return fmt.Errorf("pop")
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMarkStatesUnspent(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	tx1, tx2, tx3 := uuid.New(), uuid.New(), uuid.New()
+	var stateIDs []string
+	for _, amount := range []int{10, 20, 30} {
+		states, err := dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{
+			Schema:    schemaID,
+			Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32))),
+			CreatedBy: &tx1,
+		})
+		require.NoError(t, err)
+		stateIDs = append(stateIDs, states[0].ID.String())
+	}
+	syncFlushContext(t, dc)
+	dc.ResetTransactions(tx1)
+
+	// All states are confirmed. The first is spent on-chain by tx2, and the second
+	// is being spent in-memory by tx2. The third is being spent in-memory by tx3.
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{{DomainName: "domain1", State: pldtypes.MustParseHexBytes(stateIDs[0]), Transaction: tx2}},
+		[]*pldapi.StateReadRecord{},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: pldtypes.MustParseHexBytes(stateIDs[0]), Transaction: tx1},
+			{DomainName: "domain1", State: pldtypes.MustParseHexBytes(stateIDs[1]), Transaction: tx1},
+			{DomainName: "domain1", State: pldtypes.MustParseHexBytes(stateIDs[2]), Transaction: tx1},
+		},
+		[]*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+	err = dc.AddStateLocks(
+		&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: pldtypes.MustParseHexBytes(stateIDs[1]), Transaction: tx2},
+		&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: pldtypes.MustParseHexBytes(stateIDs[2]), Transaction: tx3},
+	)
+	require.NoError(t, err)
+
+	_, states, err := dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Sort("amount").Query())
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	// Only the spends of tx2 are released
+	err = dc.MarkStatesUnspent(ss.p.NOTX(), tx2, stateIDs)
+	require.NoError(t, err)
+	_, states, err = dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Sort("amount").Query())
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, stateIDs[0], states[0].ID.String())
+	assert.Equal(t, stateIDs[1], states[1].ID.String())
+	assert.Len(t, dc.txLocks, 1)
+
+	err = dc.MarkStatesUnspent(ss.p.NOTX(), tx2, nil)
+	require.NoError(t, err)
+
+	err = dc.MarkStatesUnspent(ss.p.NOTX(), tx2, []string{"not hex"})
+	assert.Regexp(t, "PD010144", err)
+
+	err = dc.MarkStatesUnspent(ss.p.NOTX(), tx2, []string{""})
+	assert.Regexp(t, "PD010144", err)
+
+	err = dc.DryRun(func(dryRunDC components.DomainContext) error {
+		return dryRunDC.MarkStatesUnspent(ss.p.NOTX(), tx3, stateIDs[2:])
+	})
+	assert.Regexp(t, "PD010143", err)
+
+	dc.Close()
+	err = dc.MarkStatesUnspent(ss.p.NOTX(), tx3, stateIDs[2:])
+	assert.Regexp(t, "PD010122", err)
+}
+
+func TestFindAvailableStatesByIDs(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	tx1, tx2, tx3 := uuid.New(), uuid.New(), uuid.New()
+	newCoin := func(amount int, createdBy uuid.UUID) string {
+		states, err := dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{
+			Schema:    schemaID,
+			Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32))),
+			CreatedBy: &createdBy,
+		})
+		require.NoError(t, err)
+		return states[0].ID.String()
+	}
+
+	// The first three states are confirmed in the DB, and the third is spent on-chain
+	var stateIDs []string
+	for _, amount := range []int{10, 20, 30} {
+		stateIDs = append(stateIDs, newCoin(amount, tx1))
+	}
+	syncFlushContext(t, dc)
+	dc.ResetTransactions(tx1)
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{{DomainName: "domain1", State: pldtypes.MustParseHexBytes(stateIDs[2]), Transaction: tx2}},
+		[]*pldapi.StateReadRecord{},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: pldtypes.MustParseHexBytes(stateIDs[0]), Transaction: tx1},
+			{DomainName: "domain1", State: pldtypes.MustParseHexBytes(stateIDs[1]), Transaction: tx1},
+			{DomainName: "domain1", State: pldtypes.MustParseHexBytes(stateIDs[2]), Transaction: tx1},
+		},
+		[]*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+
+	// The second state is being spent in-memory, and the fourth is only in memory
+	err = dc.AddStateLocks(&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: pldtypes.MustParseHexBytes(stateIDs[1]), Transaction: tx2})
+	require.NoError(t, err)
+	stateIDs = append(stateIDs, newCoin(40, tx3))
+
+	unknownID := pldtypes.RandHex(32)
+	_, states, err := dc.FindAvailableStatesByIDs(ss.p.NOTX(), schemaID, append(stateIDs, unknownID))
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, stateIDs[0], states[0].ID.String())
+	assert.Equal(t, stateIDs[3], states[1].ID.String())
+
+	_, states, err = dc.FindAvailableStatesByIDs(ss.p.NOTX(), schemaID, stateIDs[1:3])
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	_, _, err = dc.FindAvailableStatesByIDs(ss.p.NOTX(), schemaID, []string{"not hex"})
+	assert.Regexp(t, "PD010719", err)
+}
+
+func TestMarkStatesUnspentFail(t *testing.T) {
+
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	db.ExpectExec("DELETE.*state_spend_records").WillReturnError(fmt.Errorf("pop"))
+
+	err := dc.MarkStatesUnspent(ss.p.NOTX(), uuid.New(), []string{pldtypes.RandHex(32)})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestCountAvailableStates(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	tx1 := uuid.New()
+	newCoin := func(amount int) *components.StateUpsert {
+		return &components.StateUpsert{
+			Schema:    schemaID,
+			Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32))),
+			CreatedBy: &tx1,
+		}
+	}
+
+	// Three states are flushed and confirmed, while we still hold the creating lock on them
+	states, err := dc.UpsertStates(ss.p.NOTX(), newCoin(10), newCoin(20), newCoin(30))
+	require.NoError(t, err)
+	syncFlushContext(t, dc)
+	confirms := make([]*pldapi.StateConfirmRecord, len(states))
+	for i, s := range states {
+		confirms[i] = &pldapi.StateConfirmRecord{DomainName: "domain1", State: s.ID, Transaction: tx1}
+	}
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(), []*pldapi.StateSpendRecord{}, []*pldapi.StateReadRecord{}, confirms, []*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+
+	// Two more are only in memory, and one of the confirmed states is being spent
+	_, err = dc.UpsertStates(ss.p.NOTX(), newCoin(40), newCoin(50))
+	require.NoError(t, err)
+	err = dc.AddStateLocks(&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: states[0].ID, Transaction: uuid.New()})
+	require.NoError(t, err)
+
+	// States that are both in memory and in the DB are only counted once, and any limit is ignored
+	for expected, q := range map[uint64]*query.QueryJSON{
+		4: query.NewQueryBuilder().GreaterThan("amount", 10).Sort("-amount").Limit(1).Query(),
+		2: query.NewQueryBuilder().GreaterThan("amount", 30).Query(),
+		0: query.NewQueryBuilder().GreaterThan("amount", 100).Query(),
+	} {
+		count, err := dc.CountAvailableStates(ss.p.NOTX(), schemaID, q)
+		require.NoError(t, err)
+		assert.Equal(t, expected, count)
+	}
+	_, found, err := dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Query())
+	require.NoError(t, err)
+	assert.Len(t, found, 4)
+}
+
+func TestCountAvailableStatesFail(t *testing.T) {
+
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, fakeCoinABI))
+	require.NoError(t, err)
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", schema.ID()), schema)
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	db.ExpectQuery("SELECT count").WillReturnError(fmt.Errorf("pop"))
+	_, err = dc.CountAvailableStates(ss.p.NOTX(), schema.ID(), query.NewQueryBuilder().Query())
+	assert.Regexp(t, "pop", err)
+
+	_, err = dc.CountAvailableStates(ss.p.NOTX(), schema.ID(), query.NewQueryBuilder().Equal("wrong", "any").Query())
+	assert.Regexp(t, "PD010700", err)
+
+	db.ExpectQuery("SELECT.*schemas").WillReturnError(fmt.Errorf("pop"))
+	_, err = dc.CountAvailableStates(ss.p.NOTX(), pldtypes.RandBytes32(), query.NewQueryBuilder().Query())
+	assert.Regexp(t, "pop", err)
+
+	dc.Close()
+	_, err = dc.CountAvailableStates(ss.p.NOTX(), schema.ID(), query.NewQueryBuilder().Query())
+	assert.Regexp(t, "PD010122", err)
+}
+
+func TestGetStatesByIDFail(t *testing.T) {
+	ctx, ss, db, _, done := newDBMockStateManager(t)
 	defer done()
 	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
 	defer dc.Close()
@@ -1348,3 +2249,343 @@ func TestGetStatesByIDFail(t *testing.T) {
 	_, _, err := dc.GetStatesByID(dc.ss.p.NOTX(), pldtypes.Bytes32(pldtypes.RandBytes(32)), []string{pldtypes.RandHex(32)})
 	assert.Regexp(t, "pop", err)
 }
+
+func TestDCMergedInMemoryMatchesOrdering(t *testing.T) {
+
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, fakeCoinABI))
+	require.NoError(t, err)
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", schema.ID()), schema)
+
+	contractAddress, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	newCoin := func(amount int) *components.StateWithLabels {
+		s, err := schema.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
+			`{"amount": %d, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
+			amount, pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
+		require.NoError(t, err)
+		return s
+	}
+	amounts := func(states []*pldapi.State) []int64 {
+		ret := make([]int64, len(states))
+		for i, s := range states {
+			ret[i] = parseFakeCoin(t, s).Amount.Int64()
+		}
+		return ret
+	}
+
+	dbStates := make([]*pldapi.State, 8)
+	for i := range dbStates {
+		dbStates[i] = newCoin(i * 10).State // 0, 10, 20 ... 70
+	}
+	mem5, mem20, mem25, mem80 := newCoin(5), newCoin(20), newCoin(25), newCoin(80)
+	sortByAmount := query.NewQueryBuilder().Sort("amount").Query()
+
+	// DB results in sort order get a small number of in-memory matches merged in
+	states, err := dc.mergeInMemoryMatches(schema, dbStates, []*components.StateWithLabels{mem20, mem5}, sortByAmount)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 5, 10, 20, 20, 30, 40, 50, 60, 70}, amounts(states))
+	// equal items are ordered by ID, regardless of whether they came from the DB or memory
+	assert.Negative(t, strings.Compare(states[3].ID.HexString(), states[4].ID.HexString()))
+
+	// The order of equal items is the same, whatever order the in-memory matches are supplied in
+	memEqual := []*components.StateWithLabels{newCoin(20), newCoin(20), newCoin(20), mem20}
+	sorted1, err := dc.mergeInMemoryMatches(schema, dbStates[0:3], memEqual, sortByAmount)
+	require.NoError(t, err)
+	slices.Reverse(memEqual)
+	sorted2, err := dc.mergeInMemoryMatches(schema, dbStates[0:3], memEqual, sortByAmount)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 10, 20, 20, 20, 20, 20}, amounts(sorted1))
+	assert.Equal(t, sorted1, sorted2)
+	for i := 3; i < len(sorted1); i++ {
+		assert.Negative(t, strings.Compare(sorted1[i-1].ID.HexString(), sorted1[i].ID.HexString()))
+	}
+
+	// Limit is applied after the merge
+	states, err = dc.mergeInMemoryMatches(schema, dbStates, []*components.StateWithLabels{mem80, mem5},
+		query.NewQueryBuilder().Sort("amount").Limit(3).Query())
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 5, 10}, amounts(states))
+
+	// A larger number of in-memory matches causes a full sort
+	states, err = dc.mergeInMemoryMatches(schema, dbStates[0:3], []*components.StateWithLabels{mem80, mem25, mem5}, sortByAmount)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 5, 10, 20, 25, 80}, amounts(states))
+
+	// DB results that are not in our sort order cause a full sort
+	unsorted := append([]*pldapi.State{dbStates[7]}, dbStates[0:7]...)
+	states, err = dc.mergeInMemoryMatches(schema, unsorted, []*components.StateWithLabels{mem25}, sortByAmount)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 10, 20, 25, 30, 40, 50, 60, 70}, amounts(states))
+
+}
+
+func BenchmarkMergedUnFlushed(b *testing.B) {
+	for _, dbCount := range []int{100, 1000, 10000} {
+		for _, memCount := range []int{10, 100, 1000} {
+			b.Run(fmt.Sprintf("db=%d/mem=%d", dbCount, memCount), func(b *testing.B) {
+				benchmarkMergedUnFlushed(b, dbCount, memCount)
+			})
+		}
+	}
+}
+
+func benchmarkMergedUnFlushed(b *testing.B, dbCount, memCount int) {
+	ctx, ss, _, _, done := newDBMockStateManager(b)
+	defer done()
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(b, fakeCoinABI))
+	require.NoError(b, err)
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", schema.ID()), schema)
+
+	contractAddress, dc := newTestDomainContext(b, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	newCoin := func(amount int) *components.StateWithLabels {
+		s, err := schema.ProcessState(ctx, contractAddress, pldtypes.RawJSON(fmt.Sprintf(
+			`{"amount": %d, "owner": "0x615dD09124271D8008225054d85Ffe720E7a447A", "salt": "%s"}`,
+			amount, pldtypes.RandHex(32))), nil, dc.customHashFunction, false)
+		require.NoError(b, err)
+		return s
+	}
+
+	// The DB returns even amounts in order, and we interleave odd amounts from memory
+	dbStates := make([]*pldapi.State, dbCount)
+	for i := range dbStates {
+		dbStates[i] = newCoin(i * 2).State
+	}
+	stride := dbCount / memCount
+	if stride < 1 {
+		stride = 1
+	}
+	for i := 0; i < memCount; i++ {
+		s := newCoin((i*stride)*2 + 1)
+		dc.creatingStates[s.ID.String()] = s
+	}
+	q := query.NewQueryBuilder().Sort("amount").Query()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		states, err := dc.mergeUnFlushedApplyLocks(schema, dbStates, q, true, false)
+		require.NoError(b, err)
+		require.Len(b, states, dbCount+memCount)
+	}
+}
+
+func TestFindAvailableStatesPage(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	txID := uuid.New()
+	upsertCoins := func(amounts ...int) {
+		upserts := make([]*components.StateUpsert, len(amounts))
+		for i, amount := range amounts {
+			upserts[i] = &components.StateUpsert{Schema: schemaID, CreatedBy: &txID, Data: pldtypes.RawJSON(fmt.Sprintf(
+				`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32)))}
+		}
+		_, err := dc.UpsertStates(ss.p.NOTX(), upserts...)
+		require.NoError(t, err)
+	}
+	readPages := func(q *query.QueryJSON, betweenPages func(page int)) (amounts []int64) {
+		seen := map[string]bool{}
+		cursor := ""
+		for page := 0; ; page++ {
+			_, states, nextCursor, err := dc.FindAvailableStatesPage(ss.p.NOTX(), schemaID, q, cursor)
+			require.NoError(t, err)
+			for _, s := range states {
+				require.False(t, seen[s.ID.String()], "duplicate state %s", s.ID)
+				seen[s.ID.String()] = true
+				amounts = append(amounts, parseFakeCoin(t, s).Amount.Int64())
+			}
+			if nextCursor == "" {
+				return amounts
+			}
+			if betweenPages != nil {
+				betweenPages(page)
+			}
+			cursor = nextCursor
+		}
+	}
+
+	// Some states in the DB, and some only in memory - with duplicate sort values that span pages
+	upsertCoins(10, 20, 20, 40)
+	syncFlushContext(t, dc)
+	upsertCoins(20, 30, 50)
+
+	pageSize2 := query.NewQueryBuilder().Sort("amount").Limit(2).Query()
+	assert.Equal(t, []int64{10, 20, 20, 20, 30, 40, 50}, readPages(pageSize2, nil))
+	assert.Equal(t, []int64{50, 40, 30, 20, 20, 20, 10}, readPages(query.NewQueryBuilder().Sort("-amount").Limit(3).Query(), nil))
+
+	// Filters in the query are combined with the cursor
+	assert.Equal(t, []int64{20, 20, 20, 30}, readPages(query.NewQueryBuilder().
+		GreaterThan("amount", 10).LessThan("amount", 40).Sort("amount").Limit(1).Query(), nil))
+
+	// States inserted while paging are returned if they sort after the cursor
+	assert.Equal(t, []int64{10, 20, 20, 20, 30, 35, 40, 50}, readPages(pageSize2, func(page int) {
+		if page == 0 {
+			upsertCoins(5, 35)
+		}
+	}))
+}
+
+func TestFindAvailableStatesPageErrors(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	_, err = dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{Schema: schemaID, CreatedBy: confutil.P(uuid.New()), Data: pldtypes.RawJSON(fmt.Sprintf(
+		`{"amount": 10, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32)))})
+	require.NoError(t, err)
+
+	_, _, _, err = dc.FindAvailableStatesPage(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Sort("amount").Query(), "")
+	assert.Regexp(t, "PD010721", err)
+
+	_, _, _, err = dc.FindAvailableStatesPage(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Limit(1).Query(), "!!!")
+	assert.Regexp(t, "PD010141", err)
+
+	_, _, cursor, err := dc.FindAvailableStatesPage(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Sort(".created", ".id").Limit(1).Query(), "")
+	require.NoError(t, err)
+	assert.NotEmpty(t, cursor)
+
+	// A cursor can only be used with the same sort
+	_, _, _, err = dc.FindAvailableStatesPage(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Sort("-.created").Limit(1).Query(), cursor)
+	assert.Regexp(t, "PD010141", err)
+
+	// Query with an invalid field
+	_, _, _, err = dc.FindAvailableStatesPage(ss.p.NOTX(), schemaID, query.NewQueryBuilder().Sort("wrong").Limit(1).Query(), "")
+	assert.Regexp(t, "PD010700", err)
+
+	// Cursors can only be built from states with a value for every sort field
+	_, err = newQueryCursor(ctx, []string{"amount"}, &pldapi.State{StateBase: pldapi.StateBase{ID: pldtypes.RandBytes(32), Data: pldtypes.RawJSON(`{}`)}})
+	assert.Regexp(t, "PD010142", err)
+
+	_, err = newQueryCursor(ctx, []string{"amount"}, &pldapi.State{StateBase: pldapi.StateBase{ID: pldtypes.RandBytes(32), Data: pldtypes.RawJSON(`!!!`)}})
+	assert.Error(t, err)
+}
+
+func TestFindAvailableStatesOrQuery(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	owner1 := pldtypes.RandAddress()
+	owner2 := pldtypes.RandAddress()
+	owner3 := pldtypes.RandAddress()
+	newCoin := func(amount int, owner *pldtypes.EthAddress) *components.StateUpsert {
+		return &components.StateUpsert{
+			Schema:    schemaID,
+			Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "%s", "salt": "%s"}`, amount, owner, pldtypes.RandHex(32))),
+			CreatedBy: confutil.P(uuid.New()),
+		}
+	}
+
+	// Some states are flushed to the DB, and others only exist in the domain context
+	_, err = dc.UpsertStates(ss.p.NOTX(), newCoin(10, owner1), newCoin(20, owner2), newCoin(30, owner3))
+	require.NoError(t, err)
+	syncFlushContext(t, dc)
+	_, err = dc.UpsertStates(ss.p.NOTX(), newCoin(40, owner1), newCoin(50, owner2), newCoin(60, owner3))
+	require.NoError(t, err)
+
+	_, states, err := dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().
+		Or(
+			query.NewQueryBuilder().Equal("owner", owner1),
+			query.NewQueryBuilder().Equal("owner", owner2),
+		).
+		Sort("amount").
+		Query())
+	require.NoError(t, err)
+	require.Len(t, states, 4)
+	for i, amount := range []int64{10, 20, 40, 50} {
+		assert.Equal(t, amount, parseFakeCoin(t, states[i]).Amount.Int64())
+	}
+
+	// The OR is combined with the other statements at the same level using AND
+	_, states, err = dc.FindAvailableStates(ss.p.NOTX(), schemaID, query.NewQueryBuilder().
+		Or(
+			query.NewQueryBuilder().Equal("owner", owner1),
+			query.NewQueryBuilder().Equal("owner", owner3),
+		).
+		GreaterThan("amount", 20).
+		Sort("-amount").
+		Query())
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	for i, amount := range []int64{60, 40, 30} {
+		assert.Equal(t, amount, parseFakeCoin(t, states[i]).Amount.Int64())
+	}
+}
+
+func TestFlushTraceSpans(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	spans := tracetest.NewSpanRecorder()
+	defer func(t trace.Tracer) { tracer = t }(tracer)
+	tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("test")
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	contractAddress, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	_, err = dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{
+		Schema:    schemaID,
+		Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": 100, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))),
+		CreatedBy: confutil.P(uuid.New()),
+	})
+	require.NoError(t, err)
+	syncFlushContext(t, dc)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+	writeSpan, flushSpan := ended[0], ended[1]
+	assert.Equal(t, "DomainContext.Flush.write", writeSpan.Name())
+	assert.Equal(t, "DomainContext.Flush", flushSpan.Name())
+	assert.Equal(t, flushSpan.SpanContext().SpanID(), writeSpan.Parent().SpanID())
+	assert.Equal(t, codes.Unset, flushSpan.Status().Code)
+	assert.Contains(t, writeSpan.Attributes(), attribute.String("paladin.domain", "domain1"))
+	assert.Contains(t, writeSpan.Attributes(), attribute.String("paladin.contract_address", contractAddress.String()))
+	assert.Contains(t, writeSpan.Attributes(), attribute.Int("paladin.state_count", 1))
+	assert.Contains(t, flushSpan.Attributes(), attribute.Int("paladin.state_count", 1))
+
+	// A second flush while the first one is in error records the error on the span
+	dc.stateLock.Lock()
+	dc.flushing = dc.newPendingStateWrites()
+	dc.flushing.setError(fmt.Errorf("pop"))
+	dc.stateLock.Unlock()
+	err = dc.Flush(ss.p.NOTX())
+	assert.Regexp(t, "pop", err)
+
+	ended = spans.Ended()
+	require.Len(t, ended, 3)
+	assert.Equal(t, codes.Error, ended[2].Status().Code)
+	assert.Equal(t, "pop", ended[2].Status().Description)
+}
@@ -18,11 +18,15 @@ package statemgr
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm/clause"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
@@ -50,6 +54,11 @@ func (dc *domainContext) newPendingStateWrites() *pendingStateWrites {
 	}
 }
 
+// must hold the state lock when calling - safe to call on nil
+func (op *pendingStateWrites) isEmpty() bool {
+	return op == nil || (len(op.states) == 0 && len(op.stateNullifiers) == 0)
+}
+
 // must host the state lock when calling
 func (op *pendingStateWrites) setError(err error) {
 	if op.flushResult == nil {
@@ -58,36 +67,64 @@ func (op *pendingStateWrites) setError(err error) {
 	}
 }
 
-func (op *pendingStateWrites) exec(ctx context.Context, dbTX persistence.DBTX) error {
+func (op *pendingStateWrites) exec(ctx context.Context, dbTX persistence.DBTX) (err error) {
+
+	startTime := time.Now()
+	ctx, span := tracer.Start(ctx, "DomainContext.Flush.write", trace.WithAttributes(
+		attribute.String(traceAttrDomain, op.dc.domainName),
+		attribute.String(traceAttrContractAddress, op.dc.contractAddress.String()),
+	))
+	defer func() { endSpan(span, err) }()
 
 	// Build lists of things to insert (we are insert only)
 	var states []*pldapi.State
 	var stateLocks []*pldapi.StateLock
 	var stateNullifiers []*pldapi.StateNullifier
+	labelCount := 0
 	for _, s := range op.states {
 		states = append(states, s.State)
+		labelCount += len(s.Labels) + len(s.Int64Labels)
 	}
 	if len(op.stateNullifiers) > 0 {
 		stateNullifiers = append(stateNullifiers, op.stateNullifiers...)
 	}
 	log.L(ctx).Debugf("Writing state batch states=%d locks=%d nullifiers=%d ",
 		len(states), len(stateLocks), len(stateNullifiers))
+	span.SetAttributes(
+		attribute.Int(traceAttrStateCount, len(states)),
+		attribute.Int(traceAttrStateLockCount, len(stateLocks)),
+		attribute.Int(traceAttrNullifierCount, len(stateNullifiers)),
+	)
 
-	var err error
-
-	if len(states) > 0 {
-		err = op.dc.ss.writeStates(ctx, dbTX, states)
+	// Large flushes are split into multiple inserts, to stay within the parameter limits of the DB
+	for batch := range slices.Chunk(states, op.dc.ss.maxBatchStates) {
+		if err = op.dc.ss.writeStates(ctx, dbTX, batch); err != nil {
+			return err
+		}
 	}
-
-	if err == nil && len(stateNullifiers) > 0 {
+	for batch := range slices.Chunk(stateNullifiers, op.dc.ss.maxBatchStates) {
 		err = dbTX.DB().
 			Table("state_nullifiers").
 			Clauses(clause.OnConflict{
 				DoNothing: true, // immutable
 			}).
-			Create(stateNullifiers).
+			Create(batch).
 			Error
+		if err != nil {
+			return err
+		}
+	}
+
+	log.L(ctx).Debugf("Flushed state batch domain=%s contract=%s states=%d labels=%d locks=%d nullifiers=%d duration_ms=%.3f",
+		op.dc.domainName, op.dc.contractAddress, len(states), labelCount, len(stateLocks), len(stateNullifiers),
+		float64(time.Since(startTime).Microseconds())/1000)
+	if log.IsTraceEnabled() {
+		for _, s := range states {
+			log.L(ctx).Tracef("Flushed state domain=%s contract=%s schema=%s id=%s", op.dc.domainName, op.dc.contractAddress, s.Schema, s.ID)
+		}
+		for _, n := range stateNullifiers {
+			log.L(ctx).Tracef("Flushed nullifier domain=%s contract=%s state=%s id=%s", op.dc.domainName, op.dc.contractAddress, n.State, n.ID)
+		}
 	}
-	// We don't actually provide any result, so just build an array of nil results
-	return err
+	return nil
 }
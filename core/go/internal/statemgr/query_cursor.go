@@ -0,0 +1,131 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+)
+
+// queryCursor records the sort values of the last state returned in a page of results,
+// so the next page can be queried to start strictly after it. It is passed to callers
+// as an opaque base64 string.
+type queryCursor struct {
+	Sort   []string           `json:"sort"`
+	Values []pldtypes.RawJSON `json:"values"`
+}
+
+func parseSortInstruction(instruction string) (fieldName string, descending bool) {
+	startEnd := strings.SplitN(instruction, " ", 2)
+	fieldName, descending = strings.CutPrefix(startEnd[0], "-")
+	return fieldName, descending || (len(startEnd) == 2 && strings.EqualFold(startEnd[1], "desc"))
+}
+
+// stableSort returns the sort instructions with ".id" as a final tie-breaker (unless already present),
+// so the order is stable even when multiple states have the same values for the other fields.
+// This is required for pagination, and when merging in-memory matches with the DB results.
+func stableSort(sortInstructions []string) []string {
+	for _, s := range sortInstructions {
+		if fieldName, _ := parseSortInstruction(s); fieldName == ".id" {
+			return sortInstructions
+		}
+	}
+	return append(slices.Clone(sortInstructions), ".id")
+}
+
+// applyQueryCursor returns a copy of the query that is sorted for pagination, and if a cursor
+// is supplied only matches states that sort after the position it records.
+//
+// For sort fields f1..fn this is: (f1 > v1) OR (f1 == v1 AND f2 > v2) OR ... (with < for descending)
+// with each branch also containing the original statements of the query.
+func applyQueryCursor(ctx context.Context, jq *query.QueryJSON, cursor string) (*query.QueryJSON, error) {
+	pageQuery := *jq
+	pageQuery.Sort = stableSort(jq.Sort)
+	if cursor == "" {
+		return &pageQuery, nil
+	}
+
+	var c queryCursor
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(b, &c)
+	}
+	if err != nil || !slices.Equal(c.Sort, pageQuery.Sort) || len(c.Values) != len(c.Sort) {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgStateQueryCursorInvalid)
+	}
+
+	var originalStatements []*query.Statements
+	if !reflect.ValueOf(jq.Statements).IsZero() {
+		originalStatements = []*query.Statements{&jq.Statements}
+	}
+	after := make([]*query.Statements, len(c.Sort))
+	for i, s := range c.Sort {
+		branch := &query.Statements{Or: originalStatements}
+		for j := 0; j < i; j++ {
+			fieldName, _ := parseSortInstruction(c.Sort[j])
+			branch.Eq = append(branch.Eq, &query.OpSingleVal{Op: query.Op{Field: fieldName}, Value: c.Values[j]})
+		}
+		fieldName, descending := parseSortInstruction(s)
+		op := &query.OpSingleVal{Op: query.Op{Field: fieldName}, Value: c.Values[i]}
+		if descending {
+			branch.LT = append(branch.LT, op)
+		} else {
+			branch.GT = append(branch.GT, op)
+		}
+		after[i] = branch
+	}
+	pageQuery.Statements = query.Statements{Or: after}
+	return &pageQuery, nil
+}
+
+// newQueryCursor builds the cursor for the page of results ending with the supplied state
+func newQueryCursor(ctx context.Context, sortInstructions []string, lastState *pldapi.State) (string, error) {
+	var data map[string]pldtypes.RawJSON
+	if err := json.Unmarshal(lastState.Data, &data); err != nil {
+		return "", err
+	}
+	c := &queryCursor{
+		Sort:   sortInstructions,
+		Values: make([]pldtypes.RawJSON, len(sortInstructions)),
+	}
+	for i, s := range sortInstructions {
+		fieldName, _ := parseSortInstruction(s)
+		switch fieldName {
+		case ".id":
+			c.Values[i] = pldtypes.JSONString(lastState.ID.HexString())
+		case ".created":
+			c.Values[i] = pldtypes.JSONString(lastState.Created)
+		default:
+			v := data[fieldName]
+			if v.IsNil() {
+				return "", i18n.NewError(ctx, msgs.MsgStateQueryCursorNoSortValue, lastState.ID, fieldName)
+			}
+			c.Values[i] = v
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(pldtypes.JSONString(c)), nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// A query snapshot is a lightweight point-in-time view of the states in a domain, recorded as
+// the highest created timestamp at the time the snapshot was taken. Paging through results with
+// the same query token excludes any states created after the first page was requested.
+type querySnapshot struct {
+	domainName string
+	created    pldtypes.Timestamp
+	lastUsed   time.Time
+}
+
+func (ss *stateManager) NewQueryToken(ctx context.Context, dbTX persistence.DBTX, domainName string) (uuid.UUID, error) {
+	var maxCreated *int64
+	err := dbTX.DB().WithContext(ctx).
+		Table("states").
+		Select("MAX(created)").
+		Where("domain_name = ?", domainName).
+		Scan(&maxCreated).
+		Error
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	snapshot := &querySnapshot{domainName: domainName, lastUsed: time.Now()}
+	if maxCreated != nil {
+		snapshot.created = pldtypes.Timestamp(*maxCreated)
+	}
+	token := uuid.New()
+	ss.querySnapshotLock.Lock()
+	ss.querySnapshots[token] = snapshot
+	ss.querySnapshotLock.Unlock()
+	log.L(ctx).Debugf("Created query token %s for domain %s at created=%d", token, domainName, snapshot.created)
+	return token, nil
+}
+
+func (ss *stateManager) getQuerySnapshot(ctx context.Context, domainName string, token uuid.UUID) (*querySnapshot, error) {
+	ss.querySnapshotLock.Lock()
+	defer ss.querySnapshotLock.Unlock()
+
+	snapshot := ss.querySnapshots[token]
+	if snapshot == nil || snapshot.domainName != domainName || time.Since(snapshot.lastUsed) > ss.querySnapshotTTL {
+		return nil, i18n.NewError(ctx, msgs.MsgStateQueryTokenNotFound, token, domainName)
+	}
+	// The TTL is extended each time the token is used, so a long running pagination does not expire part way through
+	snapshot.lastUsed = time.Now()
+	return snapshot, nil
+}
+
+func (ss *stateManager) querySnapshotGC() {
+	defer close(ss.querySnapshotGCDone)
+
+	for {
+		select {
+		case <-ss.bgCtx.Done():
+			log.L(ss.bgCtx).Debugf("query snapshot GC exiting")
+			return
+		case <-time.After(ss.querySnapshotTTL):
+		}
+
+		ss.gcExpiredQuerySnapshots()
+	}
+}
+
+func (ss *stateManager) gcExpiredQuerySnapshots() {
+	ss.querySnapshotLock.Lock()
+	defer ss.querySnapshotLock.Unlock()
+
+	for token, snapshot := range ss.querySnapshots {
+		if time.Since(snapshot.lastUsed) > ss.querySnapshotTTL {
+			log.L(ss.bgCtx).Debugf("Query token %s for domain %s expired", token, snapshot.domainName)
+			delete(ss.querySnapshots, token)
+		}
+	}
+}
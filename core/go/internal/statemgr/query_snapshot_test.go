@@ -0,0 +1,199 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindStatesWithQueryToken(t *testing.T) {
+
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, widgetABI))
+	require.NoError(t, err)
+	err = ss.persistSchemas(ctx, ss.p.NOTX(), []*pldapi.Schema{schema.Schema})
+	require.NoError(t, err)
+	schemaID := schema.ID()
+
+	// A token for an empty domain sees nothing, even after states are added
+	emptyToken, err := ss.NewQueryToken(ctx, ss.p.NOTX(), "domain1")
+	require.NoError(t, err)
+
+	contractAddress := pldtypes.RandAddress()
+	widgets := makeWidgets(t, ctx, ss, "domain1", contractAddress, schemaID, []string{
+		`{"size": 11111, "color": "red",  "price": 100}`,
+		`{"size": 22222, "color": "blue", "price": 150}`,
+	})
+
+	token, err := ss.NewQueryToken(ctx, ss.p.NOTX(), "domain1")
+	require.NoError(t, err)
+
+	// States created after the token are excluded from every page
+	_ = makeWidgets(t, ctx, ss, "domain1", contractAddress, schemaID, []string{
+		`{"size": 33333, "color": "pink", "price": 199}`,
+	})
+
+	findPage := func(token uuid.UUID, after *pldtypes.Timestamp) []*pldapi.State {
+		qb := query.NewQueryBuilder().Limit(1).Sort(".created")
+		if after != nil {
+			qb = qb.GreaterThan(".created", int64(*after))
+		}
+		states, err := ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID, qb.Query(), &components.StateQueryOptions{
+			QueryToken: &token,
+		})
+		require.NoError(t, err)
+		return states
+	}
+
+	page := findPage(token, nil)
+	require.Len(t, page, 1)
+	assert.Equal(t, widgets[0].ID, page[0].ID)
+	page = findPage(token, &page[0].Created)
+	require.Len(t, page, 1)
+	assert.Equal(t, widgets[1].ID, page[0].ID)
+	page = findPage(token, &page[0].Created)
+	assert.Empty(t, page)
+
+	assert.Empty(t, findPage(emptyToken, nil))
+
+	// Without a token all states are returned
+	states, err := ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Query(), nil)
+	require.NoError(t, err)
+	assert.Len(t, states, 3)
+
+	// The token is only valid for its own domain
+	_, err = ss.FindStates(ctx, ss.p.NOTX(), "domain2", schemaID, query.NewQueryBuilder().Query(), &components.StateQueryOptions{
+		QueryToken: &token,
+	})
+	assert.Regexp(t, "PD010138", err)
+
+	// Tokens cannot be combined with a domain context
+	_, err = ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Query(), &components.StateQueryOptions{
+		StatusQualifier: pldapi.StateStatusQualifier(uuid.NewString()),
+		QueryToken:      &token,
+	})
+	assert.Regexp(t, "PD010139", err)
+
+}
+
+func TestQueryTokenExpiry(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	token, err := ss.NewQueryToken(ctx, ss.p.NOTX(), "domain1")
+	require.NoError(t, err)
+
+	_, err = ss.getQuerySnapshot(ctx, "domain1", token)
+	require.NoError(t, err)
+
+	ss.querySnapshotTTL = 0
+	_, err = ss.getQuerySnapshot(ctx, "domain1", token)
+	assert.Regexp(t, "PD010138", err)
+
+	ss.gcExpiredQuerySnapshots()
+	assert.Empty(t, ss.querySnapshots)
+
+}
+
+func TestQueryTokenGCLoop(t *testing.T) {
+	ctx := context.Background()
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	ss := NewStateManager(ctx, &pldconf.StateStoreConfig{
+		SnapshotTTL: confutil.P("100ms"),
+	}, p.P).(*stateManager)
+
+	ss.querySnapshots[uuid.New()] = &querySnapshot{domainName: "domain1", lastUsed: time.Now()}
+
+	p.Mock.ExpectQuery("SELECT.*schema_aliases").WillReturnRows(sqlmock.NewRows([]string{}))
+	err = ss.Start()
+	require.NoError(t, err)
+	defer ss.Stop()
+
+	assert.Eventually(t, func() bool {
+		ss.querySnapshotLock.Lock()
+		defer ss.querySnapshotLock.Unlock()
+		return len(ss.querySnapshots) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestNewQueryTokenFail(t *testing.T) {
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	db.ExpectQuery("SELECT.*MAX").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := ss.NewQueryToken(ctx, ss.p.NOTX(), "domain1")
+	assert.Regexp(t, "pop", err)
+}
+
+func TestQueryStatesWithTokenRPC(t *testing.T) {
+
+	ctx, ss, rpc, m, done := newTestRPCServer(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, widgetABI))
+	require.NoError(t, err)
+	err = ss.persistSchemas(ctx, ss.p.NOTX(), []*pldapi.Schema{schema.Schema})
+	require.NoError(t, err)
+
+	contractAddress := pldtypes.RandAddress()
+	widgets := makeWidgets(t, ctx, ss, "domain1", contractAddress, schema.ID(), []string{
+		`{"size": 11111, "color": "red",  "price": 100}`,
+	})
+
+	var token uuid.UUID
+	rpcErr := rpc.CallRPC(ctx, &token, "pstate_newQueryToken", "domain1")
+	require.NoError(t, rpcErr)
+
+	_ = makeWidgets(t, ctx, ss, "domain1", contractAddress, schema.ID(), []string{
+		`{"size": 22222, "color": "blue", "price": 150}`,
+	})
+
+	var states []*pldapi.State
+	rpcErr = rpc.CallRPC(ctx, &states, "pstate_queryStatesWithToken", "domain1", schema.ID(), query.NewQueryBuilder().Limit(10).Query(), pldapi.StateStatusAll, token)
+	require.NoError(t, rpcErr)
+	require.Len(t, states, 1)
+	assert.Equal(t, widgets[0].ID, states[0].ID)
+
+	rpcErr = rpc.CallRPC(ctx, &states, "pstate_queryStatesWithToken", "domain1", schema.ID(), query.NewQueryBuilder().Limit(10).Query(), pldapi.StateStatusAll, uuid.New())
+	assert.Regexp(t, "PD010138", rpcErr)
+
+}
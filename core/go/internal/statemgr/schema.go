@@ -18,9 +18,11 @@ package statemgr
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/internal/filters"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
@@ -86,6 +88,9 @@ func (ss *stateManager) GetSchemaByID(ctx context.Context, dbTX persistence.DBTX
 
 func (ss *stateManager) getSchemaByID(ctx context.Context, dbTX persistence.DBTX, domainName string, schemaID pldtypes.Bytes32, failNotFound bool) (components.Schema, error) {
 
+	// A schema that has been migrated is replaced by the one it was migrated to
+	schemaID = ss.resolveSchemaAlias(domainName, schemaID)
+
 	cacheKey := schemaCacheKey(domainName, schemaID)
 	s, cached := ss.abiSchemaCache.Get(cacheKey)
 	if cached {
@@ -115,6 +120,51 @@ func (ss *stateManager) getSchemaByID(ctx context.Context, dbTX persistence.DBTX
 	return s, nil
 }
 
+func (ss *stateManager) WarmSchemaCache(ctx context.Context, domainName string) error {
+	var results []*pldapi.Schema
+	err := ss.p.DB().
+		Table("schemas").
+		WithContext(ctx).
+		Where("domain_name = ?", domainName).
+		Find(&results).
+		Error
+	if err != nil {
+		return err
+	}
+	for _, persisted := range results {
+		s, err := ss.restoreSchema(ctx, persisted)
+		if err != nil {
+			return err
+		}
+		ss.abiSchemaCache.Set(schemaCacheKey(domainName, s.ID()), s)
+	}
+	log.L(ctx).Debugf("Loaded %d schemas into cache for domain %s", len(results), domainName)
+	return nil
+}
+
+// On startup we load the schemas for all domains in the background, so that the
+// first queries after a restart do not each need to load their schema from the DB.
+func (ss *stateManager) warmSchemaCaches() {
+	defer close(ss.warmSchemaCacheDone)
+
+	var domainNames []string
+	err := ss.p.DB().
+		Table("schemas").
+		WithContext(ss.bgCtx).
+		Distinct("domain_name").
+		Pluck("domain_name", &domainNames).
+		Error
+	if err != nil {
+		log.L(ss.bgCtx).Warnf("Failed to list domains to warm schema cache: %s", err)
+		return
+	}
+	for _, domainName := range domainNames {
+		if err := ss.WarmSchemaCache(ss.bgCtx, domainName); err != nil {
+			log.L(ss.bgCtx).Warnf("Failed to warm schema cache for domain %s: %s", domainName, err)
+		}
+	}
+}
+
 func (ss *stateManager) restoreSchema(ctx context.Context, persisted *pldapi.Schema) (components.Schema, error) {
 	switch persisted.Type.V() {
 	case pldapi.SchemaTypeABI:
@@ -160,17 +210,100 @@ func (ss *stateManager) EnsureABISchemas(ctx context.Context, dbTX persistence.D
 		return nil, nil
 	}
 
-	// Validate all the schemas
+	// Schemas that reference other schemas in the same batch as components are
+	// persisted after the schemas they depend on
+	order, err := sortABISchemaDefs(ctx, defs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate all the schemas - results are returned in the order supplied
 	prepared := make([]components.Schema, len(defs))
-	toFlush := make([]*pldapi.Schema, len(defs))
-	for i, def := range defs {
-		s, err := newABISchema(ctx, domainName, def)
+	toFlush := make([]*pldapi.Schema, 0, len(defs))
+	for _, i := range order {
+		s, err := newABISchema(ctx, domainName, defs[i])
 		if err != nil {
 			return nil, err
 		}
 		prepared[i] = s
-		toFlush[i] = s.Schema
+		toFlush = append(toFlush, s.Schema)
 	}
 
 	return prepared, ss.persistSchemas(ctx, dbTX, toFlush)
 }
+
+// The node key for each schema is its name, falling back to the struct name from
+// the internalType for top-level schemas that are not named
+func abiSchemaDefKey(def *abi.Parameter) string {
+	if def.Name != "" {
+		return def.Name
+	}
+	return abiStructName(def.InternalType)
+}
+
+// Extracts "MyStruct" from "struct MyStruct", "struct MyStruct[]" etc.
+func abiStructName(internalType string) string {
+	name, isStruct := strings.CutPrefix(internalType, "struct ")
+	if !isStruct {
+		return ""
+	}
+	name, _, _ = strings.Cut(name, "[")
+	return name
+}
+
+func abiSchemaDefRefs(components abi.ParameterArray, refs map[string]bool) {
+	for _, c := range components {
+		if name := abiStructName(c.InternalType); name != "" {
+			refs[name] = true
+		}
+		abiSchemaDefRefs(c.Components, refs)
+	}
+}
+
+// Returns the indexes of the supplied definitions topologically sorted, so each schema comes after
+// any schema in the list it references as a component. Otherwise the supplied order is preserved.
+func sortABISchemaDefs(ctx context.Context, defs []*abi.Parameter) ([]int, error) {
+	byKey := make(map[string]int, len(defs))
+	for i, def := range defs {
+		if key := abiSchemaDefKey(def); key != "" {
+			byKey[key] = i
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(defs))
+	order := make([]int, 0, len(defs))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return i18n.NewError(ctx, msgs.MsgStateSchemaDependencyCycle, abiSchemaDefKey(defs[i]))
+		}
+		state[i] = visiting
+		refs := map[string]bool{}
+		abiSchemaDefRefs(defs[i].Components, refs)
+		// Visit dependencies in the order they were supplied, for a deterministic result
+		for j, def := range defs {
+			if refs[abiSchemaDefKey(def)] && byKey[abiSchemaDefKey(def)] == j {
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+		state[i] = visited
+		order = append(order, i)
+		return nil
+	}
+	for i := range defs {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
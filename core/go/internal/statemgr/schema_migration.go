@@ -0,0 +1,265 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"gorm.io/gorm/clause"
+)
+
+// A schema alias records that a schema has been migrated to a new schema, with the same type but
+// additional labels. States written with the old schema keep their schema ID, but are queried
+// using the new schema. The labels they are missing are backfilled in the background.
+type schemaAlias struct {
+	DomainName string             `gorm:"column:domain_name;primaryKey"`
+	ID         pldtypes.Bytes32   `gorm:"column:id;primaryKey"`
+	Target     pldtypes.Bytes32   `gorm:"column:target"`
+	Created    pldtypes.Timestamp `gorm:"column:created"`
+	Backfilled bool               `gorm:"column:backfilled"`
+}
+
+func (ss *stateManager) loadSchemaAliases(ctx context.Context) error {
+	var aliases []*schemaAlias
+	err := ss.p.DB().
+		Table("schema_aliases").
+		WithContext(ctx).
+		Find(&aliases).
+		Error
+	if err != nil {
+		return err
+	}
+	for _, a := range aliases {
+		ss.setSchemaAlias(a)
+		if !a.Backfilled {
+			ss.startSchemaBackfill(a)
+		}
+	}
+	return nil
+}
+
+func (ss *stateManager) setSchemaAlias(a *schemaAlias) {
+	ss.schemaAliasLock.Lock()
+	defer ss.schemaAliasLock.Unlock()
+
+	// Remove from the sources of any previous target
+	oldKey := schemaCacheKey(a.DomainName, a.ID)
+	if prevTarget, exists := ss.schemaAliasTargets[oldKey]; exists {
+		prevKey := schemaCacheKey(a.DomainName, prevTarget)
+		ss.schemaAliasSources[prevKey] = slices.DeleteFunc(ss.schemaAliasSources[prevKey], func(id pldtypes.Bytes32) bool {
+			return id == a.ID
+		})
+	}
+	ss.schemaAliasTargets[oldKey] = a.Target
+	newKey := schemaCacheKey(a.DomainName, a.Target)
+	ss.schemaAliasSources[newKey] = append(ss.schemaAliasSources[newKey], a.ID)
+}
+
+// Returns the schema to use in place of the supplied schema, which is itself unless it has been migrated
+func (ss *stateManager) resolveSchemaAlias(domainName string, schemaID pldtypes.Bytes32) pldtypes.Bytes32 {
+	ss.schemaAliasLock.RLock()
+	defer ss.schemaAliasLock.RUnlock()
+	if target, exists := ss.schemaAliasTargets[schemaCacheKey(domainName, schemaID)]; exists {
+		return target
+	}
+	return schemaID
+}
+
+// Returns the schemas that have been migrated to the supplied schema, which must be included in queries
+func (ss *stateManager) schemaAliasesFor(domainName string, schemaID pldtypes.Bytes32) []pldtypes.Bytes32 {
+	ss.schemaAliasLock.RLock()
+	defer ss.schemaAliasLock.RUnlock()
+	return slices.Clone(ss.schemaAliasSources[schemaCacheKey(domainName, schemaID)])
+}
+
+func (ss *stateManager) MigrateABISchema(ctx context.Context, domainName string, oldSchemaID pldtypes.Bytes32, newABI *abi.Parameter) (components.Schema, error) {
+	// Migrations are serialized, so the aliases we update cannot change underneath us
+	ss.schemaMigrationLock.Lock()
+	defer ss.schemaMigrationLock.Unlock()
+
+	if target := ss.resolveSchemaAlias(domainName, oldSchemaID); target != oldSchemaID {
+		return nil, i18n.NewError(ctx, msgs.MsgStateSchemaAlreadyMigrated, oldSchemaID, target)
+	}
+	oldSchema, err := ss.getSchemaByID(ctx, ss.p.NOTX(), domainName, oldSchemaID, true)
+	if err != nil {
+		return nil, err
+	}
+	oldABISchema, ok := oldSchema.(*abiSchema)
+	if !ok {
+		return nil, i18n.NewError(ctx, msgs.MsgStateInvalidSchemaType, oldSchema.Type())
+	}
+	newSchema, err := newABISchema(ctx, domainName, newABI)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSchemaMigration(ctx, oldABISchema, newSchema); err != nil {
+		return nil, err
+	}
+
+	// Anything previously migrated to the old schema, now migrates to the new one
+	now := pldtypes.TimestampNow()
+	aliases := []*schemaAlias{{DomainName: domainName, ID: oldSchemaID, Target: newSchema.ID(), Created: now}}
+	for _, previous := range ss.schemaAliasesFor(domainName, oldSchemaID) {
+		aliases = append(aliases, &schemaAlias{DomainName: domainName, ID: previous, Target: newSchema.ID(), Created: now})
+	}
+	err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		if err := ss.persistSchemas(ctx, dbTX, []*pldapi.Schema{newSchema.Schema}); err != nil {
+			return err
+		}
+		return dbTX.DB().
+			Table("schema_aliases").
+			WithContext(ctx).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "domain_name"}, {Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"target", "created", "backfilled"}),
+			}).
+			Create(aliases).
+			Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ss.abiSchemaCache.Set(schemaCacheKey(domainName, newSchema.ID()), newSchema)
+	for _, a := range aliases {
+		log.L(ctx).Infof("Schema %s in domain %s migrated to %s", a.ID, domainName, a.Target)
+		ss.setSchemaAlias(a)
+		ss.startSchemaBackfill(a)
+	}
+	return newSchema, nil
+}
+
+// The type must be unchanged (so the state IDs are unchanged), and the labels can only be added to
+func checkSchemaMigration(ctx context.Context, oldSchema, newSchema *abiSchema) error {
+	if oldSchema.typeSet.Encode(oldSchema.primaryType) != newSchema.typeSet.Encode(newSchema.primaryType) {
+		return i18n.NewError(ctx, msgs.MsgStateSchemaMigrationTypeChange, oldSchema.ID(), newSchema.ID())
+	}
+	for _, label := range oldSchema.Labels {
+		if !slices.Contains(newSchema.Labels, label) {
+			return i18n.NewError(ctx, msgs.MsgStateSchemaMigrationLabelLost, oldSchema.ID(), newSchema.ID(), label)
+		}
+	}
+	if len(newSchema.Labels) == len(oldSchema.Labels) {
+		return i18n.NewError(ctx, msgs.MsgStateSchemaMigrationNoChange, oldSchema.ID())
+	}
+	return nil
+}
+
+func (ss *stateManager) startSchemaBackfill(a *schemaAlias) {
+	ss.schemaBackfills.Add(1)
+	go ss.schemaBackfill(a)
+}
+
+func (ss *stateManager) schemaBackfill(a *schemaAlias) {
+	defer ss.schemaBackfills.Done()
+
+	ctx := log.WithLogField(ss.bgCtx, "schema", a.ID.String())
+	err := ss.schemaBackfillRetry.Do(ctx, func(attempt int) (bool, error) {
+		return true, ss.backfillSchemaLabels(ctx, a)
+	})
+	if err != nil {
+		log.L(ctx).Warnf("Label backfill for schema %s migrated to %s stopped before completion: %s", a.ID, a.Target, err)
+	}
+}
+
+// Writes the labels of the target schema for all states of the migrated schema, in pages ordered by ID.
+// Labels that already exist are left unchanged, so this is safe to re-run after a restart.
+func (ss *stateManager) backfillSchemaLabels(ctx context.Context, a *schemaAlias) error {
+	target, err := ss.getSchemaByID(ctx, ss.p.NOTX(), a.DomainName, a.Target, true)
+	if err != nil {
+		return err
+	}
+	targetSchema, ok := target.(*abiSchema)
+	if !ok {
+		return i18n.NewError(ctx, msgs.MsgStateInvalidSchemaType, target.Type())
+	}
+
+	var after pldtypes.HexBytes
+	total := 0
+	for {
+		var page []*pldapi.State
+		q := ss.p.DB().
+			Table("states").
+			WithContext(ctx).
+			Select("id", "data").
+			Where("states.domain_name = ?", a.DomainName).
+			Where("states.schema = ?", a.ID)
+		if after != nil {
+			q = q.Where("states.id > ?", after)
+		}
+		err := q.Order("states.id").Limit(ss.maxBatchStates).Find(&page).Error
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		var labels []*pldapi.StateLabel
+		var int64Labels []*pldapi.StateInt64Label
+		for _, s := range page {
+			psd, err := targetSchema.parseStateData(ctx, s.Data)
+			if err != nil {
+				// We cannot block the migration of every other state, on one we cannot parse
+				log.L(ctx).Warnf("Unable to backfill labels for state %s: %s", s.ID, err)
+				continue
+			}
+			for _, l := range psd.labels {
+				l.DomainName, l.State = a.DomainName, s.ID
+				labels = append(labels, l)
+			}
+			for _, l := range psd.int64Labels {
+				l.DomainName, l.State = a.DomainName, s.ID
+				int64Labels = append(int64Labels, l)
+			}
+		}
+		err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return ss.writeStateLabels(dbTX, labels, int64Labels)
+		})
+		if err != nil {
+			return err
+		}
+		total += len(page)
+		after = page[len(page)-1].ID
+		log.L(ctx).Debugf("Backfilled labels for %d states of schema %s migrated to %s", total, a.ID, a.Target)
+		if len(page) < ss.maxBatchStates {
+			break
+		}
+	}
+
+	// Only mark it complete if it has not been migrated again in the meantime
+	err = ss.p.DB().
+		Table("schema_aliases").
+		WithContext(ctx).
+		Where("domain_name = ?", a.DomainName).
+		Where("id = ?", a.ID).
+		Where("target = ?", a.Target).
+		Update("backfilled", true).
+		Error
+	if err == nil {
+		log.L(ctx).Infof("Label backfill complete for %d states of schema %s migrated to %s", total, a.ID, a.Target)
+	}
+	return err
+}
@@ -0,0 +1,317 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/core/pkg/persistence/mockpersistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The FakeCoin type, with a choice of which fields are indexed
+func fakeCoinABIIndexed(indexed ...string) *abi.Parameter {
+	param := &abi.Parameter{
+		Type:         "tuple",
+		InternalType: "struct FakeCoin",
+		Components: abi.ParameterArray{
+			{Name: "salt", Type: "bytes32"},
+			{Name: "owner", Type: "address"},
+			{Name: "amount", Type: "uint256"},
+		},
+	}
+	for _, c := range param.Components {
+		for _, name := range indexed {
+			if c.Name == name {
+				c.Indexed = true
+			}
+		}
+	}
+	return param
+}
+
+func writeFakeCoins(ctx context.Context, t *testing.T, ss *stateManager, schemaID pldtypes.Bytes32, count int) []*pldapi.State {
+	upserts := make([]*components.StateUpsertOutsideContext, count)
+	for i := range upserts {
+		upserts[i] = &components.StateUpsertOutsideContext{
+			SchemaID:        schemaID,
+			ContractAddress: pldtypes.RandAddress(),
+			Data: pldtypes.RawJSON(fmt.Sprintf(
+				`{"salt": "%s", "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "amount": %d}`,
+				pldtypes.RandHex(32), i+1)),
+		}
+	}
+	var states []*pldapi.State
+	err := ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		states, err = ss.WriteReceivedStates(ctx, dbTX, "domain1", upserts)
+		return err
+	})
+	require.NoError(t, err)
+	return states
+}
+
+func fakeCoinSalt(t *testing.T, s *pldapi.State) string {
+	var coin struct {
+		Salt pldtypes.Bytes32 `json:"salt"`
+	}
+	err := json.Unmarshal(s.Data, &coin)
+	require.NoError(t, err)
+	return coin.Salt.String()
+}
+
+func findBySalt(ctx context.Context, t *testing.T, ss *stateManager, schemaID pldtypes.Bytes32, salt any) []*pldapi.State {
+	states, err := ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID,
+		query.NewQueryBuilder().Equal("salt", salt).Query(), &components.StateQueryOptions{StatusQualifier: pldapi.StateStatusAll})
+	require.NoError(t, err)
+	return states
+}
+
+func TestMigrateABISchemaBackfill(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+	ss.maxBatchStates = 2 // so we page
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{fakeCoinABIIndexed("owner")})
+	require.NoError(t, err)
+	schemaV1 := schemas[0].ID()
+	states := writeFakeCoins(ctx, t, ss, schemaV1, 5)
+
+	// Cannot query the salt before the migration
+	_, err = ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaV1,
+		query.NewQueryBuilder().Equal("salt", pldtypes.RandHex(32)).Query(), &components.StateQueryOptions{})
+	assert.Regexp(t, "PD010700", err)
+
+	schemaV2, err := ss.MigrateABISchema(ctx, "domain1", schemaV1, fakeCoinABIIndexed("owner", "salt"))
+	require.NoError(t, err)
+	assert.NotEqual(t, schemaV1, schemaV2.ID())
+	assert.Equal(t, []string{"salt", "owner"}, schemaV2.Persisted().Labels)
+	ss.schemaBackfills.Wait()
+
+	// Both the old and new schema IDs query with the new schema, and find the states written before the migration
+	salt := fakeCoinSalt(t, states[3])
+	for _, schemaID := range []pldtypes.Bytes32{schemaV1, schemaV2.ID()} {
+		found := findBySalt(ctx, t, ss, schemaID, salt)
+		require.Len(t, found, 1)
+		assert.Equal(t, states[3].ID, found[0].ID)
+		assert.Equal(t, schemaV1, found[0].Schema)
+		assert.Len(t, findBySalt(ctx, t, ss, schemaID, pldtypes.RandHex(32)), 0)
+	}
+
+	// New states can be written against the new schema, and are returned alongside the old ones
+	_ = writeFakeCoins(ctx, t, ss, schemaV2.ID(), 1)
+	all, err := ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaV1,
+		query.NewQueryBuilder().Equal("owner", "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180").Query(), &components.StateQueryOptions{})
+	require.NoError(t, err)
+	assert.Len(t, all, 6)
+
+	var aliases []*schemaAlias
+	err = ss.p.DB().Table("schema_aliases").Find(&aliases).Error
+	require.NoError(t, err)
+	require.Len(t, aliases, 1)
+	assert.Equal(t, schemaV1, aliases[0].ID)
+	assert.Equal(t, schemaV2.ID(), aliases[0].Target)
+	assert.True(t, aliases[0].Backfilled)
+
+	// Cannot migrate the same schema twice
+	_, err = ss.MigrateABISchema(ctx, "domain1", schemaV1, fakeCoinABIIndexed("owner", "salt", "amount"))
+	assert.Regexp(t, "PD010148", err)
+
+	// But can migrate the new schema, which moves the original schema along with it
+	schemaV3, err := ss.MigrateABISchema(ctx, "domain1", schemaV2.ID(), fakeCoinABIIndexed("owner", "salt", "amount"))
+	require.NoError(t, err)
+	ss.schemaBackfills.Wait()
+	assert.Equal(t, schemaV3.ID(), ss.resolveSchemaAlias("domain1", schemaV1))
+	assert.Equal(t, schemaV3.ID(), ss.resolveSchemaAlias("domain1", schemaV2.ID()))
+	assert.ElementsMatch(t, []pldtypes.Bytes32{schemaV1, schemaV2.ID()}, ss.schemaAliasesFor("domain1", schemaV3.ID()))
+	assert.Empty(t, ss.schemaAliasesFor("domain1", schemaV2.ID()))
+	for _, schemaID := range []pldtypes.Bytes32{schemaV1, schemaV2.ID(), schemaV3.ID()} {
+		found, err := ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID,
+			query.NewQueryBuilder().GreaterThan("amount", 3).Query(), &components.StateQueryOptions{})
+		require.NoError(t, err)
+		assert.Len(t, found, 2)
+	}
+
+	// The aliases are reloaded on restart
+	ss2 := NewStateManager(ctx, &pldconf.StateStoreConfig{}, ss.p).(*stateManager)
+	_, err = ss2.PreInit(m.allComponents)
+	require.NoError(t, err)
+	err = ss2.PostInit(m.allComponents)
+	require.NoError(t, err)
+	err = ss2.Start()
+	require.NoError(t, err)
+	defer ss2.Stop()
+	assert.Equal(t, schemaV3.ID(), ss2.resolveSchemaAlias("domain1", schemaV1))
+	assert.ElementsMatch(t, []pldtypes.Bytes32{schemaV1, schemaV2.ID()}, ss2.schemaAliasesFor("domain1", schemaV3.ID()))
+	assert.Len(t, findBySalt(ctx, t, ss2, schemaV1, salt), 1)
+}
+
+func TestMigrateABISchemaResumesBackfill(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{fakeCoinABIIndexed("owner"), fakeCoinABIIndexed("owner", "salt")})
+	require.NoError(t, err)
+	schemaV1, schemaV2 := schemas[0].ID(), schemas[1].ID()
+	states := writeFakeCoins(ctx, t, ss, schemaV1, 1)
+
+	// Simulate a restart before the backfill completed
+	err = ss.p.DB().Table("schema_aliases").Create(&schemaAlias{
+		DomainName: "domain1", ID: schemaV1, Target: schemaV2, Created: pldtypes.TimestampNow(),
+	}).Error
+	require.NoError(t, err)
+	err = ss.loadSchemaAliases(ctx)
+	require.NoError(t, err)
+	ss.schemaBackfills.Wait()
+
+	found := findBySalt(ctx, t, ss, schemaV2, fakeCoinSalt(t, states[0]))
+	assert.Len(t, found, 1)
+}
+
+func TestMigrateABISchemaErrors(t *testing.T) {
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{fakeCoinABIIndexed("owner", "amount")})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	_, err = ss.MigrateABISchema(ctx, "domain1", pldtypes.RandBytes32(), fakeCoinABIIndexed("owner", "amount", "salt"))
+	assert.Regexp(t, "PD010106", err)
+
+	_, err = ss.MigrateABISchema(ctx, "domain1", schemaID, testABIParam(t, fakeCoinABI2))
+	assert.Regexp(t, "PD010145", err)
+
+	_, err = ss.MigrateABISchema(ctx, "domain1", schemaID, fakeCoinABIIndexed("owner", "salt"))
+	assert.Regexp(t, "PD010146.*amount", err)
+
+	_, err = ss.MigrateABISchema(ctx, "domain1", schemaID, fakeCoinABIIndexed("amount", "owner"))
+	assert.Regexp(t, "PD010147", err)
+
+	_, err = ss.MigrateABISchema(ctx, "domain1", schemaID, &abi.Parameter{Type: "wrong"})
+	assert.Regexp(t, "PD010114", err)
+}
+
+func TestMigrateABISchemaNotABI(t *testing.T) {
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	schemaID := pldtypes.RandBytes32()
+	ms := componentsmocks.NewSchema(t)
+	ms.On("Type").Return(pldapi.SchemaTypeABI) // not an *abiSchema
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", schemaID), ms)
+
+	_, err := ss.MigrateABISchema(ctx, "domain1", schemaID, fakeCoinABIIndexed("owner", "salt"))
+	assert.Regexp(t, "PD010103", err)
+}
+
+func TestMigrateABISchemaDBFail(t *testing.T) {
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	as, err := newABISchema(ctx, "domain1", fakeCoinABIIndexed("owner"))
+	require.NoError(t, err)
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", as.ID()), as)
+
+	db.ExpectBegin()
+	db.ExpectExec("INSERT.*schemas").WillReturnResult(sqlmock.NewResult(1, 1))
+	db.ExpectExec("INSERT.*schema_aliases").WillReturnError(fmt.Errorf("pop"))
+	db.ExpectRollback()
+
+	_, err = ss.MigrateABISchema(ctx, "domain1", as.ID(), fakeCoinABIIndexed("owner", "salt"))
+	assert.Regexp(t, "pop", err)
+	assert.Equal(t, as.ID(), ss.resolveSchemaAlias("domain1", as.ID()))
+}
+
+func TestLoadSchemaAliasesFail(t *testing.T) {
+	p, err := mockpersistence.NewSQLMockProvider()
+	require.NoError(t, err)
+	ss := NewStateManager(context.Background(), &pldconf.StateStoreConfig{
+		WarmSchemaCache: confutil.P(false),
+	}, p.P)
+
+	p.Mock.ExpectQuery("SELECT.*schema_aliases").WillReturnError(fmt.Errorf("pop"))
+	err = ss.Start()
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBackfillSchemaLabelsErrors(t *testing.T) {
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	a := &schemaAlias{DomainName: "domain1", ID: pldtypes.RandBytes32(), Target: pldtypes.RandBytes32()}
+
+	// Target schema not found
+	db.ExpectQuery("SELECT.*schemas").WillReturnRows(sqlmock.NewRows([]string{}))
+	err := ss.backfillSchemaLabels(ctx, a)
+	assert.Regexp(t, "PD010106", err)
+
+	// Target schema not ABI
+	ms := componentsmocks.NewSchema(t)
+	ms.On("Type").Return(pldapi.SchemaTypeABI)
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", a.Target), ms)
+	err = ss.backfillSchemaLabels(ctx, a)
+	assert.Regexp(t, "PD010103", err)
+
+	// Query states fails
+	as, err := newABISchema(ctx, "domain1", fakeCoinABIIndexed("owner", "salt"))
+	require.NoError(t, err)
+	ss.abiSchemaCache.Set(schemaCacheKey("domain1", a.Target), as)
+	db.ExpectQuery("SELECT.*states").WillReturnError(fmt.Errorf("pop"))
+	err = ss.backfillSchemaLabels(ctx, a)
+	assert.Regexp(t, "pop", err)
+
+	// Bad data is skipped, and write labels fails
+	db.ExpectQuery("SELECT.*states").WillReturnRows(sqlmock.NewRows([]string{"id", "data"}).
+		AddRow(pldtypes.RandBytes(32), `{"wrong": "data"}`).
+		AddRow(pldtypes.RandBytes(32), fmt.Sprintf(`{"salt": "%s", "owner": "%s", "amount": 1}`, pldtypes.RandHex(32), pldtypes.RandAddress())))
+	db.ExpectBegin()
+	db.ExpectExec("INSERT.*state_labels").WillReturnError(fmt.Errorf("pop"))
+	db.ExpectRollback()
+	err = ss.backfillSchemaLabels(ctx, a)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestSchemaBackfillStopsOnClose(t *testing.T) {
+	_, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	a := &schemaAlias{DomainName: "domain1", ID: pldtypes.RandBytes32(), Target: pldtypes.RandBytes32()}
+	db.ExpectQuery("SELECT.*schemas").WillReturnError(fmt.Errorf("pop"))
+
+	ss.cancelCtx()
+	ss.startSchemaBackfill(a)
+	ss.schemaBackfills.Wait()
+}
@@ -17,10 +17,12 @@
 package statemgr
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/stretchr/testify/assert"
@@ -108,3 +110,157 @@ func TestListSchemasGetFullSchemaFail(t *testing.T) {
 	_, err := ss.ListSchemas(ctx, ss.p.NOTX(), "domain1")
 	assert.Regexp(t, "pop", err)
 }
+
+func TestWarmSchemaCache(t *testing.T) {
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+	<-ss.warmSchemaCacheDone // nothing to load on startup
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI), testABIParam(t, partABI)})
+	require.NoError(t, err)
+	for _, s := range schemas {
+		_, cached := ss.abiSchemaCache.Get(schemaCacheKey("domain1", s.ID()))
+		assert.False(t, cached)
+	}
+
+	err = ss.WarmSchemaCache(ctx, "domain1")
+	require.NoError(t, err)
+	for _, s := range schemas {
+		cachedSchema, cached := ss.abiSchemaCache.Get(schemaCacheKey("domain1", s.ID()))
+		require.True(t, cached)
+		assert.Equal(t, s.Signature(), cachedSchema.Signature())
+	}
+
+	// Check the startup load finds all domains
+	ss.abiSchemaCache.Clear()
+	domain2Schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain2", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	ss.warmSchemaCacheDone = make(chan struct{})
+	ss.warmSchemaCaches()
+	_, cached := ss.abiSchemaCache.Get(schemaCacheKey("domain1", schemas[0].ID()))
+	assert.True(t, cached)
+	_, cached = ss.abiSchemaCache.Get(schemaCacheKey("domain2", domain2Schemas[0].ID()))
+	assert.True(t, cached)
+}
+
+func TestWarmSchemaCacheQueryFail(t *testing.T) {
+	ctx, ss, mdb, _, done := newDBMockStateManager(t)
+	defer done()
+
+	mdb.ExpectQuery("SELECT.*schemas").WillReturnError(fmt.Errorf("pop"))
+
+	err := ss.WarmSchemaCache(ctx, "domain1")
+	assert.Regexp(t, "pop", err)
+}
+
+func TestWarmSchemaCacheRestoreFail(t *testing.T) {
+	ctx, ss, mdb, _, done := newDBMockStateManager(t)
+	defer done()
+
+	mdb.ExpectQuery("SELECT.*schemas").WillReturnRows(sqlmock.NewRows(
+		[]string{"type", "content"},
+	).AddRow(pldapi.SchemaTypeABI, "!!! { bad json"))
+
+	err := ss.WarmSchemaCache(ctx, "domain1")
+	assert.Regexp(t, "PD010113", err)
+}
+
+func TestWarmSchemaCachesFail(t *testing.T) {
+	_, ss, mdb, _, done := newDBMockStateManager(t)
+	defer done()
+
+	mdb.ExpectQuery("SELECT DISTINCT.*schemas").WillReturnError(fmt.Errorf("pop"))
+	ss.warmSchemaCacheDone = make(chan struct{})
+	ss.warmSchemaCaches()
+
+	mdb.ExpectQuery("SELECT DISTINCT.*schemas").WillReturnRows(sqlmock.NewRows([]string{"domain_name"}).AddRow("domain1"))
+	mdb.ExpectQuery("SELECT.*schemas").WillReturnError(fmt.Errorf("pop"))
+	ss.warmSchemaCacheDone = make(chan struct{})
+	ss.warmSchemaCaches()
+}
+
+const partABI = `{
+	"type": "tuple",
+	"internalType": "struct Part",
+	"components": [
+		{"name": "serial", "type": "uint256"}
+	]
+}`
+
+const assemblyABI = `{
+	"type": "tuple",
+	"internalType": "struct Assembly",
+	"components": [
+		{"name": "salt", "type": "bytes32"},
+		{"name": "parts", "type": "tuple[]", "internalType": "struct Part[]", "components": [
+			{"name": "serial", "type": "uint256"}
+		]}
+	]
+}`
+
+const assemblyBoxABI = `{
+	"type": "tuple",
+	"internalType": "struct AssemblyBox",
+	"components": [
+		{"name": "salt", "type": "bytes32"},
+		{"name": "widget", "type": "tuple", "internalType": "struct Assembly", "components": [
+			{"name": "salt", "type": "bytes32"},
+			{"name": "parts", "type": "tuple[]", "internalType": "struct Part[]", "components": [
+				{"name": "serial", "type": "uint256"}
+			]}
+		]}
+	]
+}`
+
+func TestEnsureABISchemasDependencyOrder(t *testing.T) {
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	// Supplied in reverse dependency order
+	defs := []*abi.Parameter{
+		testABIParam(t, assemblyBoxABI),
+		testABIParam(t, assemblyABI),
+		testABIParam(t, partABI),
+	}
+	order, err := sortABISchemaDefs(ctx, defs)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1, 0}, order)
+
+	// Results are still in the order supplied
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", defs)
+	require.NoError(t, err)
+	require.Len(t, schemas, 3)
+	assert.Equal(t, "type=AssemblyBox(bytes32 salt,Assembly widget)Assembly(bytes32 salt,Part[] parts)Part(uint256 serial),labels=[]", schemas[0].Signature())
+	assert.Equal(t, "type=Part(uint256 serial),labels=[]", schemas[2].Signature())
+
+	// Independent schemas keep their order
+	order, err = sortABISchemaDefs(ctx, []*abi.Parameter{
+		testABIParam(t, fakeCoinABI),
+		testABIParam(t, partABI),
+		testABIParam(t, fakeCoinABI2),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, order)
+}
+
+func TestEnsureABISchemasDependencyCycle(t *testing.T) {
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	_, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{
+		{Name: "A", Type: "tuple", InternalType: "struct A", Components: abi.ParameterArray{
+			{Name: "b", Type: "tuple", InternalType: "struct B", Components: abi.ParameterArray{}},
+		}},
+		{Name: "B", Type: "tuple", InternalType: "struct B", Components: abi.ParameterArray{
+			{Name: "a", Type: "tuple", InternalType: "struct A", Components: abi.ParameterArray{}},
+		}},
+	})
+	assert.Regexp(t, "PD010134.*A", err)
+
+	_, err = sortABISchemaDefs(context.Background(), []*abi.Parameter{
+		{Type: "tuple", InternalType: "struct Self", Components: abi.ParameterArray{
+			{Name: "self", Type: "tuple", InternalType: "struct Self"},
+		}},
+	})
+	assert.Regexp(t, "PD010134.*Self", err)
+}
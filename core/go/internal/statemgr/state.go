@@ -117,6 +117,8 @@ func (ss *stateManager) WriteNullifiersForReceivedStates(ctx context.Context, db
 
 func (ss *stateManager) processInsertStates(ctx context.Context, dbTX persistence.DBTX, d components.Domain, inStates []*components.StateUpsertOutsideContext) (processedStates []*pldapi.State, err error) {
 
+	customHash := d.CustomHashFunction()
+	flagExternalID := customHash && d.FlagExternalStateIDs()
 	processedStates = make([]*pldapi.State, len(inStates))
 	for i, inState := range inStates {
 		schema, err := ss.getSchemaByID(ctx, dbTX, d.Name(), inState.SchemaID, true)
@@ -124,7 +126,7 @@ func (ss *stateManager) processInsertStates(ctx context.Context, dbTX persistenc
 			return nil, err
 		}
 
-		s, err := schema.ProcessState(ctx, inState.ContractAddress, inState.Data, inState.ID, d.CustomHashFunction())
+		s, err := schema.ProcessState(ctx, inState.ContractAddress, inState.Data, inState.ID, customHash, flagExternalID)
 		if err != nil {
 			return nil, err
 		}
@@ -160,7 +162,14 @@ func (ss *stateManager) writeStates(ctx context.Context, dbTX persistence.DBTX,
 			Create(states).
 			Error
 	}
-	if err == nil && len(labels) > 0 {
+	if err == nil {
+		err = ss.writeStateLabels(dbTX, labels, int64Labels)
+	}
+	return err
+}
+
+func (ss *stateManager) writeStateLabels(dbTX persistence.DBTX, labels []*pldapi.StateLabel, int64Labels []*pldapi.StateInt64Label) (err error) {
+	if len(labels) > 0 {
 		err = dbTX.DB().
 			Table("state_labels").
 			Clauses(clause.OnConflict{
@@ -184,7 +193,9 @@ func (ss *stateManager) writeStates(ctx context.Context, dbTX persistence.DBTX,
 }
 
 func (ss *stateManager) GetStatesByID(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress *pldtypes.EthAddress, stateIDs []pldtypes.HexBytes, failNotFound, withLabels bool) ([]*pldapi.State, error) {
-	q := dbTX.DB().Table("states")
+	db, cancel := persistence.QueryContext(ctx, dbTX.DB())
+	defer cancel()
+	q := db.Table("states")
 	if withLabels {
 		q = q.Preload("Labels").Preload("Int64Labels")
 	}
@@ -204,10 +215,36 @@ func (ss *stateManager) GetStatesByID(ctx context.Context, dbTX persistence.DBTX
 	return states, err
 }
 
+// Returns a single state with its confirm, read, spend and nullifier records, and any locks held on it in memory
+// by active domain contexts - or nil if it does not exist
+func (ss *stateManager) getStateWithStatus(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress *pldtypes.EthAddress, stateID pldtypes.HexBytes) (*pldapi.State, error) {
+	db, cancel := persistence.QueryContext(ctx, dbTX.DB())
+	defer cancel()
+	q := db.Table("states").
+		Preload("Confirmed").
+		Preload("Read").
+		Preload("Spent").
+		Preload("Nullifier").
+		Preload("Nullifier.Spent").
+		Where("domain_name = ?", domainName).
+		Where("id = ?", stateID)
+	if contractAddress != nil {
+		q = q.Where("contract_address = ?", contractAddress)
+	}
+	var states []*pldapi.State
+	err := q.Limit(1).Find(&states).Error
+	if err != nil || len(states) == 0 {
+		return nil, err
+	}
+	state := states[0]
+	state.Locks = ss.findStateLocks(domainName, stateID)
+	return state, nil
+}
+
 // Built in fields all start with "." as that prevents them
 // clashing with variable names in ABI structs ($ and _ are valid leading chars there)
 var baseStateFields = map[string]filters.FieldResolver{
-	".id":      filters.HexBytesField(`"states"."id"`),
+	".id":      filters.Bytes32Field(`"states"."id"`),
 	".created": filters.TimestampField(`"states"."created"`),
 }
 
@@ -218,11 +255,21 @@ func addStateBaseLabels(labelValues filters.PassthroughValueSet, id pldtypes.Hex
 }
 
 type trackingLabelSet struct {
-	labels map[string]*schemaLabelInfo
-	used   map[string]*schemaLabelInfo
+	labels   map[string]*schemaLabelInfo
+	used     map[string]*schemaLabelInfo
+	resolved map[string]filters.FieldResolver // cache, as we are called for every field of every state evaluated in memory
 }
 
 func (ft trackingLabelSet) ResolverFor(fieldName string) filters.FieldResolver {
+	if resolver, cached := ft.resolved[fieldName]; cached {
+		return resolver
+	}
+	resolver := ft.resolve(fieldName)
+	ft.resolved[fieldName] = resolver
+	return resolver
+}
+
+func (ft trackingLabelSet) resolve(fieldName string) filters.FieldResolver {
 	baseField := baseStateFields[fieldName]
 	if baseField != nil {
 		return baseField
@@ -236,7 +283,11 @@ func (ft trackingLabelSet) ResolverFor(fieldName string) filters.FieldResolver {
 }
 
 func (ss *stateManager) labelSetFor(schema components.Schema) *trackingLabelSet {
-	tls := trackingLabelSet{labels: make(map[string]*schemaLabelInfo), used: make(map[string]*schemaLabelInfo)}
+	tls := trackingLabelSet{
+		labels:   make(map[string]*schemaLabelInfo),
+		used:     make(map[string]*schemaLabelInfo),
+		resolved: make(map[string]filters.FieldResolver),
+	}
 	for _, fi := range schema.(labelInfoAccess).labelInfo() {
 		tls.labels[fi.label] = fi
 	}
@@ -253,6 +304,27 @@ func (ss *stateManager) FindStates(ctx context.Context, dbTX persistence.DBTX, d
 	return s, err
 }
 
+func (ss *stateManager) FindStatesAcrossContracts(ctx context.Context, dbTX persistence.DBTX, domainName string, schemaID pldtypes.Bytes32, contractAddresses []pldtypes.EthAddress, query *query.QueryJSON, status pldapi.StateStatusQualifier) (s []*pldapi.State, err error) {
+	if status == "" {
+		status = pldapi.StateStatusAll
+	}
+	whereClause, isPlainDB := whereClauseForQual(dbTX.DB(), status, "Spent")
+	if !isPlainDB {
+		return nil, i18n.NewError(ctx, msgs.MsgStateQueryAcrossContractsDC, status)
+	}
+	if len(contractAddresses) == 0 {
+		return []*pldapi.State{}, nil
+	}
+	options := &components.StateQueryOptions{
+		StatusQualifier: status,
+		QueryModifier: func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
+			return q.Where("states.contract_address IN ?", contractAddresses)
+		},
+	}
+	_, s, err = ss.findStatesCommon(ctx, dbTX, domainName, nil, schemaID, query, statusQueryModifier(options, whereClause, nil))
+	return s, err
+}
+
 func (ss *stateManager) FindContractNullifiers(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress pldtypes.EthAddress, schemaID pldtypes.Bytes32, query *query.QueryJSON, status pldapi.StateStatusQualifier) (s []*pldapi.State, err error) {
 	_, s, err = ss.findNullifiers(ctx, dbTX, domainName, &contractAddress, schemaID, query, status, nil, nil)
 	return s, err
@@ -279,23 +351,17 @@ func (ss *stateManager) findStates(
 		options.StatusQualifier = pldapi.StateStatusAll
 	}
 	whereClause, isPlainDB := whereClauseForQual(dbTX.DB(), options.StatusQualifier, "Spent")
+	var snapshot *querySnapshot
+	if options.QueryToken != nil {
+		if !isPlainDB {
+			return nil, nil, i18n.NewError(ctx, msgs.MsgStateQueryTokenDomainContext)
+		}
+		if snapshot, err = ss.getQuerySnapshot(ctx, domainName, *options.QueryToken); err != nil {
+			return nil, nil, err
+		}
+	}
 	if isPlainDB {
-		return ss.findStatesCommon(ctx, dbTX, domainName, contractAddress, schemaID, jq, func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
-			q = q.Joins("Confirmed", dbTX.DB().Select("transaction")).
-				Joins("Spent", dbTX.DB().Select("transaction"))
-
-			if len(options.ExcludedIDs) > 0 {
-				q = q.Not(`"states"."id" IN(?)`, options.ExcludedIDs)
-			}
-
-			// Scope the query based on the status qualifier
-			q = q.Where(whereClause)
-
-			if options.QueryModifier != nil {
-				q = options.QueryModifier(dbTX, q)
-			}
-			return q
-		})
+		return ss.findStatesCommon(ctx, dbTX, domainName, contractAddress, schemaID, jq, statusQueryModifier(options, whereClause, snapshot))
 	}
 
 	// Otherwise, we need to run it against the specified domain context
@@ -360,6 +426,60 @@ func (ss *stateManager) findNullifiers(
 	return dc.FindAvailableNullifiers(dbTX, schemaID, jq)
 }
 
+// Returns a query modifier that scopes a query against the DB using the status qualifier and other options
+func statusQueryModifier(options *components.StateQueryOptions, whereClause *gorm.DB, snapshot *querySnapshot) func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
+	return func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB {
+		q = q.Joins("Confirmed", dbTX.DB().Select("transaction")).
+			Joins("Spent", dbTX.DB().Select("transaction"))
+
+		if len(options.ExcludedIDs) > 0 {
+			q = q.Not(`"states"."id" IN(?)`, options.ExcludedIDs)
+		}
+
+		// Scope the query based on the status qualifier
+		q = q.Where(whereClause)
+
+		if snapshot != nil {
+			q = q.Where(`"states"."created" <= ?`, snapshot.created)
+		}
+
+		if options.ExcludeData {
+			q = q.Omit("Data")
+		}
+
+		if options.QueryModifier != nil {
+			q = options.QueryModifier(dbTX, q)
+		}
+		return q
+	}
+}
+
+// countAvailableStates counts the available states in the DB matching the query, without loading them
+func (ss *stateManager) countAvailableStates(
+	ctx context.Context,
+	dbTX persistence.DBTX,
+	domainName string,
+	contractAddress *pldtypes.EthAddress,
+	schema components.Schema,
+	jq *query.QueryJSON,
+	excludedIDs []pldtypes.HexBytes,
+) (count int64, err error) {
+	options := &components.StateQueryOptions{
+		StatusQualifier: pldapi.StateStatusAvailable,
+		ExcludedIDs:     excludedIDs,
+	}
+	whereClause, _ := whereClauseForQual(dbTX.DB(), options.StatusQualifier, "Spent")
+	db, cancel := persistence.QueryContext(ctx, dbTX.DB())
+	defer cancel()
+	q, err := ss.buildStatesQuery(ctx, dbTX, db, domainName, contractAddress, schema, jq, statusQueryModifier(options, whereClause, nil))
+	if err != nil {
+		return -1, err
+	}
+	// The model is needed for the Confirmed/Spent association joins, as there is no result slice
+	err = q.Model(&pldapi.State{}).Count(&count).Error
+	return count, err
+}
+
 func (ss *stateManager) findStatesCommon(
 	ctx context.Context,
 	dbTX persistence.DBTX,
@@ -378,12 +498,41 @@ func (ss *stateManager) findStatesCommon(
 		return nil, nil, err
 	}
 
+	db, cancel := persistence.QueryContext(ctx, dbTX.DB())
+	defer cancel()
+	q, err := ss.buildStatesQuery(ctx, dbTX, db, domainName, contractAddress, schema, jq, modifyQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var states []*pldapi.State
+	q = q.Find(&states)
+	if q.Error != nil {
+		return nil, nil, q.Error
+	}
+	return schema, states, nil
+}
+
+func (ss *stateManager) buildStatesQuery(
+	ctx context.Context,
+	dbTX persistence.DBTX,
+	db *gorm.DB, // bound to the query context by the caller
+	domainName string,
+	contractAddress *pldtypes.EthAddress,
+	schema components.Schema,
+	jq *query.QueryJSON,
+	modifyQuery func(dbTX persistence.DBTX, q *gorm.DB) *gorm.DB,
+) (*gorm.DB, error) {
 	tracker := ss.labelSetFor(schema)
 
+	if ss.debugSQL {
+		db = db.Session(&gorm.Session{Logger: debugSQLLogger{}})
+	}
+
 	// Build the query
-	q := filters.BuildGORM(ctx, jq, dbTX.DB().Table("states"), tracker)
+	q := filters.BuildGORM(ctx, jq, db.Table("states"), tracker)
 	if q.Error != nil {
-		return nil, nil, q.Error
+		return nil, q.Error
 	}
 
 	// Add joins only for the fields actually used in the query
@@ -395,17 +544,15 @@ func (ss *stateManager) findStatesCommon(
 		q = q.Joins(fmt.Sprintf(`INNER JOIN state_%[1]slabels AS %[2]s ON %[2]s.state = "states"."id" AND %[2]s.label = ?`, typeMod, fi.virtualColumn), fi.label)
 	}
 
-	q = q.Where("states.domain_name = ?", domainName).
-		Where("states.schema = ?", schema.Persisted().ID)
+	q = q.Where("states.domain_name = ?", domainName)
+	if aliases := ss.schemaAliasesFor(domainName, schema.Persisted().ID); len(aliases) > 0 {
+		// Include the states of any schemas that have been migrated to this one
+		q = q.Where("states.schema IN ?", append([]pldtypes.Bytes32{schema.Persisted().ID}, aliases...))
+	} else {
+		q = q.Where("states.schema = ?", schema.Persisted().ID)
+	}
 	if contractAddress != nil {
 		q = q.Where("states.contract_address = ?", contractAddress)
 	}
-	q = modifyQuery(dbTX, q)
-
-	var states []*pldapi.State
-	q = q.Find(&states)
-	if q.Error != nil {
-		return nil, nil, q.Error
-	}
-	return schema, states, nil
+	return modifyQuery(dbTX, q), nil
 }
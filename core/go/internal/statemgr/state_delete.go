@@ -0,0 +1,129 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// Tables that reference a state, which are always cleared when the state is deleted
+var stateOwnedTables = []string{"state_labels", "state_int64_labels"}
+
+// Tables that record the finalization of a state by a transaction, which are cleared when that transaction is reverted
+var stateFinalizationTables = []string{"state_confirm_records", "state_spend_records", "state_read_records", "state_info_records"}
+
+// Tables that record the status of a state, which are only cleared by a forced delete.
+// The nullifier is kept on a normal delete, as it is the link to any spend of the state in nullifier domains.
+var stateRecordTables = []string{"state_confirm_records", "state_spend_records", "state_read_records", "state_info_records", "state_nullifiers"}
+
+func (ss *stateManager) DeleteState(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress pldtypes.EthAddress, stateID pldtypes.HexBytes) error {
+	return ss.deleteState(ctx, dbTX, domainName, contractAddress, stateID, false)
+}
+
+func (ss *stateManager) AdminDeleteState(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress pldtypes.EthAddress, stateID pldtypes.HexBytes, allowForce bool) error {
+	if !allowForce {
+		return i18n.NewError(ctx, msgs.MsgStateDeleteForceRequired)
+	}
+	return ss.deleteState(ctx, dbTX, domainName, contractAddress, stateID, true)
+}
+
+func (ss *stateManager) deleteState(ctx context.Context, dbTX persistence.DBTX, domainName string, contractAddress pldtypes.EthAddress, stateID pldtypes.HexBytes, force bool) error {
+	if _, err := ss.GetStatesByID(ctx, dbTX, domainName, &contractAddress, []pldtypes.HexBytes{stateID}, true, false); err != nil {
+		return err
+	}
+
+	if locks := ss.findStateLocks(domainName, stateID); len(locks) > 0 {
+		return i18n.NewError(ctx, msgs.MsgStateDeleteLocked, stateID, locks[0].Transaction)
+	}
+
+	if !force {
+		// In nullifier domains the spend record is against the nullifier, rather than the state
+		var count int64
+		err := dbTX.DB().WithContext(ctx).
+			Raw(`SELECT COUNT(*) FROM (`+
+				`SELECT "state" FROM "state_confirm_records" WHERE "domain_name" = ? AND "state" = ? `+
+				`UNION ALL SELECT "state" FROM "state_spend_records" WHERE "domain_name" = ? AND "state" = ? `+
+				`UNION ALL SELECT n."state" FROM "state_nullifiers" n `+
+				`JOIN "state_spend_records" s ON s."domain_name" = n."domain_name" AND s."state" = n."id" `+
+				`WHERE n."domain_name" = ? AND n."state" = ?`+
+				`) AS records`, domainName, stateID, domainName, stateID, domainName, stateID).
+			Scan(&count).
+			Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return i18n.NewError(ctx, msgs.MsgStateDeleteNotPending, stateID)
+		}
+	}
+
+	tables := append([]string{}, stateOwnedTables...)
+	if force {
+		tables = append(tables, stateRecordTables...)
+	}
+	for _, table := range tables {
+		err := dbTX.DB().WithContext(ctx).
+			Table(table).
+			Where("domain_name = ?", domainName).
+			Where("state = ?", stateID).
+			Delete(nil).
+			Error
+		if err != nil {
+			return err
+		}
+	}
+	err := dbTX.DB().WithContext(ctx).
+		Table("states").
+		Where("domain_name = ?", domainName).
+		Where("id = ?", stateID).
+		Delete(nil).
+		Error
+	if err == nil {
+		log.L(ctx).Infof("Deleted state %s in domain %s contract %s (force=%t)", stateID, domainName, contractAddress, force)
+	}
+	return err
+}
+
+// Locks are held in memory in the domain contexts, so we need to check each active context
+func (ss *stateManager) findStateLocks(domainName string, stateID pldtypes.HexBytes) []*pldapi.StateLock {
+	ss.domainContextLock.Lock()
+	dcs := make([]*domainContext, 0, len(ss.domainContexts))
+	for _, dc := range ss.domainContexts {
+		if dc.domainName == domainName {
+			dcs = append(dcs, dc)
+		}
+	}
+	ss.domainContextLock.Unlock()
+
+	locks := []*pldapi.StateLock{}
+	for _, dc := range dcs {
+		dc.stateLock.Lock()
+		for _, l := range dc.txLocks {
+			if l.StateID.Equals(stateID) {
+				locks = append(locks, l)
+			}
+		}
+		dc.stateLock.Unlock()
+	}
+	return locks
+}
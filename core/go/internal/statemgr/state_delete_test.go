@@ -0,0 +1,243 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countStateRows(t *testing.T, ss *stateManager, table, column string, stateID pldtypes.HexBytes) int64 {
+	var count int64
+	err := ss.p.DB().Table(table).Where(column+" = ?", stateID).Count(&count).Error
+	require.NoError(t, err)
+	return count
+}
+
+func TestDeleteState(t *testing.T) {
+
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, widgetABI))
+	require.NoError(t, err)
+	err = ss.persistSchemas(ctx, ss.p.NOTX(), []*pldapi.Schema{schema.Schema})
+	require.NoError(t, err)
+	schemaID := schema.ID()
+
+	contractAddress, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+
+	widgets := makeWidgets(t, ctx, ss, "domain1", contractAddress, schemaID, []string{
+		`{"size": 11111, "color": "red",  "price": 100}`,
+		`{"size": 22222, "color": "blue", "price": 150}`,
+		`{"size": 33333, "color": "pink", "price": 199}`,
+	})
+
+	deleteState := func(id pldtypes.HexBytes) error {
+		return ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return ss.DeleteState(ctx, dbTX, "domain1", *contractAddress, id)
+		})
+	}
+
+	// Unconfirmed and unlocked states can be deleted, along with their labels
+	assert.NotZero(t, countStateRows(t, ss, "state_labels", "state", widgets[0].ID))
+	err = deleteState(widgets[0].ID)
+	require.NoError(t, err)
+	assert.Zero(t, countStateRows(t, ss, "states", "id", widgets[0].ID))
+	assert.Zero(t, countStateRows(t, ss, "state_labels", "state", widgets[0].ID))
+	assert.Zero(t, countStateRows(t, ss, "state_int64_labels", "state", widgets[0].ID))
+
+	// Once deleted it cannot be found
+	err = deleteState(widgets[0].ID)
+	assert.Regexp(t, "PD010112", err)
+
+	// Confirmed states are rejected
+	err = ss.WriteStateFinalizations(ss.bgCtx, ss.p.NOTX(), []*pldapi.StateSpendRecord{}, []*pldapi.StateReadRecord{},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: widgets[1].ID, Transaction: uuid.New()},
+		}, []*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+	err = deleteState(widgets[1].ID)
+	assert.Regexp(t, "PD010135", err)
+
+	// Locked states are rejected
+	lockTX := uuid.New()
+	err = dc.AddStateLocks(&pldapi.StateLock{
+		Type:        pldapi.StateLockTypeSpend.Enum(),
+		Transaction: lockTX,
+		StateID:     widgets[2].ID,
+	})
+	require.NoError(t, err)
+	err = deleteState(widgets[2].ID)
+	assert.Regexp(t, "PD010136.*"+lockTX.String(), err)
+
+	// Once the lock is released the state can be deleted
+	dc.ResetTransactions(lockTX)
+	err = deleteState(widgets[2].ID)
+	require.NoError(t, err)
+
+}
+
+func TestDeleteStateNullifiers(t *testing.T) {
+
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, widgetABI))
+	require.NoError(t, err)
+	err = ss.persistSchemas(ctx, ss.p.NOTX(), []*pldapi.Schema{schema.Schema})
+	require.NoError(t, err)
+
+	contractAddress := pldtypes.RandAddress()
+	widgets := makeWidgets(t, ctx, ss, "domain1", contractAddress, schema.ID(), []string{
+		`{"size": 11111, "color": "red",  "price": 100}`,
+		`{"size": 22222, "color": "blue", "price": 150}`,
+	})
+
+	nullifiers := []*pldapi.StateNullifier{
+		{DomainName: "domain1", State: widgets[0].ID, ID: pldtypes.RandBytes(32)},
+		{DomainName: "domain1", State: widgets[1].ID, ID: pldtypes.RandBytes(32)},
+	}
+	err = ss.p.DB().Table("state_nullifiers").Create(nullifiers).Error
+	require.NoError(t, err)
+
+	// In a nullifier domain the spend is recorded against the nullifier
+	err = ss.WriteStateFinalizations(ss.bgCtx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{
+			{DomainName: "domain1", State: nullifiers[0].ID, Transaction: uuid.New()},
+		}, []*pldapi.StateReadRecord{}, []*pldapi.StateConfirmRecord{}, []*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+
+	deleteState := func(id pldtypes.HexBytes) error {
+		return ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return ss.DeleteState(ctx, dbTX, "domain1", *contractAddress, id)
+		})
+	}
+
+	// A state spent via its nullifier is rejected, and the link is retained
+	err = deleteState(widgets[0].ID)
+	assert.Regexp(t, "PD010135", err)
+	assert.Equal(t, int64(1), countStateRows(t, ss, "state_nullifiers", "state", widgets[0].ID))
+
+	// A state with an unspent nullifier can be deleted, but the nullifier link is not destroyed
+	err = deleteState(widgets[1].ID)
+	require.NoError(t, err)
+	assert.Zero(t, countStateRows(t, ss, "states", "id", widgets[1].ID))
+	assert.Equal(t, int64(1), countStateRows(t, ss, "state_nullifiers", "state", widgets[1].ID))
+
+	// A forced delete clears the nullifier along with the other records
+	err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return ss.AdminDeleteState(ctx, dbTX, "domain1", *contractAddress, widgets[0].ID, true)
+	})
+	require.NoError(t, err)
+	assert.Zero(t, countStateRows(t, ss, "states", "id", widgets[0].ID))
+	assert.Zero(t, countStateRows(t, ss, "state_nullifiers", "state", widgets[0].ID))
+
+}
+
+func TestAdminDeleteState(t *testing.T) {
+
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, widgetABI))
+	require.NoError(t, err)
+	err = ss.persistSchemas(ctx, ss.p.NOTX(), []*pldapi.Schema{schema.Schema})
+	require.NoError(t, err)
+	schemaID := schema.ID()
+
+	contractAddress := pldtypes.RandAddress()
+	widgets := makeWidgets(t, ctx, ss, "domain1", contractAddress, schemaID, []string{
+		`{"size": 11111, "color": "red",  "price": 100}`,
+	})
+
+	err = ss.WriteStateFinalizations(ss.bgCtx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{
+			{DomainName: "domain1", State: widgets[0].ID, Transaction: uuid.New()},
+		}, []*pldapi.StateReadRecord{},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: widgets[0].ID, Transaction: uuid.New()},
+		}, []*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+
+	adminDeleteState := func(allowForce bool) error {
+		return ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return ss.AdminDeleteState(ctx, dbTX, "domain1", *contractAddress, widgets[0].ID, allowForce)
+		})
+	}
+
+	err = adminDeleteState(false)
+	assert.Regexp(t, "PD010137", err)
+	assert.Equal(t, int64(1), countStateRows(t, ss, "states", "id", widgets[0].ID))
+
+	err = adminDeleteState(true)
+	require.NoError(t, err)
+	assert.Zero(t, countStateRows(t, ss, "states", "id", widgets[0].ID))
+	assert.Zero(t, countStateRows(t, ss, "state_confirm_records", "state", widgets[0].ID))
+	assert.Zero(t, countStateRows(t, ss, "state_spend_records", "state", widgets[0].ID))
+
+}
+
+func TestDeleteStateRPC(t *testing.T) {
+
+	ctx, ss, rpc, m, done := newTestRPCServer(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain1", false)
+	mockStateCallback(m)
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, widgetABI))
+	require.NoError(t, err)
+	err = ss.persistSchemas(ctx, ss.p.NOTX(), []*pldapi.Schema{schema.Schema})
+	require.NoError(t, err)
+
+	contractAddress := pldtypes.RandAddress()
+	widgets := makeWidgets(t, ctx, ss, "domain1", contractAddress, schema.ID(), []string{
+		`{"size": 11111, "color": "red",  "price": 100}`,
+		`{"size": 22222, "color": "blue", "price": 150}`,
+	})
+
+	var deleted bool
+	rpcErr := rpc.CallRPC(ctx, &deleted, "pstate_deleteState", "domain1", contractAddress, widgets[0].ID)
+	require.NoError(t, rpcErr)
+	assert.True(t, deleted)
+
+	deleted = false
+	rpcErr = rpc.CallRPC(ctx, &deleted, "pstate_adminDeleteState", "domain1", contractAddress, widgets[1].ID, false)
+	assert.Regexp(t, "PD010137", rpcErr)
+
+	rpcErr = rpc.CallRPC(ctx, &deleted, "pstate_adminDeleteState", "domain1", contractAddress, widgets[1].ID, true)
+	require.NoError(t, rpcErr)
+	assert.True(t, deleted)
+
+}
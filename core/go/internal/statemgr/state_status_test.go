@@ -102,10 +102,11 @@ func syncFlushContext(t *testing.T, dc components.DomainContext) {
 	require.NoError(t, err)
 }
 
-func newTestDomainContext(t *testing.T, ctx context.Context, ss *stateManager, name string, customHashFunction bool) (*pldtypes.EthAddress, *domainContext) {
+func newTestDomainContext(t testing.TB, ctx context.Context, ss *stateManager, name string, customHashFunction bool) (*pldtypes.EthAddress, *domainContext) {
 	md := componentsmocks.NewDomain(t)
 	md.On("Name").Return(name)
 	md.On("CustomHashFunction").Return(customHashFunction)
+	md.On("FlagExternalStateIDs").Return(false).Maybe()
 	contractAddress := pldtypes.RandAddress()
 	dc := ss.NewDomainContext(ctx, md, *contractAddress)
 	return contractAddress, dc.(*domainContext)
@@ -351,3 +351,111 @@ func TestFindStatesWithNilOptions(t *testing.T) {
 	assert.Regexp(t, "called", err)
 
 }
+
+func TestFindStatesExcludeData(t *testing.T) {
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	contractAddress, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+	for _, amount := range []int{10, 20} {
+		_, err = dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{
+			Schema: schemaID,
+			Data:   pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32))),
+		})
+		require.NoError(t, err)
+	}
+	syncFlushContext(t, dc)
+
+	// Label filters and sorting still apply
+	states, err := ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().GreaterThan("amount", 5).Sort("-amount").Query(), &components.StateQueryOptions{
+		ExcludeData: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	for _, s := range states {
+		assert.NotEmpty(t, s.ID)
+		assert.Equal(t, contractAddress, s.ContractAddress)
+		assert.Nil(t, s.Data)
+	}
+
+	states, err = ss.FindStates(ctx, ss.p.NOTX(), "domain1", schemaID, query.NewQueryBuilder().Sort("-amount").Query(), nil)
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+	assert.Equal(t, int64(20), parseFakeCoin(t, states[0]).Amount.Int64())
+}
+
+func TestLabelSetResolverCache(t *testing.T) {
+	ctx, ss, _, _, done := newDBMockStateManager(t)
+	defer done()
+
+	schema, err := newABISchema(ctx, "domain1", testABIParam(t, fakeCoinABI))
+	require.NoError(t, err)
+	labelSet := ss.labelSetFor(schema)
+
+	owner := labelSet.ResolverFor("owner")
+	require.NotNil(t, owner)
+	assert.Contains(t, labelSet.used, "owner")
+	assert.Equal(t, owner, labelSet.ResolverFor("owner"))
+	assert.Nil(t, labelSet.ResolverFor("unknown"))
+
+	// Subsequent calls are served from the cache, without looking at the labels
+	labelSet.labels = map[string]*schemaLabelInfo{}
+	assert.Equal(t, owner, labelSet.ResolverFor("owner"))
+	assert.Nil(t, labelSet.ResolverFor("unknown"))
+	assert.Equal(t, baseStateFields[".id"], labelSet.ResolverFor(".id"))
+}
+
+func TestFindStatesAcrossContracts(t *testing.T) {
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	schemas, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID := schemas[0].ID()
+
+	// Three contracts, each with two coins
+	contractAddresses := make([]pldtypes.EthAddress, 3)
+	for i := range contractAddresses {
+		contractAddress, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+		defer dc.Close()
+		contractAddresses[i] = *contractAddress
+		for _, amount := range []int{10 + i, 20 + i} {
+			_, err = dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{
+				Schema: schemaID,
+				Data:   pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32))),
+			})
+			require.NoError(t, err)
+		}
+		syncFlushContext(t, dc)
+	}
+
+	// Label filters and sorting apply across the contracts selected
+	states, err := ss.FindStatesAcrossContracts(ctx, ss.p.NOTX(), "domain1", schemaID,
+		[]pldtypes.EthAddress{contractAddresses[0], contractAddresses[2]},
+		query.NewQueryBuilder().GreaterThan("amount", 10).Sort("-amount").Query(), "")
+	require.NoError(t, err)
+	require.Len(t, states, 3)
+	assert.Equal(t, int64(22), parseFakeCoin(t, states[0]).Amount.Int64())
+	assert.Equal(t, contractAddresses[2], *states[0].ContractAddress)
+	assert.Equal(t, int64(20), parseFakeCoin(t, states[1]).Amount.Int64())
+	assert.Equal(t, contractAddresses[0], *states[1].ContractAddress)
+	assert.Equal(t, int64(12), parseFakeCoin(t, states[2]).Amount.Int64())
+	assert.Equal(t, contractAddresses[2], *states[2].ContractAddress)
+
+	// Status qualifiers apply - nothing has been confirmed
+	states, err = ss.FindStatesAcrossContracts(ctx, ss.p.NOTX(), "domain1", schemaID, contractAddresses, query.NewQueryBuilder().Query(), pldapi.StateStatusAvailable)
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	states, err = ss.FindStatesAcrossContracts(ctx, ss.p.NOTX(), "domain1", schemaID, nil, query.NewQueryBuilder().Query(), pldapi.StateStatusAll)
+	require.NoError(t, err)
+	assert.Empty(t, states)
+
+	_, err = ss.FindStatesAcrossContracts(ctx, ss.p.NOTX(), "domain1", schemaID, contractAddresses, query.NewQueryBuilder().Query(), pldapi.StateStatusQualifier(uuid.NewString()))
+	assert.Regexp(t, "PD010149", err)
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+// Links a state spent in one domain, to the state created in another domain by the same transaction
+type stateTransfer struct {
+	Transaction  uuid.UUID          `gorm:"column:transaction"`
+	SourceDomain string             `gorm:"column:source_domain;primaryKey"`
+	SourceState  pldtypes.HexBytes  `gorm:"column:source_state;primaryKey"`
+	TargetDomain string             `gorm:"column:target_domain"`
+	TargetState  pldtypes.HexBytes  `gorm:"column:target_state"`
+	Created      pldtypes.Timestamp `gorm:"column:created"`
+}
+
+func (dc *domainContext) CrossDomainStateTransfer(dbTX persistence.DBTX, targetDomain string, targetContract pldtypes.EthAddress, transfers []*components.StateTransferRequest) error {
+	for i, t := range transfers {
+		if t.Transaction == (uuid.UUID{}) || len(t.SourceState) == 0 {
+			return i18n.NewError(dc, msgs.MsgStateTransferInvalid, i)
+		}
+	}
+
+	dc.stateLock.Lock()
+	err := dc.checkResetInitUnFlushed()
+	if err == nil && dc.dryRun {
+		err = i18n.NewError(dc, msgs.MsgStateDomainContextDryRun, dc.id)
+	}
+	dc.stateLock.Unlock()
+	if err != nil || len(transfers) == 0 {
+		return err
+	}
+
+	// The spend and create must be atomic, so if we're not already in a transaction, take the hit of a mini-TX
+	if !dbTX.FullTransaction() {
+		return dc.ss.p.Transaction(dc, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return dc.crossDomainStateTransfer(ctx, dbTX, targetDomain, targetContract, transfers)
+		})
+	}
+	return dc.crossDomainStateTransfer(dc, dbTX, targetDomain, targetContract, transfers)
+}
+
+func (dc *domainContext) crossDomainStateTransfer(ctx context.Context, dbTX persistence.DBTX, targetDomain string, targetContract pldtypes.EthAddress, transfers []*components.StateTransferRequest) error {
+	sourceIDs := make([]pldtypes.HexBytes, len(transfers))
+	upserts := make([]*components.StateUpsertOutsideContext, len(transfers))
+	for i, t := range transfers {
+		sourceIDs[i] = t.SourceState
+		upserts[i] = &components.StateUpsertOutsideContext{
+			ID:              t.TargetID,
+			SchemaID:        t.TargetSchema,
+			ContractAddress: &targetContract,
+			Data:            t.TargetData,
+		}
+	}
+
+	// The source states must belong to our contract, and must not be locked for spending by an in-flight transaction
+	if _, err := dc.ss.GetStatesByID(ctx, dbTX, dc.domainName, &dc.contractAddress, sourceIDs, true, false); err != nil {
+		return err
+	}
+	for _, id := range sourceIDs {
+		for _, l := range dc.ss.findStateLocks(dc.domainName, id) {
+			if l.Type.V() == pldapi.StateLockTypeSpend {
+				return i18n.NewError(ctx, msgs.MsgStateTransferSourceLocked, id, l.Transaction)
+			}
+		}
+	}
+
+	// They must not already be spent - in nullifier domains the spend record is against the nullifier, rather than the state
+	var spent []*pldapi.StateSpendRecord
+	err := dbTX.DB().
+		WithContext(ctx).
+		Raw(`SELECT "state", "transaction" FROM "state_spend_records" WHERE "domain_name" = ? AND "state" IN ? `+
+			`UNION ALL SELECT n."state", s."transaction" FROM "state_nullifiers" n `+
+			`JOIN "state_spend_records" s ON s."domain_name" = n."domain_name" AND s."state" = n."id" `+
+			`WHERE n."domain_name" = ? AND n."state" IN ? `+
+			`LIMIT 1`, dc.domainName, sourceIDs, dc.domainName, sourceIDs).
+		Scan(&spent).
+		Error
+	if err != nil {
+		return err
+	}
+	if len(spent) > 0 {
+		return i18n.NewError(ctx, msgs.MsgStateTransferSourceSpent, spent[0].State, spent[0].Transaction)
+	}
+
+	// And they must have been confirmed in our domain
+	var confirmed []pldtypes.HexBytes
+	err = dbTX.DB().
+		WithContext(ctx).
+		Table("state_confirm_records").
+		Where("domain_name = ?", dc.domainName).
+		Where("state IN ?", sourceIDs).
+		Pluck("state", &confirmed).
+		Error
+	if err != nil {
+		return err
+	}
+	for _, id := range sourceIDs {
+		if !containsStateID(confirmed, id) {
+			return i18n.NewError(ctx, msgs.MsgStateTransferSourceUnconfirmed, id)
+		}
+	}
+
+	targetStates, err := dc.ss.WriteReceivedStates(ctx, dbTX, targetDomain, upserts)
+	if err != nil {
+		return err
+	}
+
+	now := pldtypes.TimestampNow()
+	spends := make([]*pldapi.StateSpendRecord, len(transfers))
+	confirms := make([]*pldapi.StateConfirmRecord, len(transfers))
+	stateTransfers := make([]*stateTransfer, len(transfers))
+	for i, t := range transfers {
+		spends[i] = &pldapi.StateSpendRecord{DomainName: dc.domainName, State: t.SourceState, Transaction: t.Transaction}
+		confirms[i] = &pldapi.StateConfirmRecord{DomainName: targetDomain, State: targetStates[i].ID, Transaction: t.Transaction}
+		stateTransfers[i] = &stateTransfer{
+			Transaction:  t.Transaction,
+			SourceDomain: dc.domainName,
+			SourceState:  t.SourceState,
+			TargetDomain: targetDomain,
+			TargetState:  targetStates[i].ID,
+			Created:      now,
+		}
+	}
+	if err := dc.ss.writeStateFinalizations(ctx, dbTX, spends, nil, confirms, nil); err != nil {
+		return err
+	}
+	err = dbTX.DB().
+		WithContext(ctx).
+		Table("state_transfers").
+		Create(stateTransfers).
+		Error
+	if err == nil {
+		log.L(ctx).Infof("Transferred %d states from domain %s contract %s to domain %s contract %s",
+			len(transfers), dc.domainName, dc.contractAddress, targetDomain, targetContract)
+	}
+	return err
+}
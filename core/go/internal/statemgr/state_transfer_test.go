@@ -0,0 +1,229 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package statemgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossDomainStateTransfer(t *testing.T) {
+	ctx, ss, m, done := newDBTestStateManager(t)
+	defer done()
+
+	_ = mockDomain(t, m, "domain2", false)
+	mockStateCallback(m)
+
+	schemas1, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain1", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID1 := schemas1[0].ID()
+	schemas2, err := ss.EnsureABISchemas(ctx, ss.p.NOTX(), "domain2", []*abi.Parameter{testABIParam(t, fakeCoinABI)})
+	require.NoError(t, err)
+	schemaID2 := schemas2[0].ID()
+
+	// Two confirmed coins in the source contract
+	contract1, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+	mintTX := uuid.New()
+	var coins []*pldapi.State
+	for _, amount := range []int{10, 20} {
+		states, err := dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{
+			Schema:    schemaID1,
+			Data:      pldtypes.RawJSON(fmt.Sprintf(`{"amount": %d, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, amount, pldtypes.RandHex(32))),
+			CreatedBy: &mintTX,
+		})
+		require.NoError(t, err)
+		coins = append(coins, states[0])
+	}
+	syncFlushContext(t, dc)
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(), nil, nil, []*pldapi.StateConfirmRecord{
+		{DomainName: "domain1", State: coins[0].ID, Transaction: mintTX},
+		{DomainName: "domain1", State: coins[1].ID, Transaction: mintTX},
+	}, nil)
+	require.NoError(t, err)
+	dc.ResetTransactions(mintTX)
+
+	// Transfer the first coin to a contract in the other domain
+	contract2 := pldtypes.RandAddress()
+	transferTX := uuid.New()
+	newTransfer := func(source pldtypes.HexBytes, data string) *components.StateTransferRequest {
+		return &components.StateTransferRequest{
+			Transaction:  transferTX,
+			SourceState:  source,
+			TargetSchema: schemaID2,
+			TargetData:   pldtypes.RawJSON(data),
+		}
+	}
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, []*components.StateTransferRequest{
+		newTransfer(coins[0].ID, fmt.Sprintf(`{"amount": 10, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))),
+	})
+	require.NoError(t, err)
+
+	available, err := ss.FindContractStates(ctx, ss.p.NOTX(), "domain1", contract1, schemaID1, query.NewQueryBuilder().Query(), pldapi.StateStatusAvailable)
+	require.NoError(t, err)
+	require.Len(t, available, 1)
+	assert.Equal(t, coins[1].ID, available[0].ID)
+
+	available, err = ss.FindContractStates(ctx, ss.p.NOTX(), "domain2", contract2, schemaID2, query.NewQueryBuilder().Query(), pldapi.StateStatusAvailable)
+	require.NoError(t, err)
+	require.Len(t, available, 1)
+	assert.Equal(t, int64(10), parseFakeCoin(t, available[0]).Amount.Int64())
+
+	var transfers []*stateTransfer
+	err = ss.p.DB().Table("state_transfers").Find(&transfers).Error
+	require.NoError(t, err)
+	require.Len(t, transfers, 1)
+	assert.Equal(t, transferTX, transfers[0].Transaction)
+	assert.Equal(t, "domain1", transfers[0].SourceDomain)
+	assert.Equal(t, coins[0].ID, transfers[0].SourceState)
+	assert.Equal(t, "domain2", transfers[0].TargetDomain)
+	assert.Equal(t, available[0].ID, transfers[0].TargetState)
+
+	// The same state cannot be transferred twice
+	err = ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return dc.CrossDomainStateTransfer(dbTX, "domain2", *contract2, []*components.StateTransferRequest{
+			newTransfer(coins[0].ID, fmt.Sprintf(`{"amount": 10, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))),
+		})
+	})
+	assert.Regexp(t, "PD010151", err)
+
+	// If the target state is invalid, nothing is spent
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, []*components.StateTransferRequest{
+		newTransfer(coins[1].ID, `{"wrong": "data"}`),
+	})
+	assert.Regexp(t, "FF22040", err)
+	available, err = ss.FindContractStates(ctx, ss.p.NOTX(), "domain1", contract1, schemaID1, query.NewQueryBuilder().Query(), pldapi.StateStatusAvailable)
+	require.NoError(t, err)
+	assert.Len(t, available, 1)
+	coinData := fmt.Sprintf(`{"amount": 20, "owner": "0xf7b1c69F5690993F2C8ecE56cc89D42b1e737180", "salt": "%s"}`, pldtypes.RandHex(32))
+
+	// A state locked for spending by an in-flight transaction cannot be transferred
+	spendTX := uuid.New()
+	err = dc.AddStateLocks(&pldapi.StateLock{
+		Type:        pldapi.StateLockTypeSpend.Enum(),
+		StateID:     coins[1].ID,
+		Transaction: spendTX,
+	})
+	require.NoError(t, err)
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, []*components.StateTransferRequest{
+		newTransfer(coins[1].ID, coinData),
+	})
+	assert.Regexp(t, "PD010152", err)
+	dc.ResetTransactions(spendTX)
+
+	// A state spent via its nullifier cannot be transferred
+	nullifier := &pldapi.StateNullifier{DomainName: "domain1", State: coins[1].ID, ID: pldtypes.RandBytes(32)}
+	err = ss.p.DB().Table("state_nullifiers").Create(nullifier).Error
+	require.NoError(t, err)
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(), []*pldapi.StateSpendRecord{
+		{DomainName: "domain1", State: nullifier.ID, Transaction: spendTX},
+	}, nil, nil, nil)
+	require.NoError(t, err)
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, []*components.StateTransferRequest{
+		newTransfer(coins[1].ID, coinData),
+	})
+	assert.Regexp(t, "PD010151.*"+spendTX.String(), err)
+
+	// A state that has not been confirmed cannot be transferred
+	states, err := dc.UpsertStates(ss.p.NOTX(), &components.StateUpsert{
+		Schema:    schemaID1,
+		Data:      pldtypes.RawJSON(coinData),
+		CreatedBy: &mintTX,
+	})
+	require.NoError(t, err)
+	syncFlushContext(t, dc)
+	dc.ResetTransactions(mintTX)
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, []*components.StateTransferRequest{
+		newTransfer(states[0].ID, coinData),
+	})
+	assert.Regexp(t, "PD010153", err)
+}
+
+func TestCrossDomainStateTransferErrors(t *testing.T) {
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+	contract2 := pldtypes.RandAddress()
+
+	err := dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, nil)
+	require.NoError(t, err)
+
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, []*components.StateTransferRequest{
+		{SourceState: pldtypes.RandBytes(32)},
+	})
+	assert.Regexp(t, "PD010150", err)
+
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, []*components.StateTransferRequest{
+		{Transaction: uuid.New(), SourceState: pldtypes.RandBytes(32)},
+	})
+	assert.Regexp(t, "PD010112", err)
+
+	err = dc.DryRun(func(dryRunDC components.DomainContext) error {
+		return dryRunDC.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, []*components.StateTransferRequest{
+			{Transaction: uuid.New(), SourceState: pldtypes.RandBytes(32)},
+		})
+	})
+	assert.Regexp(t, "PD010143", err)
+
+	dc.Close()
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *contract2, nil)
+	assert.Regexp(t, "PD010122", err)
+}
+
+func TestCrossDomainStateTransferDBErrors(t *testing.T) {
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	_, dc := newTestDomainContext(t, ctx, ss, "domain1", false)
+	defer dc.Close()
+	transfers := []*components.StateTransferRequest{
+		{Transaction: uuid.New(), SourceState: pldtypes.RandBytes(32)},
+	}
+
+	db.ExpectBegin()
+	db.ExpectQuery("SELECT.*states").WillReturnError(fmt.Errorf("pop"))
+	db.ExpectRollback()
+	err := dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *pldtypes.RandAddress(), transfers)
+	assert.Regexp(t, "pop", err)
+
+	db.ExpectBegin()
+	db.ExpectQuery("SELECT.*states").WillReturnRows(db.NewRows([]string{"id"}).AddRow(transfers[0].SourceState))
+	db.ExpectQuery("SELECT.*state_spend_records").WillReturnError(fmt.Errorf("pop"))
+	db.ExpectRollback()
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *pldtypes.RandAddress(), transfers)
+	assert.Regexp(t, "pop", err)
+
+	db.ExpectBegin()
+	db.ExpectQuery("SELECT.*states").WillReturnRows(db.NewRows([]string{"id"}).AddRow(transfers[0].SourceState))
+	db.ExpectQuery("SELECT.*state_spend_records").WillReturnRows(db.NewRows([]string{"state", "transaction"}))
+	db.ExpectQuery("SELECT.*state_confirm_records").WillReturnError(fmt.Errorf("pop"))
+	db.ExpectRollback()
+	err = dc.CrossDomainStateTransfer(ss.p.NOTX(), "domain2", *pldtypes.RandAddress(), transfers)
+	assert.Regexp(t, "pop", err)
+}
@@ -19,6 +19,7 @@ package statemgr
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
@@ -26,6 +27,8 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/cache"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 	"gorm.io/gorm/clause"
@@ -42,6 +45,35 @@ type stateManager struct {
 	rpcModule         *rpcserver.RPCModule
 	domainContextLock sync.Mutex
 	domainContexts    map[uuid.UUID]*domainContext
+
+	domainContextGCInterval  time.Duration
+	domainContextIdleTimeout time.Duration
+	domainContextGCDone      chan struct{}
+
+	maxBatchStates int
+
+	warmSchemaCache     bool
+	warmSchemaCacheDone chan struct{}
+
+	debugSQL bool
+
+	postCommitWorkers int
+	postCommitLock    sync.Mutex
+	postCommitQueue   []func(ctx context.Context) error // unbounded, so committing callers never block
+	postCommitSignal  chan struct{}
+	postCommitDone    sync.WaitGroup
+
+	querySnapshotLock   sync.Mutex
+	querySnapshots      map[uuid.UUID]*querySnapshot
+	querySnapshotTTL    time.Duration
+	querySnapshotGCDone chan struct{}
+
+	schemaMigrationLock sync.Mutex
+	schemaAliasLock     sync.RWMutex
+	schemaAliasTargets  map[string]pldtypes.Bytes32   // domain/old -> new
+	schemaAliasSources  map[string][]pldtypes.Bytes32 // domain/new -> old
+	schemaBackfillRetry *retry.Retry
+	schemaBackfills     sync.WaitGroup
 }
 
 var SchemaCacheDefaults = &pldconf.CacheConfig{
@@ -54,7 +86,21 @@ func NewStateManager(ctx context.Context, conf *pldconf.StateStoreConfig, p pers
 		conf:           conf,
 		abiSchemaCache: cache.NewCache[string, components.Schema](&conf.SchemaCache, SchemaCacheDefaults),
 		domainContexts: make(map[uuid.UUID]*domainContext),
+		querySnapshots: make(map[uuid.UUID]*querySnapshot),
+
+		schemaAliasTargets:  make(map[string]pldtypes.Bytes32),
+		schemaAliasSources:  make(map[string][]pldtypes.Bytes32),
+		schemaBackfillRetry: retry.NewRetryIndefinite(&conf.SchemaBackfillRetry, &pldconf.StateStoreConfigDefaults.SchemaBackfillRetry),
+
+		domainContextGCInterval:  confutil.DurationMin(conf.DomainContextGCInterval, 100*time.Millisecond, *pldconf.StateStoreConfigDefaults.DomainContextGCInterval),
+		domainContextIdleTimeout: confutil.DurationMin(conf.DomainContextIdleTimeout, 0, *pldconf.StateStoreConfigDefaults.DomainContextIdleTimeout),
+		postCommitWorkers:        confutil.IntMin(conf.PostCommitWorkers, 1, *pldconf.StateStoreConfigDefaults.PostCommitWorkers),
+		querySnapshotTTL:         confutil.DurationMin(conf.SnapshotTTL, 100*time.Millisecond, *pldconf.StateStoreConfigDefaults.SnapshotTTL),
+		maxBatchStates:           confutil.IntMin(conf.MaxBatchStates, 1, *pldconf.StateStoreConfigDefaults.MaxBatchStates),
+		warmSchemaCache:          confutil.Bool(conf.WarmSchemaCache, *pldconf.StateStoreConfigDefaults.WarmSchemaCache),
+		debugSQL:                 confutil.Bool(conf.DebugSQL, *pldconf.StateStoreConfigDefaults.DebugSQL),
 	}
+	ss.postCommitSignal = make(chan struct{}, 1)
 	ss.bgCtx, ss.cancelCtx = context.WithCancel(ctx)
 	return ss
 }
@@ -73,11 +119,38 @@ func (ss *stateManager) PostInit(c components.AllComponents) error {
 }
 
 func (ss *stateManager) Start() error {
+	// Aliases must be loaded before any queries are run, as they change the schema used
+	if err := ss.loadSchemaAliases(ss.bgCtx); err != nil {
+		return err
+	}
+	ss.domainContextGCDone = make(chan struct{})
+	go ss.domainContextGC()
+	ss.querySnapshotGCDone = make(chan struct{})
+	go ss.querySnapshotGC()
+	if ss.warmSchemaCache {
+		ss.warmSchemaCacheDone = make(chan struct{})
+		go ss.warmSchemaCaches()
+	}
+	for i := 0; i < ss.postCommitWorkers; i++ {
+		ss.postCommitDone.Add(1)
+		go ss.postCommitWorker()
+	}
 	return nil
 }
 
 func (ss *stateManager) Stop() {
 	ss.cancelCtx()
+	if ss.domainContextGCDone != nil {
+		<-ss.domainContextGCDone
+	}
+	if ss.querySnapshotGCDone != nil {
+		<-ss.querySnapshotGCDone
+	}
+	if ss.warmSchemaCacheDone != nil {
+		<-ss.warmSchemaCacheDone
+	}
+	ss.postCommitDone.Wait()
+	ss.schemaBackfills.Wait()
 }
 
 // Confirmation and spending records are not managed via the in-memory cached model of states,
@@ -94,6 +167,17 @@ func (ss *stateManager) Stop() {
 // might find new states become available and/or states marked locked for spending
 // become fully unavailable.
 func (ss *stateManager) WriteStateFinalizations(ctx context.Context, dbTX persistence.DBTX, spends []*pldapi.StateSpendRecord, reads []*pldapi.StateReadRecord, confirms []*pldapi.StateConfirmRecord, infoRecords []*pldapi.StateInfoRecord) (err error) {
+	// The records for the different tables must be written atomically, so a crash part way through
+	// cannot leave a state half finalized. If we're not already in a transaction, take the hit of a mini-TX
+	if !dbTX.FullTransaction() {
+		return ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return ss.writeStateFinalizations(ctx, dbTX, spends, reads, confirms, infoRecords)
+		})
+	}
+	return ss.writeStateFinalizations(ctx, dbTX, spends, reads, confirms, infoRecords)
+}
+
+func (ss *stateManager) writeStateFinalizations(ctx context.Context, dbTX persistence.DBTX, spends []*pldapi.StateSpendRecord, reads []*pldapi.StateReadRecord, confirms []*pldapi.StateConfirmRecord, infoRecords []*pldapi.StateInfoRecord) (err error) {
 	if len(spends) > 0 {
 		err = dbTX.DB().
 			WithContext(ctx).
@@ -129,32 +213,32 @@ func (ss *stateManager) WriteStateFinalizations(ctx context.Context, dbTX persis
 	return err
 }
 
+func (ss *stateManager) RevertStateFinalizations(ctx context.Context, dbTX persistence.DBTX, transactionIDs []uuid.UUID) (err error) {
+	if len(transactionIDs) == 0 {
+		return nil
+	}
+	for _, table := range stateFinalizationTables {
+		if err == nil {
+			err = dbTX.DB().
+				WithContext(ctx).
+				Table(table).
+				Where(`"transaction" IN (?)`, transactionIDs).
+				Delete(nil).
+				Error
+		}
+	}
+	return err
+}
+
 func (ss *stateManager) GetTransactionStates(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID) (*pldapi.TransactionStates, error) {
 
-	// We query from the records table, joining in the other fields
-	var records []*transactionStateRecord
-	err := dbTX.DB().
-		WithContext(ctx).
-		// This query joins across three tables in a single query - pushing the complexity to the DB.
-		// The reason we have three tables is to make the queries for available states simpler.
-		Raw(`SELECT * from "states" RIGHT JOIN ( `+
-			`SELECT "transaction", "state", 'spent'     AS "record_type" FROM "state_spend_records"   WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'read'      AS "record_type" FROM "state_read_records"    WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'confirmed' AS "record_type" FROM "state_confirm_records" WHERE "transaction" = ? UNION ALL `+
-			`SELECT "transaction", "state", 'info'      AS "record_type" FROM "state_info_records"    WHERE "transaction" = ? ) "records" `+
-			`ON "states"."id" = "records"."state"`,
-			txID, txID, txID, txID).
-		Scan(&records).
-		Error
-	if err != nil {
-		return nil, err
-	}
 	hasUnavailable := false
 	unavailable := &pldapi.UnavailableStates{}
 	txStates := &pldapi.TransactionStates{
-		None: len(records) == 0, // if we have no confirmation records at all then this is an unknown transaction
+		None: true, // if we have no confirmation records at all then this is an unknown transaction
 	}
-	for _, s := range records {
+	err := ss.streamTransactionStateRecords(ctx, dbTX, txID, func(s *transactionStateRecord) error {
+		txStates.None = false
 		switch s.RecordType {
 		case "spent":
 			if s.ID == nil {
@@ -185,6 +269,10 @@ func (ss *stateManager) GetTransactionStates(ctx context.Context, dbTX persisten
 				txStates.Info = append(txStates.Info, &s.StateBase)
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	// Only set to non-nil if we have unavailable
 	if hasUnavailable {
@@ -193,3 +281,45 @@ func (ss *stateManager) GetTransactionStates(ctx context.Context, dbTX persisten
 	return txStates, nil
 
 }
+
+func (ss *stateManager) StreamTransactionStates(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID, fn func(*pldapi.StateBase) error) error {
+	return ss.streamTransactionStateRecords(ctx, dbTX, txID, func(s *transactionStateRecord) error {
+		if s.ID == nil {
+			// We do not have the state data for this record
+			return nil
+		}
+		return fn(&s.StateBase)
+	})
+}
+
+// streamTransactionStateRecords scans the records row-by-row, so the full set does not need to be held in memory
+func (ss *stateManager) streamTransactionStateRecords(ctx context.Context, dbTX persistence.DBTX, txID uuid.UUID, fn func(*transactionStateRecord) error) error {
+
+	// We query from the records table, joining in the other fields
+	db := dbTX.DB().WithContext(ctx)
+	rows, err := db.
+		// This query joins across three tables in a single query - pushing the complexity to the DB.
+		// The reason we have three tables is to make the queries for available states simpler.
+		Raw(`SELECT * from "states" RIGHT JOIN ( `+
+			`SELECT "transaction", "state", 'spent'     AS "record_type" FROM "state_spend_records"   WHERE "transaction" = ? UNION ALL `+
+			`SELECT "transaction", "state", 'read'      AS "record_type" FROM "state_read_records"    WHERE "transaction" = ? UNION ALL `+
+			`SELECT "transaction", "state", 'confirmed' AS "record_type" FROM "state_confirm_records" WHERE "transaction" = ? UNION ALL `+
+			`SELECT "transaction", "state", 'info'      AS "record_type" FROM "state_info_records"    WHERE "transaction" = ? ) "records" `+
+			`ON "states"."id" = "records"."state"`,
+			txID, txID, txID, txID).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var record transactionStateRecord
+		if err := db.ScanRows(rows, &record); err != nil {
+			return err
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -19,6 +19,7 @@ package statemgr
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
@@ -35,11 +36,16 @@ func (ss *stateManager) initRPC() {
 	ss.rpcModule = rpcserver.NewRPCModule("pstate").
 		Add("pstate_listSchemas", ss.rpcListSchema()).
 		Add("pstate_getSchemaById", ss.rpcGetSchemaByID()).
+		Add("pstate_getState", ss.rpcGetState()).
 		Add("pstate_storeState", ss.rpcStoreState()).
 		Add("pstate_queryStates", ss.rpcQueryStates()).
+		Add("pstate_newQueryToken", ss.rpcNewQueryToken()).
+		Add("pstate_queryStatesWithToken", ss.rpcQueryStatesWithToken()).
 		Add("pstate_queryContractStates", ss.rpcQueryContractStates()).
 		Add("pstate_queryNullifiers", ss.rpcQueryNullifiers()).
-		Add("pstate_queryContractNullifiers", ss.rpcQueryContractNullifiers())
+		Add("pstate_queryContractNullifiers", ss.rpcQueryContractNullifiers()).
+		Add("pstate_deleteState", ss.rpcDeleteState()).
+		Add("pstate_adminDeleteState", ss.rpcAdminDeleteState())
 }
 
 func (ss *stateManager) rpcListSchema() rpcserver.RPCHandler {
@@ -86,6 +92,29 @@ func (ss *stateManager) rpcQueryStates() rpcserver.RPCHandler {
 	})
 }
 
+func (ss *stateManager) rpcNewQueryToken() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod1(func(ctx context.Context,
+		domain string,
+	) (uuid.UUID, error) {
+		return ss.NewQueryToken(ctx, ss.p.NOTX(), domain)
+	})
+}
+
+func (ss *stateManager) rpcQueryStatesWithToken() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod5(func(ctx context.Context,
+		domain string,
+		schema pldtypes.Bytes32,
+		query query.QueryJSON,
+		status pldapi.StateStatusQualifier,
+		queryToken uuid.UUID,
+	) ([]*pldapi.State, error) {
+		return ss.FindStates(ctx, ss.p.NOTX(), domain, schema, &query, &components.StateQueryOptions{
+			StatusQualifier: status,
+			QueryToken:      &queryToken,
+		})
+	})
+}
+
 func (ss *stateManager) rpcQueryContractStates() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod5(func(ctx context.Context,
 		domain string,
@@ -129,3 +158,40 @@ func (ss *stateManager) rpcGetSchemaByID() rpcserver.RPCHandler {
 		return ss.GetSchemaByID(ctx, ss.p.NOTX(), domain, schemaID, false /* null on not found */)
 	})
 }
+
+func (ss *stateManager) rpcGetState() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod3(func(ctx context.Context,
+		domain string,
+		contractAddress *pldtypes.EthAddress,
+		stateID pldtypes.HexBytes,
+	) (*pldapi.State, error) {
+		return ss.getStateWithStatus(ctx, ss.p.NOTX(), domain, contractAddress, stateID) // null on not found
+	})
+}
+
+func (ss *stateManager) rpcDeleteState() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod3(func(ctx context.Context,
+		domain string,
+		contractAddress pldtypes.EthAddress,
+		stateID pldtypes.HexBytes,
+	) (bool, error) {
+		err := ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return ss.DeleteState(ctx, dbTX, domain, contractAddress, stateID)
+		})
+		return err == nil, err
+	})
+}
+
+func (ss *stateManager) rpcAdminDeleteState() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod4(func(ctx context.Context,
+		domain string,
+		contractAddress pldtypes.EthAddress,
+		stateID pldtypes.HexBytes,
+		allowForce bool,
+	) (bool, error) {
+		err := ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return ss.AdminDeleteState(ctx, dbTX, domain, contractAddress, stateID, allowForce)
+		})
+		return err == nil, err
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
@@ -67,7 +68,7 @@ func TestRPC(t *testing.T) {
 	ctx, ss, c, m, done := newTestRPCServer(t)
 	defer done()
 
-	_ = mockDomain(t, m, "domain1", false)
+	md := mockDomain(t, m, "domain1", false)
 	mockStateCallback(m)
 
 	var abiParam abi.Parameter
@@ -90,6 +91,14 @@ func TestRPC(t *testing.T) {
 	rpcErr = c.CallRPC(ctx, &rpcSchema, "pstate_getSchemaById", "domain1", schemas[0].ID)
 	require.NoError(t, rpcErr)
 	require.NotNil(t, rpcSchema)
+	assert.Equal(t, schemas[0].ID, rpcSchema.ID)
+	assert.Equal(t, schema.Labels, rpcSchema.Labels)
+	assert.JSONEq(t, schema.Definition.String(), rpcSchema.Definition.String())
+
+	var missingSchema *pldapi.Schema
+	rpcErr = c.CallRPC(ctx, &missingSchema, "pstate_getSchemaById", "domain1", pldtypes.RandBytes32())
+	require.NoError(t, rpcErr)
+	assert.Nil(t, missingSchema)
 
 	contractAddress := pldtypes.RandAddress()
 	var state *pldapi.State
@@ -165,4 +174,38 @@ func TestRPC(t *testing.T) {
 	assert.Equal(t, state.ID, states[0].ID)
 	assert.Equal(t, nullifier1, states[0].Nullifier.ID)
 
+	// Get the state, with its confirmation and locks
+	txID := uuid.New()
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(), nil, nil, []*pldapi.StateConfirmRecord{
+		{DomainName: "domain1", State: state.ID, Transaction: txID},
+	}, nil)
+	require.NoError(t, err)
+	dc := ss.NewDomainContext(ctx, md, *contractAddress)
+	defer dc.Close()
+	err = dc.AddStateLocks(&pldapi.StateLock{Type: pldapi.StateLockTypeSpend.Enum(), StateID: state.ID, Transaction: txID})
+	require.NoError(t, err)
+
+	var stateByID *pldapi.State
+	rpcErr = c.CallRPC(ctx, &stateByID, "pstate_getState", "domain1", contractAddress.String(), state.ID)
+	jsonTestLog(t, "pstate_getState", stateByID)
+	require.NoError(t, rpcErr)
+	require.NotNil(t, stateByID)
+	assert.Equal(t, state.ID, stateByID.ID)
+	assert.JSONEq(t, state.Data.String(), stateByID.Data.String())
+	assert.Equal(t, txID, stateByID.Confirmed.Transaction)
+	assert.Nil(t, stateByID.Spent)
+	assert.Equal(t, nullifier1, stateByID.Nullifier.ID)
+	require.Len(t, stateByID.Locks, 1)
+	assert.Equal(t, pldapi.StateLockTypeSpend, stateByID.Locks[0].Type.V())
+
+	// Contract address is optional
+	rpcErr = c.CallRPC(ctx, &stateByID, "pstate_getState", "domain1", nil, state.ID)
+	require.NoError(t, rpcErr)
+	assert.Equal(t, state.ID, stateByID.ID)
+
+	var missingState *pldapi.State
+	rpcErr = c.CallRPC(ctx, &missingState, "pstate_getState", "domain1", pldtypes.RandAddress(), state.ID)
+	require.NoError(t, rpcErr)
+	assert.Nil(t, missingState)
+
 }
@@ -18,12 +18,14 @@ package statemgr
 
 import (
 	"context"
+	"database/sql/driver"
 	"fmt"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/mocks/componentsmocks"
 	"github.com/kaleido-io/paladin/core/pkg/persistence"
@@ -40,7 +42,7 @@ type mockComponents struct {
 	allComponents *componentsmocks.AllComponents
 }
 
-func newMockComponents(t *testing.T) *mockComponents {
+func newMockComponents(t testing.TB) *mockComponents {
 	m := &mockComponents{}
 	m.domainManager = componentsmocks.NewDomainManager(t)
 	m.txManager = componentsmocks.NewTXManager(t)
@@ -75,11 +77,13 @@ func newDBTestStateManager(t *testing.T) (context.Context, *stateManager, *mockC
 	}
 }
 
-func newDBMockStateManager(t *testing.T) (context.Context, *stateManager, sqlmock.Sqlmock, *mockComponents, func()) {
+func newDBMockStateManager(t testing.TB) (context.Context, *stateManager, sqlmock.Sqlmock, *mockComponents, func()) {
 	ctx := context.Background()
 	p, err := mockpersistence.NewSQLMockProvider()
 	require.NoError(t, err)
-	ss := NewStateManager(ctx, &pldconf.StateStoreConfig{}, p.P)
+	ss := NewStateManager(ctx, &pldconf.StateStoreConfig{
+		WarmSchemaCache: confutil.P(false),
+	}, p.P)
 
 	m := newMockComponents(t)
 
@@ -89,6 +93,7 @@ func newDBMockStateManager(t *testing.T) (context.Context, *stateManager, sqlmoc
 	err = ss.PostInit(m.allComponents)
 	require.NoError(t, err)
 
+	p.Mock.ExpectQuery("SELECT.*schema_aliases").WillReturnRows(sqlmock.NewRows([]string{}))
 	err = ss.Start()
 	require.NoError(t, err)
 
@@ -145,3 +150,200 @@ func TestGetTransactionStatesFail(t *testing.T) {
 	_, err := ss.GetTransactionStates(ctx, ss.p.NOTX(), uuid.New())
 	assert.Regexp(t, "pop", err)
 }
+
+func TestRevertStateFinalizations(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	txID1 := uuid.New()
+	txID2 := uuid.New()
+	stateID1 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	stateID2 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+	stateID3 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+
+	err := ss.WriteStateFinalizations(ctx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{
+			{DomainName: "domain1", State: stateID1, Transaction: txID1},
+		},
+		[]*pldapi.StateReadRecord{},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: stateID2, Transaction: txID1},
+			{DomainName: "domain1", State: stateID3, Transaction: txID2},
+		},
+		[]*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+
+	err = ss.RevertStateFinalizations(ctx, ss.p.NOTX(), nil)
+	require.NoError(t, err)
+
+	err = ss.RevertStateFinalizations(ctx, ss.p.NOTX(), []uuid.UUID{txID1})
+	require.NoError(t, err)
+
+	txStates, err := ss.GetTransactionStates(ctx, ss.p.NOTX(), txID1)
+	require.NoError(t, err)
+	assert.True(t, txStates.None)
+
+	txStates, err = ss.GetTransactionStates(ctx, ss.p.NOTX(), txID2)
+	require.NoError(t, err)
+	assert.Equal(t, []pldtypes.HexBytes{stateID3}, txStates.Unavailable.Confirmed)
+
+	// The rows are removed for the reverted transaction only
+	assert.Zero(t, countStateRows(t, ss, "state_spend_records", "state", stateID1))
+	assert.Zero(t, countStateRows(t, ss, "state_confirm_records", "state", stateID2))
+	assert.Equal(t, int64(1), countStateRows(t, ss, "state_confirm_records", "state", stateID3))
+}
+
+func TestRevertStateFinalizationsOnlyTransactionTables(t *testing.T) {
+
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	// Nullifiers are not recorded against a transaction, so must not be touched
+	db.ExpectExec("DELETE.*state_confirm_records").WillReturnResult(driver.ResultNoRows)
+	db.ExpectExec("DELETE.*state_spend_records").WillReturnResult(driver.ResultNoRows)
+	db.ExpectExec("DELETE.*state_read_records").WillReturnResult(driver.ResultNoRows)
+	db.ExpectExec("DELETE.*state_info_records").WillReturnResult(driver.ResultNoRows)
+
+	err := ss.RevertStateFinalizations(ctx, ss.p.NOTX(), []uuid.UUID{uuid.New()})
+	require.NoError(t, err)
+}
+
+func TestStreamTransactionStates(t *testing.T) {
+
+	ctx, ss, _, done := newDBTestStateManager(t)
+	defer done()
+
+	txID := uuid.New()
+	stateID1 := pldtypes.HexBytes(pldtypes.RandBytes(32))
+
+	// No records
+	err := ss.StreamTransactionStates(ctx, ss.p.NOTX(), txID, func(s *pldapi.StateBase) error {
+		return fmt.Errorf("unexpected state")
+	})
+	require.NoError(t, err)
+
+	// Records for states we do not have are not returned
+	err = ss.WriteStateFinalizations(ctx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{}, []*pldapi.StateReadRecord{},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: stateID1, Transaction: txID},
+		},
+		[]*pldapi.StateInfoRecord{})
+	require.NoError(t, err)
+	err = ss.StreamTransactionStates(ctx, ss.p.NOTX(), txID, func(s *pldapi.StateBase) error {
+		return fmt.Errorf("unexpected state")
+	})
+	require.NoError(t, err)
+}
+
+func TestStreamTransactionStatesFail(t *testing.T) {
+
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	db.ExpectQuery("SELECT.*states").WillReturnError(fmt.Errorf("pop"))
+	err := ss.StreamTransactionStates(ctx, ss.p.NOTX(), uuid.New(), func(s *pldapi.StateBase) error {
+		return nil
+	})
+	assert.Regexp(t, "pop", err)
+
+	db.ExpectQuery("SELECT.*states").WillReturnRows(sqlmock.NewRows([]string{"id", "record_type"}).
+		AddRow(pldtypes.RandHex(32), "confirmed").
+		AddRow(pldtypes.RandHex(32), "confirmed"))
+	calls := 0
+	err = ss.StreamTransactionStates(ctx, ss.p.NOTX(), uuid.New(), func(s *pldapi.StateBase) error {
+		calls++
+		return fmt.Errorf("stop")
+	})
+	assert.Regexp(t, "stop", err)
+	assert.Equal(t, 1, calls)
+
+	db.ExpectQuery("SELECT.*states").WillReturnRows(sqlmock.NewRows([]string{"id", "record_type"}).
+		AddRow("not hex", "confirmed"))
+	err = ss.StreamTransactionStates(ctx, ss.p.NOTX(), uuid.New(), func(s *pldapi.StateBase) error {
+		return nil
+	})
+	assert.Error(t, err)
+
+	db.ExpectQuery("SELECT.*states").WillReturnRows(sqlmock.NewRows([]string{"id", "record_type"}).
+		AddRow(pldtypes.RandHex(32), "confirmed").
+		RowError(0, fmt.Errorf("row error")))
+	err = ss.StreamTransactionStates(ctx, ss.p.NOTX(), uuid.New(), func(s *pldapi.StateBase) error {
+		return nil
+	})
+	assert.Regexp(t, "row error", err)
+}
+
+func TestRevertStateFinalizationsFail(t *testing.T) {
+
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	db.ExpectExec("DELETE.*state_confirm_records").WillReturnError(fmt.Errorf("pop"))
+
+	err := ss.RevertStateFinalizations(ctx, ss.p.NOTX(), []uuid.UUID{uuid.New()})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestWriteStateFinalizationsReadsFailRollsBackSpends(t *testing.T) {
+
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	// The spend insert succeeds, but the failure of the read insert must roll back the
+	// whole DB transaction - with the confirm and info inserts never attempted
+	db.ExpectBegin()
+	db.ExpectExec("INSERT.*state_spend_records").WillReturnResult(sqlmock.NewResult(1, 1))
+	db.ExpectExec("INSERT.*state_read_records").WillReturnError(fmt.Errorf("pop"))
+	db.ExpectRollback()
+
+	txID := uuid.New()
+	err := ss.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return ss.WriteStateFinalizations(ctx, dbTX,
+			[]*pldapi.StateSpendRecord{
+				{DomainName: "domain1", State: pldtypes.RandBytes(32), Transaction: txID},
+			},
+			[]*pldapi.StateReadRecord{
+				{DomainName: "domain1", State: pldtypes.RandBytes(32), Transaction: txID},
+			},
+			[]*pldapi.StateConfirmRecord{
+				{DomainName: "domain1", State: pldtypes.RandBytes(32), Transaction: txID},
+			},
+			[]*pldapi.StateInfoRecord{
+				{DomainName: "domain1", State: pldtypes.RandBytes(32), Transaction: txID},
+			})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestWriteStateFinalizationsNoTXConfirmsFailRollsBack(t *testing.T) {
+
+	ctx, ss, db, _, done := newDBMockStateManager(t)
+	defer done()
+
+	// Called outside of a transaction, a transaction is created for the writes so that a failure
+	// after the spend and read inserts does not leave partial writes
+	db.ExpectBegin()
+	db.ExpectExec("INSERT.*state_spend_records").WillReturnResult(sqlmock.NewResult(1, 1))
+	db.ExpectExec("INSERT.*state_read_records").WillReturnResult(sqlmock.NewResult(1, 1))
+	db.ExpectExec("INSERT.*state_confirm_records").WillReturnError(fmt.Errorf("pop"))
+	db.ExpectRollback()
+
+	txID := uuid.New()
+	err := ss.WriteStateFinalizations(ctx, ss.p.NOTX(),
+		[]*pldapi.StateSpendRecord{
+			{DomainName: "domain1", State: pldtypes.RandBytes(32), Transaction: txID},
+		},
+		[]*pldapi.StateReadRecord{
+			{DomainName: "domain1", State: pldtypes.RandBytes(32), Transaction: txID},
+		},
+		[]*pldapi.StateConfirmRecord{
+			{DomainName: "domain1", State: pldtypes.RandBytes(32), Transaction: txID},
+		},
+		[]*pldapi.StateInfoRecord{
+			{DomainName: "domain1", State: pldtypes.RandBytes(32), Transaction: txID},
+		})
+	assert.Regexp(t, "pop", err)
+	assert.NoError(t, db.ExpectationsWereMet())
+}
@@ -0,0 +1,43 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemgr
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceAttrDomain          = "paladin.domain"
+	traceAttrContractAddress = "paladin.contract_address"
+	traceAttrStateCount      = "paladin.state_count"
+	traceAttrStateLockCount  = "paladin.state_lock_count"
+	traceAttrNullifierCount  = "paladin.nullifier_count"
+)
+
+// Spans are only exported if the process registers an OpenTelemetry TracerProvider,
+// otherwise the global no-op provider is used.
+var tracer = otel.Tracer("github.com/kaleido-io/paladin/core/internal/statemgr")
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -17,7 +17,9 @@ package transportmgr
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -78,6 +80,23 @@ type transportManager struct {
 	senderBufferLen         int
 	reliableMessageResend   time.Duration
 	reliableMessagePageSize int
+
+	messageSigning       bool
+	signingKeyIdentifier string
+	signingKey           *pldapi.KeyMappingAndVerifier // resolved on first use
+
+	sendSequence     atomic.Uint64
+	replayProtection bool
+	replayWindow     uint64
+	replayLock       sync.Mutex
+	receiveSequences map[string]*sequenceWindow
+
+	stateEncoding         string
+	stateCompressionLevel int
+
+	multicastConcurrency int
+
+	maxMessageBytes int64
 }
 
 var reliableMessageFilters = filters.FieldMap{
@@ -109,7 +128,17 @@ func NewTransportManager(bgCtx context.Context, conf *pldconf.TransportManagerCo
 		peerReaperInterval:      confutil.DurationMin(conf.PeerReaperInterval, 100*time.Millisecond, *pldconf.TransportManagerDefaults.PeerReaperInterval),
 		quiesceTimeout:          1 * time.Second, // not currently tunable (considered very small edge case)
 		reliableMessagePageSize: 100,             // not currently tunable
+		messageSigning:          confutil.Bool(conf.MessageSigning.Enabled, *pldconf.TransportManagerDefaults.MessageSigning.Enabled),
+		signingKeyIdentifier:    conf.MessageSigning.KeyIdentifier,
+		replayProtection:        confutil.Bool(conf.ReplayProtection.Enabled, *pldconf.TransportManagerDefaults.ReplayProtection.Enabled),
+		replayWindow:            uint64(confutil.IntMin(conf.ReplayProtection.Window, 0, *pldconf.TransportManagerDefaults.ReplayProtection.Window)),
+		receiveSequences:        make(map[string]*sequenceWindow),
+		stateEncoding:           confutil.StringNotEmpty(conf.StateEncoding, *pldconf.TransportManagerDefaults.StateEncoding),
+		stateCompressionLevel:   confutil.Int(conf.StateCompressionLevel, *pldconf.TransportManagerDefaults.StateCompressionLevel),
+		multicastConcurrency:    confutil.IntMin(conf.MulticastConcurrency, 1, *pldconf.TransportManagerDefaults.MulticastConcurrency),
+		maxMessageBytes:         confutil.ByteSize(conf.MaxMessageBytes, 1, *pldconf.TransportManagerDefaults.MaxMessageBytes),
 	}
+	tm.sendSequence.Store(initialSendSequence())
 	tm.bgCtx, tm.cancelCtx = context.WithCancel(bgCtx)
 	return tm
 }
@@ -118,6 +147,15 @@ func (tm *transportManager) PreInit(pic components.PreInitComponents) (*componen
 	if tm.localNodeName == "" {
 		return nil, i18n.NewError(tm.bgCtx, msgs.MsgTransportNodeNameNotConfigured)
 	}
+	if tm.messageSigning && tm.signingKeyIdentifier == "" {
+		return nil, i18n.NewError(tm.bgCtx, msgs.MsgTransportSigningKeyMissing)
+	}
+	if tm.replayProtection && !tm.messageSigning {
+		return nil, i18n.NewError(tm.bgCtx, msgs.MsgTransportReplayProtectionUnsigned)
+	}
+	if err := validateStateEncoding(tm.bgCtx, tm.stateEncoding, tm.stateCompressionLevel); err != nil {
+		return nil, err
+	}
 	tm.initRPC()
 	return &components.ManagerInitResult{
 		RPCModules: []*rpcserver.RPCModule{tm.rpcModule},
@@ -128,7 +166,11 @@ func (tm *transportManager) PostInit(c components.AllComponents) error {
 	// Asserted to be thread safe to do initialization here without lock, as it's before the
 	// plugin manager starts, and thus before any domain would have started any go-routine
 	// that could have cached a nil value in memory.
-	tm.registryManager = c.RegistryManager()
+	var registryAvailable bool
+	if tm.registryManager, registryAvailable = c.RegistryManager(); !registryAvailable {
+		// Local delivery still works, but we cannot resolve or verify remote peers
+		log.L(tm.bgCtx).Warnf("Registry manager not available - remote peer connectivity disabled")
+	}
 	tm.stateManager = c.StateManager()
 	tm.domainManager = c.DomainManager()
 	tm.keyManager = c.KeyManager()
@@ -142,6 +184,13 @@ func (tm *transportManager) PostInit(c components.AllComponents) error {
 	return nil
 }
 
+func (tm *transportManager) getRegistry(ctx context.Context) (components.RegistryManager, error) {
+	if tm.registryManager == nil {
+		return nil, i18n.NewError(ctx, msgs.MsgComponentManagerNotAvailable, "registry", "transport")
+	}
+	return tm.registryManager, nil
+}
+
 func (tm *transportManager) Start() error {
 	tm.peerReaperDone = make(chan struct{})
 	tm.reliableMsgWriter.Start()
@@ -201,6 +250,18 @@ func (tm *transportManager) getTransportNames() []string {
 	return transportNames
 }
 
+func (tm *transportManager) getTransportStats() []*pldapi.TransportStats {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+
+	stats := make([]*pldapi.TransportStats, 0, len(tm.transportsByName))
+	for _, t := range tm.transportsByName {
+		stats = append(stats, t.getStats())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
 func (tm *transportManager) getTransportByName(ctx context.Context, transportName string) (*transport, error) {
 	tm.mux.Lock()
 	defer tm.mux.Unlock()
@@ -253,6 +314,10 @@ func (tm *transportManager) LocalNodeName() string {
 	return tm.localNodeName
 }
 
+func (tm *transportManager) MaxMessageBytes() int64 {
+	return tm.maxMessageBytes
+}
+
 // See docs in components package
 func (tm *transportManager) Send(ctx context.Context, send *components.FireAndForgetMessageSend) error {
 
@@ -266,18 +331,69 @@ func (tm *transportManager) Send(ctx context.Context, send *components.FireAndFo
 		msgID := uuid.New()
 		send.MessageID = &msgID
 	}
+	msg := newPaladinMsg(*send.MessageID, send.CorrelationID, send.Component, send.MessageType, send.Payload)
+
+	return tm.queueFireAndForget(ctx, send.Node, msg)
+}
+
+// See docs in components package
+func (tm *transportManager) Multicast(ctx context.Context, send *components.FireAndForgetMulticast) ([]*components.MulticastResult, error) {
+
+	// Check the message is valid
+	if len(send.Payload) == 0 || len(send.Nodes) == 0 {
+		log.L(ctx).Errorf("Invalid message multicast request %+v", send)
+		return nil, i18n.NewError(ctx, msgs.MsgTransportInvalidMessage)
+	}
+
+	if send.MessageID == nil {
+		msgID := uuid.New()
+		send.MessageID = &msgID
+	}
+
+	// Each node gets its own copy of the message, and the number of sends in parallel is bounded
+	results := make([]*components.MulticastResult, len(send.Nodes))
+	concurrency := make(chan struct{}, tm.multicastConcurrency)
+	var wg sync.WaitGroup
+	for i, node := range send.Nodes {
+		results[i] = &components.MulticastResult{Node: node}
+		concurrency <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-concurrency
+				wg.Done()
+			}()
+			msg := newPaladinMsg(*send.MessageID, send.CorrelationID, send.Component, send.MessageType, send.Payload)
+			results[i].Error = tm.queueFireAndForget(ctx, node, msg)
+		}()
+	}
+	wg.Wait()
+
+	var failed []*components.MulticastResult
+	for _, r := range results {
+		if r.Error != nil {
+			log.L(ctx).Warnf("Multicast message %s could not be sent to %s: %s", send.MessageID, r.Node, r.Error)
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) > 0 && send.RequireAllDelivered {
+		return results, i18n.NewError(ctx, msgs.MsgTransportMulticastFailed, send.MessageID, len(failed), len(results), failed[0].Error)
+	}
+	return results, nil
+}
+
+func newPaladinMsg(msgID uuid.UUID, correlationID *uuid.UUID, component prototk.PaladinMsg_Component, messageType string, payload []byte) *prototk.PaladinMsg {
 	msg := &prototk.PaladinMsg{
-		MessageId:   send.MessageID.String(),
-		MessageType: send.MessageType,
-		Component:   send.Component,
-		Payload:     send.Payload,
+		MessageId:   msgID.String(),
+		MessageType: messageType,
+		Component:   component,
+		Payload:     payload,
 	}
-	if send.CorrelationID != nil {
-		cidStr := send.CorrelationID.String()
+	if correlationID != nil {
+		cidStr := correlationID.String()
 		msg.CorrelationId = &cidStr
 	}
-
-	return tm.queueFireAndForget(ctx, send.Node, msg)
+	return msg
 }
 
 func (tm *transportManager) queueFireAndForget(ctx context.Context, nodeName string, msg *prototk.PaladinMsg) error {
@@ -71,7 +71,7 @@ func newMockComponents(t *testing.T, realDB bool) *mockComponents {
 		mc.p = mdb.P
 	}
 	mc.c.On("Persistence").Return(mc.p).Maybe()
-	mc.c.On("RegistryManager").Return(mc.registryManager).Maybe()
+	mc.c.On("RegistryManager").Return(mc.registryManager, true).Maybe()
 	mc.c.On("StateManager").Return(mc.stateManager).Maybe()
 	mc.c.On("DomainManager").Return(mc.domainManager).Maybe()
 	mc.c.On("KeyManager").Return(mc.keyManager).Maybe()
@@ -105,6 +105,7 @@ func newTestTransportManager(t *testing.T, realDB bool, conf *pldconf.TransportM
 	require.NoError(t, err)
 
 	assert.Equal(t, conf.NodeName, tm.LocalNodeName())
+	assert.Equal(t, int64(4*1024*1024), tm.MaxMessageBytes())
 
 	return ctx, tm.(*transportManager), mc, func() {
 		if !t.Failed() {
@@ -121,6 +122,25 @@ func TestMissingName(t *testing.T) {
 	assert.Regexp(t, "PD012002", err)
 }
 
+func TestPostInitNoRegistry(t *testing.T) {
+	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{})
+	mc := componentsmocks.NewAllComponents(t)
+	mc.On("RegistryManager").Return(nil, false)
+	mc.On("StateManager").Return(nil)
+	mc.On("DomainManager").Return(nil)
+	mc.On("KeyManager").Return(nil)
+	mc.On("TxManager").Return(nil)
+	mc.On("PrivateTxManager").Return(nil)
+	mc.On("IdentityResolver").Return(nil)
+	mc.On("GroupManager").Return(nil)
+	mc.On("Persistence").Return(nil)
+	err := tm.PostInit(mc)
+	require.NoError(t, err)
+
+	_, err = tm.(*transportManager).getRegistry(context.Background())
+	assert.Regexp(t, "PD010036.*registry", err)
+}
+
 func TestConfiguredTransports(t *testing.T) {
 	_, dm, _, done := newTestTransportManager(t, false, &pldconf.TransportManagerConfig{
 		NodeName: "node1",
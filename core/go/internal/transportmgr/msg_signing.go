@@ -0,0 +1,116 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+// The author signature is over SHA256 of every field of the message envelope, along with the names of the
+// sending and receiving nodes. Each field is prefixed with its big-endian uint64 length, so the boundaries
+// between fields are unambiguous. This binds the message to the sender and the intended receiver, as the
+// receiver uses the node name it received the message from, and its own node name, when verifying.
+func authorSignaturePayload(fromNode, toNode string, msg *prototk.PaladinMsg) []byte {
+	hash := sha256.New()
+	writeField := func(b []byte) {
+		hash.Write(binary.BigEndian.AppendUint64(nil, uint64(len(b))))
+		hash.Write(b)
+	}
+	writeField([]byte(fromNode))
+	writeField([]byte(toNode))
+	writeField([]byte(msg.MessageId))
+	if msg.CorrelationId != nil {
+		writeField([]byte{1})
+		writeField([]byte(*msg.CorrelationId))
+	} else {
+		writeField([]byte{0})
+	}
+	writeField(binary.BigEndian.AppendUint32(nil, uint32(msg.Component)))
+	writeField([]byte(msg.MessageType))
+	writeField(binary.BigEndian.AppendUint64(nil, msg.Sequence))
+	writeField(msg.Payload)
+	return hash.Sum(nil)
+}
+
+func (tm *transportManager) getSigningKey(ctx context.Context) (*pldapi.KeyMappingAndVerifier, error) {
+	tm.mux.Lock()
+	defer tm.mux.Unlock()
+	if tm.signingKey == nil {
+		signingKey, err := tm.keyManager.ResolveKeyNewDatabaseTX(ctx, tm.signingKeyIdentifier, algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS)
+		if err != nil {
+			return nil, err
+		}
+		log.L(ctx).Infof("Transport messages will be signed by %s (%s)", signingKey.Verifier.Verifier, tm.signingKeyIdentifier)
+		tm.signingKey = signingKey
+	}
+	return tm.signingKey, nil
+}
+
+func (tm *transportManager) signMessage(ctx context.Context, toNode string, msg *prototk.PaladinMsg) error {
+	if !tm.messageSigning {
+		return nil
+	}
+	signingKey, err := tm.getSigningKey(ctx)
+	if err != nil {
+		return err
+	}
+	msg.AuthorSignature, err = tm.keyManager.Sign(ctx, signingKey, signpayloads.OPAQUE_TO_RSV, authorSignaturePayload(tm.localNodeName, toNode, msg))
+	return err
+}
+
+func (tm *transportManager) verifyAuthorSignature(ctx context.Context, fromNode string, msg *prototk.PaladinMsg) error {
+	if !tm.messageSigning {
+		return nil
+	}
+	if len(msg.AuthorSignature) == 0 {
+		return i18n.NewError(ctx, msgs.MsgTransportAuthorSignatureMissing, msg.MessageId, fromNode)
+	}
+	registry, err := tm.getRegistry(ctx)
+	if err != nil {
+		return err
+	}
+	expectedSigner, err := registry.GetNodeSigningAddress(ctx, fromNode)
+	if err != nil {
+		return err
+	}
+	var signer *pldtypes.EthAddress
+	sig, err := secp256k1.DecodeCompactRSV(ctx, msg.AuthorSignature)
+	if err == nil {
+		addr, recoverErr := sig.RecoverDirect(authorSignaturePayload(fromNode, tm.localNodeName, msg), 0)
+		if recoverErr == nil {
+			signer = (*pldtypes.EthAddress)(addr)
+		}
+		err = recoverErr
+	}
+	if err != nil || !signer.Equals(expectedSigner) {
+		log.L(ctx).Errorf("Signature verification failed for message %s from %s (expected=%s,recovered=%s): %v", msg.MessageId, fromNode, expectedSigner, signer, err)
+		return i18n.NewError(ctx, msgs.MsgTransportAuthorSignatureInvalid, msg.MessageId, fromNode)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func mockMessageSigning(kp *secp256k1.KeyPair) func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+	return func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		conf.MessageSigning.Enabled = confutil.P(true)
+		conf.MessageSigning.KeyIdentifier = "node1.key"
+		signingKey := &pldapi.KeyMappingAndVerifier{
+			KeyMappingWithPath: &pldapi.KeyMappingWithPath{KeyMapping: &pldapi.KeyMapping{Identifier: "node1.key"}},
+			Verifier:           &pldapi.KeyVerifier{Verifier: kp.Address.String()},
+		}
+		mc.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, "node1.key", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+			Return(signingKey, nil).Maybe()
+		mc.keyManager.On("Sign", mock.Anything, signingKey, signpayloads.OPAQUE_TO_RSV, mock.Anything).
+			Return(func(_ context.Context, _ *pldapi.KeyMappingAndVerifier, _ string, payload []byte) ([]byte, error) {
+				sig, err := kp.SignDirect(payload)
+				if err != nil {
+					return nil, err
+				}
+				return sig.CompactRSV(), nil
+			}).Maybe()
+	}
+}
+
+func signedTestMessage(t *testing.T, tm *transportManager) *prototk.PaladinMsg {
+	msg := &prototk.PaladinMsg{
+		MessageId:   uuid.NewString(),
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+		MessageType: "myMessageType",
+		Payload:     []byte("some data"),
+	}
+	err := tm.signMessage(context.Background(), "node1", msg)
+	require.NoError(t, err)
+	require.Len(t, msg.AuthorSignature, 65)
+	return msg
+}
+
+func TestMessageSigningMissingKey(t *testing.T) {
+	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{
+		NodeName: "node1",
+		MessageSigning: pldconf.TransportMessageSigningConfig{
+			Enabled: confutil.P(true),
+		},
+	})
+	_, err := tm.PreInit(newMockComponents(t, false).c)
+	assert.Regexp(t, "PD012023", err)
+}
+
+func TestMessageSignVerifyOk(t *testing.T) {
+	kp, _ := secp256k1.GenerateSecp256k1KeyPair()
+	node2KP, _ := secp256k1.GenerateSecp256k1KeyPair()
+	ctx, tm, tp, done := newTestTransport(t, false, mockMessageSigning(kp), func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc.registryManager.On("GetNodeSigningAddress", mock.Anything, "node1").Return(pldtypes.EthAddressBytes(kp.Address[:]), nil)
+		mc.registryManager.On("GetNodeSigningAddress", mock.Anything, "node2").Return(pldtypes.EthAddressBytes(node2KP.Address[:]), nil)
+		mc.privateTxManager.On("HandlePaladinMsg", mock.Anything, mock.Anything).Return().Once()
+	})
+	defer done()
+
+	// We sign as node1, and verify as if we received it from node1
+	msg := signedTestMessage(t, tm)
+	err := tm.verifyAuthorSignature(ctx, "node1", msg)
+	require.NoError(t, err)
+
+	// Check a message signed by node2 is delivered through the receive path
+	msg = &prototk.PaladinMsg{
+		MessageId:   uuid.NewString(),
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+		MessageType: "myMessageType",
+		Payload:     []byte("some data"),
+	}
+	sig, err := node2KP.SignDirect(authorSignaturePayload("node2", "node1", msg))
+	require.NoError(t, err)
+	msg.AuthorSignature = sig.CompactRSV()
+	_, err = tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		FromNode: "node2",
+		Message:  msg,
+	})
+	require.NoError(t, err)
+}
+
+func TestMessageVerifyFailuresDropped(t *testing.T) {
+	kp, _ := secp256k1.GenerateSecp256k1KeyPair()
+	otherKP, _ := secp256k1.GenerateSecp256k1KeyPair()
+	ctx, tm, tp, done := newTestTransport(t, false, mockMessageSigning(kp), func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc.registryManager.On("GetNodeSigningAddress", mock.Anything, "node1").Return(pldtypes.EthAddressBytes(kp.Address[:]), nil)
+		mc.registryManager.On("GetNodeSigningAddress", mock.Anything, "node2").Return(pldtypes.EthAddressBytes(otherKP.Address[:]), nil)
+		mc.registryManager.On("GetNodeSigningAddress", mock.Anything, "node3").Return(nil, fmt.Errorf("pop"))
+		// note no HandlePaladinMsg expectation, as all messages are dropped
+	})
+	defer done()
+
+	// Unsigned
+	msg := signedTestMessage(t, tm)
+	msg.AuthorSignature = nil
+	err := tm.verifyAuthorSignature(ctx, "node1", msg)
+	assert.Regexp(t, "PD012024", err)
+	rmr, err := tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{FromNode: "node1", Message: msg})
+	require.NoError(t, err)
+	assert.NotNil(t, rmr)
+
+	// Tampered payload
+	msg = signedTestMessage(t, tm)
+	msg.Payload = []byte("other data")
+	err = tm.verifyAuthorSignature(ctx, "node1", msg)
+	assert.Regexp(t, "PD012025", err)
+
+	// Tampered sequence
+	msg = signedTestMessage(t, tm)
+	msg.Sequence++
+	err = tm.verifyAuthorSignature(ctx, "node1", msg)
+	assert.Regexp(t, "PD012025", err)
+
+	// Tampered envelope fields
+	for _, tamper := range []func(msg *prototk.PaladinMsg){
+		func(msg *prototk.PaladinMsg) { msg.MessageId = uuid.NewString() },
+		func(msg *prototk.PaladinMsg) { msg.CorrelationId = confutil.P(uuid.NewString()) },
+		func(msg *prototk.PaladinMsg) { msg.Component = prototk.PaladinMsg_IDENTITY_RESOLVER },
+		func(msg *prototk.PaladinMsg) { msg.MessageType = "otherMessageType" },
+		// moving bytes between adjacent fields must not produce the same signed payload
+		func(msg *prototk.PaladinMsg) {
+			msg.MessageType = msg.MessageType + string(msg.Payload[:4])
+			msg.Payload = msg.Payload[4:]
+		},
+	} {
+		msg = signedTestMessage(t, tm)
+		tamper(msg)
+		err = tm.verifyAuthorSignature(ctx, "node1", msg)
+		assert.Regexp(t, "PD012025", err)
+	}
+
+	// Signed for delivery to a different node
+	msg = signedTestMessage(t, tm)
+	msg.AuthorSignature = nil
+	err = tm.signMessage(ctx, "node2", msg)
+	require.NoError(t, err)
+	err = tm.verifyAuthorSignature(ctx, "node1", msg)
+	assert.Regexp(t, "PD012025", err)
+
+	// Claiming to be from a different node
+	msg = signedTestMessage(t, tm)
+	err = tm.verifyAuthorSignature(ctx, "node2", msg)
+	assert.Regexp(t, "PD012025", err)
+	rmr, err = tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{FromNode: "node2", Message: msg})
+	require.NoError(t, err)
+	assert.NotNil(t, rmr)
+
+	// Bad signature bytes
+	msg = signedTestMessage(t, tm)
+	msg.AuthorSignature = []byte("wrong")
+	err = tm.verifyAuthorSignature(ctx, "node1", msg)
+	assert.Regexp(t, "PD012025", err)
+
+	// No registered signing address
+	msg = signedTestMessage(t, tm)
+	err = tm.verifyAuthorSignature(ctx, "node3", msg)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestMessageSignResolveKeyFail(t *testing.T) {
+	_, tm, _, done := newTestTransport(t, false, func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		conf.MessageSigning.Enabled = confutil.P(true)
+		conf.MessageSigning.KeyIdentifier = "node1.key"
+		mc.keyManager.On("ResolveKeyNewDatabaseTX", mock.Anything, "node1.key", algorithms.ECDSA_SECP256K1, verifiers.ETH_ADDRESS).
+			Return(nil, fmt.Errorf("pop"))
+	})
+	defer done()
+
+	err := tm.signMessage(context.Background(), "node2", &prototk.PaladinMsg{})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestSendMessageSigned(t *testing.T) {
+	kp, _ := secp256k1.GenerateSecp256k1KeyPair()
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockEmptyReliableMsgs,
+		mockGoodTransport,
+		mockMessageSigning(kp))
+	defer done()
+
+	message := testMessage()
+
+	sentMessages := make(chan *prototk.PaladinMsg, 1)
+	mockActivateDeactivateOk(tp)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		sentMessages <- req.Message
+		return nil, nil
+	}
+
+	err := tm.Send(ctx, message)
+	require.NoError(t, err)
+
+	sent := <-sentMessages
+	sig, err := secp256k1.DecodeCompactRSV(ctx, sent.AuthorSignature)
+	require.NoError(t, err)
+	signer, err := sig.RecoverDirect(authorSignaturePayload("node1", "node2", sent), 0)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address, *signer)
+}
@@ -205,7 +205,11 @@ func (tm *transportManager) connectPeer(ctx context.Context, nodeName string, se
 func (p *peer) startSender() (string, error) {
 	// Note the registry is responsible for caching to make this call as efficient as if
 	// we maintained the transport details in-memory ourselves.
-	registeredTransportDetails, err := p.tm.registryManager.GetNodeTransports(p.ctx, p.Name)
+	registry, err := p.tm.getRegistry(p.ctx)
+	if err != nil {
+		return "", err
+	}
+	registeredTransportDetails, err := registry.GetNodeTransports(p.ctx, p.Name)
 	if err != nil {
 		return "", err
 	}
@@ -256,6 +260,10 @@ func (p *peer) notifyPersistedMsgAvailable() {
 }
 
 func (p *peer) send(msg *prototk.PaladinMsg, reliableSeq *uint64) error {
+	msg.Sequence = p.tm.nextSendSequence()
+	if err := p.tm.signMessage(p.ctx, p.Name, msg); err != nil {
+		return err
+	}
 	err := p.tm.sendShortRetry.Do(p.ctx, func(attempt int) (retryable bool, err error) {
 		return true, p.transport.send(p.ctx, p.Name, msg)
 	})
@@ -587,7 +587,7 @@ func TestProcessReliableMsgPagePrivacyGroup(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, RMHMessageTypePrivacyGroup, rMsg.MessageType)
 
-	rpg, err := parsePrivacyGroupDistribution(ctx, rMsg.MessageID, rMsg.Payload, "node2")
+	rpg, err := parsePrivacyGroupDistribution(ctx, rMsg.MessageID, rMsg.Payload, "node2", tm.maxMessageBytes)
 	require.NoError(t, err)
 	require.Equal(t, "domain1", rpg.domain)
 	require.JSONEq(t, fmt.Sprintf(`{"dataFor": "%s"}`, rpg.genesisState.ID.HexString()), rpg.genesisState.Data.Pretty())
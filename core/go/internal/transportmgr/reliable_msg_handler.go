@@ -106,7 +106,7 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 
 		switch v.msg.MessageType {
 		case RMHMessageTypeStateDistribution:
-			sd, stateToAdd, err := parseStateDistribution(ctx, v.msg.MessageID, v.msg.Payload)
+			sd, stateToAdd, err := parseStateDistribution(ctx, v.msg.MessageID, v.msg.Payload, tm.maxMessageBytes)
 			if err == nil && sd.NullifierAlgorithm != nil && sd.NullifierVerifierType != nil && sd.NullifierPayloadType != nil {
 				// We need to build any nullifiers that are required, before we dispatch to persistence
 				var nullifier *components.NullifierUpsert
@@ -126,7 +126,7 @@ func (tm *transportManager) handleReliableMsgBatch(ctx context.Context, dbTX per
 				})
 			}
 		case RMHMessageTypePrivacyGroup:
-			receivedPG, err := parsePrivacyGroupDistribution(ctx, v.msg.MessageID, v.msg.Payload, v.p.Name)
+			receivedPG, err := parsePrivacyGroupDistribution(ctx, v.msg.MessageID, v.msg.Payload, v.p.Name, tm.maxMessageBytes)
 			if err != nil {
 				acksToSend = append(acksToSend,
 					&ackInfo{node: v.p.Name, id: v.msg.MessageID, Error: err.Error()}, // reject the message permanently
@@ -369,7 +369,7 @@ func (tm *transportManager) parseReceivedAckNack(ctx context.Context, msg *compo
 func (tm *transportManager) buildStateDistributionMsg(ctx context.Context, dbTX persistence.DBTX, rm *pldapi.ReliableMessage) (*prototk.PaladinMsg, error, error) {
 
 	// Validate the message first (not retryable)
-	sd, parsed, parseErr := parseStateDistribution(ctx, rm.ID, rm.Metadata)
+	sd, parsed, parseErr := parseStateDistribution(ctx, rm.ID, rm.Metadata, tm.maxMessageBytes)
 	if parseErr != nil {
 		return nil, parseErr, nil
 	}
@@ -384,7 +384,9 @@ func (tm *transportManager) buildStateDistributionMsg(ctx context.Context, dbTX
 			i18n.NewError(ctx, msgs.MsgTransportStateNotAvailableLocally, sd.Domain, parsed.ContractAddress, parsed.ID),
 			nil
 	}
-	sd.StateData = states[0].Data
+	if err := tm.setStateData(ctx, sd, states[0].Data); err != nil {
+		return nil, err, nil
+	}
 
 	return &prototk.PaladinMsg{
 		MessageId:   rm.ID.String(),
@@ -394,10 +396,10 @@ func (tm *transportManager) buildStateDistributionMsg(ctx context.Context, dbTX
 	}, nil, nil
 }
 
-func parseStateDistribution(ctx context.Context, msgID uuid.UUID, data []byte) (sd *components.StateDistributionWithData, parsed *components.StateUpsertOutsideContext, err error) {
+func parseStateDistribution(ctx context.Context, msgID uuid.UUID, data []byte, maxBytes int64) (sd *components.StateDistributionWithData, parsed *components.StateUpsertOutsideContext, err error) {
 	err = json.Unmarshal(data, &sd)
 	if err == nil {
-		parsed, err = parseState(ctx, sd)
+		parsed, err = parseState(ctx, sd, maxBytes)
 	}
 	if err != nil {
 		return nil, nil, i18n.WrapError(ctx, err, msgs.MsgTransportInvalidMessageData, msgID)
@@ -405,10 +407,10 @@ func parseStateDistribution(ctx context.Context, msgID uuid.UUID, data []byte) (
 	return
 }
 
-func parsePrivacyGroupDistributionMetadata(ctx context.Context, msgID uuid.UUID, data []byte) (pgd *components.PrivacyGroupDistribution, parsed *components.StateUpsertOutsideContext, err error) {
+func parsePrivacyGroupDistributionMetadata(ctx context.Context, msgID uuid.UUID, data []byte, maxBytes int64) (pgd *components.PrivacyGroupDistribution, parsed *components.StateUpsertOutsideContext, err error) {
 	err = json.Unmarshal(data, &pgd)
 	if err == nil {
-		parsed, err = parseState(ctx, &pgd.GenesisState)
+		parsed, err = parseState(ctx, &pgd.GenesisState, maxBytes)
 	}
 	if err != nil {
 		return nil, nil, i18n.WrapError(ctx, err, msgs.MsgTransportInvalidMessageData, msgID)
@@ -419,7 +421,7 @@ func parsePrivacyGroupDistributionMetadata(ctx context.Context, msgID uuid.UUID,
 func (tm *transportManager) buildPrivacyGroupDistributionMsg(ctx context.Context, dbTX persistence.DBTX, rm *pldapi.ReliableMessage) (*prototk.PaladinMsg, error, error) {
 
 	// Validate the message first (not retryable) - note the input is just a state distribution
-	pgd, parsed, parseErr := parsePrivacyGroupDistributionMetadata(ctx, rm.ID, rm.Metadata)
+	pgd, parsed, parseErr := parsePrivacyGroupDistributionMetadata(ctx, rm.ID, rm.Metadata, tm.maxMessageBytes)
 	if parseErr != nil {
 		return nil, parseErr, nil
 	}
@@ -435,7 +437,9 @@ func (tm *transportManager) buildPrivacyGroupDistributionMsg(ctx context.Context
 			i18n.NewError(ctx, msgs.MsgTransportStateNotAvailableLocally, domainName, nil, parsed.ID),
 			nil
 	}
-	pgd.GenesisState.StateData = states[0].Data
+	if err := tm.setStateData(ctx, &pgd.GenesisState, states[0].Data); err != nil {
+		return nil, err, nil
+	}
 
 	return &prototk.PaladinMsg{
 		MessageId:   rm.ID.String(),
@@ -492,7 +496,7 @@ func (tm *transportManager) buildPrivacyGroupMessageMsg(ctx context.Context, dbT
 	}, nil, nil
 }
 
-func parsePrivacyGroupDistribution(ctx context.Context, msgID uuid.UUID, data []byte, node string) (receivedPG *receivedPrivacyGroup, err error) {
+func parsePrivacyGroupDistribution(ctx context.Context, msgID uuid.UUID, data []byte, node string, maxBytes int64) (receivedPG *receivedPrivacyGroup, err error) {
 	var pgInfo components.PrivacyGroupGenesis
 	err = json.Unmarshal(data, &pgInfo)
 	var id pldtypes.HexBytes
@@ -507,7 +511,7 @@ func parsePrivacyGroupDistribution(ctx context.Context, msgID uuid.UUID, data []
 			msgID:     msgID,
 			genesisTx: pgInfo.GenesisTransaction,
 		}
-		receivedPG.genesisState, err = parseState(ctx, &pgInfo.GenesisState)
+		receivedPG.genesisState, err = parseState(ctx, &pgInfo.GenesisState, maxBytes)
 	}
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportInvalidMessageData, msgID)
@@ -515,10 +519,12 @@ func parsePrivacyGroupDistribution(ctx context.Context, msgID uuid.UUID, data []
 	return
 }
 
-func parseState(ctx context.Context, sd *components.StateDistributionWithData) (parsed *components.StateUpsertOutsideContext, err error) {
+func parseState(ctx context.Context, sd *components.StateDistributionWithData, maxBytes int64) (parsed *components.StateUpsertOutsideContext, err error) {
 	parsed = &components.StateUpsertOutsideContext{}
-	parsed.Data = sd.StateData
-	parsed.ID, err = pldtypes.ParseHexBytes(ctx, sd.StateID)
+	parsed.Data, err = getStateData(ctx, sd, maxBytes)
+	if err == nil {
+		parsed.ID, err = pldtypes.ParseHexBytes(ctx, sd.StateID)
+	}
 	if err == nil {
 		parsed.SchemaID, err = pldtypes.ParseBytes32(sd.SchemaID)
 	}
@@ -0,0 +1,80 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+)
+
+// The sequences received from a single sending node. We keep the sequences seen within
+// the window below the highest, so those can arrive out of order but only once.
+type sequenceWindow struct {
+	highest uint64
+	seen    map[uint64]bool
+}
+
+// The send sequence starts from the current time in nanoseconds, so that it continues
+// to increase across restarts of this node without needing to be persisted.
+func initialSendSequence() uint64 {
+	return uint64(time.Now().UnixNano())
+}
+
+func (tm *transportManager) nextSendSequence() uint64 {
+	return tm.sendSequence.Add(1)
+}
+
+func (tm *transportManager) checkSequence(ctx context.Context, fromNode string, msg *prototk.PaladinMsg) error {
+	if !tm.replayProtection {
+		return nil
+	}
+	if msg.Sequence == 0 {
+		return i18n.NewError(ctx, msgs.MsgTransportSequenceMissing, msg.MessageId, fromNode)
+	}
+
+	tm.replayLock.Lock()
+	defer tm.replayLock.Unlock()
+
+	w := tm.receiveSequences[fromNode]
+	if w == nil {
+		w = &sequenceWindow{seen: make(map[uint64]bool)}
+		tm.receiveSequences[fromNode] = w
+	}
+
+	seq := msg.Sequence
+	switch {
+	case seq > w.highest:
+		w.highest = seq
+		w.seen[seq] = true
+		// Forget the sequences that have dropped out of the window
+		for s := range w.seen {
+			if w.highest-s >= tm.replayWindow {
+				delete(w.seen, s)
+			}
+		}
+	case w.highest-seq < tm.replayWindow && !w.seen[seq]:
+		log.L(ctx).Debugf("Message %s from %s received out of order (sequence=%d,highest=%d)", msg.MessageId, fromNode, seq, w.highest)
+		w.seen[seq] = true
+	default:
+		return i18n.NewError(ctx, msgs.MsgTransportMessageReplayed, msg.MessageId, fromNode, seq, w.highest)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Replay protection requires message signing, so this also configures signing with kp
+func mockReplayProtection(kp *secp256k1.KeyPair, window int) func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+	return func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mockMessageSigning(kp)(mc, conf)
+		conf.ReplayProtection.Enabled = confutil.P(true)
+		conf.ReplayProtection.Window = confutil.P(window)
+	}
+}
+
+func sequencedTestMessage(seq uint64) *prototk.PaladinMsg {
+	return &prototk.PaladinMsg{
+		MessageId:   uuid.NewString(),
+		Component:   prototk.PaladinMsg_TRANSACTION_ENGINE,
+		MessageType: "myMessageType",
+		Payload:     []byte("some data"),
+		Sequence:    seq,
+	}
+}
+
+func TestSendSequenceIncreases(t *testing.T) {
+	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{}).(*transportManager)
+	seq1 := tm.nextSendSequence()
+	seq2 := tm.nextSendSequence()
+	assert.Greater(t, seq2, seq1)
+
+	// A restart of the node continues from a higher sequence
+	tm = NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{}).(*transportManager)
+	assert.Greater(t, tm.nextSendSequence(), seq2)
+}
+
+func TestCheckSequenceWindow(t *testing.T) {
+	kp, _ := secp256k1.GenerateSecp256k1KeyPair()
+	ctx, tm, _, done := newTestTransport(t, false, mockReplayProtection(kp, 2))
+	defer done()
+
+	require.NoError(t, tm.checkSequence(ctx, "node2", sequencedTestMessage(10)))
+	assert.Regexp(t, "PD012031", tm.checkSequence(ctx, "node2", sequencedTestMessage(10)))
+
+	// Out of order within the window is accepted once
+	require.NoError(t, tm.checkSequence(ctx, "node2", sequencedTestMessage(12)))
+	require.NoError(t, tm.checkSequence(ctx, "node2", sequencedTestMessage(11)))
+	assert.Regexp(t, "PD012031", tm.checkSequence(ctx, "node2", sequencedTestMessage(11)))
+
+	// Below the window is rejected, even if not seen
+	assert.Regexp(t, "PD012031", tm.checkSequence(ctx, "node2", sequencedTestMessage(9)))
+	assert.Len(t, tm.receiveSequences["node2"].seen, 2)
+
+	// Each node is tracked separately
+	require.NoError(t, tm.checkSequence(ctx, "node3", sequencedTestMessage(1)))
+
+	assert.Regexp(t, "PD012030", tm.checkSequence(ctx, "node2", sequencedTestMessage(0)))
+}
+
+func TestCheckSequenceStrict(t *testing.T) {
+	kp, _ := secp256k1.GenerateSecp256k1KeyPair()
+	ctx, tm, _, done := newTestTransport(t, false, mockReplayProtection(kp, 0))
+	defer done()
+
+	require.NoError(t, tm.checkSequence(ctx, "node2", sequencedTestMessage(10)))
+	assert.Regexp(t, "PD012031", tm.checkSequence(ctx, "node2", sequencedTestMessage(9)))
+	require.NoError(t, tm.checkSequence(ctx, "node2", sequencedTestMessage(11)))
+	assert.Empty(t, tm.receiveSequences["node2"].seen)
+}
+
+func TestCheckSequenceDisabled(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	require.NoError(t, tm.checkSequence(ctx, "node2", sequencedTestMessage(0)))
+	require.NoError(t, tm.checkSequence(ctx, "node2", sequencedTestMessage(0)))
+}
+
+func TestReplayProtectionRequiresSigning(t *testing.T) {
+	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{
+		NodeName: "node1",
+		ReplayProtection: pldconf.TransportReplayProtectionConfig{
+			Enabled: confutil.P(true),
+		},
+	})
+	_, err := tm.PreInit(newMockComponents(t, false).c)
+	assert.Regexp(t, "PD012032", err)
+}
+
+func TestReceiveMessageReplayDropped(t *testing.T) {
+	kp, _ := secp256k1.GenerateSecp256k1KeyPair()
+	node2KP, _ := secp256k1.GenerateSecp256k1KeyPair()
+	ctx, tm, tp, done := newTestTransport(t, false, mockReplayProtection(kp, 64), func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+		mc.registryManager.On("GetNodeSigningAddress", mock.Anything, "node2").Return(pldtypes.EthAddressBytes(node2KP.Address[:]), nil)
+		mc.privateTxManager.On("HandlePaladinMsg", mock.Anything, mock.Anything).Return().Once()
+	})
+	defer done()
+
+	msg := sequencedTestMessage(12345)
+	sig, err := node2KP.SignDirect(authorSignaturePayload("node2", "node1", msg))
+	require.NoError(t, err)
+	msg.AuthorSignature = sig.CompactRSV()
+	for i := 0; i < 2; i++ {
+		// The second delivery is dropped, without an error to the transport
+		rmr, err := tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+			FromNode: "node2",
+			Message:  msg,
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, rmr)
+	}
+
+	// An unsigned message cannot move the window forward
+	forged := sequencedTestMessage(msg.Sequence + 1000000)
+	rmr, err := tp.t.ReceiveMessage(ctx, &prototk.ReceiveMessageRequest{
+		FromNode: "node2",
+		Message:  forged,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, rmr)
+	assert.Equal(t, msg.Sequence, tm.receiveSequences["node2"].highest)
+}
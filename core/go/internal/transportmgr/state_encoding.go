@@ -0,0 +1,169 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+)
+
+const (
+	StateEncodingJSON = "json"
+	StateEncodingCBOR = "cbor"
+
+	StateCompressionGzip = "gzip"
+)
+
+var cborEncMode, _ = cbor.CoreDetEncOptions().EncMode()
+
+var cborDecMode, _ = cbor.DecOptions{
+	DefaultMapType: reflect.TypeOf(map[string]any(nil)),
+	BigIntDec:      cbor.BigIntDecodePointer, // *big.Int marshals to a JSON number, where big.Int does not
+}.DecMode()
+
+func validateStateEncoding(ctx context.Context, encoding string, compressionLevel int) error {
+	if encoding != StateEncodingJSON && encoding != StateEncodingCBOR {
+		return i18n.NewError(ctx, msgs.MsgTransportInvalidStateEncoding, encoding)
+	}
+	if compressionLevel < 0 || compressionLevel > gzip.BestCompression {
+		return i18n.NewError(ctx, msgs.MsgTransportInvalidStateCompressionLevel, compressionLevel)
+	}
+	return nil
+}
+
+// Sets the state data on the distribution, using the configured encoding and compression.
+// With the defaults (JSON, no compression) the data is sent unmodified in StateData.
+func (tm *transportManager) setStateData(ctx context.Context, sd *components.StateDistributionWithData, data pldtypes.RawJSON) error {
+	if tm.stateEncoding == StateEncodingJSON && tm.stateCompressionLevel == 0 {
+		sd.StateData = data
+		return nil
+	}
+
+	encoded := []byte(data)
+	if tm.stateEncoding == StateEncodingCBOR {
+		var err error
+		if encoded, err = jsonToCBOR(data); err != nil {
+			return err
+		}
+	}
+	sd.StateDataEncoding = tm.stateEncoding
+
+	if tm.stateCompressionLevel > 0 {
+		buff := new(bytes.Buffer)
+		gz, _ := gzip.NewWriterLevel(buff, tm.stateCompressionLevel) // level validated on startup
+		_, _ = gz.Write(encoded)                                     // cannot fail writing to a bytes.Buffer
+		if err := gz.Close(); err != nil {
+			return err
+		}
+		encoded = buff.Bytes()
+		sd.StateDataCompression = StateCompressionGzip
+	}
+
+	sd.StateData = nil
+	sd.StateDataEncoded = encoded
+	return nil
+}
+
+// Returns the JSON state data from a received distribution, whatever encoding the sender used.
+// Compressed data is not inflated beyond maxBytes, as a state that could not be sent uncompressed
+// in a message of the maximum size is not valid.
+func getStateData(ctx context.Context, sd *components.StateDistributionWithData, maxBytes int64) (data pldtypes.RawJSON, err error) {
+	if sd.StateDataEncoding == "" {
+		return sd.StateData, nil
+	}
+
+	encoded := []byte(sd.StateDataEncoded)
+	switch sd.StateDataCompression {
+	case "":
+	case StateCompressionGzip:
+		var gz *gzip.Reader
+		gz, err = gzip.NewReader(bytes.NewReader(encoded))
+		if err == nil {
+			// Read one byte beyond the limit, so we can detect data that inflates beyond it
+			encoded, err = io.ReadAll(io.LimitReader(gz, maxBytes+1))
+		}
+		if err == nil && int64(len(encoded)) > maxBytes {
+			err = i18n.NewError(ctx, msgs.MsgTransportStateDataTooLarge, maxBytes)
+		}
+	default:
+		err = i18n.NewError(ctx, msgs.MsgTransportStateDataDecodeFailed, sd.StateDataEncoding, sd.StateDataCompression)
+	}
+
+	if err == nil {
+		switch sd.StateDataEncoding {
+		case StateEncodingJSON:
+			data = encoded
+		case StateEncodingCBOR:
+			data, err = cborToJSON(encoded)
+		default:
+			err = i18n.NewError(ctx, msgs.MsgTransportStateDataDecodeFailed, sd.StateDataEncoding, sd.StateDataCompression)
+		}
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, msgs.MsgTransportStateDataDecodeFailed, sd.StateDataEncoding, sd.StateDataCompression)
+	}
+	return data, nil
+}
+
+func jsonToCBOR(data pldtypes.RawJSON) ([]byte, error) {
+	var v any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // avoid losing precision on large integers through float64
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return cborEncMode.Marshal(jsonNumbersToCBORValues(v))
+}
+
+func cborToJSON(encoded []byte) (pldtypes.RawJSON, error) {
+	var v any
+	if err := cborDecMode.Unmarshal(encoded, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// Converts json.Number values into integer or float values CBOR can encode natively
+func jsonNumbersToCBORValues(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = jsonNumbersToCBORValues(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = jsonNumbersToCBORValues(e)
+		}
+	case json.Number:
+		if i, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return i
+		}
+		f, _ := v.Float64() // the JSON decoder has already validated the number
+		return f
+	}
+	return v
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package transportmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/components"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testStateDataJSON = `{
+	"owner": "0x19d2d3bd2f1a0d3ae3e3b2cc8c7ee2f5c9f2d1c0",
+	"amount": 1606938044258990275541962092341162602522202993782792835301376,
+	"small": 42,
+	"negative": -12345,
+	"ratio": 0.5,
+	"flags": [true, false, null],
+	"nested": {"salt": "0xfeedbeef", "items": [1, "two", {"three": 3}]}
+}`
+
+func TestStateEncodingRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		encoding         string
+		compressionLevel int
+	}{
+		{StateEncodingJSON, 0},
+		{StateEncodingJSON, 6},
+		{StateEncodingCBOR, 0},
+		{StateEncodingCBOR, 9},
+	} {
+		tm := &transportManager{stateEncoding: tc.encoding, stateCompressionLevel: tc.compressionLevel}
+
+		sd := &components.StateDistributionWithData{}
+		err := tm.setStateData(ctx, sd, pldtypes.RawJSON(testStateDataJSON))
+		require.NoError(t, err)
+
+		if tc.encoding == StateEncodingJSON && tc.compressionLevel == 0 {
+			assert.JSONEq(t, testStateDataJSON, sd.StateData.String())
+			assert.Empty(t, sd.StateDataEncoding)
+			assert.Empty(t, sd.StateDataEncoded)
+		} else {
+			assert.Nil(t, sd.StateData)
+			assert.Equal(t, tc.encoding, sd.StateDataEncoding)
+			assert.NotEmpty(t, sd.StateDataEncoded)
+			if tc.compressionLevel > 0 {
+				assert.Equal(t, StateCompressionGzip, sd.StateDataCompression)
+			}
+		}
+
+		// Check through a JSON round trip, as it would be sent over the transport
+		var received *components.StateDistributionWithData
+		err = json.Unmarshal(pldtypes.JSONString(sd), &received)
+		require.NoError(t, err)
+		data, err := getStateData(ctx, received, 4096)
+		require.NoError(t, err)
+		assert.JSONEq(t, testStateDataJSON, data.String())
+	}
+}
+
+func TestStateEncodingCBORSmallerThanJSON(t *testing.T) {
+	ctx := context.Background()
+
+	tm := &transportManager{stateEncoding: StateEncodingCBOR}
+	sd := &components.StateDistributionWithData{}
+	err := tm.setStateData(ctx, sd, pldtypes.RawJSON(testStateDataJSON))
+	require.NoError(t, err)
+
+	compacted, err := json.Marshal(json.RawMessage(testStateDataJSON))
+	require.NoError(t, err)
+	assert.Less(t, len(sd.StateDataEncoded), len(compacted))
+}
+
+func TestStateEncodingBadJSON(t *testing.T) {
+	tm := &transportManager{stateEncoding: StateEncodingCBOR}
+	err := tm.setStateData(context.Background(), &components.StateDistributionWithData{}, pldtypes.RawJSON(`{!!!`))
+	assert.Error(t, err)
+}
+
+func TestStateDecodingErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := getStateData(ctx, &components.StateDistributionWithData{
+		StateDataEncoding:    StateEncodingJSON,
+		StateDataCompression: "lz4",
+	}, 4096)
+	assert.Regexp(t, "PD012028.*lz4", err)
+
+	_, err = getStateData(ctx, &components.StateDistributionWithData{
+		StateDataEncoding: "xml",
+	}, 4096)
+	assert.Regexp(t, "PD012028.*xml", err)
+
+	_, err = getStateData(ctx, &components.StateDistributionWithData{
+		StateDataEncoding:    StateEncodingJSON,
+		StateDataCompression: StateCompressionGzip,
+		StateDataEncoded:     []byte("not gzip"),
+	}, 4096)
+	assert.Regexp(t, "PD012028", err)
+
+	_, err = getStateData(ctx, &components.StateDistributionWithData{
+		StateDataEncoding: StateEncodingCBOR,
+		StateDataEncoded:  []byte{0xff},
+	}, 4096)
+	assert.Regexp(t, "PD012028", err)
+
+	_, _, err = parseStateDistribution(ctx, uuid.New(), pldtypes.JSONString(&components.StateDistributionWithData{
+		StateDataEncoding: "xml",
+	}), 4096)
+	assert.Regexp(t, "PD012016.*PD012028", err)
+}
+
+func TestStateDecodingGzipLimit(t *testing.T) {
+	ctx := context.Background()
+
+	// A highly compressible state inflates well beyond its compressed size
+	tm := &transportManager{stateEncoding: StateEncodingJSON, stateCompressionLevel: 9}
+	sd := &components.StateDistributionWithData{}
+	data := pldtypes.RawJSON(fmt.Sprintf(`{"padding": "%s"}`, strings.Repeat("0", 10000)))
+	err := tm.setStateData(ctx, sd, data)
+	require.NoError(t, err)
+	require.Less(t, len(sd.StateDataEncoded), 1000)
+
+	decoded, err := getStateData(ctx, sd, int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	_, err = getStateData(ctx, sd, int64(len(data)-1))
+	assert.Regexp(t, "PD012028.*PD012033", err)
+}
+
+func TestStateEncodingBadConfig(t *testing.T) {
+	tm := NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{
+		NodeName:      "node1",
+		StateEncoding: confutil.P("xml"),
+	})
+	_, err := tm.PreInit(newMockComponents(t, false).c)
+	assert.Regexp(t, "PD012026", err)
+
+	tm = NewTransportManager(context.Background(), &pldconf.TransportManagerConfig{
+		NodeName:              "node1",
+		StateEncoding:         confutil.P(StateEncodingCBOR),
+		StateCompressionLevel: confutil.P(10),
+	})
+	_, err = tm.PreInit(newMockComponents(t, false).c)
+	assert.Regexp(t, "PD012027", err)
+}
+
+func TestBuildStateDistributionMsgCBOR(t *testing.T) {
+	ctx, tm, mc, done := newTestTransportManager(t, false, &pldconf.TransportManagerConfig{
+		NodeName:              "node1",
+		StateEncoding:         confutil.P(StateEncodingCBOR),
+		StateCompressionLevel: confutil.P(5),
+	})
+	defer done()
+
+	sd := &components.StateDistribution{
+		Domain:          "domain1",
+		ContractAddress: pldtypes.RandAddress().String(),
+		SchemaID:        pldtypes.RandHex(32),
+		StateID:         pldtypes.RandHex(32),
+	}
+	mc.stateManager.On("GetStatesByID", mock.Anything, mock.Anything, "domain1", mock.Anything, mock.Anything, false, false).
+		Return([]*pldapi.State{{StateBase: pldapi.StateBase{Data: pldtypes.RawJSON(testStateDataJSON)}}}, nil)
+
+	rm := &pldapi.ReliableMessage{
+		ID:          uuid.New(),
+		MessageType: pldapi.RMTState.Enum(),
+		Metadata:    pldtypes.JSONString(sd),
+	}
+	msg, parseErr, err := tm.buildStateDistributionMsg(ctx, tm.persistence.NOTX(), rm)
+	require.NoError(t, err)
+	require.NoError(t, parseErr)
+	assert.NotContains(t, string(msg.Payload), "nested")
+
+	_, parsed, err := parseStateDistribution(ctx, rm.ID, msg.Payload, tm.maxMessageBytes)
+	require.NoError(t, err)
+	assert.JSONEq(t, testStateDataJSON, parsed.Data.String())
+}
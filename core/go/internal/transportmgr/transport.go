@@ -27,6 +27,7 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/retry"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
@@ -49,6 +50,18 @@ type transport struct {
 
 	initError atomic.Pointer[error]
 	initDone  chan struct{}
+
+	metrics transportMetrics
+}
+
+// Counters for the traffic through a transport. These live on the transport, so they
+// start from zero each time the transport plugin (re-)registers.
+type transportMetrics struct {
+	sentMsgs      atomic.Uint64
+	receivedMsgs  atomic.Uint64
+	sentBytes     atomic.Uint64
+	receivedBytes atomic.Uint64
+	sendErrors    atomic.Uint64
 }
 
 func (tm *transportManager) newTransport(id uuid.UUID, name string, conf *pldconf.TransportConfig, toTransport components.TransportManagerToTransport) *transport {
@@ -104,8 +117,11 @@ func (t *transport) send(ctx context.Context, nodeName string, msg *prototk.Pala
 		Message: msg,
 	})
 	if err != nil {
+		t.metrics.sendErrors.Add(1)
 		return err
 	}
+	t.metrics.sentMsgs.Add(1)
+	t.metrics.sentBytes.Add(uint64(len(msg.Payload)))
 	var correlIDStr string
 	if msg.CorrelationId != nil {
 		correlIDStr = *msg.CorrelationId
@@ -170,12 +186,26 @@ func (t *transport) ReceiveMessage(ctx context.Context, req *prototk.ReceiveMess
 		return nil, err
 	}
 
+	if err := t.tm.verifyAuthorSignature(ctx, req.FromNode, msg); err != nil {
+		// We do not return an error to the transport, as there's nothing it can do to fix this
+		log.L(ctx).Errorf("Dropping message %s from %s: %s", rMsg.MessageID, req.FromNode, err)
+		return &prototk.ReceiveMessageResponse{}, nil
+	}
+
+	// The sequence is only trusted once the signature over it has been verified
+	if err := t.tm.checkSequence(ctx, req.FromNode, msg); err != nil {
+		log.L(ctx).Errorf("Dropping message %s from %s: %s", rMsg.MessageID, req.FromNode, err)
+		return &prototk.ReceiveMessageResponse{}, nil
+	}
+
 	p, err := t.tm.getPeer(ctx, req.FromNode, false /* we do not require a connection for sending here */)
 	if err != nil {
 		return nil, err
 	}
 
 	p.updateReceivedStats(msg)
+	t.metrics.receivedMsgs.Add(1)
+	t.metrics.receivedBytes.Add(uint64(len(msg.Payload)))
 
 	log.L(ctx).Debugf("transport %s message received from %s id=%s (cid=%s)", t.name, p.Name, rMsg.MessageID, pldtypes.StrOrEmpty(msg.CorrelationId))
 	if log.IsTraceEnabled() {
@@ -216,8 +246,12 @@ func (t *transport) GetTransportDetails(ctx context.Context, req *prototk.GetTra
 
 	// Do a cache-optimized in the registry manager to get the details of the transport.
 	// We expect this to succeed because we did it before sending (see notes on Send() function)
+	registry, err := t.tm.getRegistry(ctx)
+	if err != nil {
+		return nil, err
+	}
 	var transportDetails string
-	availableTransports, err := t.tm.registryManager.GetNodeTransports(ctx, req.Node)
+	availableTransports, err := registry.GetNodeTransports(ctx, req.Node)
 	for _, atd := range availableTransports {
 		if atd.Transport == t.name {
 			transportDetails = atd.Details
@@ -240,6 +274,17 @@ func (t *transport) getLocalDetails(ctx context.Context) (string, error) {
 	return res.TransportDetails, nil
 }
 
+func (t *transport) getStats() *pldapi.TransportStats {
+	return &pldapi.TransportStats{
+		Name:          t.name,
+		SentMsgs:      t.metrics.sentMsgs.Load(),
+		ReceivedMsgs:  t.metrics.receivedMsgs.Load(),
+		SentBytes:     t.metrics.sentBytes.Load(),
+		ReceivedBytes: t.metrics.receivedBytes.Load(),
+		SendErrors:    t.metrics.sendErrors.Load(),
+	}
+}
+
 func (t *transport) close() {
 	t.cancelCtx()
 	<-t.initDone
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
@@ -99,6 +100,7 @@ func TestDoubleRegisterReplaces(t *testing.T) {
 	defer done()
 	assert.Nil(t, tp0.t.initError.Load())
 	assert.True(t, tp0.initialized.Load())
+	tp0.t.metrics.sentMsgs.Add(1)
 
 	// Register again
 	tp1 := newTestPlugin(nil)
@@ -113,6 +115,9 @@ func TestDoubleRegisterReplaces(t *testing.T) {
 	byUUID := rm.transportsByID[tp1.t.id]
 	assert.Same(t, tp1.t, byUUID)
 
+	// Stats start again for the new registration
+	assert.Zero(t, tp1.t.getStats().SentMsgs)
+
 }
 
 func testMessage() *components.FireAndForgetMessageSend {
@@ -163,6 +168,7 @@ func TestSendMessage(t *testing.T) {
 		assert.NotEmpty(t, sent.MessageId)
 		assert.Equal(t, message.CorrelationID.String(), *sent.CorrelationId)
 		assert.Equal(t, message.Payload, sent.Payload)
+		assert.NotZero(t, sent.Sequence)
 		sentMessages <- sent
 		return nil, nil
 	}
@@ -171,6 +177,9 @@ func TestSendMessage(t *testing.T) {
 	require.NoError(t, err)
 
 	<-sentMessages
+	require.Eventually(t, func() bool { return tp.t.metrics.sentMsgs.Load() == 1 }, 5*time.Second, 1*time.Millisecond)
+	assert.Equal(t, uint64(len(message.Payload)), tp.t.metrics.sentBytes.Load())
+	assert.Zero(t, tp.t.metrics.sendErrors.Load())
 }
 
 func TestSendMessageNotInit(t *testing.T) {
@@ -223,6 +232,8 @@ func TestSendMessageFail(t *testing.T) {
 	err := tm.Send(ctx, message)
 	assert.NoError(t, err)
 	<-sent
+	require.Eventually(t, func() bool { return tp.t.metrics.sendErrors.Load() > 0 }, 5*time.Second, 1*time.Millisecond)
+	assert.Zero(t, tp.t.metrics.sentMsgs.Load())
 
 }
 
@@ -316,6 +327,96 @@ func TestSendInvalidMessageNoPayload(t *testing.T) {
 	assert.Regexp(t, "PD012000", err)
 }
 
+func TestMulticastMessage(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			conf.MulticastConcurrency = confutil.P(2)
+			for _, node := range []string{"node2", "node3", "node4"} {
+				mockEmptyReliableMsgs(mc, conf) // each peer scans for reliable messages
+				mc.registryManager.On("GetNodeTransports", mock.Anything, node).Return([]*components.RegistryNodeTransportEntry{
+					{
+						Node:      node,
+						Transport: "test1",
+						Details:   `{"likely":"json stuff"}`,
+					},
+				}, nil)
+			}
+		})
+	defer done()
+
+	sentMessages := make(chan *prototk.SendMessageRequest, 3)
+	mockActivateDeactivateOk(tp)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		sentMessages <- req
+		return nil, nil
+	}
+
+	multicast := &components.FireAndForgetMulticast{
+		Nodes:         []string{"node2", "node3", "node4"},
+		CorrelationID: confutil.P(uuid.New()),
+		MessageType:   "myMessageType",
+		Payload:       []byte("something"),
+	}
+	results, err := tm.Multicast(ctx, multicast)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for i, r := range results {
+		assert.Equal(t, multicast.Nodes[i], r.Node)
+		assert.NoError(t, r.Error)
+	}
+
+	sentTo := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		sent := <-sentMessages
+		assert.Equal(t, multicast.MessageID.String(), sent.Message.MessageId)
+		assert.Equal(t, multicast.CorrelationID.String(), *sent.Message.CorrelationId)
+		assert.Equal(t, multicast.Payload, sent.Message.Payload)
+		sentTo[sent.Node] = true
+	}
+	assert.Equal(t, map[string]bool{"node2": true, "node3": true, "node4": true}, sentTo)
+}
+
+func TestMulticastMessagePartialFailure(t *testing.T) {
+	ctx, tm, tp, done := newTestTransport(t, false,
+		mockEmptyReliableMsgs,
+		mockGoodTransport,
+		func(mc *mockComponents, conf *pldconf.TransportManagerConfig) {
+			mc.registryManager.On("GetNodeTransports", mock.Anything, "node3").Return(nil, fmt.Errorf("not found"))
+		})
+	defer done()
+
+	mockActivateDeactivateOk(tp)
+	tp.Functions.SendMessage = func(ctx context.Context, req *prototk.SendMessageRequest) (*prototk.SendMessageResponse, error) {
+		return nil, nil
+	}
+
+	multicast := &components.FireAndForgetMulticast{
+		Nodes:       []string{"node2", "node3"},
+		MessageType: "myMessageType",
+		Payload:     []byte("something"),
+	}
+	results, err := tm.Multicast(ctx, multicast)
+	require.NoError(t, err)
+	assert.NoError(t, results[0].Error)
+	assert.Regexp(t, "not found", results[1].Error)
+
+	multicast.RequireAllDelivered = true
+	results, err = tm.Multicast(ctx, multicast)
+	assert.Regexp(t, "PD012029.*1 of 2.*not found", err)
+	assert.Len(t, results, 2)
+}
+
+func TestMulticastInvalidMessage(t *testing.T) {
+	ctx, tm, _, done := newTestTransport(t, false)
+	defer done()
+
+	_, err := tm.Multicast(ctx, &components.FireAndForgetMulticast{Payload: []byte("something")})
+	assert.Regexp(t, "PD012000", err)
+
+	_, err = tm.Multicast(ctx, &components.FireAndForgetMulticast{Nodes: []string{"node2"}})
+	assert.Regexp(t, "PD012000", err)
+}
+
 func TestReceiveMessageTransactionEngine(t *testing.T) {
 	receivedMessages := make(chan *components.ReceivedMessage, 1)
 
@@ -342,6 +443,9 @@ func TestReceiveMessageTransactionEngine(t *testing.T) {
 	assert.NotNil(t, rmr)
 
 	<-receivedMessages
+	stats := tp.t.getStats()
+	assert.Equal(t, uint64(1), stats.ReceivedMsgs)
+	assert.Equal(t, uint64(len(msg.Payload)), stats.ReceivedBytes)
 }
 
 func TestReceiveMessageIdentityResolver(t *testing.T) {
@@ -35,6 +35,7 @@ func (tm *transportManager) initRPC() {
 		Add("transport_localTransportDetails", tm.rpcLocalTransportDetails()).
 		Add("transport_peers", tm.rpcPeers()).
 		Add("transport_peerInfo", tm.rpcPeerInfo()).
+		Add("transport_transportStats", tm.rpcTransportStats()).
 		Add("transport_queryReliableMessages", tm.rpcQueryReliableMessages()).
 		Add("transport_queryReliableMessageAcks", tm.rpcQueryReliableMessageAcks())
 }
@@ -73,6 +74,12 @@ func (tm *transportManager) rpcPeerInfo() rpcserver.RPCHandler {
 	})
 }
 
+func (tm *transportManager) rpcTransportStats() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) ([]*pldapi.TransportStats, error) {
+		return tm.getTransportStats(), nil
+	})
+}
+
 func (tm *transportManager) rpcQueryReliableMessages() rpcserver.RPCHandler {
 	return rpcserver.RPCMethod1(func(ctx context.Context, jq query.QueryJSON) ([]*pldapi.ReliableMessage, error) {
 		return tm.QueryReliableMessages(ctx, tm.persistence.NOTX(), &jq)
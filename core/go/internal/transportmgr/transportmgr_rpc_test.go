@@ -64,6 +64,13 @@ func TestRPCLocalDetails(t *testing.T) {
 	require.NoError(t, rpcErr)
 	assert.Equal(t, "some details", localTransportDetails)
 
+	tp.t.metrics.sentMsgs.Add(1)
+	tp.t.metrics.sentBytes.Add(10)
+	transportStats, rpcErr := transportRPC.TransportStats(ctx)
+	require.NoError(t, rpcErr)
+	require.Len(t, transportStats, 1)
+	assert.Equal(t, &pldapi.TransportStats{Name: tp.t.name, SentMsgs: 1, SentBytes: 10}, transportStats[0])
+
 	_, err := tm.getPeer(ctx, "node2", false)
 	require.NoError(t, err)
 
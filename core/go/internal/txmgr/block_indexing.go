@@ -18,6 +18,7 @@ package txmgr
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/pkg/blockindexer"
@@ -40,6 +41,16 @@ func (tm *txManager) blockIndexerPreCommit(
 		return err
 	}
 
+	// Transactions with a required confirmation depth are only matched once the new blocks take the
+	// chain deep enough on top of the block they were confirmed in
+	if len(blocks) > 0 {
+		finalizedMatches, err := tm.publicTxMgr.MatchFinalizedTransactions(ctx, dbTX, blocks[len(blocks)-1].Number)
+		if err != nil {
+			return err
+		}
+		txMatches = append(txMatches, finalizedMatches...)
+	}
+
 	// Ok now we have an ordered list of completions that match Paladin transactions
 	// - If they are public paladin transactions - just finalize the receipts on this routine
 	// - If they are private paladin transactions - the private TX manager only needs to be
@@ -90,6 +101,52 @@ func (tm *txManager) blockIndexerPreCommit(
 	return nil
 }
 
+func (tm *txManager) blockIndexerReorg(
+	ctx context.Context,
+	dbTX persistence.DBTX,
+	reorg *blockindexer.ReorgEvent,
+) error {
+
+	// Public transactions confirmed in the orphaned blocks go back to pending in the public TX manager
+	reorgedPublicTxs, err := tm.publicTxMgr.MatchRevertReorgedTransactions(ctx, dbTX, reorg.FromBlock)
+	if err != nil {
+		return err
+	}
+
+	// Any receipt recorded against the orphaned blocks is removed - for both public transactions, and for
+	// private transactions confirmed by events in those blocks - along with the state finalizations
+	// that were written with them. These are written again when the transactions confirm on the new chain.
+	var reorgedTxIDs []uuid.UUID
+	err = dbTX.DB().
+		WithContext(ctx).
+		Table("transaction_receipts").
+		Where("block_number >= ?", reorg.FromBlock).
+		Pluck("transaction", &reorgedTxIDs).
+		Error
+	if err == nil && len(reorgedTxIDs) > 0 {
+		log.L(ctx).Infof("Removing %d receipts for transactions confirmed in blocks %d-%d orphaned by reorg", len(reorgedTxIDs), reorg.FromBlock, reorg.ToBlock)
+		err = dbTX.DB().
+			WithContext(ctx).
+			Table("transaction_receipts").
+			Where(`"transaction" IN (?)`, reorgedTxIDs).
+			Delete(nil).
+			Error
+	}
+	if err == nil {
+		err = tm.stateMgr.RevertStateFinalizations(ctx, dbTX, reorgedTxIDs)
+	}
+	if err != nil {
+		return err
+	}
+
+	dbTX.AddPostCommit(func(ctx context.Context) {
+		if len(reorgedPublicTxs) > 0 {
+			tm.publicTxMgr.NotifyReorgPersisted(ctx, reorgedPublicTxs)
+		}
+	})
+	return nil
+}
+
 func (tm *txManager) mapBlockchainReceipt(pubTx *components.PublicTxMatch) *components.ReceiptInput {
 	receipt := &components.ReceiptInput{
 		TransactionID: pubTx.TransactionID,
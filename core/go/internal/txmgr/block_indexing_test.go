@@ -271,6 +271,65 @@ func TestConfirmMatchFAil(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestPublicConfirmFinalizedMatch(t *testing.T) {
+
+	txi := newTestConfirm()
+	txID := uuid.New()
+
+	ctx, txm, done := newTestTransactionManager(t, false,
+		mockEmptyReceiptListeners,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			// The confirmation in this block is held back for its required depth, but an earlier one is now final
+			mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, []*blockindexer.IndexedTransactionNotify{}).
+				Return(nil, nil)
+			mc.publicTxMgr.On("MatchFinalizedTransactions", mock.Anything, mock.Anything, int64(106)).
+				Return([]*components.PublicTxMatch{
+					{
+						PaladinTXReference: components.PaladinTXReference{
+							TransactionID:   txID,
+							TransactionType: pldapi.TransactionTypePublic.Enum(),
+						},
+						IndexedTransactionNotify: txi,
+					},
+				}, nil)
+
+			mc.db.ExpectBegin()
+			mc.db.ExpectQuery("INSERT.*transaction_receipts").WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(12345))
+			mc.db.ExpectCommit()
+
+			mc.publicTxMgr.On("NotifyConfirmPersisted", mock.Anything, mock.MatchedBy(func(matches []*components.PublicTxMatch) bool {
+				return len(matches) == 1 && matches[0].TransactionID == txID
+			}))
+		})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		return txm.blockIndexerPreCommit(ctx, dbTX, []*pldapi.IndexedBlock{{Number: 105}, {Number: 106}},
+			[]*blockindexer.IndexedTransactionNotify{})
+	})
+	require.NoError(t, err)
+}
+
+func TestConfirmFinalizedMatchFail(t *testing.T) {
+
+	ctx, txm, done := newTestTransactionManager(t, false,
+		mockEmptyReceiptListeners,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.db.ExpectBegin()
+			mc.publicTxMgr.On("MatchUpdateConfirmedTransactions", mock.Anything, mock.Anything, []*blockindexer.IndexedTransactionNotify{}).
+				Return(nil, nil)
+			mc.publicTxMgr.On("MatchFinalizedTransactions", mock.Anything, mock.Anything, int64(100)).
+				Return(nil, fmt.Errorf("pop"))
+		})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		return txm.blockIndexerPreCommit(ctx, dbTX, []*pldapi.IndexedBlock{{Number: 100}},
+			[]*blockindexer.IndexedTransactionNotify{})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
 func TestPrivateConfirmError(t *testing.T) {
 
 	txi := newTestConfirm([]byte("revert data"))
@@ -331,3 +390,80 @@ func TestConfirmInsertError(t *testing.T) {
 	})
 	assert.Regexp(t, "pop", err)
 }
+
+func TestBlockIndexerReorgRealDB(t *testing.T) {
+
+	reorgedTxID := uuid.New()
+	keptTxID := uuid.New()
+	reorgedPublicTxs := []*components.PublicTxMatch{
+		{
+			PaladinTXReference: components.PaladinTXReference{
+				TransactionID:   reorgedTxID,
+				TransactionType: pldapi.TransactionTypePublic.Enum(),
+			},
+			IndexedTransactionNotify: newTestConfirm(),
+		},
+	}
+
+	ctx, txm, done := newTestTransactionManager(t, true,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.publicTxMgr.On("MatchRevertReorgedTransactions", mock.Anything, mock.Anything, int64(100)).Return(reorgedPublicTxs, nil)
+			mc.stateMgr.On("RevertStateFinalizations", mock.Anything, mock.Anything, []uuid.UUID{reorgedTxID}).Return(nil)
+			mc.publicTxMgr.On("NotifyReorgPersisted", mock.Anything, reorgedPublicTxs)
+		})
+	defer done()
+
+	err := txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		return txm.FinalizeTransactions(ctx, dbTX, []*components.ReceiptInput{
+			{
+				TransactionID: keptTxID,
+				ReceiptType:   components.RT_Success,
+				OnChain:       pldtypes.OnChainLocation{Type: pldtypes.OnChainTransaction, TransactionHash: pldtypes.RandBytes32(), BlockNumber: 99},
+			},
+			{
+				TransactionID: reorgedTxID,
+				ReceiptType:   components.RT_Success,
+				OnChain:       pldtypes.OnChainLocation{Type: pldtypes.OnChainTransaction, TransactionHash: pldtypes.RandBytes32(), BlockNumber: 100},
+			},
+		})
+	})
+	require.NoError(t, err)
+
+	err = txm.p.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) (err error) {
+		return txm.blockIndexerReorg(ctx, dbTX, &blockindexer.ReorgEvent{FromBlock: 100, ToBlock: 101})
+	})
+	require.NoError(t, err)
+
+	receipt, err := txm.GetTransactionReceiptByID(ctx, reorgedTxID)
+	require.NoError(t, err)
+	assert.Nil(t, receipt)
+
+	receipt, err = txm.GetTransactionReceiptByID(ctx, keptTxID)
+	require.NoError(t, err)
+	assert.NotNil(t, receipt)
+}
+
+func TestBlockIndexerReorgFail(t *testing.T) {
+
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.publicTxMgr.On("MatchRevertReorgedTransactions", mock.Anything, mock.Anything, int64(100)).Return(nil, nil)
+			mc.db.ExpectQuery("SELECT.*transaction_receipts").WillReturnError(fmt.Errorf("pop"))
+		})
+	defer done()
+
+	err := txm.blockIndexerReorg(ctx, txm.p.NOTX(), &blockindexer.ReorgEvent{FromBlock: 100, ToBlock: 101})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockIndexerReorgPublicTxMgrFail(t *testing.T) {
+
+	ctx, txm, done := newTestTransactionManager(t, false, mockEmptyReceiptListeners,
+		func(conf *pldconf.TxManagerConfig, mc *mockComponents) {
+			mc.publicTxMgr.On("MatchRevertReorgedTransactions", mock.Anything, mock.Anything, int64(100)).Return(nil, fmt.Errorf("pop"))
+		})
+	defer done()
+
+	err := txm.blockIndexerReorg(ctx, txm.p.NOTX(), &blockindexer.ReorgEvent{FromBlock: 100, ToBlock: 101})
+	assert.Regexp(t, "pop", err)
+}
@@ -85,6 +85,7 @@ func (tm *txManager) PreInit(c components.PreInitComponents) (*components.Manage
 	return &components.ManagerInitResult{
 		RPCModules:       []*rpcserver.RPCModule{tm.rpcModule, tm.debugRpcModule},
 		PreCommitHandler: tm.blockIndexerPreCommit,
+		ReorgHandler:     tm.blockIndexerReorg,
 	}, nil
 }
 
@@ -98,7 +99,9 @@ func (tm *txManager) PostInit(c components.AllComponents) error {
 	tm.stateMgr = c.StateManager()
 	tm.identityResolver = c.IdentityResolver()
 	tm.blockIndexer = c.BlockIndexer()
-	tm.localNodeName = c.TransportManager().LocalNodeName()
+	if transportMgr, ok := c.TransportManager(); ok {
+		tm.localNodeName = transportMgr.LocalNodeName()
+	}
 
 	err := tm.loadReceiptListeners()
 	return err
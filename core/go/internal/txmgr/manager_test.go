@@ -81,7 +81,7 @@ func newTestTransactionManager(t *testing.T, realDB bool, init ...func(conf *pld
 	componentsmocks.On("StateManager").Return(mc.stateMgr).Maybe()
 	componentsmocks.On("IdentityResolver").Return(mc.identityResolver).Maybe()
 	componentsmocks.On("EthClientFactory").Return(mc.ethClientFactory).Maybe()
-	componentsmocks.On("TransportManager").Return(mc.transportManager).Maybe()
+	componentsmocks.On("TransportManager").Return(mc.transportManager, true).Maybe()
 	mc.transportManager.On("LocalNodeName").Return("node1").Maybe()
 
 	var p persistence.Persistence
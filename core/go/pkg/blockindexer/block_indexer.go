@@ -99,6 +99,8 @@ type blockIndexer struct {
 	batchTimeout               time.Duration
 	txWaiters                  *inflight.InflightManager[pldtypes.Bytes32, *pldapi.IndexedTransaction]
 	preCommitHandlers          []PreCommitHandler
+	reorgHandlers              []ReorgHandler
+	lastIndexedBlock           *pldapi.IndexedBlock // only accessed on the dispatcher routine, once started
 	eventStreams               map[uuid.UUID]*eventStream
 	eventStreamsHeadSet        map[uuid.UUID]*eventStream
 	eventStreamsLock           sync.Mutex
@@ -161,6 +163,8 @@ func (bi *blockIndexer) Start(internalStreams ...*InternalEventStream) error {
 			}
 		case IESTypePreCommitHandler:
 			bi.preCommitHandlers = append(bi.preCommitHandlers, ies.PreCommitHandler)
+		case IESTypeReorgHandler:
+			bi.reorgHandlers = append(bi.reorgHandlers, ies.ReorgHandler)
 		}
 	}
 	bi.blockListener.start()
@@ -311,8 +315,10 @@ func (bi *blockIndexer) restoreCheckpoint() error {
 		nextBlock := ethtypes.HexUint64(blocks[0].Number + 1)
 		bi.nextBlock = &nextBlock
 		bi.highestConfirmedBlock.Store(blocks[0].Number)
+		bi.lastIndexedBlock = blocks[0]
 	default:
 		bi.nextBlock = bi.fromBlock
+		bi.lastIndexedBlock = nil
 	}
 	return nil
 }
@@ -483,6 +489,16 @@ func (bi *blockIndexer) dispatcher(ctx context.Context) {
 					return // We know we need to exit
 				}
 			}
+			// Check the batch still builds on the chain we have already indexed
+			reorg, err := bi.detectReorg(ctx, batch.blocks[0])
+			if err != nil {
+				log.L(ctx).Debugf("Confirmed block dispatcher stopping during reorg detection: %s", err)
+				return
+			}
+			if reorg != nil {
+				go bi.rewindForReorg(reorg)
+				return // We know we need to exit
+			}
 			bi.writeBatch(ctx, batch)
 			// Write the batch
 			batch = nil
@@ -637,6 +653,9 @@ func (bi *blockIndexer) writeBatch(ctx context.Context, batch *blockWriterBatch)
 	if newHighestBlock >= 0 {
 		bi.highestConfirmedBlock.Store(newHighestBlock)
 	}
+	if err == nil && len(blocks) > 0 {
+		bi.lastIndexedBlock = blocks[len(blocks)-1]
+	}
 	if err == nil {
 		for _, t := range transactions {
 			if inflight := bi.txWaiters.GetInflight(t.Hash); inflight != nil {
@@ -994,8 +1013,9 @@ func (bi *blockIndexer) QueryIndexedBlocks(ctx context.Context, jq *query.QueryJ
 	if jq.Limit == nil || *jq.Limit == 0 {
 		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerLimitRequired)
 	}
-	db := bi.persistence.DB()
-	q := db.Table("indexed_blocks").WithContext(ctx)
+	db, cancel := persistence.QueryContext(ctx, bi.persistence.DB())
+	defer cancel()
+	q := db.Table("indexed_blocks")
 	if jq != nil {
 		q = filters.BuildGORM(ctx, jq, q, IndexedBlockFilters)
 	}
@@ -1009,8 +1029,9 @@ func (bi *blockIndexer) QueryIndexedTransactions(ctx context.Context, jq *query.
 	if jq.Limit == nil || *jq.Limit == 0 {
 		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerLimitRequired)
 	}
-	db := bi.persistence.DB()
-	q := db.Table("indexed_transactions").Joins("Block").WithContext(ctx)
+	db, cancel := persistence.QueryContext(ctx, bi.persistence.DB())
+	defer cancel()
+	q := db.Table("indexed_transactions").Joins("Block")
 	if jq != nil {
 		q = filters.BuildGORM(ctx, jq, q, IndexedTransactionFilters)
 	}
@@ -1024,8 +1045,9 @@ func (bi *blockIndexer) QueryIndexedEvents(ctx context.Context, jq *query.QueryJ
 	if jq.Limit == nil || *jq.Limit == 0 {
 		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerLimitRequired)
 	}
-	db := bi.persistence.DB()
-	q := db.Table("indexed_events").Joins("Block").WithContext(ctx)
+	db, cancel := persistence.QueryContext(ctx, bi.persistence.DB())
+	defer cancel()
+	q := db.Table("indexed_events").Joins("Block")
 	if jq != nil {
 		q = filters.BuildGORM(ctx, jq, q, IndexedEventFilters)
 	}
@@ -0,0 +1,128 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+)
+
+// Blocks are only indexed once they have the required number of confirmations, so the block listener
+// handles re-orgs at the head of the chain without any impact on the index. However, if the chain
+// reorganizes deeper than that, the next block we are about to write will not build on the last block
+// we indexed. In that case we walk back through the index to find the highest block that is still
+// on the canonical chain, and return the range of indexed blocks that were orphaned.
+func (bi *blockIndexer) detectReorg(ctx context.Context, nextBlock *BlockInfoJSONRPC) (reorg *ReorgEvent, err error) {
+	lastIndexed := bi.lastIndexedBlock
+	if lastIndexed == nil || int64(nextBlock.Number) != lastIndexed.Number+1 || bytes.Equal(nextBlock.ParentHash, lastIndexed.Hash[:]) {
+		return nil, nil
+	}
+
+	log.L(ctx).Warnf("Block %d/%s does not build on indexed block %d/%s (parentHash=%s)",
+		nextBlock.Number, nextBlock.Hash, lastIndexed.Number, lastIndexed.Hash, nextBlock.ParentHash)
+	reorg = &ReorgEvent{FromBlock: lastIndexed.Number, ToBlock: lastIndexed.Number}
+	err = bi.retry.Do(ctx, func(attempt int) (retryable bool, err error) {
+		for reorg.FromBlock > 0 {
+			indexed, err := bi.GetIndexedBlockByNumber(ctx, uint64(reorg.FromBlock-1))
+			if err != nil {
+				return true, err
+			}
+			if indexed == nil {
+				// We have reached the start of our index
+				break
+			}
+			canonical, err := bi.blockListener.getBlockInfoByNumber(ctx, ethtypes.HexUint64(indexed.Number))
+			if err != nil {
+				return true, err
+			}
+			if canonical != nil && bytes.Equal(canonical.Hash, indexed.Hash[:]) {
+				break
+			}
+			reorg.FromBlock--
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reorg, nil
+}
+
+// Removes the orphaned blocks from the index, giving the reorg handlers the chance to roll back anything
+// they recorded against them in the same DB transaction, and rewinds any event stream that had processed
+// past the fork. Then restarts indexing from the fork point on the new canonical chain.
+func (bi *blockIndexer) rewindForReorg(reorg *ReorgEvent) {
+	ctx := bi.parentCtxForReset
+	log.L(ctx).Warnf("Block indexer rewinding for reorg of indexed blocks %d-%d", reorg.FromBlock, reorg.ToBlock)
+
+	// The dispatcher and event streams must be stopped, so nothing is written past the point we rewind to
+	bi.Stop()
+
+	err := bi.retry.Do(ctx, func(attempt int) (retryable bool, err error) {
+		return true, bi.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+			return bi.rewindIndex(ctx, dbTX, reorg)
+		})
+	})
+	if err != nil {
+		log.L(ctx).Warnf("Block indexer context cancelled during reorg rewind: %s", err)
+		return
+	}
+	bi.highestConfirmedBlock.Store(reorg.FromBlock - 1)
+
+	bi.startOrReset()
+	bi.startEventStreams()
+}
+
+func (bi *blockIndexer) rewindIndex(ctx context.Context, dbTX persistence.DBTX, reorg *ReorgEvent) (err error) {
+	// Handlers are called before the index is removed, so they can use it to find what was recorded in the orphaned blocks
+	for _, reorgHandler := range bi.reorgHandlers {
+		if err == nil {
+			err = reorgHandler(ctx, dbTX, reorg)
+		}
+	}
+	for _, table := range []string{"indexed_events", "indexed_transactions"} {
+		if err == nil {
+			err = dbTX.DB().
+				WithContext(ctx).
+				Table(table).
+				Where("block_number >= ?", reorg.FromBlock).
+				Delete(nil).
+				Error
+		}
+	}
+	if err == nil {
+		err = dbTX.DB().
+			WithContext(ctx).
+			Table("indexed_blocks").
+			Where("number >= ?", reorg.FromBlock).
+			Delete(nil).
+			Error
+	}
+	if err == nil {
+		err = dbTX.DB().
+			WithContext(ctx).
+			Table("event_stream_checkpoints").
+			Where("block_number >= ?", reorg.FromBlock).
+			Update("block_number", reorg.FromBlock-1).
+			Error
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockindexer
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/core/pkg/persistence"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldapi"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockIndexerRewindsReorgOfConfirmedBlocks(t *testing.T) {
+	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	// No confirmations, so the reorg reaches blocks that are already indexed
+	bi.requiredConfirmations = 0
+
+	// The new chain keeps the first two blocks, and replaces everything from block 2
+	chainA, receipts := testBlockArray(t, 5)
+	chainB, receiptsB := testBlockArray(t, 8)
+	for hash, r := range receiptsB {
+		receipts[hash] = r
+	}
+	chainB[0], chainB[1] = chainA[0], chainA[1]
+	chainB[2].ParentHash = chainA[1].Hash
+
+	var reorged atomic.Bool
+	mockBlocksRPCCallsDynamic(mRPC, func(args mock.Arguments) ([]*BlockInfoJSONRPC, map[string][]*TXReceiptJSONRPC) {
+		if reorged.Load() {
+			return chainB, receipts
+		}
+		return chainA, receipts
+	})
+
+	// An event stream that has processed up to the head of the old chain
+	esID := uuid.New()
+	err := bi.persistence.DB().Exec(`INSERT INTO "event_streams" ("id", "created", "updated", "type", "name", "config", "sources", "format", "started") VALUES (?, 0, 0, 'internal', 'es1', '{}', '[]', '', false)`, esID).Error
+	require.NoError(t, err)
+	err = bi.persistence.DB().Table("event_stream_checkpoints").Create(&EventStreamCheckpoint{Stream: esID, BlockNumber: 4}).Error
+	require.NoError(t, err)
+
+	reorgEvents := make(chan *ReorgEvent, 1)
+	bi.reorgHandlers = append(bi.reorgHandlers, func(ctx context.Context, dbTX persistence.DBTX, reorg *ReorgEvent) error {
+		// The orphaned blocks are still in the index when the handler is called
+		var count int64
+		err := dbTX.DB().Table("indexed_transactions").Where("block_number >= ?", reorg.FromBlock).Count(&count).Error
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		reorgEvents <- reorg
+		return nil
+	})
+
+	utBatchNotify := make(chan []*pldapi.IndexedBlock)
+	addBlockPostCommit(bi, func(blocks []*pldapi.IndexedBlock) { utBatchNotify <- blocks })
+
+	bi.startOrReset() // do not start block listener
+
+	for i := 0; i < len(chainA); i++ {
+		notifiedBlocks := <-utBatchNotify
+		checkIndexedBlockEqual(t, chainA[i], notifiedBlocks[0])
+	}
+
+	// Switch to the new chain, and notify the next block on it
+	reorged.Store(true)
+	bi.blockListener.notifyBlock(chainB[5])
+
+	reorg := <-reorgEvents
+	assert.Equal(t, &ReorgEvent{FromBlock: 2, ToBlock: 4}, reorg)
+
+	// The indexer rewinds to the fork, and indexes the new chain
+	for i := 2; i < len(chainB); i++ {
+		notifiedBlocks := <-utBatchNotify
+		checkIndexedBlockEqual(t, chainB[i], notifiedBlocks[0])
+	}
+	for i := 0; i < len(chainB); i++ {
+		indexedBlock, err := bi.GetIndexedBlockByNumber(ctx, uint64(i))
+		require.NoError(t, err)
+		checkIndexedBlockEqual(t, chainB[i], indexedBlock)
+	}
+	for i := 2; i < len(chainA); i++ {
+		indexedTX, err := bi.GetIndexedTransactionByHash(ctx, pldtypes.Bytes32(receipts[chainA[i].Hash.String()][0].TransactionHash))
+		require.NoError(t, err)
+		assert.Nil(t, indexedTX, fmt.Sprintf("orphaned transaction in block %d still indexed", i))
+	}
+
+	var checkpoints []*EventStreamCheckpoint
+	err = bi.persistence.DB().Table("event_stream_checkpoints").Where("stream = ?", esID).Find(&checkpoints).Error
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 1)
+	assert.Equal(t, int64(1), checkpoints[0].BlockNumber)
+}
+
+func TestBlockIndexerRewindHandlerError(t *testing.T) {
+	ctx, bi, _, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	bi.reorgHandlers = append(bi.reorgHandlers, func(ctx context.Context, dbTX persistence.DBTX, reorg *ReorgEvent) error {
+		return fmt.Errorf("pop")
+	})
+	err := bi.persistence.Transaction(ctx, func(ctx context.Context, dbTX persistence.DBTX) error {
+		return bi.rewindIndex(ctx, dbTX, &ReorgEvent{FromBlock: 1, ToBlock: 1})
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestBlockIndexerDetectReorgCancelled(t *testing.T) {
+	ctx, bi, mRPC, blDone := newTestBlockIndexer(t)
+	defer blDone()
+
+	blocks, receipts := testBlockArray(t, 3)
+	mockBlocksRPCCalls(mRPC, blocks, receipts)
+	err := bi.persistence.DB().Table("indexed_blocks").Create([]*pldapi.IndexedBlock{
+		{Number: 0, Hash: pldtypes.RandBytes32()},
+		{Number: 1, Hash: pldtypes.RandBytes32()},
+	}).Error
+	require.NoError(t, err)
+	bi.lastIndexedBlock = &pldapi.IndexedBlock{Number: 1, Hash: pldtypes.RandBytes32()}
+
+	// Block 0 does not match either, so we walk back to the start of the index
+	reorg, err := bi.detectReorg(ctx, blocks[2])
+	require.NoError(t, err)
+	assert.Equal(t, &ReorgEvent{FromBlock: 0, ToBlock: 1}, reorg)
+
+	cancelled, cancelCtx := context.WithCancel(ctx)
+	cancelCtx()
+	_, err = bi.detectReorg(cancelled, blocks[2])
+	assert.Error(t, err)
+}
@@ -132,6 +132,17 @@ type EventDeliveryBatch struct {
 
 type PreCommitHandler func(ctx context.Context, dbTX persistence.DBTX, blocks []*pldapi.IndexedBlock, transactions []*IndexedTransactionNotify) error
 
+// A reorg affecting blocks that were already indexed as confirmed. This only happens if the chain
+// reorganizes deeper than the required confirmations of the block indexer.
+type ReorgEvent struct {
+	FromBlock int64 // the first indexed block that is no longer on the canonical chain
+	ToBlock   int64 // the highest indexed block that is no longer on the canonical chain
+}
+
+// Called within the database transaction that removes the orphaned blocks from the index,
+// so that other components can roll back anything they recorded against those blocks.
+type ReorgHandler func(ctx context.Context, dbTX persistence.DBTX, reorg *ReorgEvent) error
+
 type InternalStreamCallbackDBTX func(ctx context.Context, dbTX persistence.DBTX, batch *EventDeliveryBatch) error
 
 type InternalStreamCallbackNOTX func(ctx context.Context, batch *EventDeliveryBatch) error
@@ -152,6 +163,10 @@ const (
 	// Errors from this function rollback the DB transaction, and hence stall the block indexer.
 	// Can return a post-commit handler to be run after the DB transaction commits
 	IESTypePreCommitHandler
+
+	// An in-line callback that is fired WITHIN the database transaction the block indexer uses to remove blocks
+	// orphaned by a reorg. Errors from this function rollback the DB transaction, and the rewind is retried.
+	IESTypeReorgHandler
 )
 
 type InternalEventStream struct {
@@ -160,4 +175,5 @@ type InternalEventStream struct {
 	HandlerDBTX      InternalStreamCallbackDBTX
 	HandlerNOTX      InternalStreamCallbackNOTX
 	PreCommitHandler PreCommitHandler
+	ReorgHandler     ReorgHandler
 }
@@ -312,9 +312,10 @@ func (bi *blockIndexer) QueryEventStreamDefinitions(ctx context.Context, dbTX pe
 	if jq == nil || jq.Limit == nil || *jq.Limit == 0 {
 		return nil, i18n.NewError(ctx, msgs.MsgBlockIndexerLimitRequired)
 	}
-	q := dbTX.DB().
+	db, cancel := persistence.QueryContext(ctx, dbTX.DB())
+	defer cancel()
+	q := db.
 		Table("event_streams").
-		WithContext(ctx).
 		Where("type = ?", esType)
 
 	q = filters.BuildGORM(ctx, jq, q, EventStreamFilters)
@@ -26,12 +26,19 @@ import (
 	"github.com/kaleido-io/paladin/core/internal/componentmgr"
 	"github.com/kaleido-io/paladin/core/internal/components"
 	"github.com/kaleido-io/paladin/core/mocks/componentmgrmocks"
+	"github.com/kaleido-io/paladin/core/mocks/ethclientmocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+func newMockEthClientFactory(t *testing.T) *ethclientmocks.EthClientFactory {
+	mECF := ethclientmocks.NewEthClientFactory(t)
+	mECF.On("StopOnChainIDChange", mock.Anything).Return()
+	return mECF
+}
+
 func setupTestConfig(t *testing.T, mockers ...func(mockCM *componentmgrmocks.ComponentManager)) (socketFile, loaderUUID, configFile string, done func()) {
 	id := uuid.New()
 	origCMFactory := componentManagerFactory
@@ -46,7 +53,8 @@ func setupTestConfig(t *testing.T, mockers ...func(mockCM *componentmgrmocks.Com
 	}
 	configFile = path.Join(t.TempDir(), "paladin.conf.yaml")
 	err := os.WriteFile(configFile, []byte(`{
-	  "blockchain": { "http": { "url": "http://localhost:8545" } }
+	  "blockchain": { "http": { "url": "http://localhost:8545" } },
+	  "db": { "sqlite": { "dsn": ":memory:" } }
 	}`), 0664)
 	require.NoError(t, err)
 	return path.Join(t.TempDir(), "socket.file"), id.String(), configFile, func() {
@@ -59,6 +67,7 @@ func TestEntrypointOK(t *testing.T) {
 	cmStarted := make(chan struct{})
 	socketFile, loaderUUID, configFile, done := setupTestConfig(t, func(mockCM *componentmgrmocks.ComponentManager) {
 		mockCM.On("Init").Return(nil)
+		mockCM.On("EthClientFactory").Return(newMockEthClientFactory(t))
 		mockCM.On("StartManagers").Return(nil)
 		mockCM.On("CompleteStart").Return(nil).Run(func(args mock.Arguments) {
 			close(cmStarted)
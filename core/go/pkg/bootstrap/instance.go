@@ -17,6 +17,7 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"strconv"
@@ -97,6 +98,9 @@ func (i *instance) run() RC {
 		log.L(i.ctx).Error(err.Error())
 		return RC_FAIL
 	}
+	if !i.validateConfig(&conf) {
+		return RC_FAIL
+	}
 
 	var additionalManagers []components.AdditionalManager
 	switch i.runMode {
@@ -115,6 +119,8 @@ func (i *instance) run() RC {
 	// Start it up
 	err = cm.Init()
 	if err == nil {
+		// If the blockchain connection is ever re-established to a different chain, we must not continue
+		cm.EthClientFactory().StopOnChainIDChange(i.cancelCtx)
 		// Managers start first - so they are ready to process
 		err = cm.StartManagers()
 	}
@@ -135,6 +141,31 @@ func (i *instance) run() RC {
 	return RC_OK
 }
 
+// validateConfig reports all errors in the config to stderr, rather than letting them
+// fall back to defaults. Unknown keys are only warnings, so that a config written for
+// a newer version of Paladin can still be loaded.
+func (i *instance) validateConfig(conf *pldconf.PaladinConfig) bool {
+	if data, err := os.ReadFile(i.configFile); err == nil {
+		warnings, err := componentmgr.CheckUnknownConfigKeys(i.ctx, data)
+		if err != nil {
+			log.L(i.ctx).Warnf("Unable to check config for unknown keys: %s", err)
+		}
+		for _, w := range warnings {
+			log.L(i.ctx).Warnf("Config warning %s", w)
+		}
+	}
+
+	errors := componentmgr.ValidateConfig(conf)
+	if len(errors) == 0 {
+		return true
+	}
+	fmt.Fprintln(os.Stderr, i18n.NewError(i.ctx, msgs.MsgConfigValidationFailed, i.configFile))
+	for _, e := range errors {
+		fmt.Fprintf(os.Stderr, "  %s\n", e)
+	}
+	return false
+}
+
 func (i *instance) stop() {
 	if i.stopped.CompareAndSwap(false, true) {
 		i.cancelCtx()
@@ -17,6 +17,7 @@ package bootstrap
 
 import (
 	"fmt"
+	"os"
 	"path"
 	"syscall"
 	"testing"
@@ -24,6 +25,7 @@ import (
 	"github.com/kaleido-io/paladin/core/mocks/componentmgrmocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSignalHandlerStop(t *testing.T) {
@@ -31,6 +33,7 @@ func TestSignalHandlerStop(t *testing.T) {
 	cmStarted := make(chan struct{})
 	socketFile, loaderUUID, configFile, done := setupTestConfig(t, func(mockCM *componentmgrmocks.ComponentManager) {
 		mockCM.On("Init").Return(nil)
+		mockCM.On("EthClientFactory").Return(newMockEthClientFactory(t))
 		mockCM.On("StartManagers").Return(nil)
 		mockCM.On("CompleteStart").Return(nil).Run(func(args mock.Arguments) {
 			close(cmStarted)
@@ -79,6 +82,7 @@ func TestComponentManagerStartFail(t *testing.T) {
 
 	socketFile, loaderUUID, configFile, done := setupTestConfig(t, func(mockCM *componentmgrmocks.ComponentManager) {
 		mockCM.On("Init").Return(nil)
+		mockCM.On("EthClientFactory").Return(newMockEthClientFactory(t))
 		mockCM.On("StartManagers").Return(nil)
 		mockCM.On("CompleteStart").Return(fmt.Errorf("pop"))
 		mockCM.On("Stop").Return()
@@ -88,3 +92,20 @@ func TestComponentManagerStartFail(t *testing.T) {
 	Run(socketFile, loaderUUID, configFile, "engine")
 
 }
+
+func TestInvalidConfig(t *testing.T) {
+
+	socketFile, loaderUUID, _, done := setupTestConfig(t)
+	defer done()
+
+	configFile := path.Join(t.TempDir(), "paladin.conf.yaml")
+	err := os.WriteFile(configFile, []byte(`{
+	  "unknownKey": true,
+	  "blockIndexer": { "commitBatchTimeout": "wrong" }
+	}`), 0664)
+	require.NoError(t, err)
+
+	rc := Run(socketFile, loaderUUID, configFile, "engine")
+	assert.Equal(t, RC_FAIL, rc)
+
+}
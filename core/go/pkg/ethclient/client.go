@@ -46,11 +46,17 @@ type EthClient interface {
 
 	GasPrice(ctx context.Context) (gasPrice *pldtypes.HexUint256, err error)
 	GetBalance(ctx context.Context, address pldtypes.EthAddress, block string) (balance *pldtypes.HexUint256, err error)
+	GetStorageProof(ctx context.Context, address pldtypes.EthAddress, storageKeys []pldtypes.Bytes32, block string) (proof *StorageProof, err error)
 
 	EstimateGasNoResolve(ctx context.Context, tx *ethsigner.Transaction, opts ...CallOption) (res EstimateGasResult, err error)
 	CallContractNoResolve(ctx context.Context, tx *ethsigner.Transaction, block string, opts ...CallOption) (res CallResult, err error)
+	CallContractABI(ctx context.Context, contractAddress pldtypes.EthAddress, a *abi.ABI, method string, inputs map[string]any, block string) (map[string]any, error)
 	GetTransactionCount(ctx context.Context, fromAddr pldtypes.EthAddress) (transactionCount *pldtypes.HexUint64, err error)
+	// Includes transactions from the address that are in the node's mempool, but not yet mined
+	GetPendingTransactionCount(ctx context.Context, fromAddr pldtypes.EthAddress) (transactionCount *pldtypes.HexUint64, err error)
 	SendRawTransaction(ctx context.Context, rawTX pldtypes.HexBytes) (*pldtypes.Bytes32, error)
+
+	GetConnectionStats() *ConnectionStats
 }
 
 // Higher level client interface to the base Ethereum ledger for TX submission.
@@ -144,6 +150,7 @@ type ethClient struct {
 	gasEstimateFactor float64
 	rpc               rpcclient.Client
 	keymgr            KeyManager
+	stats             connectionStats
 }
 
 // A direct creation of a dedicated RPC client for things like unit tests outside of Paladin.
@@ -157,6 +164,7 @@ func WrapRPCClient(ctx context.Context, keymgr KeyManager, rpc rpcclient.Client,
 	if err := ec.setupChainID(ctx); err != nil {
 		return nil, err
 	}
+	ec.stats.connectedSince = pldtypes.TimestampNow()
 	return ec, nil
 }
 
@@ -189,7 +197,7 @@ func (ec *ethClient) ChainID() int64 {
 
 func (ec *ethClient) setupChainID(ctx context.Context) error {
 	var chainID ethtypes.HexUint64
-	if rpcErr := ec.rpc.CallRPC(ctx, &chainID, "eth_chainId"); rpcErr != nil {
+	if rpcErr := ec.callRPC(ctx, &chainID, "eth_chainId"); rpcErr != nil {
 		log.L(ctx).Errorf("eth_chainId failed: %+v", rpcErr)
 		return i18n.WrapError(ctx, rpcErr, msgs.MsgEthClientChainIDFailed)
 	}
@@ -244,7 +252,7 @@ func (ec *ethClient) CallContractNoResolve(ctx context.Context, tx *ethsigner.Tr
 			res.serializer = co.serializer
 		}
 	}
-	if err := ec.rpc.CallRPC(ctx, &res.Data, "eth_call", tx, block); err != nil {
+	if err := ec.callRPC(ctx, &res.Data, "eth_call", tx, block); err != nil {
 		rpcErr := err.RPCError()
 		log.L(ctx).Errorf("eth_call failed: %+v", rpcErr)
 		if len(rpcErr.Data) != 0 {
@@ -273,19 +281,32 @@ func (ec *ethClient) CallContractNoResolve(ctx context.Context, tx *ethsigner.Tr
 func (ec *ethClient) GetBalance(ctx context.Context, address pldtypes.EthAddress, block string) (*pldtypes.HexUint256, error) {
 	var addressBalance pldtypes.HexUint256
 
-	if rpcErr := ec.rpc.CallRPC(ctx, &addressBalance, "eth_getBalance", address, block); rpcErr != nil {
+	if rpcErr := ec.callRPC(ctx, &addressBalance, "eth_getBalance", address, block); rpcErr != nil {
 		log.L(ctx).Errorf("eth_getBalance failed: %+v", rpcErr)
 		return nil, rpcErr
 	}
 	return &addressBalance, nil
 }
 
+func (ec *ethClient) GetStorageProof(ctx context.Context, address pldtypes.EthAddress, storageKeys []pldtypes.Bytes32, block string) (*StorageProof, error) {
+	var proof StorageProof
+
+	if storageKeys == nil {
+		storageKeys = []pldtypes.Bytes32{}
+	}
+	if rpcErr := ec.callRPC(ctx, &proof, "eth_getProof", address, storageKeys, block); rpcErr != nil {
+		log.L(ctx).Errorf("eth_getProof failed: %+v", rpcErr)
+		return nil, rpcErr
+	}
+	return &proof, nil
+}
+
 func (ec *ethClient) GasPrice(ctx context.Context) (*pldtypes.HexUint256, error) {
 	// currently only support London style gas price
 	// For EIP1559, will need to add support for `eth_maxPriorityFeePerGas`
 	var gasPrice pldtypes.HexUint256
 
-	if rpcErr := ec.rpc.CallRPC(ctx, &gasPrice, "eth_gasPrice"); rpcErr != nil {
+	if rpcErr := ec.callRPC(ctx, &gasPrice, "eth_gasPrice"); rpcErr != nil {
 		log.L(ctx).Errorf("eth_gasPrice failed: %+v", rpcErr)
 		return nil, rpcErr
 	}
@@ -300,7 +321,7 @@ func (ec *ethClient) EstimateGas(ctx context.Context, from *string, tx *ethsigne
 }
 
 func (ec *ethClient) EstimateGasNoResolve(ctx context.Context, tx *ethsigner.Transaction, opts ...CallOption) (res EstimateGasResult, err error) {
-	if err = ec.rpc.CallRPC(ctx, &res.GasLimit, "eth_estimateGas", tx); err != nil {
+	if err = ec.callRPC(ctx, &res.GasLimit, "eth_estimateGas", tx); err != nil {
 		log.L(ctx).Errorf("eth_estimateGas failed: %+v", err)
 		// Fall back to a call, to see if we can get an error
 		callRes, callErr := ec.CallContractNoResolve(ctx, tx, "latest", opts...)
@@ -314,9 +335,17 @@ func (ec *ethClient) EstimateGasNoResolve(ctx context.Context, tx *ethsigner.Tra
 }
 
 func (ec *ethClient) GetTransactionCount(ctx context.Context, fromAddr pldtypes.EthAddress) (*pldtypes.HexUint64, error) {
+	return ec.getTransactionCount(ctx, fromAddr, "latest")
+}
+
+func (ec *ethClient) GetPendingTransactionCount(ctx context.Context, fromAddr pldtypes.EthAddress) (*pldtypes.HexUint64, error) {
+	return ec.getTransactionCount(ctx, fromAddr, "pending")
+}
+
+func (ec *ethClient) getTransactionCount(ctx context.Context, fromAddr pldtypes.EthAddress, block string) (*pldtypes.HexUint64, error) {
 	var transactionCount pldtypes.HexUint64
-	if rpcErr := ec.rpc.CallRPC(ctx, &transactionCount, "eth_getTransactionCount", fromAddr, "latest"); rpcErr != nil {
-		log.L(ctx).Errorf("eth_getTransactionCount(%s) failed: %+v", fromAddr, rpcErr)
+	if rpcErr := ec.callRPC(ctx, &transactionCount, "eth_getTransactionCount", fromAddr, block); rpcErr != nil {
+		log.L(ctx).Errorf("eth_getTransactionCount(%s,%s) failed: %+v", fromAddr, block, rpcErr)
 		return nil, rpcErr
 	}
 	return &transactionCount, nil
@@ -396,7 +425,7 @@ func (ec *ethClient) SendRawTransaction(ctx context.Context, rawTX pldtypes.HexB
 
 	// Submit
 	var txHash pldtypes.Bytes32
-	if rpcErr := ec.rpc.CallRPC(ctx, &txHash, "eth_sendRawTransaction", pldtypes.HexBytes(rawTX)); rpcErr != nil {
+	if rpcErr := ec.callRPC(ctx, &txHash, "eth_sendRawTransaction", pldtypes.HexBytes(rawTX)); rpcErr != nil {
 		addr, decodedTX, err := ethsigner.RecoverRawTransaction(ctx, ethtypes.HexBytes0xPrefix(rawTX), ec.chainID)
 		if err != nil {
 			log.L(ctx).Errorf("Invalid transaction build during signing: %s", err)
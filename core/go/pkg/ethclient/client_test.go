@@ -108,6 +108,40 @@ func TestGetBalanceFail(t *testing.T) {
 
 }
 
+func TestGetStorageProof(t *testing.T) {
+	proof, stateRoot := newTestStorageProof(t)
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_getProof: func(ctx context.Context, addr pldtypes.EthAddress, keys []pldtypes.Bytes32, block string) (*StorageProof, error) {
+			assert.Equal(t, proof.Address, addr)
+			assert.Len(t, keys, 2)
+			assert.Equal(t, "latest", block)
+			return proof, nil
+		},
+	})
+	defer done()
+
+	res, err := ec.HTTPClient().GetStorageProof(ctx, proof.Address, []pldtypes.Bytes32{
+		pldtypes.Bytes32(slotKey(3)), pldtypes.Bytes32(slotKey(17)),
+	}, "latest")
+	require.NoError(t, err)
+	assert.True(t, VerifyStorageProof(res, stateRoot))
+
+}
+
+func TestGetStorageProofFail(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_getProof: func(ctx context.Context, addr pldtypes.EthAddress, keys []pldtypes.Bytes32, block string) (*StorageProof, error) {
+			assert.Empty(t, keys)
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	defer done()
+
+	_, err := ec.HTTPClient().GetStorageProof(ctx, *pldtypes.RandAddress(), nil, "latest")
+	assert.Regexp(t, "pop", err)
+
+}
+
 func TestGasPrice(t *testing.T) {
 	gasPriceHexInt := (*pldtypes.HexUint256)(big.NewInt(200000))
 	ctx, ec, done := newTestClientAndServer(t, &mockEth{
@@ -181,6 +215,26 @@ func TestGetTransactionCount(t *testing.T) {
 
 }
 
+func TestGetPendingTransactionCount(t *testing.T) {
+	ctx, ec, done := newTestClientAndServer(t, &mockEth{
+		eth_getTransactionCount: func(ctx context.Context, addr pldtypes.EthAddress, block string) (pldtypes.HexUint64, error) {
+			if block == "pending" {
+				return 12, nil
+			}
+			return 10, nil
+		},
+	})
+	defer done()
+
+	addr := *pldtypes.MustEthAddress("0x1d0cD5b99d2E2a380e52b4000377Dd507c6df754")
+	txCount, err := ec.HTTPClient().GetPendingTransactionCount(ctx, addr)
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(12), *txCount)
+	txCount, err = ec.HTTPClient().GetTransactionCount(ctx, addr)
+	require.NoError(t, err)
+	assert.Equal(t, pldtypes.HexUint64(10), *txCount)
+}
+
 func TestGetTransactionCountFail(t *testing.T) {
 	ctx, ec, done := newTestClientAndServer(t, &mockEth{
 		eth_getTransactionCount: func(ctx context.Context, addr pldtypes.EthAddress, block string) (pldtypes.HexUint64, error) {
@@ -17,12 +17,20 @@ package ethclient
 
 import (
 	"context"
+	"net"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/core/internal/msgs"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/wsclient"
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 )
 
 // Allows separate components to maintain separate connections/connection-pools to the
@@ -35,9 +43,15 @@ type EthClientFactoryBase interface {
 
 type EthClientFactory interface {
 	EthClientFactoryBase
-	HTTPClient() EthClient     // HTTP client
-	SharedWS() EthClient       // WS client with a single long lived socket shared across multiple components
-	NewWS() (EthClient, error) // created a dedicated socket - which the caller responsible for closing
+	RPCModule() *rpcserver.RPCModule        // exposes connection diagnostics
+	HTTPClient() EthClient                  // HTTP client
+	SharedWS() EthClient                    // WS client with a single long lived socket shared across multiple components
+	NewWS() (EthClient, error)              // created a dedicated socket - which the caller responsible for closing
+	WSPool(size int) (EthClientPool, error) // a fixed size pool of sockets - which the caller is responsible for closing
+	// Registers a function to stop the node if a WebSocket reconnects to a different chain, which must be called before Start
+	StopOnChainIDChange(cancelCtx context.CancelFunc)
+	// Delivers each new block header to ch, from a single newHeads subscription on the shared WS that is restored after reconnect
+	SubscribeNewHeads(ctx context.Context, ch chan<- *BlockInfo) (UnsubscribeFn, error)
 }
 
 type EthClientFactoryWithKeyManager interface {
@@ -58,9 +72,18 @@ type ethClientFactory struct {
 
 	sharedWSClient *ethClient
 
-	wsConf *pldconf.WSClientConfig
+	newHeadsLock sync.Mutex
+	newHeads     *newHeadsDispatcher
+
+	wsConf             *pldconf.WSClientConfig
+	dnsRefreshInterval time.Duration
+	dnsResolver        wsclient.HostResolver
 
 	chainID int64
+	// called to stop the node if a WS reconnect finds a different chain ID
+	cancelCtx context.CancelFunc
+
+	rpcModule *rpcserver.RPCModule
 }
 
 type ethClientFactoryKeyManagerWrapper struct {
@@ -89,7 +112,12 @@ func newEthClientFactory(bgCtx context.Context, keymgr KeyManager, conf *pldconf
 		conf:    conf,
 		keymgr:  keymgr,
 		chainID: -1,
+		// The HTTP client picks up DNS changes implicitly as Go's transport dials new connections,
+		// but the WS client holds a long-lived connection so needs to explicitly re-resolve
+		dnsRefreshInterval: confutil.DurationMin(conf.DNSRefreshInterval, 0, "0"),
+		dnsResolver:        net.DefaultResolver,
 	}
+	ecf.initRPC()
 	// Parse the HTTP and build the HTTP client - we only have one of these across the factory
 	// as within the HTTP client there are as many connections as required for parallelism
 	if conf.HTTP.URL == "" {
@@ -126,6 +154,7 @@ func (ecf *ethClientFactory) Start() (err error) {
 		return err
 	}
 	ecf.httpClient = httpClient.(*ethClient)
+	ecf.httpClient.stats.url = ecf.conf.HTTP.URL
 	ecf.sharedWSClient = sharedWSClient.(*ethClient)
 	httpChainID := ecf.httpClient.ChainID()
 	wsChainID := ecf.sharedWSClient.ChainID()
@@ -137,14 +166,47 @@ func (ecf *ethClientFactory) Start() (err error) {
 }
 
 func (ecf *ethClientFactory) NewWS() (ec EthClient, err error) {
-	wsRPC := rpcclient.WrapWSConfig(ecf.wsConf)
+	var wsRPC rpcclient.WSClient
+	if ecf.dnsRefreshInterval > 0 {
+		wsRPC = rpcclient.WrapWSConfigWithDNSRefresh(ecf.wsConf, &wsclient.DNSRefreshOptions{
+			Interval: ecf.dnsRefreshInterval,
+			Resolver: ecf.dnsResolver,
+		})
+	} else {
+		wsRPC = rpcclient.WrapWSConfig(ecf.wsConf)
+	}
 	err = wsRPC.Connect(ecf.bgCtx)
 	if err == nil {
 		ec, err = WrapRPCClient(ecf.bgCtx, ecf.keymgr, wsRPC, ecf.conf)
 	}
+	if err == nil {
+		wsEC := ec.(*ethClient)
+		wsRPC.OnReconnect(func(ctx context.Context) { ecf.checkChainIDAfterReconnect(ctx, wsEC) })
+	}
 	return ec, err
 }
 
+func (ecf *ethClientFactory) StopOnChainIDChange(cancelCtx context.CancelFunc) {
+	ecf.cancelCtx = cancelCtx
+}
+
+// A reconnect might be to a different node than the original connection (such as after a failover),
+// so we check it is on the same chain. If it is not, nothing we submit or index can be trusted.
+func (ecf *ethClientFactory) checkChainIDAfterReconnect(ctx context.Context, ec *ethClient) {
+	var chainID ethtypes.HexUint64
+	if rpcErr := ec.callRPC(ctx, &chainID, "eth_chainId"); rpcErr != nil {
+		// we will check again if this is because the connection dropped again
+		log.L(ctx).Warnf("Unable to check chain ID after WebSocket reconnect: %s", rpcErr)
+		return
+	}
+	if int64(chainID.Uint64()) != ec.chainID {
+		log.L(ctx).Errorf("FATAL: %s", i18n.NewError(ctx, msgs.MsgEthClientChainIDChanged, ec.chainID, chainID.Uint64()))
+		if ecf.cancelCtx != nil {
+			ecf.cancelCtx()
+		}
+	}
+}
+
 func (ecf *ethClientFactory) HTTPClient() EthClient {
 	return ecf.httpClient
 }
@@ -157,6 +219,7 @@ func (ecf *ethClientFactory) SharedWS() EthClient {
 }
 
 func (ecf *ethClientFactory) Stop() {
+	ecf.stopNewHeads()
 	ecf.httpClient.Close()
 	ecf.sharedWSClient.Close()
 }
@@ -0,0 +1,48 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+
+	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
+)
+
+func (ecf *ethClientFactory) RPCModule() *rpcserver.RPCModule {
+	return ecf.rpcModule
+}
+
+func (ecf *ethClientFactory) initRPC() {
+	ecf.rpcModule = rpcserver.NewRPCModule("eth").
+		Add("eth_clientStats", ecf.rpcClientStats())
+}
+
+func (ecf *ethClientFactory) GetConnectionStats() *FactoryConnectionStats {
+	stats := &FactoryConnectionStats{}
+	if ecf.httpClient != nil {
+		stats.HTTP = ecf.httpClient.GetConnectionStats()
+	}
+	if ecf.sharedWSClient != nil {
+		stats.WS = ecf.sharedWSClient.GetConnectionStats()
+	}
+	return stats
+}
+
+func (ecf *ethClientFactory) rpcClientStats() rpcserver.RPCHandler {
+	return rpcserver.RPCMethod0(func(ctx context.Context) (*FactoryConnectionStats, error) {
+		return ecf.GetConnectionStats(), nil
+	})
+}
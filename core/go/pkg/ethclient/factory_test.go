@@ -18,22 +18,28 @@ package ethclient
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly-signer/pkg/ethsigner"
 	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/mocks/rpcclientmocks"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
 	"github.com/kaleido-io/paladin/toolkit/pkg/rpcserver"
 	"github.com/kaleido-io/paladin/toolkit/pkg/signerapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 type mockEth struct {
 	eth_getBalance          func(context.Context, pldtypes.EthAddress, string) (*pldtypes.HexUint256, error)
+	eth_getProof            func(context.Context, pldtypes.EthAddress, []pldtypes.Bytes32, string) (*StorageProof, error)
 	eth_gasPrice            func(context.Context) (*pldtypes.HexUint256, error)
 	eth_gasLimit            func(context.Context, ethsigner.Transaction) (*pldtypes.HexUint256, error)
 	eth_chainId             func(context.Context) (pldtypes.HexUint64, error)
@@ -86,6 +92,7 @@ func newTestServer(t *testing.T, ctx context.Context, isWS bool, mEth *mockEth)
 		Add("eth_sendRawTransaction", checkNil(mEth.eth_sendRawTransaction, rpcserver.RPCMethod1)).
 		Add("eth_call", primarySecondary(mEth.eth_callErr, checkNil(mEth.eth_call, rpcserver.RPCMethod2))).
 		Add("eth_getBalance", checkNil(mEth.eth_getBalance, rpcserver.RPCMethod2)).
+		Add("eth_getProof", checkNil(mEth.eth_getProof, rpcserver.RPCMethod3)).
 		Add("eth_gasPrice", checkNil(mEth.eth_gasPrice, rpcserver.RPCMethod0)).
 		Add("eth_gasLimit", checkNil(mEth.eth_gasLimit, rpcserver.RPCMethod1)),
 	)
@@ -247,6 +254,33 @@ func TestMismatchedChainID(t *testing.T) {
 
 }
 
+func TestChainIDCheckedAfterReconnect(t *testing.T) {
+	chainID := pldtypes.HexUint64(12345)
+	mEth := &mockEth{
+		eth_chainId: func(ctx context.Context) (pldtypes.HexUint64, error) { return chainID, nil },
+	}
+	ctx, ecf, done := newTestClientAndServer(t, mEth)
+	defer done()
+
+	nodeCtx, stopNode := context.WithCancel(ctx)
+	defer stopNode()
+	ecf.ecf.StopOnChainIDChange(stopNode)
+	sharedWS := ecf.ecf.sharedWSClient
+
+	// reconnect to the same chain
+	ecf.ecf.checkChainIDAfterReconnect(ctx, sharedWS)
+	assert.NoError(t, nodeCtx.Err())
+
+	// unable to query
+	ecf.ecf.checkChainIDAfterReconnect(ctx, &ethClient{rpc: &unconnectedRPC{}, chainID: 12345})
+	assert.NoError(t, nodeCtx.Err())
+
+	// reconnect to a different chain
+	chainID = 11111
+	ecf.ecf.checkChainIDAfterReconnect(ctx, sharedWS)
+	assert.Error(t, nodeCtx.Err())
+}
+
 func TestSharedWSBeforeStart(t *testing.T) {
 	assert.PanicsWithValue(t, "call to SharedWS() before Start", func() {
 		_ = (&ethClientFactory{}).SharedWS()
@@ -263,3 +297,213 @@ func TestNewECFNoWrapper(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, ecf)
 }
+
+type mockResolver struct {
+	lookups []string
+}
+
+func (r *mockResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.lookups = append(r.lookups, host)
+	return []string{"127.0.0.1"}, nil
+}
+
+func TestNewWSWithDNSRefresh(t *testing.T) {
+	ctx := context.Background()
+	wsRPCServer, wsServerDone := newTestServer(t, ctx, true, &mockEth{})
+	defer wsServerDone()
+
+	ecf, err := newEthClientFactory(ctx, nil, &pldconf.EthClientConfig{
+		HTTP: pldconf.HTTPClientConfig{
+			URL: "http://localhost:8545",
+		},
+		WS: pldconf.WSClientConfig{
+			HTTPClientConfig: pldconf.HTTPClientConfig{
+				URL: fmt.Sprintf("ws://paladin-node.test:%d", wsRPCServer.WSAddr().(*net.TCPAddr).Port),
+			},
+		},
+		DNSRefreshInterval: confutil.P("1m"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1*time.Minute, ecf.dnsRefreshInterval)
+	resolver := &mockResolver{}
+	ecf.dnsResolver = resolver
+
+	ec, err := ecf.NewWS()
+	require.NoError(t, err)
+	defer ec.Close()
+	assert.Equal(t, int64(12345), ec.ChainID())
+	assert.Equal(t, []string{"paladin-node.test"}, resolver.lookups)
+}
+
+func TestWSPool(t *testing.T) {
+	var chainIDCalls atomic.Int32
+	mEth := &mockEth{
+		eth_chainId: func(ctx context.Context) (pldtypes.HexUint64, error) {
+			chainIDCalls.Add(1)
+			return 12345, nil
+		},
+		eth_getTransactionCount: func(ctx context.Context, addr pldtypes.EthAddress, block string) (pldtypes.HexUint64, error) {
+			return 10, nil
+		},
+	}
+	ctx, ecf, done := newTestClientAndServer(t, mEth)
+	defer done()
+
+	_, err := ecf.ecf.WSPool(0)
+	assert.Regexp(t, "PD011519", err)
+
+	ecf.ecf.conf.WSPoolHealthCheckInterval = confutil.P("1ms")
+	pool, err := ecf.ecf.WSPool(3)
+	require.NoError(t, err)
+	defer pool.Close()
+	assert.Equal(t, 3, pool.Size())
+	assert.Equal(t, int64(12345), pool.ChainID())
+	p := pool.(*wsPool)
+
+	// health checks run in the background
+	startCalls := chainIDCalls.Load()
+	require.Eventually(t, func() bool { return chainIDCalls.Load() > startCalls+3 }, time.Second, time.Millisecond)
+	p.cancelCtx()
+	<-p.healthCheckDone
+
+	// a check interrupted by the shutdown does not mark the connections unhealthy
+	for _, m := range p.members {
+		assert.True(t, m.healthy.Load())
+	}
+	p.checkHealth(p.ctx)
+	for _, m := range p.members {
+		assert.True(t, m.healthy.Load())
+	}
+
+	// requests are distributed across the connections
+	p.stats.requestCount.Store(0)
+	for _, m := range p.members {
+		m.ec.stats.requestCount.Store(0)
+	}
+	for i := 0; i < 6; i++ {
+		_, err := pool.GetTransactionCount(ctx, *pldtypes.RandAddress())
+		require.NoError(t, err)
+	}
+	for _, m := range p.members {
+		assert.Equal(t, uint64(2), m.ec.GetConnectionStats().RequestCount)
+	}
+	assert.Equal(t, uint64(6), pool.GetConnectionStats().RequestCount)
+
+	// unhealthy connections are skipped until they pass a health check
+	goodRPC := p.members[1].ec.rpc
+	p.members[1].ec.rpc = &unconnectedRPC{}
+	p.checkHealth(ctx)
+	assert.False(t, p.members[1].healthy.Load())
+	for i := 0; i < 4; i++ {
+		_, err := pool.GetTransactionCount(ctx, *pldtypes.RandAddress())
+		require.NoError(t, err)
+	}
+	p.members[1].ec.rpc = goodRPC
+	p.checkHealth(ctx)
+	assert.True(t, p.members[1].healthy.Load())
+
+	// if none are healthy we still try
+	for _, m := range p.members {
+		m.healthy.Store(false)
+	}
+	_, err = pool.GetTransactionCount(ctx, *pldtypes.RandAddress())
+	require.NoError(t, err)
+}
+
+func TestWSPoolConnectFail(t *testing.T) {
+	_, ecf, done := newTestClientAndServer(t, &mockEth{})
+	defer done()
+
+	ecf.ecf.wsConf.URL = "http://not-a-websocket"
+	_, err := ecf.ecf.WSPool(2)
+	assert.Regexp(t, "PD021100", err)
+}
+
+func newTestNewHeadsFactory(t *testing.T) (context.Context, *ethClientFactory, *rpcclientmocks.WSClient) {
+	ctx := context.Background()
+	mWS := rpcclientmocks.NewWSClient(t)
+	ecf := &ethClientFactory{
+		bgCtx:          ctx,
+		sharedWSClient: &ethClient{rpc: mWS},
+	}
+	return ctx, ecf, mWS
+}
+
+func newTestNewHeadsNotification(t *testing.T, result string) rpcclient.RPCSubscriptionNotification {
+	n := rpcclientmocks.NewRPCSubscriptionNotification(t)
+	n.On("GetResult").Return(pldtypes.RawJSON(result))
+	return n
+}
+
+func TestSubscribeNewHeads(t *testing.T) {
+	ctx, ecf, mWS := newTestNewHeadsFactory(t)
+
+	notifications := make(chan rpcclient.RPCSubscriptionNotification)
+	mSub := rpcclientmocks.NewSubscription(t)
+	mSub.On("Notifications").Return(notifications)
+	mWS.On("Subscribe", mock.Anything, rpcclient.EthSubscribeConfig(), []interface{}{"newHeads"}).Return(mSub, nil).Once()
+
+	// all subscribers share a single subscription
+	ch1 := make(chan *BlockInfo, 1)
+	unsub1, err := ecf.SubscribeNewHeads(ctx, ch1)
+	require.NoError(t, err)
+	ch2 := make(chan *BlockInfo, 1)
+	unsub2, err := ecf.SubscribeNewHeads(ctx, ch2)
+	require.NoError(t, err)
+	defer unsub2()
+	cancelledCtx, cancelCtx := context.WithCancel(ctx)
+	ch3 := make(chan *BlockInfo, 1)
+	_, err = ecf.SubscribeNewHeads(cancelledCtx, ch3)
+	require.NoError(t, err)
+	cancelCtx()
+
+	notifications <- newTestNewHeadsNotification(t, `{"number":"0x10","hash":"0xaabb","parentHash":"0xccdd","timestamp":"0x64"}`)
+	b1 := <-ch1
+	assert.Equal(t, uint64(16), b1.Number.Uint64())
+	assert.Equal(t, "0xaabb", b1.Hash.String())
+	assert.Equal(t, "0xccdd", b1.ParentHash.String())
+	assert.Equal(t, uint64(100), b1.Timestamp.Uint64())
+	b2 := <-ch2
+	assert.Equal(t, *b1, *b2)
+	assert.NotSame(t, b1, b2)
+
+	// invalid notifications are skipped, as are subscribers that are full
+	unsub1()
+	notifications <- newTestNewHeadsNotification(t, `"wrong"`)
+	notifications <- newTestNewHeadsNotification(t, `{"number":"0x11"}`)
+	notifications <- newTestNewHeadsNotification(t, `{"number":"0x12"}`)
+	assert.Equal(t, uint64(17), (<-ch2).Number.Uint64())
+	assert.Empty(t, ch1)
+	assert.Empty(t, ch3)
+
+	ecf.newHeads.lock.Lock()
+	assert.Len(t, ecf.newHeads.subscribers, 1)
+	ecf.newHeads.lock.Unlock()
+
+	ecf.stopNewHeads()
+	assert.Nil(t, ecf.newHeads)
+}
+
+func TestSubscribeNewHeadsSubscribeFail(t *testing.T) {
+	ctx, ecf, mWS := newTestNewHeadsFactory(t)
+
+	mWS.On("Subscribe", mock.Anything, rpcclient.EthSubscribeConfig(), []interface{}{"newHeads"}).Return(nil, rpcclient.WrapRPCError(rpcclient.RPCCodeInternalError, fmt.Errorf("pop")))
+	_, err := ecf.SubscribeNewHeads(ctx, make(chan *BlockInfo))
+	assert.Regexp(t, "pop", err)
+	assert.Nil(t, ecf.newHeads)
+}
+
+func TestSubscribeNewHeadsSubscriptionClosed(t *testing.T) {
+	ctx, ecf, mWS := newTestNewHeadsFactory(t)
+
+	notifications := make(chan rpcclient.RPCSubscriptionNotification)
+	mSub := rpcclientmocks.NewSubscription(t)
+	mSub.On("Notifications").Return(notifications)
+	mWS.On("Subscribe", mock.Anything, rpcclient.EthSubscribeConfig(), []interface{}{"newHeads"}).Return(mSub, nil)
+
+	_, err := ecf.SubscribeNewHeads(ctx, make(chan *BlockInfo))
+	require.NoError(t, err)
+	close(notifications)
+	<-ecf.newHeads.done
+	ecf.stopNewHeads()
+}
@@ -174,6 +174,37 @@ func (abic *abiClient) Function(ctx context.Context, nameOrFullSig string) (_ AB
 	return ac.functionCommon(ctx, functionABI)
 }
 
+// CallContractABI is a convenience for read-only contract calls, where the inputs and outputs
+// are supplied and returned as named-field maps. Unnamed outputs are keyed by their index.
+func (ec *ethClient) CallContractABI(ctx context.Context, contractAddress pldtypes.EthAddress, a *abi.ABI, method string, inputs map[string]any, block string) (map[string]any, error) {
+	abic, err := ec.ABI(ctx, *a)
+	var fn ABIFunctionClient
+	if err == nil {
+		fn, err = abic.Function(ctx, method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	req := fn.R(ctx).
+		To(contractAddress.Address0xHex()).
+		Serializer(pldtypes.StandardABISerializer())
+	if inputs != nil {
+		req = req.Input(inputs)
+	}
+	if block != "" {
+		req = req.BlockRef(BlockRef(block))
+	}
+	res, err := req.CallResult()
+	if err != nil {
+		return nil, err
+	}
+	outputs := map[string]any{}
+	if res.DecodedResult != nil {
+		err = json.Unmarshal([]byte(res.JSON()), &outputs)
+	}
+	return outputs, err
+}
+
 func (ec *ethClient) ABIFunction(ctx context.Context, functionABI *abi.Entry) (fc ABIFunctionClient, err error) {
 	a, err := ec.ABI(ctx, abi.ABI{functionABI})
 	if err == nil {
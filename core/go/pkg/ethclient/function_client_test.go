@@ -323,6 +323,70 @@ func TestCallGetWidgetsFromWithBlockResOk(t *testing.T) {
 	testCallGetWidgetsOk(t, true, false, true)
 }
 
+func TestCallContractABIOk(t *testing.T) {
+	var a abi.ABI
+	err := json.Unmarshal(testABIJSON, &a)
+	require.NoError(t, err)
+	contractAddr := pldtypes.RandAddress()
+
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		eth_call: func(ctx context.Context, tx ethsigner.Transaction, s string) (pldtypes.HexBytes, error) {
+			assert.Equal(t, "pending", s)
+			assert.Equal(t, contractAddr.Address0xHex(), tx.To)
+			assert.Nil(t, tx.From)
+			cv, err := a.Functions()["getWidgets"].DecodeCallData(tx.Data)
+			require.NoError(t, err)
+			jsonData, err := pldtypes.StandardABISerializer().SerializeJSON(cv)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"sku": "12345"}`, string(jsonData))
+			return a.Functions()["getWidgets"].Outputs.EncodeABIDataJSON([]byte(`{
+				"0": [{
+					"id":       "0xfd33700f0511abb60ff31a8a533854db90b0a32a",
+					"sku":      "12345",
+					"features": ["shiny"]
+				}]
+			}`))
+		},
+	})
+	defer done()
+
+	res, err := ecf.HTTPClient().CallContractABI(ctx, *contractAddr, &a, "getWidgets", map[string]any{"sku": 12345}, "pending")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"0": []any{
+			map[string]any{
+				"id":       "0xfd33700f0511abb60ff31a8a533854db90b0a32a",
+				"sku":      "12345",
+				"features": []any{"shiny"},
+			},
+		},
+	}, res)
+}
+
+func TestCallContractABIFail(t *testing.T) {
+	var a abi.ABI
+	err := json.Unmarshal(testABIJSON, &a)
+	require.NoError(t, err)
+
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		eth_call: func(ctx context.Context, tx ethsigner.Transaction, s string) (pldtypes.HexBytes, error) {
+			assert.Equal(t, "latest", s)
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	defer done()
+	ec := ecf.HTTPClient()
+
+	_, err = ec.CallContractABI(ctx, *pldtypes.RandAddress(), &a, "missing", nil, "")
+	assert.Regexp(t, "PD011507", err)
+
+	_, err = ec.CallContractABI(ctx, *pldtypes.RandAddress(), &a, "getWidgets", nil, "")
+	assert.Regexp(t, "PD011503", err)
+
+	_, err = ec.CallContractABI(ctx, *pldtypes.RandAddress(), &a, "getWidgets", map[string]any{"sku": 12345}, "")
+	assert.Regexp(t, "pop", err)
+}
+
 func TestABIFail(t *testing.T) {
 	ctx, ec, done := newTestClientAndServer(t, &mockEth{})
 	defer done()
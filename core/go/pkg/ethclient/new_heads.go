@@ -0,0 +1,149 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// The subset of the block header from a newHeads notification that is useful to subscribers
+type BlockInfo struct {
+	Number     ethtypes.HexUint64        `json:"number"`
+	Hash       ethtypes.HexBytes0xPrefix `json:"hash"`
+	ParentHash ethtypes.HexBytes0xPrefix `json:"parentHash"`
+	Timestamp  ethtypes.HexUint64        `json:"timestamp"`
+}
+
+type UnsubscribeFn func()
+
+// A single newHeads subscription on the shared WS connection, fanned out to every subscriber.
+// The WS client re-establishes its subscriptions after a reconnect, so this lives until the factory stops.
+type newHeadsDispatcher struct {
+	ctx         context.Context
+	cancelCtx   context.CancelFunc
+	sub         rpcclient.Subscription
+	lock        sync.Mutex
+	subscribers map[uuid.UUID]*newHeadsSubscriber
+	done        chan struct{}
+}
+
+type newHeadsSubscriber struct {
+	ctx context.Context
+	ch  chan<- *BlockInfo
+}
+
+// Notifications are delivered without blocking, so a subscriber that is not keeping up will miss blocks
+// rather than holding up the others. The subscriber is removed when ctx is cancelled, or the returned
+// function is called.
+func (ecf *ethClientFactory) SubscribeNewHeads(ctx context.Context, ch chan<- *BlockInfo) (UnsubscribeFn, error) {
+	nh, err := ecf.getNewHeadsDispatcher(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id := uuid.New()
+	nh.lock.Lock()
+	nh.subscribers[id] = &newHeadsSubscriber{ctx: ctx, ch: ch}
+	nh.lock.Unlock()
+	log.L(ctx).Debugf("Added newHeads subscriber %s", id)
+	return func() { nh.removeSubscriber(id) }, nil
+}
+
+func (ecf *ethClientFactory) getNewHeadsDispatcher(ctx context.Context) (*newHeadsDispatcher, error) {
+	ecf.newHeadsLock.Lock()
+	defer ecf.newHeadsLock.Unlock()
+	if ecf.newHeads != nil {
+		return ecf.newHeads, nil
+	}
+
+	wsRPC := ecf.SharedWS().(*ethClient).rpc.(rpcclient.WSClient)
+	sub, rpcErr := wsRPC.Subscribe(ctx, rpcclient.EthSubscribeConfig(), "newHeads")
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	nh := &newHeadsDispatcher{
+		sub:         sub,
+		subscribers: make(map[uuid.UUID]*newHeadsSubscriber),
+		done:        make(chan struct{}),
+	}
+	nh.ctx, nh.cancelCtx = context.WithCancel(log.WithLogField(ecf.bgCtx, "role", "new_heads"))
+	go nh.dispatchLoop()
+	ecf.newHeads = nh
+	return nh, nil
+}
+
+func (ecf *ethClientFactory) stopNewHeads() {
+	ecf.newHeadsLock.Lock()
+	defer ecf.newHeadsLock.Unlock()
+	if ecf.newHeads != nil {
+		// We do not unsubscribe, as the subscription ends when the shared WS connection is closed
+		ecf.newHeads.cancelCtx()
+		<-ecf.newHeads.done
+		ecf.newHeads = nil
+	}
+}
+
+func (nh *newHeadsDispatcher) removeSubscriber(id uuid.UUID) {
+	nh.lock.Lock()
+	defer nh.lock.Unlock()
+	delete(nh.subscribers, id)
+}
+
+func (nh *newHeadsDispatcher) dispatchLoop() {
+	defer close(nh.done)
+	for {
+		select {
+		case n, ok := <-nh.sub.Notifications():
+			if !ok {
+				log.L(nh.ctx).Debugf("newHeads subscription closed")
+				return
+			}
+			var block BlockInfo
+			if err := json.Unmarshal(n.GetResult(), &block); err != nil {
+				log.L(nh.ctx).Warnf("Invalid newHeads notification: %s", err)
+				continue
+			}
+			nh.deliver(&block)
+		case <-nh.ctx.Done():
+			log.L(nh.ctx).Debugf("newHeads dispatch loop exiting")
+			return
+		}
+	}
+}
+
+func (nh *newHeadsDispatcher) deliver(block *BlockInfo) {
+	nh.lock.Lock()
+	defer nh.lock.Unlock()
+	for id, s := range nh.subscribers {
+		if s.ctx.Err() != nil {
+			delete(nh.subscribers, id)
+			continue
+		}
+		// Each subscriber gets its own copy, so none can modify what the others see
+		b := *block
+		select {
+		case s.ch <- &b:
+		default:
+			log.L(nh.ctx).Warnf("Dropped newHeads notification for block %d to subscriber %s that is not keeping up", block.Number, id)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// Diagnostic statistics for the connection from an EthClient to the blockchain node
+type ConnectionStats struct {
+	URL              string             `json:"url"`
+	ConnectedSince   pldtypes.Timestamp `json:"connectedSince,omitempty"`
+	RequestCount     uint64             `json:"requestCount"`
+	ErrorCount       uint64             `json:"errorCount"`
+	LastErrorAt      pldtypes.Timestamp `json:"lastErrorAt,omitempty"`
+	LastErrorMsg     string             `json:"lastErrorMsg,omitempty"`
+	AverageLatencyMs float64            `json:"averageLatencyMs"`
+	ReconnectCount   *uint64            `json:"reconnectCount,omitempty"`  // WebSocket only
+	LastReconnectAt  pldtypes.Timestamp `json:"lastReconnectAt,omitempty"` // WebSocket only
+}
+
+// Stats for each of the long-lived clients maintained by the factory
+type FactoryConnectionStats struct {
+	HTTP *ConnectionStats `json:"http"`
+	WS   *ConnectionStats `json:"ws"`
+}
+
+type connectionStats struct {
+	url            string
+	connectedSince pldtypes.Timestamp
+	requestCount   atomic.Uint64
+	errorCount     atomic.Uint64
+	totalLatencyNS atomic.Int64
+	lastErrorLock  sync.Mutex
+	lastErrorAt    pldtypes.Timestamp
+	lastErrorMsg   string
+}
+
+func (ec *ethClient) callRPC(ctx context.Context, result interface{}, method string, params ...interface{}) rpcclient.ErrorRPC {
+	start := time.Now()
+	rpcErr := ec.rpc.CallRPC(ctx, result, method, params...)
+	ec.stats.requestCount.Add(1)
+	ec.stats.totalLatencyNS.Add(int64(time.Since(start)))
+	if rpcErr != nil {
+		ec.stats.errorCount.Add(1)
+		ec.stats.lastErrorLock.Lock()
+		ec.stats.lastErrorAt = pldtypes.TimestampNow()
+		ec.stats.lastErrorMsg = rpcErr.Error()
+		ec.stats.lastErrorLock.Unlock()
+	}
+	return rpcErr
+}
+
+func (ec *ethClient) GetConnectionStats() *ConnectionStats {
+	stats := &ConnectionStats{
+		URL:            ec.stats.url,
+		ConnectedSince: ec.stats.connectedSince,
+		RequestCount:   ec.stats.requestCount.Load(),
+		ErrorCount:     ec.stats.errorCount.Load(),
+	}
+	if stats.RequestCount > 0 {
+		stats.AverageLatencyMs = float64(ec.stats.totalLatencyNS.Load()) / float64(stats.RequestCount) / float64(time.Millisecond)
+	}
+	ec.stats.lastErrorLock.Lock()
+	stats.LastErrorAt = ec.stats.lastErrorAt
+	stats.LastErrorMsg = ec.stats.lastErrorMsg
+	ec.stats.lastErrorLock.Unlock()
+
+	// For WebSockets the connection is maintained (and re-established) by the RPC client
+	if wsRPC, isWS := ec.rpc.(rpcclient.WSClient); isWS {
+		wsStats := wsRPC.ConnectionStats()
+		stats.URL = wsStats.URL
+		stats.ConnectedSince = wsStats.ConnectedSince
+		stats.ReconnectCount = &wsStats.ReconnectCount
+		stats.LastReconnectAt = wsStats.LastReconnectAt
+	}
+	return stats
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionStatsErrorCount(t *testing.T) {
+	ctx, ecf, done := newTestClientAndServer(t, &mockEth{
+		eth_gasPrice: func(ctx context.Context) (*pldtypes.HexUint256, error) {
+			return nil, fmt.Errorf("pop")
+		},
+	})
+	defer done()
+
+	ec := ecf.HTTPClient()
+	stats := ec.GetConnectionStats()
+	assert.Equal(t, ecf.ecf.conf.HTTP.URL, stats.URL)
+	assert.NotZero(t, stats.ConnectedSince)
+	assert.Equal(t, uint64(1), stats.RequestCount) // eth_chainId on startup
+	assert.Zero(t, stats.ErrorCount)
+	assert.Nil(t, stats.ReconnectCount)
+
+	_, err := ec.GasPrice(ctx)
+	assert.Regexp(t, "pop", err)
+
+	stats = ec.GetConnectionStats()
+	assert.Equal(t, uint64(2), stats.RequestCount)
+	assert.Equal(t, uint64(1), stats.ErrorCount)
+	assert.NotZero(t, stats.LastErrorAt)
+	assert.Regexp(t, "pop", stats.LastErrorMsg)
+	assert.Greater(t, stats.AverageLatencyMs, float64(0))
+
+	// Check the factory aggregates both the HTTP and WS clients
+	fStats := ecf.ecf.GetConnectionStats()
+	assert.Equal(t, uint64(1), fStats.HTTP.ErrorCount)
+	require.NotNil(t, fStats.WS.ReconnectCount)
+	assert.Zero(t, *fStats.WS.ReconnectCount)
+	assert.NotZero(t, fStats.WS.ConnectedSince)
+	assert.Equal(t, ecf.ecf.conf.WS.URL, fStats.WS.URL)
+
+	assert.Contains(t, ecf.ecf.RPCModule().MethodNames(), "eth_clientStats")
+	res := ecf.ecf.rpcClientStats().Handle(ctx, &rpcclient.RPCRequest{
+		JSONRpc: "2.0",
+		ID:      pldtypes.RawJSON("1"),
+		Method:  "eth_clientStats",
+	})
+	assert.Nil(t, res.Error)
+	assert.Regexp(t, `"errorCount":1`, res.Result.String())
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"golang.org/x/crypto/sha3"
+)
+
+// StorageProof is the EIP-1186 result of eth_getProof. The proofs are the RLP encoded
+// Merkle Patricia Trie nodes from the root down to the account/storage slot.
+type StorageProof struct {
+	Address      pldtypes.EthAddress  `json:"address"`
+	AccountProof []pldtypes.HexBytes  `json:"accountProof"`
+	Balance      *pldtypes.HexUint256 `json:"balance"`
+	CodeHash     pldtypes.Bytes32     `json:"codeHash"`
+	Nonce        pldtypes.HexUint64   `json:"nonce"`
+	StorageHash  pldtypes.Bytes32     `json:"storageHash"`
+	StorageProof []*StorageKeyProof   `json:"storageProof"`
+}
+
+type StorageKeyProof struct {
+	Key   *pldtypes.HexUint256 `json:"key"`
+	Value *pldtypes.HexUint256 `json:"value"`
+	Proof []pldtypes.HexBytes  `json:"proof"`
+}
+
+var (
+	emptyTrieRoot = keccak256(rlp.Data{}.Encode())
+	emptyCodeHash = keccak256(nil)
+)
+
+func keccak256(b []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(b)
+	return hash.Sum(nil)
+}
+
+// VerifyStorageProof checks the account proof against the supplied state root (from the block header),
+// and then each storage proof against the storage hash of the account.
+// Proofs of absence are supported for both accounts and storage slots, where the account is empty
+// or the storage value is zero.
+func VerifyStorageProof(proof *StorageProof, stateRoot pldtypes.Bytes32) bool {
+	if proof == nil {
+		return false
+	}
+	accountRLP, ok := verifyMPTProof(stateRoot[:], keccak256(proof.Address[:]), proof.AccountProof)
+	if !ok {
+		return false
+	}
+	balance := big.NewInt(0)
+	if proof.Balance != nil {
+		balance = proof.Balance.Int()
+	}
+	if accountRLP == nil {
+		// Proof of absence, which is only valid for an empty account
+		if proof.Nonce != 0 || balance.Sign() != 0 ||
+			!bytes.Equal(proof.StorageHash[:], emptyTrieRoot) || !bytes.Equal(proof.CodeHash[:], emptyCodeHash) {
+			return false
+		}
+	} else {
+		account, _, err := rlp.Decode(accountRLP)
+		if err != nil || !account.IsList() || len(account.(rlp.List)) != 4 {
+			return false
+		}
+		fields := account.(rlp.List)
+		for _, f := range fields {
+			if f.IsList() {
+				return false
+			}
+		}
+		if fields[0].(rlp.Data).IntOrZero().Uint64() != proof.Nonce.Uint64() ||
+			fields[1].(rlp.Data).IntOrZero().Cmp(balance) != 0 ||
+			!bytes.Equal(fields[2].(rlp.Data), proof.StorageHash[:]) ||
+			!bytes.Equal(fields[3].(rlp.Data), proof.CodeHash[:]) {
+			return false
+		}
+	}
+
+	for _, sp := range proof.StorageProof {
+		if sp == nil || sp.Key == nil {
+			return false
+		}
+		slot := make([]byte, 32)
+		sp.Key.Int().FillBytes(slot)
+		valueRLP, ok := verifyMPTProof(proof.StorageHash[:], keccak256(slot), sp.Proof)
+		if !ok {
+			return false
+		}
+		expected := big.NewInt(0)
+		if sp.Value != nil {
+			expected = sp.Value.Int()
+		}
+		actual := big.NewInt(0)
+		if valueRLP != nil {
+			value, _, err := rlp.Decode(valueRLP)
+			if err != nil || value.IsList() {
+				return false
+			}
+			actual = value.(rlp.Data).IntOrZero()
+		}
+		if actual.Cmp(expected) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Walks the proof from the root following the nibbles of the key. Returns ok=true with a nil
+// value if the proof shows the key is absent from the trie.
+func verifyMPTProof(root, key []byte, proof []pldtypes.HexBytes) (value []byte, ok bool) {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+
+	wantHash := root
+	var node rlp.List // set when the next node is embedded in its parent, rather than referenced by hash
+	proofIdx := 0
+	for {
+		if node == nil {
+			if proofIdx >= len(proof) || !bytes.Equal(keccak256(proof[proofIdx]), wantHash) {
+				return nil, false
+			}
+			decoded, _, err := rlp.Decode(proof[proofIdx])
+			if err != nil || !decoded.IsList() {
+				return nil, false
+			}
+			node = decoded.(rlp.List)
+			proofIdx++
+		}
+
+		var next rlp.Element
+		switch len(node) {
+		case 17: // branch
+			if len(nibbles) == 0 {
+				return mptValue(node[16])
+			}
+			next = node[nibbles[0]]
+			nibbles = nibbles[1:]
+		case 2: // leaf or extension
+			if node[0].IsList() {
+				return nil, false
+			}
+			path, isLeaf, valid := decodeHexPrefix(node[0].(rlp.Data))
+			if !valid {
+				return nil, false
+			}
+			if isLeaf {
+				if !bytes.Equal(path, nibbles) {
+					return nil, true // a different key is stored here
+				}
+				return mptValue(node[1])
+			}
+			if len(nibbles) < len(path) || !bytes.Equal(path, nibbles[:len(path)]) {
+				return nil, true // the path diverges
+			}
+			nibbles = nibbles[len(path):]
+			next = node[1]
+		default:
+			return nil, false
+		}
+
+		switch {
+		case next.IsList():
+			node = next.(rlp.List)
+		case len(next.(rlp.Data)) == 0:
+			return nil, true // empty branch
+		case len(next.(rlp.Data)) == 32:
+			wantHash = next.(rlp.Data)
+			node = nil
+		default:
+			return nil, false
+		}
+	}
+}
+
+func mptValue(e rlp.Element) ([]byte, bool) {
+	if e.IsList() {
+		return nil, false
+	}
+	if len(e.(rlp.Data)) == 0 {
+		return nil, true
+	}
+	return e.(rlp.Data), true
+}
+
+// Hex-prefix encoding of a path, where the high nibble of the first byte contains
+// flags for a leaf (2) and an odd length path (1)
+func decodeHexPrefix(encoded []byte) (nibbles []byte, isLeaf bool, valid bool) {
+	if len(encoded) == 0 {
+		return nil, false, false
+	}
+	flags := encoded[0] >> 4
+	if flags > 3 {
+		return nil, false, false
+	}
+	isLeaf = flags&2 != 0
+	if flags&1 != 0 {
+		nibbles = append(nibbles, encoded[0]&0x0f)
+	}
+	for _, b := range encoded[1:] {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles, isLeaf, true
+}
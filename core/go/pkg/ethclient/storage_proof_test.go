@@ -0,0 +1,267 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/rlp"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTrieEntry struct {
+	nibbles []byte
+	value   []byte
+	proof   []pldtypes.HexBytes
+}
+
+func encodeHexPrefix(nibbles []byte, isLeaf bool) rlp.Data {
+	flags := byte(0)
+	if isLeaf {
+		flags = 2
+	}
+	var out []byte
+	if len(nibbles)%2 == 1 {
+		out = append(out, (flags+1)<<4|nibbles[0])
+		nibbles = nibbles[1:]
+	} else {
+		out = append(out, flags<<4)
+	}
+	for i := 0; i < len(nibbles); i += 2 {
+		out = append(out, nibbles[i]<<4|nibbles[i+1])
+	}
+	return out
+}
+
+// Builds a minimal Merkle Patricia Trie, recording the proof for each entry.
+// Nodes that encode to less than 32 bytes are embedded in their parent (except the root).
+func buildTestTrieNode(entries []*testTrieEntry, depth int, isRoot bool) rlp.Element {
+	var node rlp.List
+	if len(entries) == 1 {
+		e := entries[0]
+		node = rlp.List{encodeHexPrefix(e.nibbles[depth:], true), rlp.Data(e.value)}
+	} else {
+		// Common prefix gives an extension node
+		prefixLen := 0
+		for depth+prefixLen < len(entries[0].nibbles) {
+			n := entries[0].nibbles[depth+prefixLen]
+			same := true
+			for _, e := range entries[1:] {
+				same = same && e.nibbles[depth+prefixLen] == n
+			}
+			if !same {
+				break
+			}
+			prefixLen++
+		}
+		if prefixLen > 0 {
+			node = rlp.List{encodeHexPrefix(entries[0].nibbles[depth:depth+prefixLen], false), buildTestTrieNode(entries, depth+prefixLen, false)}
+		} else {
+			node = make(rlp.List, 17)
+			for i := 0; i < 16; i++ {
+				var children []*testTrieEntry
+				for _, e := range entries {
+					if int(e.nibbles[depth]) == i {
+						children = append(children, e)
+					}
+				}
+				if len(children) > 0 {
+					node[i] = buildTestTrieNode(children, depth+1, false)
+				} else {
+					node[i] = rlp.Data{}
+				}
+			}
+			node[16] = rlp.Data{}
+		}
+	}
+	encoded := node.Encode()
+	if len(encoded) < 32 && !isRoot {
+		return node
+	}
+	for _, e := range entries {
+		e.proof = append([]pldtypes.HexBytes{encoded}, e.proof...)
+	}
+	return rlp.Data(keccak256(encoded))
+}
+
+func buildTestTrie(t *testing.T, kvs map[string][]byte) (root []byte, entries map[string]*testTrieEntry) {
+	entries = map[string]*testTrieEntry{}
+	list := make([]*testTrieEntry, 0, len(kvs))
+	for k, v := range kvs {
+		e := &testTrieEntry{value: v}
+		for _, b := range keccak256([]byte(k)) {
+			e.nibbles = append(e.nibbles, b>>4, b&0x0f)
+		}
+		entries[k] = e
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool { return bytes.Compare(list[i].nibbles, list[j].nibbles) < 0 })
+	rootNode := buildTestTrieNode(list, 0, true)
+	require.False(t, rootNode.IsList())
+	return rootNode.(rlp.Data), entries
+}
+
+func slotKey(i int64) []byte {
+	slot := make([]byte, 32)
+	big.NewInt(i).FillBytes(slot)
+	return slot
+}
+
+func newTestStorageProof(t *testing.T) (*StorageProof, pldtypes.Bytes32) {
+	// Storage trie with enough slots to get branch, extension and leaf nodes
+	storage := map[string][]byte{}
+	for i := int64(0); i < 20; i++ {
+		storage[string(slotKey(i))] = rlp.WrapInt(big.NewInt(1000 + i)).Encode()
+	}
+	storageRoot, storageEntries := buildTestTrie(t, storage)
+
+	address := pldtypes.RandAddress()
+	codeHash := pldtypes.RandBytes32()
+	account := rlp.List{
+		rlp.WrapInt(big.NewInt(5)),
+		rlp.WrapInt(big.NewInt(1e18)),
+		rlp.Data(storageRoot),
+		rlp.Data(codeHash[:]),
+	}
+	accounts := map[string][]byte{
+		string(address[:]): account.Encode(),
+	}
+	for i := 0; i < 10; i++ {
+		accounts[string(pldtypes.RandBytes(20))] = rlp.List{rlp.WrapInt(big.NewInt(1)), rlp.WrapInt(big.NewInt(1)), rlp.Data(emptyTrieRoot), rlp.Data(emptyCodeHash)}.Encode()
+	}
+	stateRoot, accountEntries := buildTestTrie(t, accounts)
+
+	proof := &StorageProof{
+		Address:      *address,
+		AccountProof: accountEntries[string(address[:])].proof,
+		Balance:      (*pldtypes.HexUint256)(big.NewInt(1e18)),
+		CodeHash:     codeHash,
+		Nonce:        5,
+		StorageHash:  pldtypes.Bytes32(storageRoot),
+	}
+	for _, i := range []int64{3, 17} {
+		proof.StorageProof = append(proof.StorageProof, &StorageKeyProof{
+			Key:   (*pldtypes.HexUint256)(big.NewInt(i)),
+			Value: (*pldtypes.HexUint256)(big.NewInt(1000 + i)),
+			Proof: storageEntries[string(slotKey(i))].proof,
+		})
+	}
+	return proof, pldtypes.Bytes32(stateRoot)
+}
+
+func TestVerifyStorageProofOk(t *testing.T) {
+	proof, stateRoot := newTestStorageProof(t)
+	assert.True(t, VerifyStorageProof(proof, stateRoot))
+}
+
+func TestVerifyStorageProofBadStateRoot(t *testing.T) {
+	proof, _ := newTestStorageProof(t)
+	assert.False(t, VerifyStorageProof(proof, pldtypes.RandBytes32()))
+	assert.False(t, VerifyStorageProof(nil, pldtypes.RandBytes32()))
+}
+
+func TestVerifyStorageProofWrongAccountFields(t *testing.T) {
+	proof, stateRoot := newTestStorageProof(t)
+	proof.Nonce = 6
+	assert.False(t, VerifyStorageProof(proof, stateRoot))
+
+	proof, stateRoot = newTestStorageProof(t)
+	proof.CodeHash = pldtypes.RandBytes32()
+	assert.False(t, VerifyStorageProof(proof, stateRoot))
+}
+
+func TestVerifyStorageProofWrongValue(t *testing.T) {
+	proof, stateRoot := newTestStorageProof(t)
+	proof.StorageProof[1].Value = (*pldtypes.HexUint256)(big.NewInt(12345))
+	assert.False(t, VerifyStorageProof(proof, stateRoot))
+}
+
+func TestVerifyStorageProofTruncated(t *testing.T) {
+	proof, stateRoot := newTestStorageProof(t)
+	sp := proof.StorageProof[0]
+	sp.Proof = sp.Proof[:len(sp.Proof)-1]
+	assert.False(t, VerifyStorageProof(proof, stateRoot))
+}
+
+func TestVerifyStorageProofAbsentSlot(t *testing.T) {
+	// A storage trie with a single leaf, which proves any other slot is absent (zero)
+	storageRoot, storageEntries := buildTestTrie(t, map[string][]byte{
+		string(slotKey(1)): rlp.WrapInt(big.NewInt(42)).Encode(),
+	})
+	address := pldtypes.RandAddress()
+	stateRoot, accountEntries := buildTestTrie(t, map[string][]byte{
+		string(address[:]): rlp.List{rlp.WrapInt(big.NewInt(0)), rlp.WrapInt(big.NewInt(0)), rlp.Data(storageRoot), rlp.Data(emptyCodeHash)}.Encode(),
+	})
+
+	proof := &StorageProof{
+		Address:      *address,
+		AccountProof: accountEntries[string(address[:])].proof,
+		CodeHash:     pldtypes.Bytes32(emptyCodeHash),
+		StorageHash:  pldtypes.Bytes32(storageRoot),
+		StorageProof: []*StorageKeyProof{{
+			Key:   (*pldtypes.HexUint256)(big.NewInt(99)),
+			Value: (*pldtypes.HexUint256)(big.NewInt(0)),
+			Proof: storageEntries[string(slotKey(1))].proof,
+		}},
+	}
+	assert.True(t, VerifyStorageProof(proof, pldtypes.Bytes32(stateRoot)))
+
+	proof.StorageProof[0].Value = (*pldtypes.HexUint256)(big.NewInt(42))
+	assert.False(t, VerifyStorageProof(proof, pldtypes.Bytes32(stateRoot)))
+}
+
+func TestVerifyStorageProofEmptyAccount(t *testing.T) {
+	address := pldtypes.RandAddress()
+	other := rlp.List{rlp.WrapInt(big.NewInt(1)), rlp.WrapInt(big.NewInt(1)), rlp.Data(emptyTrieRoot), rlp.Data(emptyCodeHash)}.Encode()
+	stateRoot, entries := buildTestTrie(t, map[string][]byte{string(pldtypes.RandBytes(20)): other})
+
+	var root pldtypes.Bytes32
+	copy(root[:], stateRoot)
+	proof := &StorageProof{
+		Address:     *address,
+		CodeHash:    pldtypes.Bytes32(emptyCodeHash),
+		StorageHash: pldtypes.Bytes32(emptyTrieRoot),
+	}
+	for _, e := range entries {
+		proof.AccountProof = e.proof // single leaf for a different key
+	}
+	assert.True(t, VerifyStorageProof(proof, root))
+
+	proof.Nonce = 1
+	assert.False(t, VerifyStorageProof(proof, root))
+}
+
+func TestDecodeHexPrefix(t *testing.T) {
+	nibbles, isLeaf, valid := decodeHexPrefix([]byte{0x3a, 0xbc})
+	assert.True(t, valid)
+	assert.True(t, isLeaf)
+	assert.Equal(t, []byte{0xa, 0xb, 0xc}, nibbles)
+
+	nibbles, isLeaf, valid = decodeHexPrefix([]byte{0x00, 0xbc})
+	assert.True(t, valid)
+	assert.False(t, isLeaf)
+	assert.Equal(t, []byte{0xb, 0xc}, nibbles)
+
+	_, _, valid = decodeHexPrefix([]byte{})
+	assert.False(t, valid)
+	_, _, valid = decodeHexPrefix([]byte{0x40})
+	assert.False(t, valid)
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ethclient
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/core/internal/msgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// EthClientPool is an EthClient that spreads requests across a fixed number of WebSocket connections,
+// for components that would otherwise each need their own dedicated connection.
+type EthClientPool interface {
+	EthClient
+	Size() int
+}
+
+// Requests are sent to each connection in turn, skipping any that failed their most recent health check
+type wsPool struct {
+	*ethClient // with the pool itself as the RPC client
+	ctx        context.Context
+	cancelCtx  context.CancelFunc
+	members    []*wsPoolMember
+	next       atomic.Uint64
+
+	healthCheckInterval time.Duration
+	healthCheckDone     chan struct{}
+}
+
+type wsPoolMember struct {
+	ec      *ethClient
+	healthy atomic.Bool
+}
+
+func (ecf *ethClientFactory) WSPool(size int) (_ EthClientPool, err error) {
+	if size < 1 {
+		return nil, i18n.NewError(ecf.bgCtx, msgs.MsgEthClientWSPoolSize, size)
+	}
+	p := &wsPool{
+		members:             make([]*wsPoolMember, 0, size),
+		healthCheckInterval: confutil.DurationMin(ecf.conf.WSPoolHealthCheckInterval, 0, *pldconf.EthClientDefaults.WSPoolHealthCheckInterval),
+		healthCheckDone:     make(chan struct{}),
+	}
+	p.ctx, p.cancelCtx = context.WithCancel(log.WithLogField(ecf.bgCtx, "role", "ws_pool"))
+	defer func() {
+		if err != nil {
+			p.closeMembers()
+		}
+	}()
+	for i := 0; i < size; i++ {
+		ec, err := ecf.NewWS()
+		if err != nil {
+			return nil, err
+		}
+		m := &wsPoolMember{ec: ec.(*ethClient)}
+		m.healthy.Store(true)
+		p.members = append(p.members, m)
+	}
+	ec, err := WrapRPCClient(p.ctx, ecf.keymgr, p, ecf.conf)
+	if err != nil {
+		return nil, err
+	}
+	p.ethClient = ec.(*ethClient)
+	p.stats.url = ecf.wsConf.URL
+	go p.healthCheckLoop()
+	return p, nil
+}
+
+func (p *wsPool) Size() int {
+	return len(p.members)
+}
+
+func (p *wsPool) CallRPC(ctx context.Context, result interface{}, method string, params ...interface{}) rpcclient.ErrorRPC {
+	return p.nextMember().ec.callRPC(ctx, result, method, params...)
+}
+
+func (p *wsPool) nextMember() *wsPoolMember {
+	start := p.next.Add(1)
+	for i := range p.members {
+		m := p.members[(start+uint64(i))%uint64(len(p.members))]
+		if m.healthy.Load() {
+			return m
+		}
+	}
+	// None are healthy, but one might have recovered since it was checked - so we try anyway
+	return p.members[start%uint64(len(p.members))]
+}
+
+func (p *wsPool) healthCheckLoop() {
+	defer close(p.healthCheckDone)
+	if p.healthCheckInterval == 0 {
+		return
+	}
+	ticker := time.NewTicker(p.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.checkHealth(p.ctx)
+		case <-p.ctx.Done():
+			log.L(p.ctx).Debugf("WebSocket pool health check loop exiting")
+			return
+		}
+	}
+}
+
+func (p *wsPool) checkHealth(ctx context.Context) {
+	for i, m := range p.members {
+		var chainID ethtypes.HexUint64
+		rpcErr := m.ec.callRPC(ctx, &chainID, "eth_chainId")
+		if ctx.Err() != nil {
+			// we are shutting down, so the failure says nothing about the connection
+			return
+		}
+		healthy := rpcErr == nil
+		if m.healthy.Swap(healthy) != healthy {
+			if healthy {
+				log.L(ctx).Infof("WebSocket pool connection %d is healthy", i)
+			} else {
+				log.L(ctx).Warnf("WebSocket pool connection %d is unhealthy: %s", i, rpcErr)
+			}
+		}
+	}
+}
+
+func (p *wsPool) closeMembers() {
+	for _, m := range p.members {
+		m.ec.Close()
+	}
+}
+
+func (p *wsPool) Close() {
+	p.cancelCtx()
+	if p.ethClient != nil {
+		<-p.healthCheckDone
+	}
+	p.closeMembers()
+}
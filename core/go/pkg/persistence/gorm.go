@@ -77,6 +77,11 @@ func NewSQLProvider(ctx context.Context, p SQLDBProvider, conf *pldconf.SQLDBCon
 		}
 		gp.db, err = gdb.DB()
 	}
+	if err == nil {
+		err = gdb.Use(&queryTimeoutPlugin{
+			timeout: confutil.DurationMin(conf.QueryTimeout, 0, *defs.QueryTimeout),
+		})
+	}
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, msgs.MsgPersistenceInitFailed)
 	}
@@ -35,6 +35,7 @@ var SQLMockDefaults = &pldconf.SQLDBConfig{
 	MaxIdleConns:    confutil.P(1),
 	ConnMaxIdleTime: confutil.P("0"),
 	ConnMaxLifetime: confutil.P("0"),
+	QueryTimeout:    confutil.P("0"),
 	StatementCache:  confutil.P(false),
 }
 
@@ -35,6 +35,7 @@ var PostgresDefaults = &pldconf.SQLDBConfig{
 	MaxIdleConns:    confutil.P(100),
 	ConnMaxIdleTime: confutil.P("60s"),
 	ConnMaxLifetime: confutil.P("0"),
+	QueryTimeout:    confutil.P("0"),
 	StatementCache:  confutil.P(true),
 }
 
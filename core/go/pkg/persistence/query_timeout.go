@@ -0,0 +1,59 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const queryTimeoutPluginName = "paladin:queryTimeout"
+
+// The query timeout is registered as a gORM plugin, so it is available on the
+// shared config of every *gorm.DB derived from the provider (including transactions)
+type queryTimeoutPlugin struct {
+	timeout time.Duration
+}
+
+func (p *queryTimeoutPlugin) Name() string {
+	return queryTimeoutPluginName
+}
+
+func (p *queryTimeoutPlugin) Initialize(*gorm.DB) error {
+	return nil
+}
+
+// QueryContext binds the DB to a context that has a deadline no later than the configured
+// query timeout, so long running queries cannot hold connections from the pool indefinitely.
+// If the parent context already has an earlier deadline, or no timeout is configured,
+// the parent context is used unchanged.
+//
+// The returned DB can be used for multiple statements, so the caller must call the returned
+// cancel function once it has finished with the DB, to release the deadline timer.
+func QueryContext(parentCtx context.Context, db *gorm.DB) (*gorm.DB, context.CancelFunc) {
+	plugin, ok := db.Config.Plugins[queryTimeoutPluginName].(*queryTimeoutPlugin)
+	if !ok || plugin.timeout <= 0 {
+		return db.WithContext(parentCtx), func() {}
+	}
+	deadline := time.Now().Add(plugin.timeout)
+	if parentDeadline, hasDeadline := parentCtx.Deadline(); hasDeadline && !parentDeadline.After(deadline) {
+		return db.WithContext(parentCtx), func() {}
+	}
+	ctx, cancel := context.WithDeadline(parentCtx, deadline)
+	return db.WithContext(ctx), cancel
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockQueryTimeoutPersistence(t *testing.T, timeout time.Duration) (Persistence, sqlmock.Sqlmock) {
+	p, mdb := newMockGormPSQLPersistence(t)
+	err := p.DB().Use(&queryTimeoutPlugin{timeout: timeout})
+	require.NoError(t, err)
+	return p, mdb
+}
+
+func TestQueryContextTimeoutFires(t *testing.T) {
+	p, mdb := newMockQueryTimeoutPersistence(t, 10*time.Millisecond)
+
+	mdb.ExpectQuery("SELECT.*").WillDelayFor(10 * time.Second).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	var results []map[string]any
+	startTime := time.Now()
+	db, cancel := QueryContext(context.Background(), p.DB())
+	defer cancel()
+	err := db.Table("things").Find(&results).Error
+	assert.Regexp(t, "cancel", err)
+	assert.Less(t, time.Since(startTime), 5*time.Second)
+}
+
+func TestQueryContextParentDeadlineEarlier(t *testing.T) {
+	p, _ := newMockQueryTimeoutPersistence(t, 1*time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	parentDeadline, _ := ctx.Deadline()
+
+	db, queryCancel := QueryContext(ctx, p.DB())
+	defer queryCancel()
+	deadline, ok := db.Statement.Context.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, parentDeadline, deadline)
+}
+
+func TestQueryContextTimeoutEarlier(t *testing.T) {
+	p, _ := newMockQueryTimeoutPersistence(t, 1*time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour)
+	defer cancel()
+
+	db, queryCancel := QueryContext(ctx, p.DB())
+	deadline, ok := db.Statement.Context.Deadline()
+	assert.True(t, ok)
+	assert.Less(t, time.Until(deadline), 1*time.Minute)
+
+	// Cancelling releases the derived context, without affecting the parent
+	queryCancel()
+	assert.Error(t, db.Statement.Context.Err())
+	assert.NoError(t, ctx.Err())
+}
+
+func TestQueryContextNoTimeout(t *testing.T) {
+	p, _ := newMockGormPSQLPersistence(t)
+
+	db, cancel := QueryContext(context.Background(), p.DB())
+	defer cancel()
+	_, ok := db.Statement.Context.Deadline()
+	assert.False(t, ok)
+}
+
+func TestQueryContextConfigured(t *testing.T) {
+	p, err := newSQLiteProvider(context.Background(), &pldconf.DBConfig{
+		Type: "sqlite",
+		SQLite: pldconf.SQLiteConfig{
+			SQLDBConfig: pldconf.SQLDBConfig{
+				DSN:          ":memory:",
+				QueryTimeout: confutil.P("5s"),
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer p.Close()
+
+	// Applies within a transaction as well as outside
+	err = p.Transaction(context.Background(), func(ctx context.Context, dbTX DBTX) error {
+		db, cancel := QueryContext(ctx, dbTX.DB())
+		defer cancel()
+		_, ok := db.Statement.Context.Deadline()
+		assert.True(t, ok)
+		return nil
+	})
+	require.NoError(t, err)
+	db, cancel := QueryContext(context.Background(), p.DB())
+	defer cancel()
+	_, ok := db.Statement.Context.Deadline()
+	assert.True(t, ok)
+}
@@ -200,7 +200,7 @@ func (tb *testbed) StartForTest(configFile string, domains map[string]*TestbedDo
 				for name, domain := range domains {
 					loaderMap[name] = domain.Plugin
 				}
-				pc := c.PluginManager()
+				pc, _ := c.PluginManager() // always available in the testbed
 				pl, err = plugins.NewUnitTestPluginLoader(pc.GRPCTargetURL(), pc.LoaderID().String(), loaderMap)
 				if err != nil {
 					return err
@@ -267,7 +267,8 @@ func (tb *testbed) execPrivateTransaction(ctx context.Context, tx *testbedTransa
 	sender := tx.localTx.Transaction.From
 	if !strings.Contains(sender, "@") {
 		// Transaction manager normally does the full version of this
-		tx.localTx.Transaction.From = fmt.Sprintf("%s@%s", sender, tb.c.TransportManager().LocalNodeName())
+		transportMgr, _ := tb.c.TransportManager() // always available in the testbed
+		tx.localTx.Transaction.From = fmt.Sprintf("%s@%s", sender, transportMgr.LocalNodeName())
 	}
 
 	// Testbed just uses a domain context for the duration of the TX, and flushes before returning
@@ -69,4 +69,14 @@ var (
 	MsgMissingStateData            = pde("PD200029", "Missing state data for one or more states: %s")
 	MsgLockNotAllowed              = pde("PD200030", "Lock is not enabled")
 	MsgUnlockOnlyCreator           = pde("PD200031", "Only the lock creator can perform unlock: expected=%s actual=%s")
+	MsgFactoryAddressChanged       = pde("PD200032", "Factory address cannot be changed on reconfiguration: current=%s new=%s")
+	MsgInvalidDecimalAmount        = pde("PD200033", "Invalid decimal amount for '%s': %s")
+	MsgTooManyDecimalPlaces        = pde("PD200034", "Amount for '%s' has more than %d decimal places: %s")
+	MsgDuplicateSender             = pde("PD200035", "Duplicate sender '%s'")
+	MsgMultiTransferNotSupported   = pde("PD200036", "Multi-party transfer is not supported in notary mode '%s'")
+	MsgInvalidNetAmount            = pde("PD200037", "Invalid net amount for '%s': expected=%s actual=%s")
+	MsgParameterAtLeast            = pde("PD200038", "Parameter '%s' must be at least %d")
+	MsgNothingToConsolidate        = pde("PD200039", "Not enough coins to consolidate (available=%d)")
+	MsgInvalidOutputs              = pde("PD200040", "Invalid outputs to '%s': %v")
+	MsgContractExpired             = pde("PD200041", "Contract expired at block %d (transaction base block %d)")
 )
@@ -37,7 +37,7 @@ type burnHandler struct {
 
 func (h *burnHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
 	var BurnParams types.BurnParams
-	if err := json.Unmarshal([]byte(params), &BurnParams); err != nil {
+	if err := h.noto.unmarshalAmountParams(ctx, params, &BurnParams); err != nil {
 		return nil, err
 	}
 	if BurnParams.Amount == nil || BurnParams.Amount.Int().Sign() != 1 {
@@ -248,3 +248,19 @@ func TestBurn(t *testing.T) {
 		}
 	}`, senderKey.Address, senderKey.Address, contractAddress, pldtypes.HexBytes(encodedCall)), prepareRes.Transaction.ParamsJson)
 }
+
+func TestBurnValidateParamsDecimals(t *testing.T) {
+	n := &Noto{
+		Callbacks: mockCallbacks,
+		config:    types.DomainConfig{Decimals: 6},
+	}
+	h := burnHandler{noto: n}
+	ctx := context.Background()
+
+	params, err := h.ValidateParams(ctx, notoBasicConfig, `{"amount": "0.25"}`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(250000), params.(*types.BurnParams).Amount.Int().Int64())
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"amount": "0.0000001"}`)
+	assert.Regexp(t, "PD200034", err)
+}
@@ -0,0 +1,337 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsolidate(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["consolidate"]
+
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	senderKey, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	var inputCoins []*types.NotoCoinState
+	var storedStates []*prototk.StoredState
+	for _, amount := range []int64{10, 20, 30} {
+		coin := &types.NotoCoinState{
+			ID: pldtypes.RandBytes32(),
+			Data: types.NotoCoin{
+				Owner:  (*pldtypes.EthAddress)(&senderKey.Address),
+				Amount: pldtypes.Int64ToInt256(amount),
+			},
+		}
+		inputCoins = append(inputCoins, coin)
+		storedStates = append(storedStates, &prototk.StoredState{
+			Id:       coin.ID.String(),
+			SchemaId: "coin",
+			DataJson: mustParseJSON(coin.Data),
+		})
+	}
+	mockCallbacks.MockFindAvailableStates = func() (*prototk.FindAvailableStatesResponse, error) {
+		return &prototk.FindAvailableStatesResponse{
+			States: storedStates,
+		}, nil
+	}
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    contractAddress,
+			ContractConfigJson: mustParseJSON(notoBasicConfig),
+		},
+		FunctionAbiJson:   mustParseJSON(fn),
+		FunctionSignature: fn.SolString(),
+		FunctionParamsJson: `{
+			"maxInputs": 3,
+			"data": "0x1234"
+		}`,
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 2)
+	assert.Equal(t, "notary@node1", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, "sender@node1", initRes.RequiredVerifiers[1].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "sender@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     senderKey.Address.String(),
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 3)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 1)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 1)
+
+	outputCoin, err := n.unmarshalCoin(assembleRes.AssembledTransaction.OutputStates[0].StateDataJson)
+	require.NoError(t, err)
+	assert.Equal(t, senderKey.Address.String(), outputCoin.Owner.String())
+	assert.Equal(t, "60", outputCoin.Amount.Int().String())
+	assert.Equal(t, []string{"notary@node1", "sender@node1"}, assembleRes.AssembledTransaction.OutputStates[0].DistributionList)
+
+	encodedTransfer, err := n.encodeTransferUnmasked(ctx, ethtypes.MustNewAddress(contractAddress),
+		[]*types.NotoCoin{&inputCoins[0].Data, &inputCoins[1].Data, &inputCoins[2].Data},
+		[]*types.NotoCoin{outputCoin},
+	)
+	require.NoError(t, err)
+	signature, err := senderKey.SignDirect(encodedTransfer)
+	require.NoError(t, err)
+	signatureBytes := pldtypes.HexBytes(signature.CompactRSV())
+
+	inputStates := make([]*prototk.EndorsableState, len(inputCoins))
+	for i, coin := range inputCoins {
+		inputStates[i] = &prototk.EndorsableState{
+			SchemaId:      "coin",
+			Id:            coin.ID.String(),
+			StateDataJson: mustParseJSON(coin.Data),
+		}
+	}
+	outputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "coin",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000001",
+			StateDataJson: assembleRes.AssembledTransaction.OutputStates[0].StateDataJson,
+		},
+	}
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000002",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+	}
+	signatures := []*prototk.AttestationResult{
+		{
+			Name:     "sender",
+			Verifier: &prototk.ResolvedVerifier{Verifier: senderKey.Address.String()},
+			Payload:  signatureBytes,
+		},
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		Inputs:            inputStates,
+		Outputs:           outputStates,
+		Info:              infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{
+			Name: "notary",
+		},
+		Signatures: signatures,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	// Value cannot be lost in a consolidation
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            []*prototk.EndorsableState{},
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	assert.Regexp(t, "PD200040", err)
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates[1:],
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	assert.Regexp(t, "PD200013", err)
+
+	// Coins cannot be consolidated into another owner's coin
+	otherCoin := *outputCoin
+	otherCoin.Owner = pldtypes.MustEthAddress(notaryAddress)
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		Inputs:            inputStates,
+		Outputs: []*prototk.EndorsableState{{
+			SchemaId:      "coin",
+			Id:            outputStates[0].Id,
+			StateDataJson: mustParseJSON(otherCoin),
+		}},
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	assert.Regexp(t, "PD200018", err)
+
+	// Prepare once to test base invoke
+	attestations := append(signatures, &prototk.AttestationResult{
+		Name:     "notary",
+		Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+	})
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: attestations,
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["transfer"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.Nil(t, prepareRes.Transaction.ContractAddress)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"inputs": ["%s", "%s", "%s"],
+		"outputs": ["0x0000000000000000000000000000000000000000000000000000000000000001"],
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002"
+	}`, inputCoins[0].ID, inputCoins[1].ID, inputCoins[2].ID, signatureBytes), prepareRes.Transaction.ParamsJson)
+
+	var invokeFn abi.Entry
+	err = json.Unmarshal([]byte(prepareRes.Transaction.FunctionAbiJson), &invokeFn)
+	require.NoError(t, err)
+	encodedCall, err := invokeFn.EncodeCallDataJSONCtx(ctx, []byte(prepareRes.Transaction.ParamsJson))
+	require.NoError(t, err)
+
+	// Prepare again to test hook invoke
+	hookAddress := "0x515fba7fe1d8b9181be074bd4c7119544426837c"
+	tx.ContractInfo.ContractConfigJson = mustParseJSON(&types.NotoParsedConfig{
+		NotaryLookup: "notary@node1",
+		NotaryMode:   types.NotaryModeHooks.Enum(),
+		Options: types.NotoOptions{
+			Hooks: &types.NotoHooksOptions{
+				PublicAddress:     pldtypes.MustEthAddress(hookAddress),
+				DevUsePublicHooks: true,
+			},
+		},
+	})
+	prepareRes, err = n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: attestations,
+	})
+	require.NoError(t, err)
+	expectedFunction = mustParseJSON(hooksBuild.ABI.Functions()["onTransfer"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.Equal(t, &hookAddress, prepareRes.Transaction.ContractAddress)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"sender": "%s",
+		"from": "%s",
+		"to": "%s",
+		"amount": "0x3c",
+		"data": "0x1234",
+		"prepared": {
+			"contractAddress": "%s",
+			"encodedCall": "%s"
+		}
+	}`, senderKey.Address, senderKey.Address, senderKey.Address, contractAddress, pldtypes.HexBytes(encodedCall)), prepareRes.Transaction.ParamsJson)
+}
+
+func TestConsolidateValidateParams(t *testing.T) {
+	h := consolidateHandler{noto: &Noto{Callbacks: mockCallbacks}}
+	ctx := context.Background()
+
+	_, err := h.ValidateParams(ctx, notoBasicConfig, `{"maxInputs": 1}`)
+	assert.Regexp(t, "PD200038", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{!!!`)
+	assert.Error(t, err)
+}
+
+func TestConsolidateAssembleNotEnoughCoins(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	h := consolidateHandler{noto: n}
+	ctx := context.Background()
+
+	mockCallbacks.MockFindAvailableStates = func() (*prototk.FindAvailableStatesResponse, error) {
+		return &prototk.FindAvailableStatesResponse{
+			States: []*prototk.StoredState{
+				{
+					Id:       pldtypes.RandBytes32().String(),
+					SchemaId: "coin",
+					DataJson: `{"owner": "0x1000000000000000000000000000000000000000", "amount": "0x0a"}`,
+				},
+			},
+		}, nil
+	}
+	parsedTx := &types.ParsedTransaction{
+		Transaction:     &prototk.TransactionSpecification{From: "sender@node1"},
+		ContractAddress: ethtypes.MustNewAddress("0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"),
+		DomainConfig:    notoBasicConfig,
+		Params:          &types.ConsolidateParams{MaxInputs: 10},
+	}
+	res, err := h.Assemble(ctx, parsedTx, &prototk.AssembleTransactionRequest{
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "sender@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x1000000000000000000000000000000000000000",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, res.AssemblyResult)
+	assert.Regexp(t, "PD200039", *res.RevertReason)
+}
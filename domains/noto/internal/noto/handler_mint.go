@@ -36,7 +36,7 @@ type mintHandler struct {
 
 func (h *mintHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
 	var mintParams types.MintParams
-	if err := json.Unmarshal([]byte(params), &mintParams); err != nil {
+	if err := h.noto.unmarshalAmountParams(ctx, params, &mintParams); err != nil {
 		return nil, err
 	}
 	if mintParams.To == "" {
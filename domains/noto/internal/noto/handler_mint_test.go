@@ -226,3 +226,42 @@ func TestMint(t *testing.T) {
 		}
 	}`, notaryKey.Address, contractAddress, pldtypes.HexBytes(encodedCall)), prepareRes.Transaction.ParamsJson)
 }
+
+func TestMintValidateParamsDecimals(t *testing.T) {
+	n := &Noto{
+		Callbacks: mockCallbacks,
+		config:    types.DomainConfig{Decimals: 18},
+	}
+	h := mintHandler{noto: n}
+	ctx := context.Background()
+
+	params, err := h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "1.5"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "1500000000000000000", params.(*types.MintParams).Amount.Int().Text(10))
+
+	params, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": 2}`)
+	require.NoError(t, err)
+	assert.Equal(t, "2000000000000000000", params.(*types.MintParams).Amount.Int().Text(10))
+
+	params, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "0x64"}`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), params.(*types.MintParams).Amount.Int().Int64())
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "0.0000000000000000001"}`)
+	assert.Regexp(t, "PD200034", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "1.2.3"}`)
+	assert.Regexp(t, "PD200033", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "0.0"}`)
+	assert.Regexp(t, "PD200008", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": 1.5e3}`)
+	assert.Regexp(t, "PD200033", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": {}}`)
+	assert.Error(t, err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{!!!`)
+	assert.Error(t, err)
+}
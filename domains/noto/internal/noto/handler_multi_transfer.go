@@ -0,0 +1,348 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"slices"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/domains/noto/internal/msgs"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/domain"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/signpayloads"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+)
+
+type multiTransferHandler struct {
+	noto *Noto
+}
+
+func (h *multiTransferHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
+	var multiTransferParams types.MultiTransferParams
+	if err := json.Unmarshal([]byte(params), &multiTransferParams); err != nil {
+		return nil, err
+	}
+	if config.NotaryMode != types.NotaryModeBasic.Enum() {
+		return nil, i18n.NewError(ctx, msgs.MsgMultiTransferNotSupported, config.NotaryMode)
+	}
+	if len(multiTransferParams.Senders) == 0 {
+		return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "senders")
+	}
+	senders := make(map[string]bool, len(multiTransferParams.Senders))
+	for _, sender := range multiTransferParams.Senders {
+		if sender.From == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "from")
+		}
+		if sender.To == "" {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterRequired, "to")
+		}
+		if sender.Amount == nil || sender.Amount.Int().Sign() != 1 {
+			return nil, i18n.NewError(ctx, msgs.MsgParameterGreaterThanZero, "amount")
+		}
+		// Each sender's coins are selected independently, so a sender may only appear once
+		if senders[sender.From] {
+			return nil, i18n.NewError(ctx, msgs.MsgDuplicateSender, sender.From)
+		}
+		senders[sender.From] = true
+	}
+	return &multiTransferParams, nil
+}
+
+// All parties to the transfer, starting with the notary
+func (h *multiTransferHandler) parties(notary string, params *types.MultiTransferParams) []string {
+	lookups := []string{notary}
+	for _, sender := range params.Senders {
+		lookups = append(lookups, sender.From, sender.To)
+	}
+	parties := make([]string, 0, len(lookups))
+	for _, lookup := range lookups {
+		if !slices.Contains(parties, lookup) {
+			parties = append(parties, lookup)
+		}
+	}
+	return parties
+}
+
+func (h *multiTransferHandler) senderLookups(params *types.MultiTransferParams) []string {
+	lookups := make([]string, len(params.Senders))
+	for i, sender := range params.Senders {
+		lookups[i] = sender.From
+	}
+	return lookups
+}
+
+func (h *multiTransferHandler) Init(ctx context.Context, tx *types.ParsedTransaction, req *prototk.InitTransactionRequest) (*prototk.InitTransactionResponse, error) {
+	params := tx.Params.(*types.MultiTransferParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	return &prototk.InitTransactionResponse{
+		RequiredVerifiers: h.noto.ethAddressVerifiers(h.parties(notary, params)...),
+	}, nil
+}
+
+func (h *multiTransferHandler) Assemble(ctx context.Context, tx *types.ParsedTransaction, req *prototk.AssembleTransactionRequest) (*prototk.AssembleTransactionResponse, error) {
+	params := tx.Params.(*types.MultiTransferParams)
+	notary := tx.DomainConfig.NotaryLookup
+
+	inputStates := &preparedInputs{total: big.NewInt(0)}
+	outputStates := &preparedOutputs{}
+	for _, sender := range params.Senders {
+		fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", sender.From, req.ResolvedVerifiers)
+		if err != nil {
+			return nil, err
+		}
+		toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", sender.To, req.ResolvedVerifiers)
+		if err != nil {
+			return nil, err
+		}
+
+		// Coins are selected separately from each sender's available states
+		senderInputs, revert, err := h.noto.prepareInputs(ctx, req.StateQueryContext, fromAddress, sender.Amount)
+		if err != nil {
+			if revert {
+				message := err.Error()
+				return &prototk.AssembleTransactionResponse{
+					AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+					RevertReason:   &message,
+				}, nil
+			}
+			return nil, err
+		}
+		senderOutputs, err := h.noto.prepareOutputs(toAddress, sender.Amount, []string{notary, sender.From, sender.To})
+		if err != nil {
+			return nil, err
+		}
+		if senderInputs.total.Cmp(sender.Amount.Int()) == 1 {
+			remainder := big.NewInt(0).Sub(senderInputs.total, sender.Amount.Int())
+			returnedStates, err := h.noto.prepareOutputs(fromAddress, (*pldtypes.HexUint256)(remainder), []string{notary, sender.From})
+			if err != nil {
+				return nil, err
+			}
+			senderOutputs.coins = append(senderOutputs.coins, returnedStates.coins...)
+			senderOutputs.states = append(senderOutputs.states, returnedStates.states...)
+		}
+
+		inputStates.coins = append(inputStates.coins, senderInputs.coins...)
+		inputStates.states = append(inputStates.states, senderInputs.states...)
+		inputStates.total = inputStates.total.Add(inputStates.total, senderInputs.total)
+		outputStates.coins = append(outputStates.coins, senderOutputs.coins...)
+		outputStates.states = append(outputStates.states, senderOutputs.states...)
+	}
+
+	infoStates, err := h.noto.prepareInfo(params.Data, h.parties(notary, params))
+	if err != nil {
+		return nil, err
+	}
+
+	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputStates.coins, outputStates.coins)
+	if err != nil {
+		return nil, err
+	}
+	attestation := []*prototk.AttestationRequest{
+		// Every sender confirms the combined transfer with a signature
+		{
+			Name:            "senders",
+			AttestationType: prototk.AttestationType_SIGN,
+			Algorithm:       algorithms.ECDSA_SECP256K1,
+			VerifierType:    verifiers.ETH_ADDRESS,
+			Payload:         encodedTransfer,
+			PayloadType:     signpayloads.OPAQUE_TO_RSV,
+			Parties:         h.senderLookups(params),
+		},
+		// Notary will endorse the assembled transaction (by submitting to the ledger)
+		{
+			Name:            "notary",
+			AttestationType: prototk.AttestationType_ENDORSE,
+			Algorithm:       algorithms.ECDSA_SECP256K1,
+			VerifierType:    verifiers.ETH_ADDRESS,
+			Parties:         []string{notary},
+		},
+	}
+
+	return &prototk.AssembleTransactionResponse{
+		AssemblyResult: prototk.AssembleTransactionResponse_OK,
+		AssembledTransaction: &prototk.AssembledTransaction{
+			InputStates:  inputStates.states,
+			OutputStates: outputStates.states,
+			InfoStates:   infoStates,
+		},
+		AttestationPlan: attestation,
+	}, nil
+}
+
+// Check that the net change in value for every owner matches the requested transfers
+func (h *multiTransferHandler) validateNetAmounts(ctx context.Context, params *types.MultiTransferParams, verifierList []*prototk.ResolvedVerifier, inputs, outputs *parsedCoins) error {
+	expected := map[pldtypes.EthAddress]*big.Int{}
+	actual := map[pldtypes.EthAddress]*big.Int{}
+	adjust := func(amounts map[pldtypes.EthAddress]*big.Int, owner *pldtypes.EthAddress, amount *big.Int, negate bool) {
+		if amounts[*owner] == nil {
+			amounts[*owner] = big.NewInt(0)
+		}
+		if negate {
+			amounts[*owner].Sub(amounts[*owner], amount)
+		} else {
+			amounts[*owner].Add(amounts[*owner], amount)
+		}
+	}
+
+	for _, sender := range params.Senders {
+		fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", sender.From, verifierList)
+		if err != nil {
+			return err
+		}
+		toAddress, err := h.noto.findEthAddressVerifier(ctx, "to", sender.To, verifierList)
+		if err != nil {
+			return err
+		}
+		adjust(expected, fromAddress, sender.Amount.Int(), true)
+		adjust(expected, toAddress, sender.Amount.Int(), false)
+	}
+	for _, coin := range inputs.coins {
+		adjust(actual, coin.Owner, coin.Amount.Int(), true)
+	}
+	for _, coin := range outputs.coins {
+		adjust(actual, coin.Owner, coin.Amount.Int(), false)
+	}
+
+	for owner, amount := range actual {
+		if expected[owner] == nil {
+			expected[owner] = big.NewInt(0)
+		}
+		if amount.Cmp(expected[owner]) != 0 {
+			return i18n.NewError(ctx, msgs.MsgInvalidNetAmount, owner, expected[owner].Text(10), amount.Text(10))
+		}
+	}
+	for owner, amount := range expected {
+		if actual[owner] == nil && amount.Sign() != 0 {
+			return i18n.NewError(ctx, msgs.MsgInvalidNetAmount, owner, amount.Text(10), "0")
+		}
+	}
+	return nil
+}
+
+func (h *multiTransferHandler) Endorse(ctx context.Context, tx *types.ParsedTransaction, req *prototk.EndorseTransactionRequest) (*prototk.EndorseTransactionResponse, error) {
+	params := tx.Params.(*types.MultiTransferParams)
+
+	inputs, err := h.noto.parseCoinList(ctx, "input", req.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := h.noto.parseCoinList(ctx, "output", req.Outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Senders and the notary all validate the amounts moved for every party
+	if err := h.noto.validateTransferAmounts(ctx, inputs, outputs); err != nil {
+		return nil, err
+	}
+	if err := h.validateNetAmounts(ctx, params, req.ResolvedVerifiers, inputs, outputs); err != nil {
+		return nil, err
+	}
+
+	if req.EndorsementRequest.Name != "notary" {
+		return nil, i18n.NewError(ctx, msgs.MsgUnrecognizedEndorsement, req.EndorsementRequest.Name)
+	}
+
+	// Notary checks the signature from every sender, then submits the transaction
+	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputs.coins, outputs.coins)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.validateSenderSignatures(ctx, params, req.ResolvedVerifiers, req.Signatures, encodedTransfer); err != nil {
+		return nil, err
+	}
+	return &prototk.EndorseTransactionResponse{
+		EndorsementResult: prototk.EndorseTransactionResponse_ENDORSER_SUBMIT,
+	}, nil
+}
+
+// Check that every sender has signed the combined transfer with their resolved key
+func (h *multiTransferHandler) validateSenderSignatures(ctx context.Context, params *types.MultiTransferParams, verifierList []*prototk.ResolvedVerifier, attestations []*prototk.AttestationResult, encodedTransfer []byte) error {
+	for _, sender := range params.Senders {
+		fromAddress, err := h.noto.findEthAddressVerifier(ctx, "from", sender.From, verifierList)
+		if err != nil {
+			return err
+		}
+		if err := h.noto.validatePartySignature(ctx, "senders", sender.From, fromAddress, attestations, encodedTransfer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *multiTransferHandler) Prepare(ctx context.Context, tx *types.ParsedTransaction, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
+	params := tx.Params.(*types.MultiTransferParams)
+
+	endorsement := domain.FindAttestation("notary", req.AttestationResult)
+	if endorsement == nil || endorsement.Verifier.Lookup != tx.DomainConfig.NotaryLookup {
+		return nil, i18n.NewError(ctx, msgs.MsgAttestationNotFound, "notary")
+	}
+
+	inputs, err := h.noto.parseCoinList(ctx, "input", req.InputStates)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := h.noto.parseCoinList(ctx, "output", req.OutputStates)
+	if err != nil {
+		return nil, err
+	}
+	encodedTransfer, err := h.noto.encodeTransferUnmasked(ctx, tx.ContractAddress, inputs.coins, outputs.coins)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.validateSenderSignatures(ctx, params, req.ResolvedVerifiers, req.AttestationResult, encodedTransfer); err != nil {
+		return nil, err
+	}
+
+	// Include the signatures from all senders, in the order they were requested
+	// These are not verified on the base ledger, but can be verified by anyone with the unmasked state data
+	var signatures pldtypes.HexBytes
+	for _, sender := range params.Senders {
+		for _, ar := range req.AttestationResult {
+			if ar.Name == "senders" && ar.Verifier != nil && ar.Verifier.Lookup == sender.From {
+				signatures = append(signatures, ar.Payload...)
+				break
+			}
+		}
+	}
+
+	data, err := h.noto.encodeTransactionData(ctx, req.Transaction, req.InfoStates)
+	if err != nil {
+		return nil, err
+	}
+	transferParams := &NotoTransferParams{
+		Inputs:    endorsableStateIDs(req.InputStates),
+		Outputs:   endorsableStateIDs(req.OutputStates),
+		Signature: signatures,
+		Data:      data,
+	}
+	paramsJSON, err := json.Marshal(transferParams)
+	if err != nil {
+		return nil, err
+	}
+	baseTransaction := &TransactionWrapper{
+		functionABI: interfaceBuild.ABI.Functions()["transfer"],
+		paramsJSON:  paramsJSON,
+	}
+	return baseTransaction.prepare(nil)
+}
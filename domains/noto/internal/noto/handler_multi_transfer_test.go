@@ -0,0 +1,431 @@
+/*
+ * Copyright © 2025 Kaleido, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+ * the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+ * an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package noto
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/kaleido-io/paladin/domains/noto/pkg/types"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/toolkit/pkg/algorithms"
+	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
+	"github.com/kaleido-io/paladin/toolkit/pkg/verifiers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiTransfer(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["multiTransfer"]
+
+	notaryAddress := "0x1000000000000000000000000000000000000000"
+	receiverAddress := "0x2000000000000000000000000000000000000000"
+	sender1Key, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+	sender2Key, err := secp256k1.GenerateSecp256k1KeyPair()
+	require.NoError(t, err)
+
+	inputCoins := []*types.NotoCoinState{
+		{
+			ID: pldtypes.RandBytes32(),
+			Data: types.NotoCoin{
+				Owner:  (*pldtypes.EthAddress)(&sender1Key.Address),
+				Amount: pldtypes.Int64ToInt256(100),
+			},
+		},
+		{
+			ID: pldtypes.RandBytes32(),
+			Data: types.NotoCoin{
+				Owner:  (*pldtypes.EthAddress)(&sender2Key.Address),
+				Amount: pldtypes.Int64ToInt256(30),
+			},
+		},
+	}
+	// Each sender's coins are queried in turn
+	queries := 0
+	mockCallbacks.MockFindAvailableStates = func() (*prototk.FindAvailableStatesResponse, error) {
+		inputCoin := inputCoins[queries]
+		queries++
+		return &prototk.FindAvailableStatesResponse{
+			States: []*prototk.StoredState{
+				{
+					Id:       inputCoin.ID.String(),
+					SchemaId: "coin",
+					DataJson: mustParseJSON(inputCoin.Data),
+				},
+			},
+		}, nil
+	}
+
+	contractAddress := "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "sender1@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    contractAddress,
+			ContractConfigJson: mustParseJSON(notoBasicConfig),
+		},
+		FunctionAbiJson:   mustParseJSON(fn),
+		FunctionSignature: fn.SolString(),
+		FunctionParamsJson: `{
+			"senders": [
+				{"from": "sender1@node1", "to": "receiver@node3", "amount": 75},
+				{"from": "sender2@node2", "to": "sender1@node1", "amount": 30}
+			],
+			"data": "0x1234"
+		}`,
+	}
+
+	initRes, err := n.InitTransaction(ctx, &prototk.InitTransactionRequest{
+		Transaction: tx,
+	})
+	require.NoError(t, err)
+	require.Len(t, initRes.RequiredVerifiers, 4)
+	assert.Equal(t, "notary@node1", initRes.RequiredVerifiers[0].Lookup)
+	assert.Equal(t, "sender1@node1", initRes.RequiredVerifiers[1].Lookup)
+	assert.Equal(t, "receiver@node3", initRes.RequiredVerifiers[2].Lookup)
+	assert.Equal(t, "sender2@node2", initRes.RequiredVerifiers[3].Lookup)
+
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     notaryAddress,
+		},
+		{
+			Lookup:       "sender1@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     sender1Key.Address.String(),
+		},
+		{
+			Lookup:       "receiver@node3",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     receiverAddress,
+		},
+		{
+			Lookup:       "sender2@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     sender2Key.Address.String(),
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+	require.Len(t, assembleRes.AssembledTransaction.InputStates, 2)
+	require.Len(t, assembleRes.AssembledTransaction.OutputStates, 3)
+	require.Len(t, assembleRes.AssembledTransaction.InfoStates, 1)
+	assert.Equal(t, inputCoins[0].ID.String(), assembleRes.AssembledTransaction.InputStates[0].Id)
+	assert.Equal(t, inputCoins[1].ID.String(), assembleRes.AssembledTransaction.InputStates[1].Id)
+	assert.Equal(t, []string{"notary@node1", "sender1@node1", "receiver@node3", "sender2@node2"}, assembleRes.AssembledTransaction.InfoStates[0].DistributionList)
+	require.Len(t, assembleRes.AttestationPlan, 2)
+	assert.Equal(t, "senders", assembleRes.AttestationPlan[0].Name)
+	assert.Equal(t, prototk.AttestationType_SIGN, assembleRes.AttestationPlan[0].AttestationType)
+	assert.Equal(t, []string{"sender1@node1", "sender2@node2"}, assembleRes.AttestationPlan[0].Parties)
+	assert.Equal(t, "notary", assembleRes.AttestationPlan[1].Name)
+
+	outputCoins := make([]*types.NotoCoin, 3)
+	outputStates := make([]*prototk.EndorsableState, 3)
+	for i, state := range assembleRes.AssembledTransaction.OutputStates {
+		outputCoins[i], err = n.unmarshalCoin(state.StateDataJson)
+		require.NoError(t, err)
+		outputStates[i] = &prototk.EndorsableState{
+			SchemaId:      "coin",
+			Id:            fmt.Sprintf("0x%064d", i+1),
+			StateDataJson: state.StateDataJson,
+		}
+	}
+	assert.Equal(t, receiverAddress, outputCoins[0].Owner.String())
+	assert.Equal(t, "75", outputCoins[0].Amount.Int().String())
+	assert.Equal(t, sender1Key.Address.String(), outputCoins[1].Owner.String())
+	assert.Equal(t, "25", outputCoins[1].Amount.Int().String())
+	assert.Equal(t, sender1Key.Address.String(), outputCoins[2].Owner.String())
+	assert.Equal(t, "30", outputCoins[2].Amount.Int().String())
+	assert.Equal(t, []string{"notary@node1", "sender2@node2", "sender1@node1"}, assembleRes.AssembledTransaction.OutputStates[2].DistributionList)
+
+	inputStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "coin",
+			Id:            inputCoins[0].ID.String(),
+			StateDataJson: mustParseJSON(inputCoins[0].Data),
+		},
+		{
+			SchemaId:      "coin",
+			Id:            inputCoins[1].ID.String(),
+			StateDataJson: mustParseJSON(inputCoins[1].Data),
+		},
+	}
+	infoStates := []*prototk.EndorsableState{
+		{
+			SchemaId:      "data",
+			Id:            "0x0000000000000000000000000000000000000000000000000000000000000004",
+			StateDataJson: assembleRes.AssembledTransaction.InfoStates[0].StateDataJson,
+		},
+	}
+
+	// Each sender signs the combined transfer
+	encodedTransfer, err := n.encodeTransferUnmasked(ctx, ethtypes.MustNewAddress(contractAddress),
+		[]*types.NotoCoin{&inputCoins[0].Data, &inputCoins[1].Data},
+		outputCoins,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(encodedTransfer), []byte(assembleRes.AttestationPlan[0].Payload))
+	sender1Signature, err := sender1Key.SignDirect(encodedTransfer)
+	require.NoError(t, err)
+	sender2Signature, err := sender2Key.SignDirect(encodedTransfer)
+	require.NoError(t, err)
+	signatures := []*prototk.AttestationResult{
+		{
+			Name:     "senders",
+			Verifier: &prototk.ResolvedVerifier{Lookup: "sender2@node2", Verifier: sender2Key.Address.String()},
+			Payload:  sender2Signature.CompactRSV(),
+		},
+		{
+			Name:     "senders",
+			Verifier: &prototk.ResolvedVerifier{Lookup: "sender1@node1", Verifier: sender1Key.Address.String()},
+			Payload:  sender1Signature.CompactRSV(),
+		},
+	}
+
+	endorseRes, err := n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.EndorseTransactionResponse_ENDORSER_SUBMIT, endorseRes.EndorsementResult)
+
+	// Notary rejects a transfer missing a sender's signature
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         signatures[:1],
+	})
+	assert.Regexp(t, "PD200015.*senders", err)
+
+	// Notary rejects a signature that does not come from the named sender
+	forgedSignatures := []*prototk.AttestationResult{
+		signatures[0],
+		{
+			Name:     "senders",
+			Verifier: &prototk.ResolvedVerifier{Lookup: "sender1@node1", Verifier: sender1Key.Address.String()},
+			Payload:  sender2Signature.CompactRSV(),
+		},
+	}
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+		Signatures:         forgedSignatures,
+	})
+	assert.Regexp(t, "PD200017.*senders", err)
+
+	// Senders sign directly, and are never asked to endorse
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:         tx,
+		ResolvedVerifiers:   verifiers,
+		Inputs:              inputStates,
+		Outputs:             outputStates,
+		Info:                infoStates,
+		EndorsementRequest:  &prototk.AttestationRequest{Name: "senders"},
+		EndorsementVerifier: verifiers[3],
+	})
+	assert.Regexp(t, "PD200019", err)
+
+	// Value moved to the wrong party is rejected
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates[:2],
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+	})
+	assert.Regexp(t, "PD200013", err)
+	tamperedCoin := *outputCoins[2]
+	tamperedCoin.Owner = pldtypes.MustEthAddress(receiverAddress)
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		Inputs:            inputStates,
+		Outputs: []*prototk.EndorsableState{outputStates[0], outputStates[1], {
+			SchemaId:      "coin",
+			Id:            outputStates[2].Id,
+			StateDataJson: mustParseJSON(tamperedCoin),
+		}},
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+	})
+	assert.Regexp(t, "PD200037", err)
+
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		Inputs:             inputStates,
+		Outputs:            outputStates,
+		Info:               infoStates,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "unknown"},
+	})
+	assert.Regexp(t, "PD200019", err)
+
+	attestations := append(signatures, &prototk.AttestationResult{
+		Name:     "notary",
+		Verifier: &prototk.ResolvedVerifier{Lookup: "notary@node1"},
+	})
+	prepareRes, err := n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: attestations,
+	})
+	require.NoError(t, err)
+	expectedFunction := mustParseJSON(interfaceBuild.ABI.Functions()["transfer"])
+	assert.JSONEq(t, expectedFunction, prepareRes.Transaction.FunctionAbiJson)
+	assert.Nil(t, prepareRes.Transaction.ContractAddress)
+	combinedSignatures := append(pldtypes.HexBytes(sender1Signature.CompactRSV()), sender2Signature.CompactRSV()...)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"inputs": ["%s", "%s"],
+		"outputs": [
+			"0x0000000000000000000000000000000000000000000000000000000000000001",
+			"0x0000000000000000000000000000000000000000000000000000000000000002",
+			"0x0000000000000000000000000000000000000000000000000000000000000003"
+		],
+		"signature": "%s",
+		"data": "0x00010000015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000004"
+	}`, inputCoins[0].ID, inputCoins[1].ID, combinedSignatures), prepareRes.Transaction.ParamsJson)
+
+	_, err = n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: attestations[1:],
+	})
+	assert.Regexp(t, "PD200015.*senders", err)
+
+	_, err = n.PrepareTransaction(ctx, &prototk.PrepareTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+		InputStates:       inputStates,
+		OutputStates:      outputStates,
+		InfoStates:        infoStates,
+		AttestationResult: append(forgedSignatures, attestations[2]),
+	})
+	assert.Regexp(t, "PD200017.*senders", err)
+}
+
+func TestMultiTransferValidateParams(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	h := multiTransferHandler{noto: n}
+	ctx := context.Background()
+
+	_, err := h.ValidateParams(ctx, notoBasicConfig, `{"senders": []}`)
+	assert.Regexp(t, "PD200007.*senders", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"senders": [{"to": "receiver@node2", "amount": 1}]}`)
+	assert.Regexp(t, "PD200007.*from", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"senders": [{"from": "sender@node1", "amount": 1}]}`)
+	assert.Regexp(t, "PD200007.*to", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"senders": [{"from": "sender@node1", "to": "receiver@node2", "amount": 0}]}`)
+	assert.Regexp(t, "PD200008", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"senders": [
+		{"from": "sender@node1", "to": "receiver@node2", "amount": 1},
+		{"from": "sender@node1", "to": "receiver@node3", "amount": 2}
+	]}`)
+	assert.Regexp(t, "PD200035", err)
+
+	_, err = h.ValidateParams(ctx, &types.NotoParsedConfig{NotaryMode: types.NotaryModeHooks.Enum()}, `{"senders": []}`)
+	assert.Regexp(t, "PD200036", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{!!!`)
+	assert.Error(t, err)
+}
+
+func TestMultiTransferAssembleInsufficientFunds(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	h := multiTransferHandler{noto: n}
+	ctx := context.Background()
+
+	mockCallbacks.MockFindAvailableStates = func() (*prototk.FindAvailableStatesResponse, error) {
+		return &prototk.FindAvailableStatesResponse{}, nil
+	}
+	parsedTx := &types.ParsedTransaction{
+		Transaction:     &prototk.TransactionSpecification{From: "sender@node1"},
+		ContractAddress: ethtypes.MustNewAddress("0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3"),
+		DomainConfig:    notoBasicConfig,
+		Params: &types.MultiTransferParams{
+			Senders: []*types.SenderEntry{
+				{From: "sender@node1", To: "receiver@node2", Amount: pldtypes.Int64ToInt256(10)},
+			},
+		},
+	}
+	res, err := h.Assemble(ctx, parsedTx, &prototk.AssembleTransactionRequest{
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "sender@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x1000000000000000000000000000000000000000",
+			},
+			{
+				Lookup:       "receiver@node2",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x2000000000000000000000000000000000000000",
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, res.AssemblyResult)
+	assert.Regexp(t, "PD200005", *res.RevertReason)
+}
@@ -37,7 +37,7 @@ type transferHandler struct {
 
 func (h *transferHandler) ValidateParams(ctx context.Context, config *types.NotoParsedConfig, params string) (interface{}, error) {
 	var transferParams types.TransferParams
-	if err := json.Unmarshal([]byte(params), &transferParams); err != nil {
+	if err := h.noto.unmarshalAmountParams(ctx, params, &transferParams); err != nil {
 		return nil, err
 	}
 	if transferParams.To == "" {
@@ -364,3 +364,26 @@ func TestTransferAssembleMissingTo(t *testing.T) {
 	_, err := h.Assemble(ctx, parsedTx, req)
 	assert.Regexp(t, "PD200011.*'to'", err)
 }
+
+func TestTransferValidateParamsDecimals(t *testing.T) {
+	n := &Noto{
+		Callbacks: mockCallbacks,
+		config:    types.DomainConfig{Decimals: 2},
+	}
+	h := transferHandler{noto: n}
+	ctx := context.Background()
+
+	params, err := h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "12.34"}`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), params.(*types.TransferParams).Amount.Int().Int64())
+
+	params, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "1."}`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), params.(*types.TransferParams).Amount.Int().Int64())
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "1.234"}`)
+	assert.Regexp(t, "PD200034", err)
+
+	_, err = h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "-1"}`)
+	assert.Regexp(t, "PD200033", err)
+}
@@ -18,6 +18,7 @@ package noto
 import (
 	"context"
 	"math/big"
+	"strings"
 
 	"encoding/json"
 
@@ -38,8 +39,12 @@ func (n *Noto) GetHandler(method string) types.DomainHandler {
 		return &mintHandler{noto: n}
 	case "transfer":
 		return &transferHandler{noto: n}
+	case "multiTransfer":
+		return &multiTransferHandler{noto: n}
 	case "burn":
 		return &burnHandler{noto: n}
+	case "consolidate":
+		return &consolidateHandler{noto: n}
 	case "approveTransfer":
 		return &approveHandler{noto: n}
 	case "lock":
@@ -59,6 +64,62 @@ func (n *Noto) GetHandler(method string) types.DomainHandler {
 	}
 }
 
+// Unmarshal function params containing an "amount", converting decimal amounts to base units when the domain has decimals configured
+func (n *Noto) unmarshalAmountParams(ctx context.Context, params string, v any) error {
+	decimals := n.domainConfig().Decimals
+	if decimals > 0 {
+		var rawParams map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(params), &rawParams); err != nil {
+			return err
+		}
+		if rawAmount, ok := rawParams["amount"]; ok {
+			amount, err := parseDecimalAmount(ctx, "amount", rawAmount, decimals)
+			if err != nil {
+				return err
+			}
+			if amount != nil {
+				rawParams["amount"], _ = json.Marshal(amount.Text(10))
+				b, _ := json.Marshal(rawParams)
+				params = string(b)
+			}
+		}
+	}
+	return json.Unmarshal([]byte(params), v)
+}
+
+// Parse a JSON number or string in decimal notation into base units with the given number of decimal places.
+// Returns nil for values that are not in decimal notation (such as 0x hex strings), which are left as-is.
+func parseDecimalAmount(ctx context.Context, name string, rawAmount json.RawMessage, decimals uint8) (*big.Int, error) {
+	var amount any
+	decoder := json.NewDecoder(strings.NewReader(string(rawAmount)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&amount); err != nil {
+		return nil, err
+	}
+	var s string
+	switch v := amount.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = strings.TrimSpace(v)
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" || strings.Trim(whole, "0123456789") != "" || strings.Trim(fraction, "0123456789") != "" {
+		return nil, i18n.NewError(ctx, msgs.MsgInvalidDecimalAmount, name, s)
+	}
+	if len(fraction) > int(decimals) {
+		return nil, i18n.NewError(ctx, msgs.MsgTooManyDecimalPlaces, name, decimals, s)
+	}
+	value, _ := new(big.Int).SetString(whole+fraction+strings.Repeat("0", int(decimals)-len(fraction)), 10)
+	return value, nil
+}
+
 // Check that a mint has no inputs, and an output matching the requested amount
 func (n *Noto) validateMintAmounts(ctx context.Context, params *types.MintParams, inputs, outputs *parsedCoins) error {
 	if len(inputs.coins) > 0 {
@@ -93,6 +154,20 @@ func (n *Noto) validateBurnAmounts(ctx context.Context, params *types.BurnParams
 	return nil
 }
 
+// Check that a consolidation has multiple inputs, and a single output of the same total
+func (n *Noto) validateConsolidateAmounts(ctx context.Context, inputs, outputs *parsedCoins) error {
+	if len(inputs.coins) < 2 {
+		return i18n.NewError(ctx, msgs.MsgInvalidInputs, "consolidate", inputs.coins)
+	}
+	if len(outputs.coins) != 1 {
+		return i18n.NewError(ctx, msgs.MsgInvalidOutputs, "consolidate", outputs.coins)
+	}
+	if inputs.total.Cmp(outputs.total) != 0 {
+		return i18n.NewError(ctx, msgs.MsgInvalidAmount, "consolidate", inputs.total.Text(10), outputs.total.Text(10))
+	}
+	return nil
+}
+
 // Check that a lock produces locked coins matching the difference between the inputs and outputs
 func (n *Noto) validateLockAmounts(ctx context.Context, inputs, outputs *parsedCoins) error {
 	if len(inputs.coins) == 0 {
@@ -133,6 +208,28 @@ func (n *Noto) validateSignature(ctx context.Context, name string, attestations
 	return nil
 }
 
+// Check that the signature from one of several parties to an attestation recovers to that party's address
+func (n *Noto) validatePartySignature(ctx context.Context, name, party string, address *pldtypes.EthAddress, attestations []*prototk.AttestationResult, encodedMessage []byte) error {
+	var signature *prototk.AttestationResult
+	for _, ar := range attestations {
+		if ar.Name == name && ar.Verifier != nil && ar.Verifier.Lookup == party {
+			signature = ar
+			break
+		}
+	}
+	if signature == nil {
+		return i18n.NewError(ctx, msgs.MsgAttestationNotFound, name)
+	}
+	recoveredSignature, err := n.recoverSignature(ctx, encodedMessage, signature.Payload)
+	if err != nil {
+		return err
+	}
+	if !address.Equals((*pldtypes.EthAddress)(recoveredSignature)) {
+		return i18n.NewError(ctx, msgs.MsgSignatureDoesNotMatch, name, address, recoveredSignature.String())
+	}
+	return nil
+}
+
 // Check that all coins are owned by the transaction sender
 func (n *Noto) validateOwners(ctx context.Context, owner string, req *prototk.EndorseTransactionRequest, coins []*types.NotoCoin, states []*prototk.StateRef) error {
 	fromAddress, err := n.findEthAddressVerifier(ctx, "from", owner, req.ResolvedVerifiers)
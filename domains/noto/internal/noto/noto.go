@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
@@ -91,6 +92,7 @@ type Noto struct {
 	Callbacks plugintk.DomainCallbacks
 
 	name             string
+	configLock       sync.RWMutex
 	config           types.DomainConfig
 	chainID          int64
 	coinSchema       *prototk.StateSchema
@@ -272,13 +274,27 @@ func (n *Noto) DataSchemaID() string {
 }
 
 func (n *Noto) ConfigureDomain(ctx context.Context, req *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error) {
-	err := json.Unmarshal([]byte(req.ConfigJson), &n.config)
+	var config types.DomainConfig
+	err := json.Unmarshal([]byte(req.ConfigJson), &config)
 	if err != nil {
 		return nil, err
 	}
 
-	n.name = req.Name
-	n.chainID = req.ChainId
+	n.configLock.Lock()
+	defer n.configLock.Unlock()
+
+	// ConfigureDomain is called again when the domain is reconfigured at runtime, while transactions
+	// are in flight. Only the domain config can change then, and it is only read under the lock.
+	if n.name != "" {
+		if config.FactoryAddress != n.config.FactoryAddress {
+			return nil, i18n.NewError(ctx, msgs.MsgFactoryAddressChanged, n.config.FactoryAddress, config.FactoryAddress)
+		}
+		n.config = config
+	} else {
+		n.config = config
+		n.name = req.Name
+		n.chainID = req.ChainId
+	}
 
 	return &prototk.ConfigureDomainResponse{
 		DomainConfig: &prototk.DomainConfig{
@@ -288,6 +304,12 @@ func (n *Noto) ConfigureDomain(ctx context.Context, req *prototk.ConfigureDomain
 	}, nil
 }
 
+func (n *Noto) domainConfig() types.DomainConfig {
+	n.configLock.RLock()
+	defer n.configLock.RUnlock()
+	return n.config
+}
+
 func (n *Noto) InitDomain(ctx context.Context, req *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error) {
 	for i, schema := range allSchemas {
 		switch schema.Name {
@@ -359,7 +381,8 @@ func (n *Noto) PrepareDeploy(ctx context.Context, req *prototk.PrepareDeployRequ
 	}
 
 	deployData := &types.NotoConfigData_V0{
-		NotaryLookup: notaryQualified.String(),
+		NotaryLookup:   notaryQualified.String(),
+		ExpiresAtBlock: params.ExpiresAtBlock,
 	}
 	switch params.NotaryMode {
 	case types.NotaryModeBasic:
@@ -434,10 +457,11 @@ func (n *Noto) InitContract(ctx context.Context, req *prototk.InitContractReques
 	}
 
 	parsedConfig := &types.NotoParsedConfig{
-		NotaryMode:   types.NotaryModeBasic.Enum(),
-		Variant:      domainConfig.Variant,
-		NotaryLookup: decodedData.NotaryLookup,
-		IsNotary:     notaryNodeName == localNodeName.Name,
+		NotaryMode:     types.NotaryModeBasic.Enum(),
+		Variant:        domainConfig.Variant,
+		NotaryLookup:   decodedData.NotaryLookup,
+		IsNotary:       notaryNodeName == localNodeName.Name,
+		ExpiresAtBlock: decodedData.ExpiresAtBlock,
 	}
 	if decodedData.NotaryMode == types.NotaryModeIntHooks {
 		parsedConfig.NotaryMode = types.NotaryModeHooks.Enum()
@@ -480,6 +504,13 @@ func (n *Noto) AssembleTransaction(ctx context.Context, req *prototk.AssembleTra
 	if err != nil {
 		return nil, err
 	}
+	if err := n.checkExpiry(ctx, tx); err != nil {
+		message := err.Error()
+		return &prototk.AssembleTransactionResponse{
+			AssemblyResult: prototk.AssembleTransactionResponse_REVERT,
+			RevertReason:   &message,
+		}, nil
+	}
 	return handler.Assemble(ctx, tx, req)
 }
 
@@ -488,9 +519,23 @@ func (n *Noto) EndorseTransaction(ctx context.Context, req *prototk.EndorseTrans
 	if err != nil {
 		return nil, err
 	}
+	if err := n.checkExpiry(ctx, tx); err != nil {
+		return nil, err
+	}
 	return handler.Endorse(ctx, tx, req)
 }
 
+// Once a contract has expired, its coins can no longer be spent (and no new coins can be created).
+// The expiry is checked against the base block of the transaction, which is set by the notary as the
+// coordinator, so the assembly and endorsement of a transaction always reach the same result.
+func (n *Noto) checkExpiry(ctx context.Context, tx *types.ParsedTransaction) error {
+	expiresAtBlock := tx.DomainConfig.ExpiresAtBlock
+	if expiresAtBlock != nil && tx.Transaction.BaseBlock >= int64(expiresAtBlock.Uint64()) {
+		return i18n.NewError(ctx, msgs.MsgContractExpired, expiresAtBlock.Uint64(), tx.Transaction.BaseBlock)
+	}
+	return nil
+}
+
 func (n *Noto) PrepareTransaction(ctx context.Context, req *prototk.PrepareTransactionRequest) (*prototk.PrepareTransactionResponse, error) {
 	tx, handler, err := n.validateTransaction(ctx, req.Transaction)
 	if err != nil {
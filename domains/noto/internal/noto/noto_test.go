@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/hyperledger/firefly-signer/pkg/abi"
@@ -97,6 +98,64 @@ func TestNotoDomainInit(t *testing.T) {
 	assert.Equal(t, "schema4", n.DataSchemaID())
 }
 
+func TestNotoDomainReconfigure(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	_, err := n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		Name:       "noto",
+		ConfigJson: `{"factoryAddress":"0x1234"}`,
+	})
+	require.NoError(t, err)
+
+	configureRes, err := n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		Name:       "noto",
+		ConfigJson: `{"factoryAddress":"0x1234"}`,
+	})
+	require.NoError(t, err)
+	assert.Len(t, configureRes.DomainConfig.AbiStateSchemasJson, 4)
+
+	_, err = n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		Name:       "noto",
+		ConfigJson: `{"factoryAddress":"0x5678"}`,
+	})
+	assert.ErrorContains(t, err, "PD200032")
+	assert.Equal(t, "0x1234", n.domainConfig().FactoryAddress)
+}
+
+func TestNotoDomainReconfigureConcurrent(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	_, err := n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+		Name:       "noto",
+		ConfigJson: `{"factoryAddress":"0x1234","decimals":2}`,
+	})
+	require.NoError(t, err)
+
+	// Reconfigure while handlers are reading the config (run with -race to check)
+	h := n.GetHandler("transfer")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := n.ConfigureDomain(ctx, &prototk.ConfigureDomainRequest{
+				Name:       "noto",
+				ConfigJson: fmt.Sprintf(`{"factoryAddress":"0x1234","decimals":%d}`, 2+i%2),
+			})
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := h.ValidateParams(ctx, notoBasicConfig, `{"to": "receiver@node2", "amount": "1.5", "data": "0x"}`)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, "noto", n.Name())
+}
+
 func TestNotoDomainDeployDefaults(t *testing.T) {
 	n := &Noto{Callbacks: mockCallbacks}
 	ctx := context.Background()
@@ -216,6 +275,115 @@ func TestNotoDomainDeployBasicConfig(t *testing.T) {
 	assert.True(t, initContractRes.Valid)
 }
 
+func TestNotoDomainDeployExpiry(t *testing.T) {
+	n := &Noto{Callbacks: mockCallbacks}
+	ctx := context.Background()
+
+	prepareDeployRes, err := n.PrepareDeploy(ctx, &prototk.PrepareDeployRequest{
+		Transaction: &prototk.DeployTransactionSpecification{
+			TransactionId: "tx1",
+			ConstructorParamsJson: `{
+				"notary": "notary@node1",
+				"notaryMode": "basic",
+				"expiresAtBlock": 12345
+			}`,
+		},
+		ResolvedVerifiers: []*prototk.ResolvedVerifier{
+			{
+				Lookup:       "notary@node1",
+				Algorithm:    algorithms.ECDSA_SECP256K1,
+				VerifierType: verifiers.ETH_ADDRESS,
+				Verifier:     "0x6e2430d15301a7ee28ceaaee0dff9781f8f82f71",
+			},
+		},
+	})
+	require.NoError(t, err)
+	var deployParams map[string]any
+	err = json.Unmarshal([]byte(prepareDeployRes.Transaction.ParamsJson), &deployParams)
+	require.NoError(t, err)
+	var deployData types.NotoConfigData_V0
+	err = json.Unmarshal(pldtypes.MustParseHexBytes(deployParams["data"].(string)), &deployData)
+	require.NoError(t, err)
+	require.NotNil(t, deployData.ExpiresAtBlock)
+	assert.Equal(t, uint64(12345), deployData.ExpiresAtBlock.Uint64())
+
+	initContractRes, err := n.InitContract(ctx, &prototk.InitContractRequest{
+		ContractAddress: "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+		ContractConfig:  encodedConfig(&deployData),
+	})
+	require.NoError(t, err)
+	assert.True(t, initContractRes.Valid)
+	var parsedConfig types.NotoParsedConfig
+	err = json.Unmarshal([]byte(initContractRes.ContractConfig.ContractConfigJson), &parsedConfig)
+	require.NoError(t, err)
+	require.NotNil(t, parsedConfig.ExpiresAtBlock)
+	assert.Equal(t, uint64(12345), parsedConfig.ExpiresAtBlock.Uint64())
+}
+
+func TestTransactionExpired(t *testing.T) {
+	n := &Noto{
+		Callbacks:  mockCallbacks,
+		coinSchema: &prototk.StateSchema{Id: "coin"},
+		dataSchema: &prototk.StateSchema{Id: "data"},
+	}
+	ctx := context.Background()
+	fn := types.NotoABI.Functions()["mint"]
+
+	expiredConfig := *notoBasicConfig
+	expiresAtBlock := pldtypes.HexUint64(100)
+	expiredConfig.ExpiresAtBlock = &expiresAtBlock
+	tx := &prototk.TransactionSpecification{
+		TransactionId: "0x015e1881f2ba769c22d05c841f06949ec6e1bd573f5e1e0328885494212f077d",
+		From:          "notary@node1",
+		ContractInfo: &prototk.ContractInfo{
+			ContractAddress:    "0xf6a75f065db3cef95de7aa786eee1d0cb1aeafc3",
+			ContractConfigJson: mustParseJSON(&expiredConfig),
+		},
+		FunctionAbiJson:    mustParseJSON(fn),
+		FunctionSignature:  fn.SolString(),
+		FunctionParamsJson: `{"to": "receiver@node2", "amount": 100, "data": "0x"}`,
+		BaseBlock:          100,
+	}
+	verifiers := []*prototk.ResolvedVerifier{
+		{
+			Lookup:       "notary@node1",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     "0x1000000000000000000000000000000000000000",
+		},
+		{
+			Lookup:       "receiver@node2",
+			Algorithm:    algorithms.ECDSA_SECP256K1,
+			VerifierType: verifiers.ETH_ADDRESS,
+			Verifier:     "0x2000000000000000000000000000000000000000",
+		},
+	}
+
+	assembleRes, err := n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_REVERT, assembleRes.AssemblyResult)
+	assert.Regexp(t, "PD200041.*100", *assembleRes.RevertReason)
+
+	_, err = n.EndorseTransaction(ctx, &prototk.EndorseTransactionRequest{
+		Transaction:        tx,
+		ResolvedVerifiers:  verifiers,
+		EndorsementRequest: &prototk.AttestationRequest{Name: "notary"},
+	})
+	assert.Regexp(t, "PD200041", err)
+
+	// Before the expiry block, transactions are processed as normal
+	tx.BaseBlock = 99
+	assembleRes, err = n.AssembleTransaction(ctx, &prototk.AssembleTransactionRequest{
+		Transaction:       tx,
+		ResolvedVerifiers: verifiers,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prototk.AssembleTransactionResponse_OK, assembleRes.AssemblyResult)
+}
+
 func TestNotoDomainDeployHooksConfig(t *testing.T) {
 	n := &Noto{Callbacks: mockCallbacks}
 	ctx := context.Background()
@@ -256,6 +256,41 @@ func (n *Noto) prepareInputs(ctx context.Context, stateQueryContext string, owne
 	}
 }
 
+// Select up to maxInputs of the oldest available coins for an owner, with no target amount
+func (n *Noto) prepareConsolidationInputs(ctx context.Context, stateQueryContext string, owner *pldtypes.EthAddress, maxInputs uint64) (inputs *preparedInputs, revert bool, err error) {
+	queryBuilder := query.NewQueryBuilder().
+		Limit(int(maxInputs)).
+		Sort(".created").
+		Equal("owner", owner.String())
+
+	log.L(ctx).Debugf("State query: %s", queryBuilder.Query())
+	states, err := n.findAvailableStates(ctx, stateQueryContext, n.coinSchema.Id, queryBuilder.Query().String())
+	if err != nil {
+		return nil, false, err
+	}
+	if len(states) < 2 {
+		return nil, true, i18n.NewError(ctx, msgs.MsgNothingToConsolidate, len(states))
+	}
+	inputs = &preparedInputs{
+		coins:  make([]*types.NotoCoin, 0, len(states)),
+		states: make([]*prototk.StateRef, 0, len(states)),
+		total:  big.NewInt(0),
+	}
+	for _, state := range states {
+		coin, err := n.unmarshalCoin(state.DataJson)
+		if err != nil {
+			return nil, false, i18n.NewError(ctx, msgs.MsgInvalidStateData, state.Id, err)
+		}
+		inputs.total = inputs.total.Add(inputs.total, coin.Amount.Int())
+		inputs.states = append(inputs.states, &prototk.StateRef{
+			SchemaId: state.SchemaId,
+			Id:       state.Id,
+		})
+		inputs.coins = append(inputs.coins, coin)
+	}
+	return inputs, false, nil
+}
+
 func (n *Noto) prepareLockedInputs(ctx context.Context, stateQueryContext string, lockID pldtypes.Bytes32, owner *pldtypes.EthAddress, amount *big.Int) (inputs *preparedLockedInputs, revert bool, err error) {
 	var lastStateTimestamp int64
 	total := big.NewInt(0)
@@ -30,10 +30,11 @@ var notoPrivateJSON []byte
 var NotoABI = solutils.MustParseBuildABI(notoPrivateJSON)
 
 type ConstructorParams struct {
-	Notary         string      `json:"notary"`                   // Lookup string for the notary identity
-	NotaryMode     NotaryMode  `json:"notaryMode"`               // Notary mode (basic or hooks)
-	Implementation string      `json:"implementation,omitempty"` // Use a specific implementation of Noto that was registered to the factory (blank to use default)
-	Options        NotoOptions `json:"options"`                  // Configure options for the chosen notary mode
+	Notary         string              `json:"notary"`                   // Lookup string for the notary identity
+	NotaryMode     NotaryMode          `json:"notaryMode"`               // Notary mode (basic or hooks)
+	Implementation string              `json:"implementation,omitempty"` // Use a specific implementation of Noto that was registered to the factory (blank to use default)
+	Options        NotoOptions         `json:"options"`                  // Configure options for the chosen notary mode
+	ExpiresAtBlock *pldtypes.HexUint64 `json:"expiresAtBlock,omitempty"` // Base ledger block number from which the notary will no longer process any transactions (blank for no expiry)
 }
 
 type NotaryMode string
@@ -66,11 +67,27 @@ type TransferParams struct {
 	Data   pldtypes.HexBytes    `json:"data"`
 }
 
+type MultiTransferParams struct {
+	Senders []*SenderEntry    `json:"senders"`
+	Data    pldtypes.HexBytes `json:"data"`
+}
+
+type SenderEntry struct {
+	From   string               `json:"from"`
+	To     string               `json:"to"`
+	Amount *pldtypes.HexUint256 `json:"amount"`
+}
+
 type BurnParams struct {
 	Amount *pldtypes.HexUint256 `json:"amount"`
 	Data   pldtypes.HexBytes    `json:"data"`
 }
 
+type ConsolidateParams struct {
+	MaxInputs pldtypes.HexUint64 `json:"maxInputs"`
+	Data      pldtypes.HexBytes  `json:"data"`
+}
+
 type ApproveParams struct {
 	Inputs   []*pldapi.StateEncoded `json:"inputs"`
 	Outputs  []*pldapi.StateEncoded `json:"outputs"`
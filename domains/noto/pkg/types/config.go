@@ -23,6 +23,9 @@ import (
 
 type DomainConfig struct {
 	FactoryAddress string `json:"factoryAddress"`
+	// If set, mint, transfer and burn amounts in decimal notation (such as "1.5") are treated as whole
+	// token units and scaled by 10^decimals. Amounts in 0x hex notation are always in base units.
+	Decimals uint8 `json:"decimals"`
 }
 
 var NotoConfigID_V0 = pldtypes.MustParseHexBytes("0x00010000")
@@ -43,15 +46,17 @@ type NotoConfigData_V0 struct {
 	RestrictMint   bool                 `json:"restrictMint"`
 	AllowBurn      bool                 `json:"allowBurn"`
 	AllowLock      bool                 `json:"allowLock"`
+	ExpiresAtBlock *pldtypes.HexUint64  `json:"expiresAtBlock,omitempty"`
 }
 
 // This is the structure we parse the config into in InitConfig and gets passed back to us on every call
 type NotoParsedConfig struct {
-	NotaryLookup string                    `json:"notaryLookup"`
-	NotaryMode   pldtypes.Enum[NotaryMode] `json:"notaryMode"`
-	Variant      pldtypes.HexUint64        `json:"variant"`
-	IsNotary     bool                      `json:"isNotary"`
-	Options      NotoOptions               `json:"options"`
+	NotaryLookup   string                    `json:"notaryLookup"`
+	NotaryMode     pldtypes.Enum[NotaryMode] `json:"notaryMode"`
+	Variant        pldtypes.HexUint64        `json:"variant"`
+	IsNotary       bool                      `json:"isNotary"`
+	Options        NotoOptions               `json:"options"`
+	ExpiresAtBlock *pldtypes.HexUint64       `json:"expiresAtBlock,omitempty"`
 }
 
 type NotoOptions struct {
@@ -51,6 +51,9 @@ func (dc *testDomainCallbacks) DecodeData(ctx context.Context, req *pb.DecodeDat
 func (dc *testDomainCallbacks) GetStatesByID(ctx context.Context, req *pb.GetStatesByIDRequest) (*pb.GetStatesByIDResponse, error) {
 	return nil, nil
 }
+func (dc *testDomainCallbacks) PushStateUpdate(ctx context.Context, req *pb.PushStateUpdateRequest) (*pb.PushStateUpdateResponse, error) {
+	return nil, nil
+}
 func (dc *testDomainCallbacks) LocalNodeName(context.Context, *pb.LocalNodeNameRequest) (*pb.LocalNodeNameResponse, error) {
 	return nil, nil
 }
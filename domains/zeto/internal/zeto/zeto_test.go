@@ -64,6 +64,9 @@ func (dc *testDomainCallbacks) DecodeData(context.Context, *pb.DecodeDataRequest
 func (dc *testDomainCallbacks) GetStatesByID(ctx context.Context, req *pb.GetStatesByIDRequest) (*pb.GetStatesByIDResponse, error) {
 	return nil, nil
 }
+func (dc *testDomainCallbacks) PushStateUpdate(ctx context.Context, req *pb.PushStateUpdateRequest) (*pb.PushStateUpdateResponse, error) {
+	return nil, nil
+}
 func (dc *testDomainCallbacks) LocalNodeName(context.Context, *pb.LocalNodeNameRequest) (*pb.LocalNodeNameResponse, error) {
 	return nil, nil
 }
@@ -83,3 +83,23 @@ type PublicTxWithBinding struct {
 	*PublicTx
 	PublicTxBinding
 }
+
+type SignerStatus string
+
+const (
+	SignerStatusActive    SignerStatus = "active"    // new transactions are picked up for submission
+	SignerStatusSuspended SignerStatus = "suspended" // no new transactions are picked up, and none are in-flight
+	SignerStatusDraining  SignerStatus = "draining"  // no new transactions are picked up, but in-flight transactions are still being tracked to completion
+)
+
+func (ss SignerStatus) Enum() pldtypes.Enum[SignerStatus] {
+	return pldtypes.Enum[SignerStatus](ss)
+}
+
+func (ss SignerStatus) Options() []string {
+	return []string{
+		string(SignerStatusActive),
+		string(SignerStatusSuspended),
+		string(SignerStatusDraining),
+	}
+}
@@ -97,6 +97,7 @@ type StateBase struct {
 	Schema          pldtypes.Bytes32     `docstruct:"State" json:"schema"`
 	ContractAddress *pldtypes.EthAddress `docstruct:"State" json:"contractAddress"` // nil used for states like privacy group genesis that exists before state creation
 	Data            pldtypes.RawJSON     `docstruct:"State" json:"data"`
+	ExternalID      bool                 `docstruct:"State" json:"externalId,omitempty"`
 }
 
 // Like StateBase, but encodes Data as HexBytes
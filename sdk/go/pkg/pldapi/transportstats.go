@@ -0,0 +1,26 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pldapi
+
+type TransportStats struct {
+	Name          string `docstruct:"TransportStats" json:"name"`
+	SentMsgs      uint64 `docstruct:"TransportStats" json:"sentMsgs"`
+	ReceivedMsgs  uint64 `docstruct:"TransportStats" json:"receivedMsgs"`
+	SentBytes     uint64 `docstruct:"TransportStats" json:"sentBytes"`
+	ReceivedBytes uint64 `docstruct:"TransportStats" json:"receivedBytes"`
+	SendErrors    uint64 `docstruct:"TransportStats" json:"sendErrors"`
+}
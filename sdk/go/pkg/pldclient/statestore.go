@@ -27,6 +27,7 @@ type StateStore interface {
 	RPCModule
 
 	ListSchemas(ctx context.Context, domain string) (schemas []*pldapi.Schema, err error)
+	GetState(ctx context.Context, domain string, contractAddress *pldtypes.EthAddress, stateID pldtypes.HexBytes) (state *pldapi.State, err error)
 	StoreState(ctx context.Context, domain string, contractAddress pldtypes.EthAddress, schemaRef pldtypes.Bytes32, data pldtypes.RawJSON) (state *pldapi.State, err error)
 	QueryStates(ctx context.Context, domain string, schemaRef pldtypes.Bytes32, query *query.QueryJSON, qualifier pldapi.StateStatusQualifier) (states []*pldapi.State, err error)
 	QueryContractStates(ctx context.Context, domain string, contractAddress pldtypes.EthAddress, schemaRef pldtypes.Bytes32, query *query.QueryJSON, qualifier pldapi.StateStatusQualifier) (states []*pldapi.State, err error)
@@ -42,6 +43,10 @@ var stateStoreInfo = &rpcModuleInfo{
 			Inputs: []string{"domain"},
 			Output: "schemas",
 		},
+		"pstate_getState": {
+			Inputs: []string{"domain", "contractAddress", "stateId"},
+			Output: "state",
+		},
 		"pstate_storeState": {
 			Inputs: []string{"domain", "contractAddress", "schemaRef", "data"},
 			Output: "state",
@@ -79,6 +84,11 @@ func (r *stateStore) ListSchemas(ctx context.Context, domain string) (schemas []
 	return
 }
 
+func (r *stateStore) GetState(ctx context.Context, domain string, contractAddress *pldtypes.EthAddress, stateID pldtypes.HexBytes) (state *pldapi.State, err error) {
+	err = r.c.CallRPC(ctx, &state, "pstate_getState", domain, contractAddress, stateID)
+	return
+}
+
 func (r *stateStore) StoreState(ctx context.Context, domain string, contractAddress pldtypes.EthAddress, schemaRef pldtypes.Bytes32, data pldtypes.RawJSON) (state *pldapi.State, err error) {
 	err = r.c.CallRPC(ctx, &state, "pstate_storeState", domain, contractAddress, schemaRef, data)
 	return
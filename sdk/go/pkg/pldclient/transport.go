@@ -30,6 +30,7 @@ type Transport interface {
 	LocalTransportDetails(ctx context.Context, transportName string) (transportDetailsStr string, err error)
 	Peers(ctx context.Context) (peers []*pldapi.PeerInfo, err error)
 	PeerInfo(ctx context.Context, nodeName string) (peer *pldapi.PeerInfo, err error)
+	TransportStats(ctx context.Context) (transportStats []*pldapi.TransportStats, err error)
 	QueryReliableMessages(ctx context.Context, query *query.QueryJSON) (reliableMessages []*pldapi.ReliableMessage, err error)
 	QueryReliableMessageAcks(ctx context.Context, query *query.QueryJSON) (reliableMessageAcks []*pldapi.ReliableMessageAck, err error)
 }
@@ -58,6 +59,10 @@ var transportInfo = &rpcModuleInfo{
 			Inputs: []string{"nodeName"},
 			Output: "peer",
 		},
+		"transport_transportStats": {
+			Inputs: []string{},
+			Output: "transportStats",
+		},
 		"transport_queryReliableMessages": {
 			Inputs: []string{"query"},
 			Output: "reliableMessages",
@@ -105,6 +110,11 @@ func (t *transport) PeerInfo(ctx context.Context, nodeName string) (peer *pldapi
 	return
 }
 
+func (t *transport) TransportStats(ctx context.Context) (transportStats []*pldapi.TransportStats, err error) {
+	err = t.c.CallRPC(ctx, &transportStats, "transport_transportStats")
+	return
+}
+
 func (t *transport) QueryReliableMessages(ctx context.Context, query *query.QueryJSON) (reliableMessages []*pldapi.ReliableMessage, err error) {
 	err = t.c.CallRPC(ctx, &reliableMessages, "transport_queryReliableMessages", query)
 	return
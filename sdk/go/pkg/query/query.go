@@ -61,6 +61,9 @@ type QueryBuilder interface {
 	// NotIn adds a not in filter to the query
 	NotIn(field string, values []any, adds ...addOns) QueryBuilder
 
+	// Between adds an inclusive range filter to the query
+	Between(field string, from, to any, adds ...addOns) QueryBuilder
+
 	// Null adds an is null filter to the query
 	Null(field string) QueryBuilder
 
@@ -180,6 +183,12 @@ func (qb *queryBuilderImpl) NotIn(field string, values []any, adds ...addOns) Qu
 	return qb
 }
 
+// Between adds an inclusive range filter to the query
+func (qb *queryBuilderImpl) Between(field string, from, to any, adds ...addOns) QueryBuilder {
+	qb.statements.Between = append(qb.statements.Between, buildMultiValueOp(field, []any{from, to}, adds...))
+	return qb
+}
+
 // Null adds an is null filter to the query
 func (qb *queryBuilderImpl) Null(field string) QueryBuilder {
 	qb.statements.Null = append(qb.statements.Null, buildOp(field))
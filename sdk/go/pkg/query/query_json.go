@@ -76,8 +76,11 @@ type Ops struct {
 	GreaterThanOrEqual []*OpSingleVal `docstruct:"Ops" json:"greaterThanOrEqual,omitempty"`
 	GTE                []*OpSingleVal `docstruct:"Ops" json:"gte,omitempty"` // short name
 	In                 []*OpMultiVal  `docstruct:"Ops" json:"in,omitempty"`
-	NIn                []*OpMultiVal  `docstruct:"Ops" json:"nin,omitempty"` // negated short name
+	NIn                []*OpMultiVal  `docstruct:"Ops" json:"nin,omitempty"`     // negated short name
+	Between            []*OpMultiVal  `docstruct:"Ops" json:"between,omitempty"` // exactly two values, the lower and upper bound (inclusive)
 	Null               []*Op          `docstruct:"Ops" json:"null,omitempty"`
+	IsNull             []*Op          `docstruct:"Ops" json:"isNull,omitempty"`    // alternative name
+	IsNotNull          []*Op          `docstruct:"Ops" json:"isNotNull,omitempty"` // negated alternative name
 }
 
 func (jq *QueryJSON) String() string {
@@ -83,6 +83,9 @@ func TestQuery(t *testing.T) {
         "nin": [
             { "field": "field9", "values": ["x","y","z"] }
         ],
+        "between": [
+            { "field": "field13", "values": [1,10] }
+        ],
         "null": [
             { "field": "field10", "not": true },
             { "field": "field11" }
@@ -104,6 +107,7 @@ func TestQuery(t *testing.T) {
 		NotNull("field10").
 		Null("field11").
 		Equal("field12", "value12", Not, CaseInsensitive).
+		Between("field13", 1, 10).
 		Query()
 
 	jsonQuery, err := query.JSON()
@@ -39,7 +39,10 @@ type RPCCode int64
 const (
 	RPCCodeParseError     RPCCode = -32700
 	RPCCodeInvalidRequest RPCCode = -32600
+	RPCCodeMethodNotFound RPCCode = -32601
+	RPCCodeInvalidParams  RPCCode = -32602
 	RPCCodeInternalError  RPCCode = -32603
+	RPCCodeLimitExceeded  RPCCode = -32005
 )
 
 // NewRPCClient Constructor
@@ -99,6 +102,14 @@ func EthSubscribeConfig() SubscriptionConfig {
 	}
 }
 
+func PldSubscribeConfig() SubscriptionConfig {
+	return SubscriptionConfig{
+		SubscribeMethod:    "pld_subscribe",
+		UnsubscribeMethod:  "pld_unsubscribe",
+		NotificationMethod: "pld_subscription",
+	}
+}
+
 type WSClient interface {
 	Client
 	Subscribe(ctx context.Context, conf SubscriptionConfig, params ...interface{}) (Subscription, ErrorRPC)
@@ -106,6 +117,18 @@ type WSClient interface {
 	UnsubscribeAll(ctx context.Context) ErrorRPC
 	Connect(ctx context.Context) error
 	Close()
+	ConnectionStats() *WSConnectionStats
+	// OnReconnect registers a handler that is called after every reconnect (but not the initial connect).
+	// Handlers are called on a separate goroutine once the connection is ready, so can make RPC calls.
+	OnReconnect(handler func(ctx context.Context))
+}
+
+// Connection level statistics for a WebSocket, which are maintained across reconnects
+type WSConnectionStats struct {
+	URL             string             `json:"url"`
+	ConnectedSince  pldtypes.Timestamp `json:"connectedSince,omitempty"`
+	ReconnectCount  uint64             `json:"reconnectCount"`
+	LastReconnectAt pldtypes.Timestamp `json:"lastReconnectAt,omitempty"`
 }
 
 type rpcClient struct {
@@ -43,8 +43,15 @@ func NewWSClient(ctx context.Context, conf *pldconf.WSClientConfig) (WSClient, e
 }
 
 func WrapWSConfig(conf *pldconf.WSClientConfig) WSClient {
+	return WrapWSConfigWithDNSRefresh(conf, nil)
+}
+
+// WrapWSConfigWithDNSRefresh re-resolves the hostname of the WebSocket URL before each reconnect,
+// and periodically while connected, so the client follows changes to the IP addresses of a service.
+func WrapWSConfigWithDNSRefresh(conf *pldconf.WSClientConfig, dnsRefresh *wsclient.DNSRefreshOptions) WSClient {
 	return &wsRPCClient{
 		wsConf:              *conf,
+		dnsRefresh:          dnsRefresh,
 		calls:               make(map[string]chan *RPCResponse),
 		configuredSubs:      make(map[uuid.UUID]*sub),
 		pendingSubsByReqID:  make(map[string]*sub),
@@ -99,6 +106,7 @@ func (n *rpcSubscriptionNotification) GetResult() pldtypes.RawJSON {
 type wsRPCClient struct {
 	mux                 sync.Mutex
 	wsConf              pldconf.WSClientConfig
+	dnsRefresh          *wsclient.DNSRefreshOptions
 	client              wsclient.WSClient
 	requestCounter      int64
 	connected           chan struct{}
@@ -107,6 +115,10 @@ type wsRPCClient struct {
 	pendingSubsByReqID  map[string]*sub
 	activeSubsBySubID   map[string]*sub
 	notificationMethods map[string]bool
+	connectCount        uint64
+	connectedSince      pldtypes.Timestamp
+	lastReconnectAt     pldtypes.Timestamp
+	reconnectHandlers   []func(ctx context.Context)
 }
 
 type sub struct {
@@ -123,7 +135,7 @@ type sub struct {
 }
 
 func (rc *wsRPCClient) Connect(ctx context.Context) (err error) {
-	rc.client, err = wsclient.New(ctx, &rc.wsConf, nil, rc.handleReconnect)
+	rc.client, err = wsclient.NewWithDNSRefresh(ctx, &rc.wsConf, nil, rc.handleReconnect, rc.dnsRefresh)
 	if err != nil {
 		return err
 	}
@@ -153,7 +165,43 @@ func (rc *wsRPCClient) Close() {
 	}
 }
 
+func (rc *wsRPCClient) ConnectionStats() *WSConnectionStats {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+	stats := &WSConnectionStats{
+		URL:             rc.wsConf.URL,
+		ConnectedSince:  rc.connectedSince,
+		LastReconnectAt: rc.lastReconnectAt,
+	}
+	if rc.connectCount > 1 {
+		stats.ReconnectCount = rc.connectCount - 1
+	}
+	return stats
+}
+
+func (rc *wsRPCClient) OnReconnect(handler func(ctx context.Context)) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+	rc.reconnectHandlers = append(rc.reconnectHandlers, handler)
+}
+
+// recordConnect returns the reconnect handlers to call, if this is a reconnect
+func (rc *wsRPCClient) recordConnect() []func(ctx context.Context) {
+	rc.mux.Lock()
+	defer rc.mux.Unlock()
+	now := pldtypes.TimestampNow()
+	rc.connectCount++
+	if rc.connectCount == 1 {
+		rc.connectedSince = now
+		return nil
+	}
+	rc.lastReconnectAt = now
+	return rc.reconnectHandlers
+}
+
 func (rc *wsRPCClient) handleReconnect(ctx context.Context, w wsclient.WSClient) error {
+	// called on the initial connect, as well as each subsequent reconnect
+	reconnectHandlers := rc.recordConnect()
 	calls, subs := rc.clearActiveReturnConfiguredSubs()
 	for rpcID, c := range calls {
 		rc.deliverCallResponse(c, &RPCResponse{
@@ -174,6 +222,11 @@ func (rc *wsRPCClient) handleReconnect(ctx context.Context, w wsclient.WSClient)
 		close(rc.connected)
 		rc.connected = nil
 	}
+
+	// We are called before the read loop starts, so handlers that make RPC calls must not block us
+	for _, handler := range reconnectHandlers {
+		go handler(ctx)
+	}
 	return nil
 }
 
@@ -425,6 +425,32 @@ func TestHandleReonnnectFail(t *testing.T) {
 	assert.Regexp(t, "PD020505", err)
 }
 
+func TestConnectionStatsReconnects(t *testing.T) {
+	ctx, rc, _, _, done := newTestWSRPC(t)
+	defer done()
+
+	stats := rc.ConnectionStats()
+	assert.Equal(t, rc.wsConf.URL, stats.URL)
+	assert.Zero(t, stats.ConnectedSince)
+
+	var err error
+	rc.client, err = wsclient.New(ctx, &rc.wsConf, nil, nil /* so we can invoke it directly */)
+	assert.NoError(t, err)
+
+	err = rc.handleReconnect(ctx, rc.client)
+	assert.NoError(t, err)
+	stats = rc.ConnectionStats()
+	assert.NotZero(t, stats.ConnectedSince)
+	assert.Zero(t, stats.ReconnectCount)
+	assert.Zero(t, stats.LastReconnectAt)
+
+	err = rc.handleReconnect(ctx, rc.client)
+	assert.NoError(t, err)
+	stats = rc.ConnectionStats()
+	assert.Equal(t, uint64(1), stats.ReconnectCount)
+	assert.NotZero(t, stats.LastReconnectAt)
+}
+
 func TestConnectClosedContextFail(t *testing.T) {
 	ctx, rc, _, _, done := newTestWSRPC(t)
 
@@ -494,3 +520,24 @@ func TestHandleAckNack(t *testing.T) {
 
 	<-subDone
 }
+
+func TestOnReconnectHandlers(t *testing.T) {
+	ctx, rc, _, _, done := newTestWSRPC(t)
+	defer done()
+
+	var err error
+	rc.client, err = wsclient.New(ctx, &rc.wsConf, nil, nil /* so we can invoke it directly */)
+	assert.NoError(t, err)
+
+	reconnected := make(chan struct{}, 2)
+	rc.OnReconnect(func(ctx context.Context) { reconnected <- struct{}{} })
+
+	// not called on the initial connect
+	err = rc.handleReconnect(ctx, rc.client)
+	assert.NoError(t, err)
+	assert.Empty(t, reconnected)
+
+	err = rc.handleReconnect(ctx, rc.client)
+	assert.NoError(t, err)
+	<-reconnected
+}
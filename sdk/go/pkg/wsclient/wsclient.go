@@ -22,6 +22,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -67,6 +68,23 @@ type wsClient struct {
 	heartbeatMux         sync.Mutex
 	activePingSent       *time.Time
 	lastPingCompleted    time.Time
+	dnsRefresh           *DNSRefreshOptions
+	dnsMux               sync.Mutex
+	dialedAddr           string
+	dialedConn           net.Conn
+}
+
+// HostResolver is satisfied by net.DefaultResolver, and allows the DNS lookups to be replaced in tests
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+// DNSRefreshOptions enable explicit resolution of the hostname in the URL before every connect/reconnect,
+// and a periodic check that the address we are connected to is still one the hostname resolves to.
+// This allows a long lived connection to follow a service (such as in Kubernetes) whose IP addresses change.
+type DNSRefreshOptions struct {
+	Interval time.Duration
+	Resolver HostResolver
 }
 
 // WSPreConnectHandler will be called before every connect/reconnect. Any error returned will prevent the websocket from connecting.
@@ -76,6 +94,10 @@ type WSPreConnectHandler func(ctx context.Context, w WSClient) error
 type WSPostConnectHandler func(ctx context.Context, w WSClient) error
 
 func New(ctx context.Context, config *pldconf.WSClientConfig, beforeConnect WSPreConnectHandler, afterConnect WSPostConnectHandler) (WSClient, error) {
+	return NewWithDNSRefresh(ctx, config, beforeConnect, afterConnect, nil)
+}
+
+func NewWithDNSRefresh(ctx context.Context, config *pldconf.WSClientConfig, beforeConnect WSPreConnectHandler, afterConnect WSPostConnectHandler, dnsRefresh *DNSRefreshOptions) (WSClient, error) {
 	l := log.L(ctx)
 
 	url, tlsConfig, err := ValidateConfig(ctx, config)
@@ -105,6 +127,13 @@ func New(ctx context.Context, config *pldconf.WSClientConfig, beforeConnect WSPr
 		heartbeatInterval:    confutil.DurationMin(config.HeartbeatInterval, 0, *pldconf.DefaultWSConfig.HeartbeatInterval),
 	}
 	w.receive = make(chan []byte)
+	if dnsRefresh != nil && dnsRefresh.Interval > 0 {
+		w.dnsRefresh = dnsRefresh
+		if w.dnsRefresh.Resolver == nil {
+			w.dnsRefresh.Resolver = net.DefaultResolver
+		}
+		w.wsdialer.NetDialContext = w.dialResolved
+	}
 
 	for k, v := range config.HTTPHeaders {
 		if vs, ok := v.(string); ok {
@@ -153,6 +182,9 @@ func (w *wsClient) Connect() error {
 	}
 
 	go w.receiveReconnectLoop()
+	if w.dnsRefresh != nil {
+		go w.dnsRefreshLoop()
+	}
 
 	return nil
 }
@@ -247,6 +279,83 @@ func (w *wsClient) connect(initial bool) error {
 	})
 }
 
+// dialResolved is used in place of the default dialer when DNS refresh is enabled, so that the hostname
+// is re-resolved on every connect attempt and we know which of the addresses we connected to
+func (w *wsClient) dialResolved(ctx context.Context, network, addr string) (net.Conn, error) {
+	l := log.L(w.ctx)
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := w.dnsRefresh.Resolver.LookupHost(ctx, host)
+	if err != nil {
+		l.Warnf("WS %s failed to resolve host %s: %s", w.url, host, err)
+		return nil, err
+	}
+	l.Debugf("WS %s resolved host %s to %v", w.url, host, addrs)
+	var dialer net.Dialer
+	for _, a := range addrs {
+		dialAddr := net.JoinHostPort(a, port)
+		var conn net.Conn
+		if conn, err = dialer.DialContext(ctx, network, dialAddr); err == nil {
+			w.dnsMux.Lock()
+			w.dialedAddr = a
+			w.dialedConn = conn
+			w.dnsMux.Unlock()
+			return conn, nil
+		}
+		l.Debugf("WS %s dial to %s failed: %s", w.url, dialAddr, err)
+	}
+	if err == nil {
+		err = i18n.NewError(ctx, pldmsgs.MsgWSClientNoAddressesResolved, host)
+	}
+	return nil, err
+}
+
+// dnsRefreshLoop periodically re-resolves the hostname, and drops the connection if it is to an
+// address the hostname no longer resolves to. The reconnect logic will then dial a current address.
+func (w *wsClient) dnsRefreshLoop() {
+	l := log.L(w.ctx)
+	ticker := time.NewTicker(w.dnsRefresh.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.checkDNSRefresh()
+		case <-w.closing:
+			l.Debugf("WS %s DNS refresh loop exiting", w.url)
+			return
+		}
+	}
+}
+
+func (w *wsClient) checkDNSRefresh() {
+	l := log.L(w.ctx)
+	u, err := url.Parse(w.url)
+	if err != nil {
+		return
+	}
+	addrs, err := w.dnsRefresh.Resolver.LookupHost(w.ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		// We do not drop a working connection just because DNS is temporarily unavailable
+		l.Warnf("WS %s failed to refresh DNS for host %s: %v", w.url, u.Hostname(), err)
+		return
+	}
+	w.dnsMux.Lock()
+	defer w.dnsMux.Unlock()
+	if w.dialedConn == nil {
+		return
+	}
+	for _, a := range addrs {
+		if a == w.dialedAddr {
+			return
+		}
+	}
+	l.Infof("WS %s host %s now resolves to %v (connected to %s) - reconnecting", w.url, u.Hostname(), addrs, w.dialedAddr)
+	_ = w.dialedConn.Close()
+	w.dialedConn = nil
+}
+
 func (w *wsClient) readLoop() {
 	l := log.L(w.ctx)
 	for {
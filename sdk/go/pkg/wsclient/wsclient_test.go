@@ -19,10 +19,12 @@ package wsclient
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path"
+	"sync"
 	"testing"
 	"time"
 
@@ -439,3 +441,135 @@ func TestWSClientContextClosed(t *testing.T) {
 	err = wsc.Send(context.Background(), []byte{})
 	assert.Regexp(t, "PD021102", err)
 }
+
+type mockResolver struct {
+	mux     sync.Mutex
+	addrs   []string
+	err     error
+	lookups []string
+}
+
+func (r *mockResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.lookups = append(r.lookups, host)
+	return r.addrs, r.err
+}
+
+func (r *mockResolver) setAddrs(addrs ...string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.addrs = addrs
+}
+
+func (r *mockResolver) lookupCount() int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return len(r.lookups)
+}
+
+func newDNSTestServer(t *testing.T) (port int, conns chan *websocket.Conn, done func()) {
+	upgrader := &websocket.Upgrader{}
+	conns = make(chan *websocket.Conn, 10)
+	svr := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		ws, err := upgrader.Upgrade(res, req, http.Header{})
+		require.NoError(t, err)
+		conns <- ws
+	}))
+	return svr.Listener.Addr().(*net.TCPAddr).Port, conns, svr.Close
+}
+
+func TestWSDNSRefreshReResolvesOnReconnect(t *testing.T) {
+	port, conns, done := newDNSTestServer(t)
+	defer done()
+
+	resolver := &mockResolver{addrs: []string{"127.0.0.1"}}
+	wsConfig := &pldconf.WSClientConfig{}
+	wsConfig.URL = fmt.Sprintf("ws://paladin-node.test:%d", port)
+	wsConfig.ConnectRetry.InitialDelay = confutil.P("1ms")
+
+	wsc, err := NewWithDNSRefresh(context.Background(), wsConfig, nil, nil, &DNSRefreshOptions{
+		Interval: 1 * time.Hour,
+		Resolver: resolver,
+	})
+	require.NoError(t, err)
+	defer wsc.Close()
+
+	err = wsc.Connect()
+	require.NoError(t, err)
+	conn1 := <-conns
+	assert.Equal(t, 1, resolver.lookupCount())
+
+	// Drop the connection from the server side, and check we re-resolve before reconnecting
+	conn1.Close()
+	conn2 := <-conns
+	defer conn2.Close()
+	assert.Equal(t, 2, resolver.lookupCount())
+	assert.Equal(t, []string{"paladin-node.test", "paladin-node.test"}, resolver.lookups)
+}
+
+func TestWSDNSRefreshReconnectsWhenAddressChanges(t *testing.T) {
+	port, conns, done := newDNSTestServer(t)
+	defer done()
+
+	resolver := &mockResolver{addrs: []string{"127.0.0.1"}}
+	wsConfig := &pldconf.WSClientConfig{}
+	wsConfig.URL = fmt.Sprintf("ws://paladin-node.test:%d", port)
+	wsConfig.ConnectRetry.InitialDelay = confutil.P("1ms")
+	wsConfig.ConnectionTimeout = confutil.P("1s")
+
+	wsc, err := NewWithDNSRefresh(context.Background(), wsConfig, nil, nil, &DNSRefreshOptions{
+		Interval: 10 * time.Millisecond,
+		Resolver: resolver,
+	})
+	require.NoError(t, err)
+	defer wsc.Close()
+
+	err = wsc.Connect()
+	require.NoError(t, err)
+	conn1 := <-conns
+
+	// The service moves to a new address, so the client should drop the connection
+	resolver.setAddrs("127.0.0.2")
+	_, _, err = conn1.ReadMessage()
+	assert.Error(t, err)
+
+	// ... and reconnect once the service is available at the address it resolves to
+	resolver.setAddrs("127.0.0.1")
+	conn2 := <-conns
+	defer conn2.Close()
+}
+
+func TestWSDNSRefreshResolveFail(t *testing.T) {
+	wsConfig := &pldconf.WSClientConfig{}
+	wsConfig.URL = "ws://paladin-node.test:8545"
+
+	wsc, err := NewWithDNSRefresh(context.Background(), wsConfig, nil, nil, &DNSRefreshOptions{
+		Interval: 1 * time.Hour,
+		Resolver: &mockResolver{err: fmt.Errorf("pop")},
+	})
+	require.NoError(t, err)
+	err = wsc.Connect()
+	assert.Regexp(t, "PD021103.*pop", err)
+
+	wsc, err = NewWithDNSRefresh(context.Background(), wsConfig, nil, nil, &DNSRefreshOptions{
+		Interval: 1 * time.Hour,
+		Resolver: &mockResolver{},
+	})
+	require.NoError(t, err)
+	err = wsc.Connect()
+	assert.Regexp(t, "PD021103.*PD021105", err)
+}
+
+func TestWSDNSRefreshKeepsConnectionOnResolveFail(t *testing.T) {
+	resolver := &mockResolver{err: fmt.Errorf("pop")}
+	w := &wsClient{
+		ctx:        context.Background(),
+		url:        "ws://paladin-node.test:8545",
+		dnsRefresh: &DNSRefreshOptions{Resolver: resolver},
+		dialedAddr: "127.0.0.1",
+	}
+	w.checkDNSRefresh()
+	assert.Equal(t, 1, resolver.lookupCount())
+	assert.Equal(t, "127.0.0.1", w.dialedAddr)
+}
@@ -19,8 +19,15 @@ interface INotoPrivate {
         bytes calldata data
     ) external;
 
+    function multiTransfer(
+        SenderEntry[] calldata senders,
+        bytes calldata data
+    ) external;
+
     function burn(uint256 amount, bytes calldata data) external;
 
+    function consolidate(uint256 maxInputs, bytes calldata data) external;
+
     function approveTransfer(
         StateEncoded[] calldata inputs,
         StateEncoded[] calldata outputs,
@@ -59,6 +66,12 @@ interface INotoPrivate {
         bytes data;
     }
 
+    struct SenderEntry {
+        string from;
+        string to;
+        uint256 amount;
+    }
+
     struct UnlockRecipient {
         string to;
         uint256 amount;
@@ -52,3 +52,7 @@ func (dc *MockDomainCallbacks) LocalNodeName(context.Context, *prototk.LocalNode
 func (dc *MockDomainCallbacks) GetStatesByID(context.Context, *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error) {
 	return nil, nil
 }
+
+func (dc *MockDomainCallbacks) PushStateUpdate(context.Context, *prototk.PushStateUpdateRequest) (*prototk.PushStateUpdateResponse, error) {
+	return nil, nil
+}
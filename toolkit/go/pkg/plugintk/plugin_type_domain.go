@@ -24,6 +24,13 @@ import (
 	pb "google.golang.org/protobuf/proto"
 )
 
+// The range of domain protocol versions supported by this toolkit. Version 1 is the protocol
+// that pre-dates version negotiation, so it is assumed for a Paladin node that does not offer a range.
+const (
+	DomainProtocolVersionMin uint32 = 1
+	DomainProtocolVersionMax uint32 = 1
+)
+
 type DomainAPI interface {
 	ConfigureDomain(context.Context, *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error)
 	InitDomain(context.Context, *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error)
@@ -54,6 +61,7 @@ type DomainCallbacks interface {
 	SendTransaction(ctx context.Context, tx *prototk.SendTransactionRequest) (*prototk.SendTransactionResponse, error)
 	LocalNodeName(context.Context, *prototk.LocalNodeNameRequest) (*prototk.LocalNodeNameResponse, error)
 	GetStatesByID(ctx context.Context, req *prototk.GetStatesByIDRequest) (*prototk.GetStatesByIDResponse, error)
+	PushStateUpdate(ctx context.Context, req *prototk.PushStateUpdateRequest) (*prototk.PushStateUpdateResponse, error)
 }
 
 type DomainFactory func(callbacks DomainCallbacks) DomainAPI
@@ -140,6 +148,9 @@ func (dp *domainHandler) RequestToPlugin(ctx context.Context, iReq PluginMessage
 	case *prototk.DomainMessage_ConfigureDomain:
 		resMsg := &prototk.DomainMessage_ConfigureDomainRes{}
 		resMsg.ConfigureDomainRes, err = dp.api.ConfigureDomain(ctx, input.ConfigureDomain)
+		if err == nil && resMsg.ConfigureDomainRes != nil && resMsg.ConfigureDomainRes.NegotiatedVersion == 0 {
+			resMsg.ConfigureDomainRes.NegotiatedVersion = negotiateDomainProtocolVersion(input.ConfigureDomain)
+		}
 		res.ResponseFromDomain = resMsg
 	case *prototk.DomainMessage_InitDomain:
 		resMsg := &prototk.DomainMessage_InitDomainRes{}
@@ -213,6 +224,9 @@ func (dp *domainHandler) RequestToPlugin(ctx context.Context, iReq PluginMessage
 		resMsg := &prototk.DomainMessage_WrapPrivacyGroupEvmtxRes{}
 		resMsg.WrapPrivacyGroupEvmtxRes, err = dp.api.WrapPrivacyGroupEVMTX(ctx, input.WrapPrivacyGroupEvmtx)
 		res.ResponseFromDomain = resMsg
+	case *prototk.DomainMessage_Ping:
+		// Answered here, rather than by the domain, as it only needs to confirm the plugin is responding
+		res.ResponseFromDomain = &prototk.DomainMessage_PingRes{PingRes: &prototk.PingResponse{}}
 	default:
 		err = i18n.NewError(ctx, pldmsgs.MsgPluginUnsupportedRequest, input)
 	}
@@ -296,6 +310,30 @@ func (dp *domainHandler) GetStatesByID(ctx context.Context, req *prototk.GetStat
 	})
 }
 
+func (dp *domainHandler) PushStateUpdate(ctx context.Context, req *prototk.PushStateUpdateRequest) (*prototk.PushStateUpdateResponse, error) {
+	res, err := dp.proxy.RequestFromPlugin(ctx, dp.Wrap(&prototk.DomainMessage{
+		RequestFromDomain: &prototk.DomainMessage_PushStateUpdate{
+			PushStateUpdate: req,
+		},
+	}))
+	return responseToPluginAs(ctx, res, err, func(msg *prototk.DomainMessage_PushStateUpdateRes) *prototk.PushStateUpdateResponse {
+		return msg.PushStateUpdateRes
+	})
+}
+
+// Chooses the highest version supported by both sides. If the ranges do not overlap we still return
+// our own highest version, so that Paladin can reject the plugin with the version it is running.
+func negotiateDomainProtocolVersion(req *prototk.ConfigureDomainRequest) uint32 {
+	if req.MaxProtocolVersion == 0 {
+		return DomainProtocolVersionMin
+	}
+	version := min(req.MaxProtocolVersion, DomainProtocolVersionMax)
+	if version < max(req.MinProtocolVersion, DomainProtocolVersionMin) {
+		return DomainProtocolVersionMax
+	}
+	return version
+}
+
 type DomainAPIFunctions struct {
 	ConfigureDomain       func(context.Context, *prototk.ConfigureDomainRequest) (*prototk.ConfigureDomainResponse, error)
 	InitDomain            func(context.Context, *prototk.InitDomainRequest) (*prototk.InitDomainResponse, error)
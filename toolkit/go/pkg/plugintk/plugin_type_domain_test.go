@@ -153,6 +153,19 @@ func TestDomainCallback_GetStates(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDomainCallback_PushStateUpdate(t *testing.T) {
+	ctx, _, _, callbacks, inOutMap, done := setupDomainTests(t)
+	defer done()
+
+	inOutMap[fmt.Sprintf("%T", &prototk.DomainMessage_PushStateUpdate{})] = func(dm *prototk.DomainMessage) {
+		dm.ResponseToDomain = &prototk.DomainMessage_PushStateUpdateRes{
+			PushStateUpdateRes: &prototk.PushStateUpdateResponse{},
+		}
+	}
+	_, err := callbacks.PushStateUpdate(ctx, &prototk.PushStateUpdateRequest{})
+	require.NoError(t, err)
+}
+
 func TestDomainFunction_ConfigureDomain(t *testing.T) {
 	_, exerciser, funcs, _, _, done := setupDomainTests(t)
 	defer done()
@@ -163,13 +176,31 @@ func TestDomainFunction_ConfigureDomain(t *testing.T) {
 	}
 	exerciser.doExchangeToPlugin(func(req *prototk.DomainMessage) {
 		req.RequestToDomain = &prototk.DomainMessage_ConfigureDomain{
-			ConfigureDomain: &prototk.ConfigureDomainRequest{},
+			ConfigureDomain: &prototk.ConfigureDomainRequest{
+				MinProtocolVersion: 1,
+				MaxProtocolVersion: 5,
+			},
 		}
 	}, func(res *prototk.DomainMessage) {
 		assert.IsType(t, &prototk.DomainMessage_ConfigureDomainRes{}, res.ResponseFromDomain)
+		assert.Equal(t, DomainProtocolVersionMax, res.GetConfigureDomainRes().NegotiatedVersion)
 	})
 }
 
+func TestNegotiateDomainProtocolVersion(t *testing.T) {
+	// Paladin nodes that pre-date negotiation do not send a range
+	assert.Equal(t, DomainProtocolVersionMin, negotiateDomainProtocolVersion(&prototk.ConfigureDomainRequest{}))
+	assert.Equal(t, DomainProtocolVersionMax, negotiateDomainProtocolVersion(&prototk.ConfigureDomainRequest{
+		MinProtocolVersion: DomainProtocolVersionMin,
+		MaxProtocolVersion: DomainProtocolVersionMax + 10,
+	}))
+	// No overlap
+	assert.Equal(t, DomainProtocolVersionMax, negotiateDomainProtocolVersion(&prototk.ConfigureDomainRequest{
+		MinProtocolVersion: DomainProtocolVersionMax + 1,
+		MaxProtocolVersion: DomainProtocolVersionMax + 10,
+	}))
+}
+
 func TestDomainFunction_InitDomain(t *testing.T) {
 	_, exerciser, funcs, _, _, done := setupDomainTests(t)
 	defer done()
@@ -476,6 +507,20 @@ func TestDomainFunction_WrapPrivacyGroupEVMTX(t *testing.T) {
 	})
 }
 
+func TestDomainFunction_Ping(t *testing.T) {
+	_, exerciser, _, _, _, done := setupDomainTests(t)
+	defer done()
+
+	// Ping - paladin to domain (answered by the toolkit, so no function is needed)
+	exerciser.doExchangeToPlugin(func(req *prototk.DomainMessage) {
+		req.RequestToDomain = &prototk.DomainMessage_Ping{
+			Ping: &prototk.PingRequest{},
+		}
+	}, func(res *prototk.DomainMessage) {
+		assert.IsType(t, &prototk.DomainMessage_PingRes{}, res.ResponseFromDomain)
+	})
+}
+
 func TestDomainRequestError(t *testing.T) {
 	_, exerciser, _, _, _, done := setupDomainTests(t)
 	defer done()
@@ -117,6 +117,7 @@ var allTypes = []interface{}{
 	pldapi.EventWithData{},
 	pldapi.ABIDecodedData{},
 	pldapi.PeerInfo{},
+	pldapi.TransportStats{},
 	pldapi.KeyMappingAndVerifier{},
 	pldapi.ReliableMessageAck{},
 	pldapi.ReliableMessage{},
@@ -0,0 +1,117 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// A token bucket for each remote IP address, which refills at the configured rate up to the burst size.
+// Each request (including each request within a batch) takes one token.
+type rateLimiter struct {
+	rate      float64 // tokens per second
+	burst     float64
+	mux       sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Returns nil if rate limiting is disabled
+func newRateLimiter(ctx context.Context, conf *pldconf.RPCServerRateLimitConfig) *rateLimiter {
+	rate := confutil.Float64Min(conf.RequestsPerSecond, 0, 0)
+	if rate == 0 {
+		return nil
+	}
+	burst := confutil.IntMin(conf.Burst, 1, int(math.Ceil(rate)))
+	log.L(ctx).Infof("JSON/RPC rate limit requestsPerSecond=%f burst=%d", rate, burst)
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+func (rl *rateLimiter) allow(remoteIP string) bool {
+	if rl == nil {
+		return true
+	}
+	now := time.Now()
+
+	rl.mux.Lock()
+	defer rl.mux.Unlock()
+	rl.sweep(now)
+
+	b := rl.buckets[remoteIP]
+	if b == nil {
+		b = &tokenBucket{tokens: rl.burst}
+		rl.buckets[remoteIP] = b
+	} else {
+		b.tokens = math.Min(rl.burst, b.tokens+now.Sub(b.last).Seconds()*rl.rate)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// A bucket that has been idle long enough to refill completely is the same as a new one, so can be removed
+func (rl *rateLimiter) sweep(now time.Time) {
+	refillTime := time.Duration(rl.burst / rl.rate * float64(time.Second))
+	if now.Sub(rl.lastSweep) < refillTime {
+		return
+	}
+	for remoteIP, b := range rl.buckets {
+		if now.Sub(b.last) >= refillTime {
+			delete(rl.buckets, remoteIP)
+		}
+	}
+	rl.lastSweep = now
+}
+
+func remoteIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+func (s *rpcServer) processRPCRateLimited(ctx context.Context, remoteIP string, rpcReq *rpcclient.RPCRequest, wsc *webSocketConnection) (*rpcclient.RPCResponse, bool) {
+	if !s.rateLimiter.allow(remoteIP) {
+		err := i18n.NewError(ctx, pldmsgs.MsgJSONRPCRateLimitExceeded)
+		return rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeLimitExceeded), false
+	}
+	return s.processRPC(ctx, rpcReq, wsc)
+}
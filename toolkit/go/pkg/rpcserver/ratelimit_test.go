@@ -0,0 +1,158 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterDisabled(t *testing.T) {
+	rl := newRateLimiter(context.Background(), &pldconf.RPCServerRateLimitConfig{})
+	assert.Nil(t, rl)
+	assert.True(t, rl.allow("10.0.0.1"))
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	rl := newRateLimiter(context.Background(), &pldconf.RPCServerRateLimitConfig{
+		RequestsPerSecond: confutil.P(10.0),
+		Burst:             confutil.P(2),
+	})
+
+	// each address has its own bucket
+	assert.True(t, rl.allow("10.0.0.1"))
+	assert.True(t, rl.allow("10.0.0.1"))
+	assert.False(t, rl.allow("10.0.0.1"))
+	assert.True(t, rl.allow("10.0.0.2"))
+
+	// tokens are refilled at the configured rate
+	rl.buckets["10.0.0.1"].last = time.Now().Add(-100 * time.Millisecond)
+	assert.True(t, rl.allow("10.0.0.1"))
+	assert.False(t, rl.allow("10.0.0.1"))
+
+	// buckets that have refilled completely are removed
+	rl.buckets["10.0.0.2"].last = time.Now().Add(-1 * time.Second)
+	rl.lastSweep = time.Now().Add(-1 * time.Second)
+	assert.False(t, rl.allow("10.0.0.1"))
+	assert.Len(t, rl.buckets, 1)
+}
+
+func TestRateLimiterDefaultBurst(t *testing.T) {
+	rl := newRateLimiter(context.Background(), &pldconf.RPCServerRateLimitConfig{
+		RequestsPerSecond: confutil.P(0.5),
+	})
+	assert.Equal(t, float64(1), rl.burst)
+}
+
+func TestRemoteIP(t *testing.T) {
+	assert.Equal(t, "10.0.0.1", remoteIP("10.0.0.1:12345"))
+	assert.Equal(t, "::1", remoteIP("[::1]:12345"))
+	assert.Equal(t, "pipe", remoteIP("pipe"))
+}
+
+func TestRPCRateLimitHTTP(t *testing.T) {
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		RateLimit: pldconf.RPCServerRateLimitConfig{
+			RequestsPerSecond: confutil.P(0.001),
+			Burst:             confutil.P(2),
+		},
+	})
+	defer done()
+
+	regTestRPC(s, "ut_method", RPCMethod0(func(ctx context.Context) (string, error) {
+		return "result", nil
+	}))
+
+	// the batch uses up the last token
+	var batchRes []*rpcclient.RPCResponse
+	res, err := resty.New().R().
+		SetBody(`{"jsonrpc": "2.0", "id": "1", "method": "ut_method"}`).
+		Post(url)
+	require.NoError(t, err)
+	assert.True(t, res.IsSuccess())
+	res, err = resty.New().R().
+		SetBody(`[
+			{"jsonrpc": "2.0", "id": "2", "method": "ut_method"},
+			{"jsonrpc": "2.0", "id": "3", "method": "ut_method"}
+		]`).
+		SetResult(&batchRes).
+		Post(url)
+	require.NoError(t, err)
+	assert.True(t, res.IsSuccess())
+	require.Len(t, batchRes, 2)
+	limited := 0
+	for _, r := range batchRes {
+		if r.Error != nil {
+			assert.Equal(t, int64(rpcclient.RPCCodeLimitExceeded), r.Error.Code)
+			limited++
+		}
+	}
+	assert.Equal(t, 1, limited)
+
+	var errRes rpcclient.RPCResponse
+	res, err = resty.New().R().
+		SetBody(`{"jsonrpc": "2.0", "id": "4", "method": "ut_method"}`).
+		SetError(&errRes).
+		Post(url)
+	require.NoError(t, err)
+	assert.False(t, res.IsSuccess())
+	assert.Equal(t, `"4"`, errRes.ID.String())
+	assert.Equal(t, int64(rpcclient.RPCCodeLimitExceeded), errRes.Error.Code)
+	assert.Regexp(t, "PD020707", errRes.Error.Message)
+}
+
+func TestRPCRateLimitWebSockets(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+	url, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{
+		RateLimit: pldconf.RPCServerRateLimitConfig{
+			RequestsPerSecond: confutil.P(0.001),
+			Burst:             confutil.P(1),
+		},
+	})
+	defer done()
+
+	wsConfig := &pldconf.WSClientConfig{}
+	wsConfig.URL = url
+	client := rpcclient.WrapWSConfig(wsConfig)
+	defer client.Close()
+	err := client.Connect(ctx)
+	require.NoError(t, err)
+
+	regTestRPC(s, "ut_method", RPCMethod0(func(ctx context.Context) (string, error) {
+		return "result", nil
+	}))
+
+	var result string
+	rpcErr := client.CallRPC(ctx, &result, "ut_method")
+	assert.Nil(t, rpcErr)
+
+	// the connection remains usable after the limit is exceeded
+	for i := 0; i < 2; i++ {
+		rpcErr = client.CallRPC(ctx, &result, "ut_method")
+		require.NotNil(t, rpcErr)
+		assert.Equal(t, int64(rpcclient.RPCCodeLimitExceeded), rpcErr.RPCError().Code)
+	}
+}
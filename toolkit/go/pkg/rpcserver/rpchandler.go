@@ -31,17 +31,22 @@ import (
 )
 
 type handlerResult struct {
-	sendRes bool
-	isOK    bool
-	res     any
+	sendRes  bool
+	isOK     bool
+	tooLarge bool
+	res      any
 }
 
-func (s *rpcServer) rpcHandler(ctx context.Context, r io.Reader, wsc *webSocketConnection) handlerResult {
+func (s *rpcServer) rpcHandler(ctx context.Context, remoteIP string, r io.Reader, wsc *webSocketConnection) handlerResult {
 
-	b, err := io.ReadAll(r)
+	// We read one byte beyond the limit, so we can tell if it has been exceeded
+	b, err := io.ReadAll(io.LimitReader(r, s.maxRequestBodyBytes+1))
 	if err != nil {
 		return s.replyRPCParseError(ctx, b, err)
 	}
+	if int64(len(b)) > s.maxRequestBodyBytes {
+		return s.replyRPCTooLarge(ctx)
+	}
 
 	if log.IsTraceEnabled() {
 		log.L(ctx).Tracef("RPC[Server] --> %s", b)
@@ -54,7 +59,7 @@ func (s *rpcServer) rpcHandler(ctx context.Context, r io.Reader, wsc *webSocketC
 			log.L(ctx).Errorf("Bad RPC array received %s", b)
 			return s.replyRPCParseError(ctx, b, err)
 		}
-		batchRes, isOK := s.handleRPCBatch(ctx, rpcArray, wsc)
+		batchRes, isOK := s.handleRPCBatch(ctx, remoteIP, rpcArray, wsc)
 		return handlerResult{isOK: isOK, sendRes: true, res: batchRes}
 	}
 
@@ -65,7 +70,7 @@ func (s *rpcServer) rpcHandler(ctx context.Context, r io.Reader, wsc *webSocketC
 	}
 	startTime := time.Now()
 	log.L(ctx).Debugf("RPC-server[%s] --> %s", rpcRequest.ID, rpcRequest.Method)
-	res, isOK := s.processRPC(ctx, &rpcRequest, wsc)
+	res, isOK := s.processRPCRateLimited(ctx, remoteIP, &rpcRequest, wsc)
 	durationMS := float64(time.Since(startTime)) / float64(time.Millisecond)
 	if res != nil && res.Error != nil {
 		log.L(ctx).Errorf("RPC-server[%s] <-- %s [%.2fms]: %s", rpcRequest.ID.StringValue(), rpcRequest.Method, durationMS, res.Error.Message)
@@ -92,6 +97,20 @@ func (s *rpcServer) replyRPCParseError(ctx context.Context, b []byte, err error)
 	}
 }
 
+func (s *rpcServer) replyRPCTooLarge(ctx context.Context) handlerResult {
+	log.L(ctx).Errorf("Request exceeds the maximum size of %d bytes", s.maxRequestBodyBytes)
+	return handlerResult{
+		isOK:     false,
+		tooLarge: true,
+		sendRes:  true,
+		res: rpcclient.NewRPCErrorResponse(
+			i18n.NewError(ctx, pldmsgs.MsgJSONRPCRequestTooLarge, s.maxRequestBodyBytes),
+			pldtypes.RawJSON(`"1"`),
+			rpcclient.RPCCodeParseError,
+		),
+	}
+}
+
 func (s *rpcServer) sniffFirstByte(data []byte) byte {
 	sniffLen := len(data)
 	if sniffLen > 100 {
@@ -105,19 +124,33 @@ func (s *rpcServer) sniffFirstByte(data []byte) byte {
 	return 0x00
 }
 
-func (s *rpcServer) handleRPCBatch(ctx context.Context, rpcArray []*rpcclient.RPCRequest, wsc *webSocketConnection) ([]*rpcclient.RPCResponse, bool) {
+type batchResult struct {
+	responseNumber int
+	res            *rpcclient.RPCResponse
+	ok             bool
+}
 
-	// Kick off a routine to fill in each
+func (s *rpcServer) handleRPCBatch(ctx context.Context, remoteIP string, rpcArray []*rpcclient.RPCRequest, wsc *webSocketConnection) ([]*rpcclient.RPCResponse, bool) {
+
+	// A slow request must not hold up the response for the whole batch, so each request shares a deadline
+	batchCtx := ctx
+	if s.batchRequestTimeout > 0 {
+		var cancelCtx context.CancelFunc
+		batchCtx, cancelCtx = context.WithTimeout(ctx, s.batchRequestTimeout)
+		defer cancelCtx()
+	}
+
+	// Kick off a routine to fill in each.
+	// The channel is buffered so any that complete after we've timed out do not block
 	rpcResponses := make([]*rpcclient.RPCResponse, len(rpcArray))
-	results := make(chan bool)
+	results := make(chan *batchResult, len(rpcArray))
 	for i, r := range rpcArray {
 		responseNumber := i
 		rpcRequest := r
 		go func() {
-			var ok bool
 			startTime := time.Now()
 			log.L(ctx).Debugf("RPC-server[%v] (b=%d) --> %s", rpcRequest.ID.StringValue(), i, rpcRequest.Method)
-			res, ok := s.processRPC(ctx, rpcRequest, wsc)
+			res, ok := s.processRPCRateLimited(batchCtx, remoteIP, rpcRequest, wsc)
 			durationMS := float64(time.Since(startTime)) / float64(time.Millisecond)
 			if res != nil && res.Error != nil {
 				log.L(ctx).Errorf("RPC-server[%s] (b=%d) <-- %s [%.2fms]: %s", rpcRequest.ID.StringValue(), i, rpcRequest.Method, durationMS, res.Error.Message)
@@ -127,17 +160,35 @@ func (s *rpcServer) handleRPCBatch(ctx context.Context, rpcArray []*rpcclient.RP
 			if log.IsTraceEnabled() {
 				log.L(ctx).Tracef("RPC-server[%s] (b=%d) <-- %s", rpcRequest.ID.StringValue(), i, pldtypes.JSONString(res))
 			}
-			rpcResponses[responseNumber] = res
-			results <- ok
+			results <- &batchResult{responseNumber: responseNumber, res: res, ok: ok}
 		}()
 	}
 	failCount := 0
 	for range rpcResponses {
-		ok := <-results
-		if !ok {
-			failCount++
+		select {
+		case r := <-results:
+			rpcResponses[r.responseNumber] = r.res
+			if !r.ok {
+				failCount++
+			}
+		case <-batchCtx.Done():
+			failCount += s.timeoutBatchResponses(ctx, rpcArray, rpcResponses)
+			return rpcResponses, failCount != len(rpcArray)
 		}
 	}
 	// Only return a failure response code if all the requests in the batch failed
 	return rpcResponses, failCount != len(rpcArray)
 }
+
+func (s *rpcServer) timeoutBatchResponses(ctx context.Context, rpcArray []*rpcclient.RPCRequest, rpcResponses []*rpcclient.RPCResponse) (timedOut int) {
+	for i, res := range rpcResponses {
+		if res == nil {
+			rpcReq := rpcArray[i]
+			log.L(ctx).Errorf("RPC-server[%s] (b=%d) <-- %s timed out after %s", rpcReq.ID.StringValue(), i, rpcReq.Method, s.batchRequestTimeout)
+			err := i18n.NewError(ctx, pldmsgs.MsgJSONRPCBatchRequestTimeout, rpcReq.Method, s.batchRequestTimeout)
+			rpcResponses[i] = rpcclient.NewRPCErrorResponse(err, rpcReq.ID, rpcclient.RPCCodeInternalError)
+			timedOut++
+		}
+	}
+	return timedOut
+}
@@ -24,6 +24,7 @@ import (
 	"testing/iotest"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/config/pkg/pldconf"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
 	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
@@ -214,6 +215,72 @@ func TestRPCMessageBatchAllFail(t *testing.T) {
 
 }
 
+func TestRPCMessageBatchRequestTimeout(t *testing.T) {
+
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		BatchRequestTimeout: confutil.P("50ms"),
+	})
+	defer done()
+
+	// The slow method ignores the context, so only completes after the batch has been returned
+	unblock := make(chan struct{})
+	defer close(unblock)
+	regTestRPC(s, "ut_methodA", RPCMethod0(func(ctx context.Context) (string, error) {
+		return "resultA", nil
+	}))
+	regTestRPC(s, "ut_methodB", RPCMethod0(func(ctx context.Context) (string, error) {
+		<-unblock
+		return "resultB", nil
+	}))
+
+	var jsonResponse []*rpcclient.RPCResponse
+	res, err := resty.New().R().
+		SetBody(`[
+			{"jsonrpc": "2.0", "id": "1", "method": "ut_methodA"},
+			{"jsonrpc": "2.0", "id": "2", "method": "ut_methodB"}
+		]`).
+		SetResult(&jsonResponse).
+		Post(url)
+	require.NoError(t, err)
+	assert.True(t, res.IsSuccess())
+	require.Len(t, jsonResponse, 2)
+	assert.Nil(t, jsonResponse[0].Error)
+	assert.Equal(t, `"resultA"`, jsonResponse[0].Result.String())
+	assert.Equal(t, `"2"`, jsonResponse[1].ID.String())
+	assert.Equal(t, int64(rpcclient.RPCCodeInternalError), jsonResponse[1].Error.Code)
+	assert.Regexp(t, "PD020710.*ut_methodB", jsonResponse[1].Error.Message)
+}
+
+func TestRPCRequestTooLarge(t *testing.T) {
+
+	url, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{
+		MaxRequestBodyBytes: confutil.P("64"),
+	})
+	defer done()
+
+	regTestRPC(s, "ut_method", RPCMethod1(func(ctx context.Context, param0 string) (string, error) {
+		return param0, nil
+	}))
+
+	var jsonResponse rpcclient.RPCResponse
+	res, err := resty.New().R().
+		SetBody(`{"id":1,"method":"ut_method","params":["ok"]}`).
+		SetResult(&jsonResponse).
+		Post(url)
+	require.NoError(t, err)
+	assert.True(t, res.IsSuccess())
+	assert.Equal(t, `"ok"`, jsonResponse.Result.String())
+
+	res, err = resty.New().R().
+		SetBody(`{"id":1,"method":"ut_method","params":["` + strings.Repeat("a", 64) + `"]}`).
+		SetError(&jsonResponse).
+		Post(url)
+	require.NoError(t, err)
+	assert.Equal(t, 413, res.StatusCode())
+	assert.Equal(t, int64(rpcclient.RPCCodeParseError), jsonResponse.Error.Code)
+	assert.Regexp(t, "PD020711", jsonResponse.Error.Message)
+}
+
 func TestRPCHandleBadDataEmptySpace(t *testing.T) {
 
 	url, _, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
@@ -237,7 +304,7 @@ func TestRPCHandleIOError(t *testing.T) {
 	_, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
 	defer done()
 
-	r := s.rpcHandler(context.Background(), iotest.ErrReader(fmt.Errorf("pop")), nil)
+	r := s.rpcHandler(context.Background(), "127.0.0.1", iotest.ErrReader(fmt.Errorf("pop")), nil)
 	assert.False(t, r.isOK)
 	jsonResponse := r.res.(*rpcclient.RPCResponse)
 	assert.Equal(t, int64(rpcclient.RPCCodeInvalidRequest), jsonResponse.Error.Code)
@@ -250,7 +317,7 @@ func TestRPCBadArrayError(t *testing.T) {
 	_, s, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
 	defer done()
 
-	r := s.rpcHandler(context.Background(), strings.NewReader("[... this is not an array"), nil)
+	r := s.rpcHandler(context.Background(), "127.0.0.1", strings.NewReader("[... this is not an array"), nil)
 	assert.False(t, r.isOK)
 	jsonResponse := r.res.(*rpcclient.RPCResponse)
 	assert.Equal(t, int64(rpcclient.RPCCodeInvalidRequest), jsonResponse.Error.Code)
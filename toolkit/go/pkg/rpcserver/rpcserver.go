@@ -22,6 +22,7 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
@@ -40,6 +41,9 @@ type RPCServer interface {
 
 	Register(module *RPCModule)
 
+	// Publish sends an event to all WebSocket clients that have subscribed to the topic with pld_subscribe
+	Publish(topic string, event any)
+
 	WSHandler(w http.ResponseWriter, r *http.Request)   // Provides access to the WebSocket handler directly to be able to install it into another server
 	HTTPHandler(w http.ResponseWriter, r *http.Request) // Provides access to the http handler directly to be able to install it into another server
 }
@@ -49,7 +53,13 @@ func NewRPCServer(ctx context.Context, conf *pldconf.RPCServerConfig) (_ *rpcSer
 		bgCtx:         ctx,
 		wsConnections: make(map[string]*webSocketConnection),
 		rpcModules:    make(map[string]*RPCModule),
+		rateLimiter:   newRateLimiter(ctx, &conf.RateLimit),
+		topics:        newTopicSubscriptions(),
+
+		batchRequestTimeout: confutil.DurationMin(conf.BatchRequestTimeout, 0, *pldconf.RPCServerDefaults.BatchRequestTimeout),
+		maxRequestBodyBytes: confutil.ByteSize(conf.MaxRequestBodyBytes, 1, *pldconf.RPCServerDefaults.MaxRequestBodyBytes),
 	}
+	s.Register(NewRPCModule("pld").AddAsync(s.topics))
 
 	// Add the HTTP server
 	if !conf.HTTP.Disabled {
@@ -99,6 +109,11 @@ type rpcServer struct {
 	wsUpgrader    *websocket.Upgrader
 	wsConnections map[string]*webSocketConnection
 	rpcModules    map[string]*RPCModule
+	rateLimiter   *rateLimiter
+	topics        *topicSubscriptions
+
+	batchRequestTimeout time.Duration
+	maxRequestBodyBytes int64
 }
 
 func (s *rpcServer) Register(module *RPCModule) {
@@ -133,11 +148,14 @@ func (s *rpcServer) httpHandler(res http.ResponseWriter, req *http.Request) {
 		res.WriteHeader(http.StatusMethodNotAllowed)
 	}
 
-	r := s.rpcHandler(req.Context(), req.Body, nil /* not websockets */)
+	r := s.rpcHandler(req.Context(), remoteIP(req.RemoteAddr), req.Body, nil /* not websockets */)
 
 	res.Header().Set("Content-Type", "application/json; charset=utf-8")
 	status := http.StatusOK
-	if !r.isOK {
+	switch {
+	case r.tooLarge:
+		status = http.StatusRequestEntityTooLarge
+	case !r.isOK:
 		status = http.StatusInternalServerError
 	}
 	res.WriteHeader(status)
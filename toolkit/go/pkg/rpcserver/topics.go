@@ -0,0 +1,173 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/common/go/pkg/pldmsgs"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+)
+
+// The number of events that can be queued for a subscription before events are dropped
+const topicSubscriptionBufferSize = 100
+
+// Built-in pld_subscribe/pld_unsubscribe handling, that allows components in the server (such as
+// domains) to push events to WebSocket clients for a topic such as "domain:noto:confirmed".
+// Delivery is fire-and-forget in the same way as eth_subscribe, with a "pld_subscription" notification.
+type topicSubscriptions struct {
+	lock    sync.Mutex
+	byTopic map[string]map[string]*topicSubscription
+}
+
+type topicSubscription struct {
+	ts        *topicSubscriptions
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+	ctrl      RPCAsyncControl
+	topic     string
+	events    chan any
+	done      chan struct{}
+}
+
+func newTopicSubscriptions() *topicSubscriptions {
+	return &topicSubscriptions{
+		byTopic: make(map[string]map[string]*topicSubscription),
+	}
+}
+
+func (s *rpcServer) Publish(topic string, event any) {
+	s.topics.publish(topic, event)
+}
+
+func (ts *topicSubscriptions) publish(topic string, event any) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	for id, sub := range ts.byTopic[topic] {
+		// We must not block the publisher on a slow client
+		select {
+		case sub.events <- event:
+		default:
+			log.L(sub.ctx).Warnf("Dropped event for topic '%s' to subscription %s that is not keeping up", topic, id)
+		}
+	}
+}
+
+func (ts *topicSubscriptions) StartMethod() string {
+	return "pld_subscribe"
+}
+
+func (ts *topicSubscriptions) LifecycleMethods() []string {
+	return []string{"pld_unsubscribe"}
+}
+
+func (ts *topicSubscriptions) HandleStart(ctx context.Context, req *rpcclient.RPCRequest, ctrl RPCAsyncControl) (RPCAsyncInstance, *rpcclient.RPCResponse) {
+	if len(req.Params) != 1 || req.Params[0].StringValue() == "" {
+		return nil, rpcclient.NewRPCErrorResponse(i18n.NewError(ctx, pldmsgs.MsgJSONRPCSubscribeNoTopic), req.ID, rpcclient.RPCCodeInvalidRequest)
+	}
+	sub := &topicSubscription{
+		ts:     ts,
+		ctrl:   ctrl,
+		topic:  req.Params[0].StringValue(),
+		events: make(chan any, topicSubscriptionBufferSize),
+		done:   make(chan struct{}),
+	}
+	// The context is that of the connection, so the sender also exits if the connection closes
+	sub.ctx, sub.cancelCtx = context.WithCancel(log.WithLogField(ctx, "topic", sub.topic))
+
+	ts.lock.Lock()
+	subMap := ts.byTopic[sub.topic]
+	if subMap == nil {
+		subMap = make(map[string]*topicSubscription)
+		ts.byTopic[sub.topic] = subMap
+	}
+	subMap[ctrl.ID()] = sub
+	ts.lock.Unlock()
+
+	go sub.sender()
+	log.L(ctx).Debugf("Subscription %s started for topic '%s'", ctrl.ID(), sub.topic)
+	return sub, &rpcclient.RPCResponse{
+		JSONRpc: "2.0",
+		ID:      req.ID,
+		Result:  pldtypes.JSONString(ctrl.ID()),
+	}
+}
+
+func (ts *topicSubscriptions) HandleLifecycle(ctx context.Context, req *rpcclient.RPCRequest) *rpcclient.RPCResponse {
+	// pld_unsubscribe is the only lifecycle method
+	if len(req.Params) != 1 {
+		return rpcclient.NewRPCErrorResponse(i18n.NewError(ctx, pldmsgs.MsgJSONRPCUnsubscribeNoID), req.ID, rpcclient.RPCCodeInvalidRequest)
+	}
+	sub := ts.popSub(req.Params[0].StringValue())
+	if sub != nil {
+		sub.close()
+		sub.ctrl.Closed()
+	}
+	return &rpcclient.RPCResponse{
+		JSONRpc: "2.0",
+		ID:      req.ID,
+		Result:  pldtypes.JSONString(sub != nil),
+	}
+}
+
+func (ts *topicSubscriptions) popSub(subID string) *topicSubscription {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	for topic, subMap := range ts.byTopic {
+		sub := subMap[subID]
+		if sub != nil {
+			delete(subMap, subID)
+			if len(subMap) == 0 {
+				delete(ts.byTopic, topic)
+			}
+			return sub
+		}
+	}
+	return nil
+}
+
+func (sub *topicSubscription) ConnectionClosed() {
+	sub.ts.popSub(sub.ctrl.ID())
+	sub.close()
+}
+
+func (sub *topicSubscription) close() {
+	sub.cancelCtx()
+	<-sub.done
+}
+
+func (sub *topicSubscription) sender() {
+	defer close(sub.done)
+	for {
+		select {
+		case event := <-sub.events:
+			sub.ctrl.Send("pld_subscription", map[string]any{
+				"subscription": sub.ctrl.ID(),
+				"result":       event,
+			})
+		case <-sub.ctx.Done():
+			log.L(sub.ctx).Debugf("Subscription %s sender exiting", sub.ctrl.ID())
+			return
+		}
+	}
+}
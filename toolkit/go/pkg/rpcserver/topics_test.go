@@ -0,0 +1,114 @@
+// Copyright © 2025 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kaleido-io/paladin/config/pkg/pldconf"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/pldtypes"
+	"github.com/kaleido-io/paladin/sdk/go/pkg/rpcclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (ts *topicSubscriptions) subCount(topic string) int {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+	return len(ts.byTopic[topic])
+}
+
+func TestWebSocketPldSubscribeUnsubscribe(t *testing.T) {
+	url, s, done := newTestServerWebSockets(t, &pldconf.RPCServerConfig{})
+	defer done()
+
+	wsConfig := &pldconf.WSClientConfig{}
+	wsConfig.URL = url
+	client := rpcclient.WrapWSConfig(wsConfig)
+	defer client.Close()
+	err := client.Connect(context.Background())
+	require.NoError(t, err)
+
+	rpcErr := client.CallRPC(context.Background(), &pldtypes.RawJSON{}, "pld_subscribe")
+	assert.Regexp(t, "PD020708", rpcErr)
+	rpcErr = client.CallRPC(context.Background(), &pldtypes.RawJSON{}, "pld_unsubscribe")
+	assert.Regexp(t, "PD020709", rpcErr)
+
+	sub1, rpcErr := client.Subscribe(context.Background(), rpcclient.PldSubscribeConfig(), "domain:noto:confirmed")
+	require.Nil(t, rpcErr)
+	_, rpcErr = client.Subscribe(context.Background(), rpcclient.PldSubscribeConfig(), "domain:zeto:confirmed")
+	require.Nil(t, rpcErr)
+	assert.Equal(t, 1, s.topics.subCount("domain:noto:confirmed"))
+	assert.Equal(t, 1, s.topics.subCount("domain:zeto:confirmed"))
+
+	s.Publish("domain:other:confirmed", map[string]any{"not": "delivered"})
+	s.Publish("domain:noto:confirmed", map[string]any{"some": "thing"})
+
+	notification := <-sub1.Notifications()
+	assert.JSONEq(t, `{"some": "thing"}`, notification.GetResult().String())
+
+	rpcErr = sub1.Unsubscribe(context.Background())
+	assert.Nil(t, rpcErr)
+	assert.Equal(t, 0, s.topics.subCount("domain:noto:confirmed"))
+	assert.Equal(t, 1, s.topics.subCount("domain:zeto:confirmed"))
+
+	var found bool
+	rpcErr = client.CallRPC(context.Background(), &found, "pld_unsubscribe", "unknown")
+	assert.Nil(t, rpcErr)
+	assert.False(t, found)
+
+	// Closing the connection cleans up the remaining subscription
+	client.Close()
+	before := time.Now()
+	for s.topics.subCount("domain:zeto:confirmed") > 0 {
+		time.Sleep(1 * time.Millisecond)
+		if time.Since(before) > 1*time.Second {
+			panic("timed out waiting for cleanup")
+		}
+	}
+}
+
+func TestTopicPublishDropsWhenFull(t *testing.T) {
+	ts := newTopicSubscriptions()
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	sub := &topicSubscription{
+		ts:        ts,
+		ctx:       ctx,
+		cancelCtx: cancelCtx,
+		events:    make(chan any, 1),
+		done:      make(chan struct{}),
+	}
+	ts.byTopic["topic1"] = map[string]*topicSubscription{"sub1": sub}
+
+	ts.publish("topic1", "event1")
+	ts.publish("topic1", "event2") // dropped rather than blocking
+	assert.Equal(t, "event1", <-sub.events)
+}
+
+func TestPldSubscribeNonWS(t *testing.T) {
+	url, _, done := newTestServerHTTP(t, &pldconf.RPCServerConfig{})
+	defer done()
+
+	client := rpcclient.WrapRestyClient(resty.New().SetBaseURL(url))
+
+	var res any
+	rpcErr := client.CallRPC(context.Background(), &res, "pld_subscribe", "topic1")
+	assert.Regexp(t, "PD020706", rpcErr)
+}
@@ -38,6 +38,7 @@ func (s *rpcServer) newWSConnection(conn *websocket.Conn) {
 		id:             pldtypes.ShortID(),
 		server:         s,
 		conn:           conn,
+		remoteIP:       remoteIP(conn.RemoteAddr().String()),
 		asyncInstances: make(map[uuid.UUID]*asyncWrapper),
 		send:           make(chan []byte),
 		closing:        make(chan struct{}),
@@ -64,6 +65,7 @@ type webSocketConnection struct {
 	closeMux       sync.Mutex
 	closed         bool
 	conn           *websocket.Conn
+	remoteIP       string
 	asyncMux       sync.Mutex
 	asyncInstances map[uuid.UUID]*asyncWrapper
 	send           chan ([]byte)
@@ -168,7 +170,7 @@ func (c *webSocketConnection) sender() {
 }
 
 func (c *webSocketConnection) handleMessage(payload []byte) {
-	r := c.server.rpcHandler(c.ctx, bytes.NewBuffer(payload), c)
+	r := c.server.rpcHandler(c.ctx, c.remoteIP, bytes.NewBuffer(payload), c)
 	if r.sendRes {
 		c.sendMessage(r.res)
 	}
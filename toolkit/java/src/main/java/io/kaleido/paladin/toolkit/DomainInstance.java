@@ -123,6 +123,7 @@
                  case CONFIGURE_PRIVACY_GROUP -> configurePrivacyGroup(request.getConfigurePrivacyGroup()).thenApply(response::setConfigurePrivacyGroupRes);
                  case INIT_PRIVACY_GROUP -> initPrivacyGroup(request.getInitPrivacyGroup()).thenApply(response::setInitPrivacyGroupRes);
                  case WRAP_PRIVACY_GROUP_EVMTX -> wrapPrivacyGroupTransaction(request.getWrapPrivacyGroupEvmtx()).thenApply(response::setWrapPrivacyGroupEvmtxRes);
+                 case PING -> CompletableFuture.completedFuture(PingResponse.getDefaultInstance()).thenApply(response::setPingRes);
                  default -> throw new IllegalArgumentException("unknown request: %s".formatted(request.getRequestToDomainCase()));
              };
              return resultApplied.thenApply((ra) -> {
@@ -36,11 +36,15 @@ type Config struct {
 	// By default directCertVerification will expect the CN of the subject to be the exact registered node name.
 	// Optionally certSubjectMatcher can supply a regexp containing a SINGLE CAPTURE GROUP that can be used to extract the name from the subject string
 	CertSubjectMatcher *string `json:"certSubjectMatcher,omitempty"`
+	// The number of gRPC streams opened to each peer, with outbound messages distributed across them round-robin.
+	// Messages sent on different streams can arrive out of order, so set to 1 if strict ordering is required.
+	MaxStreamsPerTransport *int `json:"maxStreamsPerTransport,omitempty"`
 }
 
 var ConfigDefaults = &Config{
 	Address:                confutil.P("0.0.0.0"), // public connectivity
 	DirectCertVerification: confutil.P(true),      // with self-signed certificates
+	MaxStreamsPerTransport: confutil.P(4),
 }
 
 // This is the JSON structure that any node in the network must share to be connectable
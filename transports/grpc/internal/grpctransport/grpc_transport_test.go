@@ -19,8 +19,13 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/toolkit/pkg/prototk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -258,3 +263,119 @@ func TestConnectSendStreamBadSecurityCtx(t *testing.T) {
 	}
 	assert.Error(t, err)
 }
+
+func activateWithStreams(t testing.TB, plugin1, plugin2 *grpcTransport, streams int) *outboundConn {
+	ctx := context.Background()
+
+	plugin1.conf.MaxStreamsPerTransport = confutil.P(streams)
+	details, err := plugin2.GetLocalDetails(ctx, &prototk.GetLocalDetailsRequest{})
+	require.NoError(t, err)
+	_, err = plugin1.ActivatePeer(ctx, &prototk.ActivatePeerRequest{
+		NodeName:         "node2",
+		TransportDetails: details.TransportDetails,
+	})
+	require.NoError(t, err)
+	return plugin1.getConnection("node2")
+}
+
+func TestSendRoundRobinAcrossStreams(t *testing.T) {
+
+	ctx := context.Background()
+
+	received := make(chan string, 8)
+	plugin1, plugin2, done := newSuccessfulVerifiedConnection(t, func(_, callbacks2 *testCallbacks) {
+		callbacks2.receiveMessage = func(ctx context.Context, rmr *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+			received <- rmr.Message.MessageId
+			return &prototk.ReceiveMessageResponse{}, nil
+		}
+	})
+	defer done()
+
+	// The default is a pool of streams, only the first of which is connected up-front
+	oc := plugin1.getConnection("node2")
+	require.Len(t, oc.streams, 4)
+	assert.NotNil(t, oc.streams[0].stream)
+	assert.Nil(t, oc.streams[1].stream)
+
+	// Invalid values fall back to a single stream
+	oc = activateWithStreams(t, plugin1, plugin2, 0)
+	require.Len(t, oc.streams, 1)
+
+	oc = activateWithStreams(t, plugin1, plugin2, 3)
+	require.Len(t, oc.streams, 3)
+
+	sent := map[string]bool{}
+	for i := 0; i < 6; i++ {
+		msgID := uuid.NewString()
+		sent[msgID] = true
+		_, err := plugin1.SendMessage(ctx, &prototk.SendMessageRequest{
+			Node: "node2",
+			Message: &prototk.PaladinMsg{
+				MessageId: msgID,
+				Component: prototk.PaladinMsg_TRANSACTION_ENGINE,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	// Every stream was used, and all the messages arrive through the single receive handler
+	for _, st := range oc.streams {
+		assert.NotNil(t, st.stream)
+	}
+	for i := 0; i < 6; i++ {
+		assert.True(t, sent[<-received])
+	}
+
+}
+
+func benchmarkSendStreams(b *testing.B, streams int) {
+
+	ctx := context.Background()
+	log.SetLevel("error")
+	defer log.SetLevel("info")
+
+	var receivedCount atomic.Int64
+	allReceived := make(chan struct{})
+	plugin1, plugin2, done := newSuccessfulVerifiedConnection(b, func(_, callbacks2 *testCallbacks) {
+		callbacks2.receiveMessage = func(ctx context.Context, rmr *prototk.ReceiveMessageRequest) (*prototk.ReceiveMessageResponse, error) {
+			// Simulate the round trip to deliver the message to Paladin, which happens
+			// sequentially for the messages received on each stream
+			time.Sleep(100 * time.Microsecond)
+			if receivedCount.Add(1) == int64(b.N) {
+				close(allReceived)
+			}
+			return &prototk.ReceiveMessageResponse{}, nil
+		}
+	})
+	defer done()
+	activateWithStreams(b, plugin1, plugin2, streams)
+
+	payload := make([]byte, 1024)
+	b.SetParallelism(streams)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, err := plugin1.SendMessage(ctx, &prototk.SendMessageRequest{
+				Node: "node2",
+				Message: &prototk.PaladinMsg{
+					MessageId: uuid.NewString(),
+					Component: prototk.PaladinMsg_TRANSACTION_ENGINE,
+					Payload:   payload,
+				},
+			})
+			if err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	<-allReceived
+
+}
+
+func BenchmarkSendSingleStream(b *testing.B) {
+	benchmarkSendStreams(b, 1)
+}
+
+func BenchmarkSendStreamPool(b *testing.B) {
+	benchmarkSendStreams(b, 4)
+}
@@ -19,9 +19,11 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+	"sync/atomic"
 
 	"github.com/kaleido-io/paladin/common/go/pkg/i18n"
 	"github.com/kaleido-io/paladin/common/go/pkg/log"
+	"github.com/kaleido-io/paladin/config/pkg/confutil"
 	"github.com/kaleido-io/paladin/transports/grpc/internal/msgs"
 	"github.com/kaleido-io/paladin/transports/grpc/pkg/proto"
 	"google.golang.org/grpc"
@@ -32,6 +34,15 @@ type outboundConn struct {
 	nodeName string
 	client   proto.PaladinGRPCTransportClient
 	peerInfo PeerInfo
+	streams  []*outboundStream
+	next     atomic.Uint32
+}
+
+// Each stream is used by one sender at a time, and the connection distributes
+// messages across the streams round-robin.
+type outboundStream struct {
+	oc       *outboundConn
+	idx      int
 	sendLock sync.Mutex
 	stream   grpc.ClientStreamingClient[proto.Message, proto.Empty]
 }
@@ -63,7 +74,13 @@ func (t *grpcTransport) newConnection(ctx context.Context, nodeName string, tran
 	)
 	if err == nil {
 		oc.client = proto.NewPaladinGRPCTransportClient(grpcConn)
-		err = oc.ensureStream()
+		streamCount := confutil.IntMin(t.conf.MaxStreamsPerTransport, 1, *ConfigDefaults.MaxStreamsPerTransport)
+		oc.streams = make([]*outboundStream, streamCount)
+		for i := range oc.streams {
+			oc.streams[i] = &outboundStream{oc: oc, idx: i}
+		}
+		// Only the first stream is established up-front, the others are established on first use
+		err = oc.streams[0].ensureStream()
 	}
 	if err != nil {
 		return nil, nil, i18n.WrapError(ctx, err, msgs.MsgConnectionFailed, transportDetails.Endpoint)
@@ -73,40 +90,52 @@ func (t *grpcTransport) newConnection(ctx context.Context, nodeName string, tran
 }
 
 func (oc *outboundConn) close(ctx context.Context) {
-	oc.sendLock.Lock()
-	defer oc.sendLock.Unlock()
-
 	log.L(ctx).Errorf("cleaning up connection to %s", oc.nodeName)
 
-	if oc.stream != nil {
-		_ = oc.stream.CloseSend()
-		oc.stream = nil
+	for _, st := range oc.streams {
+		st.close()
 	}
 }
 
-func (oc *outboundConn) ensureStream() (err error) {
-	if oc.stream != nil {
+func (oc *outboundConn) send(message *proto.Message) error {
+	st := oc.streams[int(oc.next.Add(1)-1)%len(oc.streams)]
+	return st.send(message)
+}
+
+func (st *outboundStream) close() {
+	st.sendLock.Lock()
+	defer st.sendLock.Unlock()
+
+	if st.stream != nil {
+		_ = st.stream.CloseSend()
+		st.stream = nil
+	}
+}
+
+func (st *outboundStream) ensureStream() (err error) {
+	if st.stream != nil {
 		return nil
 	}
-	log.L(oc.t.bgCtx).Infof("GRPC establishing new stream to peer %s (endpoint=%s)", oc.nodeName, oc.peerInfo.Endpoint)
-	oc.stream, err = oc.client.ConnectSendStream(oc.t.bgCtx)
+	oc := st.oc
+	log.L(oc.t.bgCtx).Infof("GRPC establishing new stream %d to peer %s (endpoint=%s)", st.idx, oc.nodeName, oc.peerInfo.Endpoint)
+	st.stream, err = oc.client.ConnectSendStream(oc.t.bgCtx)
 	return err
 }
 
-func (oc *outboundConn) send(message *proto.Message) error {
-	oc.sendLock.Lock()
-	defer oc.sendLock.Unlock()
+func (st *outboundStream) send(message *proto.Message) error {
+	st.sendLock.Lock()
+	defer st.sendLock.Unlock()
 
-	err := oc.ensureStream()
+	err := st.ensureStream()
 
 	if err == nil {
-		err = oc.stream.Send(message)
+		err = st.stream.Send(message)
 	}
 
-	if err != nil {
+	if err != nil && st.stream != nil {
 		// Clean up the stream - we'll create a new one on next send
-		_ = oc.stream.CloseSend()
-		oc.stream = nil
+		_ = st.stream.CloseSend()
+		st.stream = nil
 	}
 	return err
 }
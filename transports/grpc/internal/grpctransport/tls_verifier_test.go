@@ -39,7 +39,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func getRSAKeyFromPEM(t *testing.T, pemBytes string) *rsa.PrivateKey {
+func getRSAKeyFromPEM(t testing.TB, pemBytes string) *rsa.PrivateKey {
 	block, _ := pem.Decode([]byte(pemBytes))
 	assert.NotNil(t, block)
 	assert.Equal(t, "RSA PRIVATE KEY", block.Type)
@@ -48,7 +48,7 @@ func getRSAKeyFromPEM(t *testing.T, pemBytes string) *rsa.PrivateKey {
 	return privateKey
 }
 
-func buildTestCertificate(t *testing.T, subject pkix.Name, ca *x509.Certificate, caKey *rsa.PrivateKey) (string, string) {
+func buildTestCertificate(t testing.TB, subject pkix.Name, ca *x509.Certificate, caKey *rsa.PrivateKey) (string, string) {
 	// Create an X509 certificate pair
 	privatekey, _ := rsa.GenerateKey(rand.Reader, 1024 /* smallish key to make the test faster */)
 	publickey := &privatekey.PublicKey
@@ -84,7 +84,7 @@ func buildTestCertificate(t *testing.T, subject pkix.Name, ca *x509.Certificate,
 	return publicKeyPEM.String(), privateKeyPEM.String()
 }
 
-func newTestGRPCTransport(t *testing.T, nodeCert, nodeKey string, conf *Config) (*grpcTransport, *PublishedTransportDetails, *testCallbacks, func()) {
+func newTestGRPCTransport(t testing.TB, nodeCert, nodeKey string, conf *Config) (*grpcTransport, *PublishedTransportDetails, *testCallbacks, func()) {
 	// Grab a localhost port to use and put that in config
 	portGrabber, err := net.Listen("tcp", "127.0.0.1:0")
 	assert.NoError(t, err)
@@ -154,7 +154,7 @@ func mockRegistry(cb *testCallbacks, ptds map[string]*PublishedTransportDetails)
 	}
 }
 
-func newSuccessfulVerifiedConnection(t *testing.T, setup ...func(callbacks1, callbacks2 *testCallbacks)) (plugin1, plugin2 *grpcTransport, done func()) {
+func newSuccessfulVerifiedConnection(t testing.TB, setup ...func(callbacks1, callbacks2 *testCallbacks)) (plugin1, plugin2 *grpcTransport, done func()) {
 	// the default config is direct cert verification
 	node1Cert, node1Key := buildTestCertificate(t, pkix.Name{CommonName: "node1"}, nil, nil)
 	plugin1, transportDetails1, callbacks1, done1 := newTestGRPCTransport(t, node1Cert, node1Key, &Config{})
@@ -180,7 +180,7 @@ func newSuccessfulVerifiedConnection(t *testing.T, setup ...func(callbacks1, cal
 	}
 }
 
-func testActivatePeer(t *testing.T, sender *grpcTransport, remoteNodeName string, transportDetails *PublishedTransportDetails) func() {
+func testActivatePeer(t testing.TB, sender *grpcTransport, remoteNodeName string, transportDetails *PublishedTransportDetails) func() {
 
 	ctx := context.Background()
 